@@ -21,8 +21,8 @@ import (
 	"os"
 
 	v1 "github.com/canonical/rebac-admin-ui-handlers/v1"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/memory"
 
-	"example/cmd/database"
 	"example/cmd/service"
 )
 
@@ -33,23 +33,38 @@ const stateFilename = "state.json"
 const zeroStateFilename = "state.zero.json"
 
 func main() {
-	db := database.NewDatabase(stateFilename, zeroStateFilename)
-	_ = db.Load()
-
-	rebac, err := v1.NewReBACAdminBackend(v1.ReBACAdminBackendParams{
-		Authenticator:     &service.HappyAuthenticator{},
-		Capabilities:      &service.CapabilitiesService{Database: db},
-		Groups:            &service.GroupsService{Database: db},
-		Identities:        &service.IdentitiesService{Database: db},
-		Roles:             &service.RolesService{Database: db},
-		Entitlements:      &service.EntitlementsService{Database: db},
-		Resources:         &service.ResourcesService{Database: db},
-		IdentityProviders: &service.IdentityProvidersService{Database: db},
+	zeroState, err := memory.LoadState(zeroStateFilename)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	db, err := memory.NewDatabase(memory.DatabaseParams{
+		Seed:             zeroState,
+		SnapshotFilename: stateFilename,
 	})
 	if err != nil {
 		panic(err.Error())
 	}
 
+	// The in-memory services are provided by the `v1/memory` package. In your
+	// project, you should use your own implementations of the `*Service`
+	// interfaces instead.
+	//
+	// The capabilities service is not set, so the capabilities are inferred
+	// from the configured services, and always match the endpoints that are
+	// actually wired up. To serve the ones stored in the state instead (e.g.,
+	// to exercise the UI with different responses), set it to
+	// `memory.NewCapabilitiesService(db)`.
+	params := memory.NewBackendParams(db)
+	params.Authenticator = &service.HappyAuthenticator{}
+	params.SequentialBulk = true
+	params.IdempotencyStore = v1.NewMemoryIdempotencyStore(v1.MemoryIdempotencyStoreParams{})
+
+	rebac, err := v1.NewReBACAdminBackend(params)
+	if err != nil {
+		panic(err.Error())
+	}
+
 	mux := http.NewServeMux()
 
 	// NOTE: When using the standard Go ServeMux, make sure you provide the same
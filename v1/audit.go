@@ -0,0 +1,377 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// handlerWithAudit decorates a given handler with audit logic. After mutating
+// operations (i.e., `Post*`, `Put*`, `Delete*` and `Patch*`) are handled,
+// an audit event is sent to the provided audit sink backend.
+type handlerWithAudit struct {
+	// Wrapped/decorated handler
+	resources.ServerInterface
+
+	sink       interfaces.AuditSink
+	failClosed bool
+}
+
+// newHandlerWithAudit returns a new instance of the handlerWithAudit struct.
+func newHandlerWithAudit(handler resources.ServerInterface, sink interfaces.AuditSink, failClosed bool) *handlerWithAudit {
+	return &handlerWithAudit{
+		ServerInterface: handler,
+		sink:            sink,
+		failClosed:      failClosed,
+	}
+}
+
+// auditEntryContextKey is the context key to retrieve the audit entry of the
+// current request.
+type auditEntryContextKey struct{}
+
+// auditEntry holds the request information that is only available to the inner
+// handlers, like the parsed request body.
+type auditEntry struct {
+	body     any
+	replayed bool
+
+	// bulkOperations and bulkResults are the operations of a bulk request and
+	// their results, which are recorded as separate audit events.
+	bulkOperations []resources.BulkOperation
+	bulkResults    []resources.BulkOperationResult
+}
+
+// setAuditRequestBody sets the parsed request body on the audit entry
+// associated with the given context, if any.
+func setAuditRequestBody(ctx context.Context, body any) {
+	if entry, ok := ctx.Value(auditEntryContextKey{}).(*auditEntry); ok {
+		entry.body = body
+	}
+}
+
+// setAuditReplayed marks the audit entry associated with the given context, if
+// any, as replayed.
+func setAuditReplayed(ctx context.Context) {
+	if entry, ok := ctx.Value(auditEntryContextKey{}).(*auditEntry); ok {
+		entry.replayed = true
+	}
+}
+
+// setAuditBulkResults sets the operations of a bulk request, along with their
+// results, on the audit entry associated with the given context, if any.
+func setAuditBulkResults(ctx context.Context, operations []resources.BulkOperation, results []resources.BulkOperationResult) {
+	if entry, ok := ctx.Value(auditEntryContextKey{}).(*auditEntry); ok {
+		entry.bulkOperations = operations
+		entry.bulkResults = results
+	}
+}
+
+// withoutAuditEntry returns a copy of the given context that is not associated
+// with an audit entry, so that nested requests do not alter the entry.
+func withoutAuditEntry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, auditEntryContextKey{}, nil)
+}
+
+// audit is a helper method to avoid repetition. It delegates to the provided
+// callback and then records an audit event with the outcome. For bulk
+// requests, an audit event is also recorded for each executed operation, as if
+// it was requested via its equivalent endpoint.
+//
+// If the handler is configured to fail closed, a pending event is recorded
+// before delegating, and if the audit sink fails, the request is rejected with
+// an internal server error without performing the operation. Since the
+// operation cannot be undone afterwards, failures to record the outcome are
+// only logged, in either mode.
+func (h handlerWithAudit) audit(w http.ResponseWriter, r *http.Request, operationId string, targetId string, f func(w http.ResponseWriter, r *http.Request)) {
+	event := interfaces.AuditEvent{
+		Identity:    getIdentityOrNilFromContext(r.Context()),
+		OperationId: operationId,
+		TargetId:    targetId,
+		Timestamp:   time.Now(),
+	}
+
+	if h.failClosed {
+		pending := event
+		pending.Pending = true
+		if err := h.sink.Record(r.Context(), pending); err != nil {
+			getLogger(w).Error("failed to record audit event", "operation", operationId, "error", err)
+			writeErrorResponse(w, NewUnknownError("failed to record audit event"))
+			return
+		}
+	}
+
+	entry := &auditEntry{}
+	r = r.WithContext(context.WithValue(r.Context(), auditEntryContextKey{}, entry))
+
+	sw := &statusResponseWriter{ResponseWriter: w}
+	f(sw, r)
+
+	event.RequestBody = entry.body
+	event.Replayed = entry.replayed
+	event.Status = sw.responseStatus()
+	if err := h.sink.Record(r.Context(), event); err != nil {
+		getLogger(w).Error("failed to record audit event", "operation", operationId, "error", err)
+	}
+
+	for i, op := range entry.bulkOperations {
+		if i >= len(entry.bulkResults) {
+			break
+		}
+		index := i
+		opEvent := interfaces.AuditEvent{
+			Identity:    event.Identity,
+			OperationId: bulkOperationIds[op.Op],
+			RequestBody: bulkOperationRequestBody(op),
+			Status:      entry.bulkResults[i].Status,
+			BulkIndex:   &index,
+			Timestamp:   event.Timestamp,
+		}
+		if op.Id != nil {
+			opEvent.TargetId = *op.Id
+		}
+		if err := h.sink.Record(r.Context(), opEvent); err != nil {
+			getLogger(w).Error("failed to record audit event", "operation", opEvent.OperationId, "error", err)
+		}
+	}
+}
+
+// bufferedResponseWriter is an http.ResponseWriter that buffers the response,
+// so that it can be written to the underlying writer later, or be discarded.
+type bufferedResponseWriter struct {
+	http.ResponseWriter
+
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+// newBufferedResponseWriter returns a new instance of the bufferedResponseWriter struct.
+func newBufferedResponseWriter(w http.ResponseWriter) *bufferedResponseWriter {
+	return &bufferedResponseWriter{
+		ResponseWriter: w,
+		header:         http.Header{},
+	}
+}
+
+// Header implements the http.ResponseWriter interface.
+func (w *bufferedResponseWriter) Header() http.Header {
+	return w.header
+}
+
+// WriteHeader implements the http.ResponseWriter interface.
+func (w *bufferedResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+// Write implements the http.ResponseWriter interface.
+func (w *bufferedResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(b)
+}
+
+// Unwrap returns the underlying http.ResponseWriter.
+func (w *bufferedResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// responseStatus returns the buffered status code.
+func (w *bufferedResponseWriter) responseStatus() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+// flush writes the buffered response to the underlying writer.
+func (w *bufferedResponseWriter) flush() {
+	header := w.ResponseWriter.Header()
+	for k, v := range w.header {
+		header[k] = v
+	}
+	w.ResponseWriter.WriteHeader(w.responseStatus())
+	if _, err := w.ResponseWriter.Write(w.body.Bytes()); err != nil {
+		getLogger(w).Error("failed to write response body", "error", err)
+	}
+}
+
+// PostIdentityProviders records an audit event and delegates the call to the wrapped handler's `PostIdentityProviders` method.
+func (h handlerWithAudit) PostIdentityProviders(w http.ResponseWriter, r *http.Request) {
+	h.audit(w, r, "PostIdentityProviders", "", func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PostIdentityProviders(w, r)
+	})
+}
+
+// DeleteIdentityProvidersItem records an audit event and delegates the call to the wrapped handler's `DeleteIdentityProvidersItem` method.
+func (h handlerWithAudit) DeleteIdentityProvidersItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.audit(w, r, "DeleteIdentityProvidersItem", id, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.DeleteIdentityProvidersItem(w, r, id)
+	})
+}
+
+// PutIdentityProvidersItem records an audit event and delegates the call to the wrapped handler's `PutIdentityProvidersItem` method.
+func (h handlerWithAudit) PutIdentityProvidersItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.audit(w, r, "PutIdentityProvidersItem", id, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PutIdentityProvidersItem(w, r, id)
+	})
+}
+
+// PostGroups records an audit event and delegates the call to the wrapped handler's `PostGroups` method.
+func (h handlerWithAudit) PostGroups(w http.ResponseWriter, r *http.Request) {
+	h.audit(w, r, "PostGroups", "", func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PostGroups(w, r)
+	})
+}
+
+// DeleteGroupsItem records an audit event and delegates the call to the wrapped handler's `DeleteGroupsItem` method.
+func (h handlerWithAudit) DeleteGroupsItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.audit(w, r, "DeleteGroupsItem", id, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.DeleteGroupsItem(w, r, id)
+	})
+}
+
+// PutGroupsItem records an audit event and delegates the call to the wrapped handler's `PutGroupsItem` method.
+func (h handlerWithAudit) PutGroupsItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.audit(w, r, "PutGroupsItem", id, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PutGroupsItem(w, r, id)
+	})
+}
+
+// PatchGroupsItemEntitlements records an audit event and delegates the call to the wrapped handler's `PatchGroupsItemEntitlements` method.
+func (h handlerWithAudit) PatchGroupsItemEntitlements(w http.ResponseWriter, r *http.Request, id string) {
+	h.audit(w, r, "PatchGroupsItemEntitlements", id, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PatchGroupsItemEntitlements(w, r, id)
+	})
+}
+
+// PatchGroupsItemIdentities records an audit event and delegates the call to the wrapped handler's `PatchGroupsItemIdentities` method.
+func (h handlerWithAudit) PatchGroupsItemIdentities(w http.ResponseWriter, r *http.Request, id string) {
+	h.audit(w, r, "PatchGroupsItemIdentities", id, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PatchGroupsItemIdentities(w, r, id)
+	})
+}
+
+// PatchGroupsItemRoles records an audit event and delegates the call to the wrapped handler's `PatchGroupsItemRoles` method.
+func (h handlerWithAudit) PatchGroupsItemRoles(w http.ResponseWriter, r *http.Request, id string) {
+	h.audit(w, r, "PatchGroupsItemRoles", id, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PatchGroupsItemRoles(w, r, id)
+	})
+}
+
+// PostIdentities records an audit event and delegates the call to the wrapped handler's `PostIdentities` method.
+func (h handlerWithAudit) PostIdentities(w http.ResponseWriter, r *http.Request) {
+	h.audit(w, r, "PostIdentities", "", func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PostIdentities(w, r)
+	})
+}
+
+// DeleteIdentitiesItem records an audit event and delegates the call to the wrapped handler's `DeleteIdentitiesItem` method.
+func (h handlerWithAudit) DeleteIdentitiesItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.audit(w, r, "DeleteIdentitiesItem", id, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.DeleteIdentitiesItem(w, r, id)
+	})
+}
+
+// PutIdentitiesItem records an audit event and delegates the call to the wrapped handler's `PutIdentitiesItem` method.
+func (h handlerWithAudit) PutIdentitiesItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.audit(w, r, "PutIdentitiesItem", id, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PutIdentitiesItem(w, r, id)
+	})
+}
+
+// PatchIdentitiesItemEntitlements records an audit event and delegates the call to the wrapped handler's `PatchIdentitiesItemEntitlements` method.
+func (h handlerWithAudit) PatchIdentitiesItemEntitlements(w http.ResponseWriter, r *http.Request, id string) {
+	h.audit(w, r, "PatchIdentitiesItemEntitlements", id, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PatchIdentitiesItemEntitlements(w, r, id)
+	})
+}
+
+// PatchIdentitiesItemGroups records an audit event and delegates the call to the wrapped handler's `PatchIdentitiesItemGroups` method.
+func (h handlerWithAudit) PatchIdentitiesItemGroups(w http.ResponseWriter, r *http.Request, id string) {
+	h.audit(w, r, "PatchIdentitiesItemGroups", id, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PatchIdentitiesItemGroups(w, r, id)
+	})
+}
+
+// PatchIdentitiesItemRoles records an audit event and delegates the call to the wrapped handler's `PatchIdentitiesItemRoles` method.
+func (h handlerWithAudit) PatchIdentitiesItemRoles(w http.ResponseWriter, r *http.Request, id string) {
+	h.audit(w, r, "PatchIdentitiesItemRoles", id, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PatchIdentitiesItemRoles(w, r, id)
+	})
+}
+
+// PostRoles records an audit event and delegates the call to the wrapped handler's `PostRoles` method.
+func (h handlerWithAudit) PostRoles(w http.ResponseWriter, r *http.Request) {
+	h.audit(w, r, "PostRoles", "", func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PostRoles(w, r)
+	})
+}
+
+// DeleteRolesItem records an audit event and delegates the call to the wrapped handler's `DeleteRolesItem` method.
+func (h handlerWithAudit) DeleteRolesItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.audit(w, r, "DeleteRolesItem", id, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.DeleteRolesItem(w, r, id)
+	})
+}
+
+// PutRolesItem records an audit event and delegates the call to the wrapped handler's `PutRolesItem` method.
+func (h handlerWithAudit) PutRolesItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.audit(w, r, "PutRolesItem", id, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PutRolesItem(w, r, id)
+	})
+}
+
+// PatchRolesItemEntitlements records an audit event and delegates the call to the wrapped handler's `PatchRolesItemEntitlements` method.
+func (h handlerWithAudit) PatchRolesItemEntitlements(w http.ResponseWriter, r *http.Request, id string) {
+	h.audit(w, r, "PatchRolesItemEntitlements", id, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PatchRolesItemEntitlements(w, r, id)
+	})
+}
+
+// PostBulk records an audit event and delegates the call to the wrapped handler's `PostBulk` method.
+func (h handlerWithAudit) PostBulk(w http.ResponseWriter, r *http.Request) {
+	h.audit(w, r, "PostBulk", "", func(w http.ResponseWriter, r *http.Request) {
+		extensionsOf(h.ServerInterface).PostBulk(w, r)
+	})
+}
+
+// PostCheck delegates the call to the wrapped handler's `PostCheck` method.
+func (h handlerWithAudit) PostCheck(w http.ResponseWriter, r *http.Request) {
+	// This operation does not mutate anything, so it's not audited.
+	extensionsOf(h.ServerInterface).PostCheck(w, r)
+}
+
+// GetIdentitiesItemEffectiveEntitlements delegates the call to the wrapped handler's `GetIdentitiesItemEffectiveEntitlements` method.
+func (h handlerWithAudit) GetIdentitiesItemEffectiveEntitlements(w http.ResponseWriter, r *http.Request, id string) {
+	// This operation does not mutate anything, so it's not audited.
+	extensionsOf(h.ServerInterface).GetIdentitiesItemEffectiveEntitlements(w, r, id)
+}
+
+// GetResourcesItemAccess delegates the call to the wrapped handler's `GetResourcesItemAccess` method.
+func (h handlerWithAudit) GetResourcesItemAccess(w http.ResponseWriter, r *http.Request, pType string, id string, params resources.GetResourcesItemAccessParams) {
+	// This operation does not mutate anything, so it's not audited.
+	extensionsOf(h.ServerInterface).GetResourcesItemAccess(w, r, pType, id, params)
+}
@@ -0,0 +1,304 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+//go:generate mockgen -package interfaces -destination ./interfaces/mock_audit.go -source=./interfaces/audit.go
+
+func TestAudit(t *testing.T) {
+	c := qt.New(t)
+
+	patchBody := `{"patches":[{"identity":"some-identity","op":"add"}]}`
+	parsedPatchBody := &resources.GroupIdentitiesPatchRequestBody{
+		Patches: []resources.GroupIdentitiesPatchItem{{
+			Identity: "some-identity",
+			Op:       resources.GroupIdentitiesPatchItemOpAdd,
+		}},
+	}
+
+	tests := []struct {
+		name             string
+		method           string
+		path             string
+		body             string
+		setupGroupsMock  func(mock *interfaces.MockGroupsService)
+		setupAuthorizer  func(mock *interfaces.MockAuthorizer)
+		sinkError        error
+		failClosed       bool
+		expectedEvents   []interfaces.AuditEvent
+		expectedStatus   int
+		expectedResponse string
+		expectedLog      string
+	}{{
+		name:   "successful patch",
+		method: http.MethodPatch,
+		path:   "/v1/groups/some-group/identities",
+		body:   patchBody,
+		setupGroupsMock: func(mock *interfaces.MockGroupsService) {
+			mock.EXPECT().PatchGroupIdentities(gomock.Any(), "some-group", parsedPatchBody.Patches).Return(true, nil)
+		},
+		expectedEvents: []interfaces.AuditEvent{{
+			Identity:    "some-caller",
+			OperationId: "PatchGroupsItemIdentities",
+			TargetId:    "some-group",
+			RequestBody: parsedPatchBody,
+			Status:      http.StatusOK,
+		}},
+		expectedStatus: http.StatusOK,
+	}, {
+		name:   "failed delete",
+		method: http.MethodDelete,
+		path:   "/v1/groups/some-group",
+		setupGroupsMock: func(mock *interfaces.MockGroupsService) {
+			mock.EXPECT().DeleteGroup(gomock.Any(), "some-group").Return(false, NewNotFoundError("no such group"))
+		},
+		expectedEvents: []interfaces.AuditEvent{{
+			Identity:    "some-caller",
+			OperationId: "DeleteGroupsItem",
+			TargetId:    "some-group",
+			Status:      http.StatusNotFound,
+		}},
+		expectedStatus: http.StatusNotFound,
+	}, {
+		name:   "invalid request body",
+		method: http.MethodPost,
+		path:   "/v1/groups",
+		body:   "{",
+		expectedEvents: []interfaces.AuditEvent{{
+			Identity:    "some-caller",
+			OperationId: "PostGroups",
+			Status:      http.StatusBadRequest,
+		}},
+		expectedStatus: http.StatusBadRequest,
+	}, {
+		name:   "unauthorized",
+		method: http.MethodDelete,
+		path:   "/v1/groups/some-group",
+		setupAuthorizer: func(mock *interfaces.MockAuthorizer) {
+			mock.EXPECT().Authorize(gomock.Any(), "some-caller", "DeleteGroupsItem", []string{"some-group"}).Return(false, nil)
+		},
+		expectedEvents: []interfaces.AuditEvent{{
+			Identity:    "some-caller",
+			OperationId: "DeleteGroupsItem",
+			TargetId:    "some-group",
+			Status:      http.StatusForbidden,
+		}},
+		expectedStatus: http.StatusForbidden,
+	}, {
+		name:   "read operations are not audited",
+		method: http.MethodGet,
+		path:   "/v1/groups/some-group",
+		setupGroupsMock: func(mock *interfaces.MockGroupsService) {
+			mock.EXPECT().GetGroup(gomock.Any(), "some-group").Return(&resources.Group{Name: "some-group"}, nil)
+		},
+		expectedStatus: http.StatusOK,
+	}, {
+		name:   "sink failure, fail-open",
+		method: http.MethodDelete,
+		path:   "/v1/groups/some-group",
+		setupGroupsMock: func(mock *interfaces.MockGroupsService) {
+			mock.EXPECT().DeleteGroup(gomock.Any(), "some-group").Return(true, nil)
+		},
+		sinkError: errors.New("sink is down"),
+		expectedEvents: []interfaces.AuditEvent{{
+			Identity:    "some-caller",
+			OperationId: "DeleteGroupsItem",
+			TargetId:    "some-group",
+			Status:      http.StatusOK,
+		}},
+		expectedStatus: http.StatusOK,
+		expectedLog:    "failed to record audit event",
+	}, {
+		name:       "sink failure, fail-closed",
+		method:     http.MethodDelete,
+		path:       "/v1/groups/some-group",
+		sinkError:  errors.New("sink is down"),
+		failClosed: true,
+		// The operation is not performed, since the pending event could not be
+		// recorded.
+		expectedEvents: []interfaces.AuditEvent{{
+			Identity:    "some-caller",
+			OperationId: "DeleteGroupsItem",
+			TargetId:    "some-group",
+			Pending:     true,
+		}},
+		expectedStatus:   http.StatusInternalServerError,
+		expectedResponse: "failed to record audit event",
+		expectedLog:      "failed to record audit event",
+	}, {
+		name:   "successful sink, fail-closed",
+		method: http.MethodPost,
+		path:   "/v1/groups",
+		body:   `{"name":"some-group"}`,
+		setupGroupsMock: func(mock *interfaces.MockGroupsService) {
+			mock.EXPECT().CreateGroup(gomock.Any(), &resources.Group{Name: "some-group"}).Return(&resources.Group{Name: "some-group"}, nil)
+		},
+		failClosed: true,
+		expectedEvents: []interfaces.AuditEvent{{
+			Identity:    "some-caller",
+			OperationId: "PostGroups",
+			Pending:     true,
+		}, {
+			Identity:    "some-caller",
+			OperationId: "PostGroups",
+			RequestBody: &resources.Group{Name: "some-group"},
+			Status:      http.StatusCreated,
+		}},
+		expectedStatus:   http.StatusCreated,
+		expectedResponse: `"name":"some-group"`,
+	}}
+
+	for _, test := range tests {
+		tt := test
+		c.Run(tt.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+			defer ctrl.Finish()
+
+			groups := interfaces.NewMockGroupsService(ctrl)
+			if tt.setupGroupsMock != nil {
+				tt.setupGroupsMock(groups)
+			}
+
+			authenticator := interfaces.NewMockAuthenticator(ctrl)
+			authenticator.EXPECT().Authenticate(gomock.Any()).Return("some-caller", nil)
+
+			var authorizer interfaces.Authorizer
+			if tt.setupAuthorizer != nil {
+				mockAuthorizer := interfaces.NewMockAuthorizer(ctrl)
+				tt.setupAuthorizer(mockAuthorizer)
+				authorizer = mockAuthorizer
+			}
+
+			start := time.Now()
+			sink := interfaces.NewMockAuditSink(ctrl)
+			var events []interfaces.AuditEvent
+			sink.EXPECT().Record(gomock.Any(), gomock.Any()).DoAndReturn(func(_ any, event interfaces.AuditEvent) error {
+				c.Assert(event.Timestamp.Before(start), qt.IsFalse)
+				event.Timestamp = time.Time{}
+				events = append(events, event)
+				return tt.sinkError
+			}).AnyTimes()
+
+			logs := &bytes.Buffer{}
+			sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+				Authenticator:       authenticator,
+				Authorizer:          authorizer,
+				Groups:              groups,
+				AuditSink:           sink,
+				AuditSinkFailClosed: tt.failClosed,
+				Logger:              slog.New(slog.NewTextHandler(logs, nil)),
+			})
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			sut.Handler("").ServeHTTP(w, req)
+
+			c.Assert(w.Code, qt.Equals, tt.expectedStatus)
+			c.Assert(events, qt.DeepEquals, tt.expectedEvents)
+			body, err := io.ReadAll(w.Result().Body)
+			c.Assert(err, qt.IsNil)
+			if len(body) > 0 {
+				c.Assert(json.Valid(body), qt.IsTrue)
+			}
+			if tt.expectedResponse != "" {
+				c.Assert(string(body), qt.Contains, tt.expectedResponse)
+				c.Assert(w.Header().Get("Content-Type"), qt.Equals, "application/json")
+			}
+			if tt.expectedLog != "" {
+				c.Assert(logs.String(), qt.Contains, tt.expectedLog)
+			}
+		})
+	}
+}
+
+func TestAuditBulkOperations(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	operations := []resources.BulkOperation{{
+		Op: resources.BulkOperationOpPatchGroupIdentities,
+		Id: stringPtr("some-group"),
+		GroupIdentities: []resources.GroupIdentitiesPatchItem{
+			{Identity: "some-identity", Op: resources.GroupIdentitiesPatchItemOpAdd},
+		},
+	}, {
+		Op: resources.BulkOperationOpDeleteRole,
+		Id: stringPtr("some-role"),
+	}}
+
+	groups := interfaces.NewMockGroupsService(ctrl)
+	groups.EXPECT().PatchGroupIdentities(gomock.Any(), "some-group", operations[0].GroupIdentities).Return(true, nil)
+	roles := interfaces.NewMockRolesService(ctrl)
+	roles.EXPECT().DeleteRole(gomock.Any(), "some-role").Return(false, NewNotFoundError("no such role"))
+
+	var events []interfaces.AuditEvent
+	sink := interfaces.NewMockAuditSink(ctrl)
+	sink.EXPECT().Record(gomock.Any(), gomock.Any()).DoAndReturn(func(_ any, event interfaces.AuditEvent) error {
+		event.Timestamp = time.Time{}
+		events = append(events, event)
+		return nil
+	}).AnyTimes()
+
+	sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+		Groups:         groups,
+		Roles:          roles,
+		SequentialBulk: true,
+		AuditSink:      sink,
+	})
+
+	raw, _ := json.Marshal(resources.BulkRequestBody{Operations: operations})
+	req := httptest.NewRequest(http.MethodPost, "/v1/bulk", bytes.NewReader(raw))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	sut.Handler("").ServeHTTP(w, req)
+	c.Assert(w.Code, qt.Equals, http.StatusMultiStatus)
+
+	// The bulk request is recorded, followed by each of its operations.
+	c.Assert(events, qt.HasLen, 3)
+	c.Assert(events[0].OperationId, qt.Equals, "PostBulk")
+	c.Assert(events[0].BulkIndex, qt.IsNil)
+	c.Assert(events[1:], qt.DeepEquals, []interfaces.AuditEvent{{
+		OperationId: "PatchGroupsItemIdentities",
+		TargetId:    "some-group",
+		RequestBody: &resources.GroupIdentitiesPatchRequestBody{Patches: operations[0].GroupIdentities},
+		Status:      http.StatusOK,
+		BulkIndex:   intPtr(0),
+	}, {
+		OperationId: "DeleteRolesItem",
+		TargetId:    "some-role",
+		Status:      http.StatusNotFound,
+		BulkIndex:   intPtr(1),
+	}})
+}
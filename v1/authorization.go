@@ -0,0 +1,430 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// handlerWithAuthorization decorates a given handler with authorization logic.
+// Before delegating to the wrapped handler, the caller identity, the operation
+// ID and the target entity IDs are passed to the provided authorizer backend.
+type handlerWithAuthorization struct {
+	// Wrapped/decorated handler
+	resources.ServerInterface
+
+	authorizer  interfaces.Authorizer
+	errorMapper ErrorResponseMapper
+}
+
+// newHandlerWithAuthorization returns a new instance of the handlerWithAuthorization struct.
+func newHandlerWithAuthorization(handler resources.ServerInterface, authorizer interfaces.Authorizer, errorMapper ErrorResponseMapper) *handlerWithAuthorization {
+	return &handlerWithAuthorization{
+		ServerInterface: handler,
+		authorizer:      authorizer,
+		errorMapper:     errorMapper,
+	}
+}
+
+// authorize is a helper method to avoid repetition. It asks the authorizer
+// backend whether the caller is allowed to perform the given operation and if
+// so, will delegate to the provided callback. Otherwise, it responds with a
+// `403 Forbidden` status code.
+func (h handlerWithAuthorization) authorize(w http.ResponseWriter, r *http.Request, operationId string, targetIds []string, f func(w http.ResponseWriter, r *http.Request)) {
+	if !h.allow(w, r, operationId, targetIds) {
+		return
+	}
+	f(w, r)
+}
+
+// allow asks the authorizer backend whether the caller is allowed to perform
+// the given operation. If not, it writes the error response and returns false.
+func (h handlerWithAuthorization) allow(w http.ResponseWriter, r *http.Request, operationId string, targetIds []string) bool {
+	allowed, err := h.authorizer.Authorize(r.Context(), getIdentityOrNilFromContext(r.Context()), operationId, targetIds)
+	if err != nil {
+		writeServiceErrorResponse(w, h.errorMapper, err)
+		return false
+	}
+	if !allowed {
+		writeErrorResponse(w, NewForbiddenError(operationId))
+		return false
+	}
+	return true
+}
+
+// operationAuthorizer authorizes an operation that is only known to the inner
+// handlers (e.g., the operations of a bulk request, which are only known after
+// the request body is parsed). If the caller is not allowed to perform the
+// operation, it writes the error response and returns false.
+type operationAuthorizer func(w http.ResponseWriter, r *http.Request, operationId string, targetIds []string) bool
+
+// operationAuthorizerContextKey is the context key to retrieve the operation
+// authorizer of the current request.
+type operationAuthorizerContextKey struct{}
+
+// getOperationAuthorizerFromContext returns the operation authorizer stored in
+// the given context, or nil if there is none (i.e., no authorizer backend is
+// provided).
+func getOperationAuthorizerFromContext(ctx context.Context) operationAuthorizer {
+	authorizer, _ := ctx.Value(operationAuthorizerContextKey{}).(operationAuthorizer)
+	return authorizer
+}
+
+// getIdentityOrNilFromContext returns the caller identity stored in the given
+// context, or nil if there is none.
+func getIdentityOrNilFromContext(ctx context.Context) any {
+	identity, err := GetIdentityFromContext(ctx)
+	if err != nil {
+		return nil
+	}
+	return identity
+}
+
+// callerScope returns the hash of the caller identity associated with the
+// given context, so that data kept per caller (e.g., idempotency keys, or
+// cached responses) does not leak between callers. Identities are compared by
+// their JSON form; unknown callers share the same (empty) scope.
+func callerScope(ctx context.Context) string {
+	identity := getIdentityOrNilFromContext(ctx)
+	if identity == nil {
+		return ""
+	}
+	raw, err := json.Marshal(identity)
+	if err != nil {
+		raw = []byte(fmt.Sprintf("%#v", identity))
+	}
+	hash := sha256.Sum256(raw)
+	return hex.EncodeToString(hash[:])
+}
+
+// SwaggerJson delegates the call to the wrapped handler's `SwaggerJson` method.
+func (h handlerWithAuthorization) SwaggerJson(w http.ResponseWriter, r *http.Request) {
+	// This endpoint is public (i.e., no authentication is required), so there's
+	// no need to authorize the request.
+	h.ServerInterface.SwaggerJson(w, r)
+}
+
+// GetIdentityProviders authorizes the request and delegates the call to the wrapped handler's `GetIdentityProviders` method.
+func (h handlerWithAuthorization) GetIdentityProviders(w http.ResponseWriter, r *http.Request, params resources.GetIdentityProvidersParams) {
+	h.authorize(w, r, "GetIdentityProviders", nil, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetIdentityProviders(w, r, params)
+	})
+}
+
+// PostIdentityProviders authorizes the request and delegates the call to the wrapped handler's `PostIdentityProviders` method.
+func (h handlerWithAuthorization) PostIdentityProviders(w http.ResponseWriter, r *http.Request) {
+	h.authorize(w, r, "PostIdentityProviders", nil, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PostIdentityProviders(w, r)
+	})
+}
+
+// GetAvailableIdentityProviders authorizes the request and delegates the call to the wrapped handler's `GetAvailableIdentityProviders` method.
+func (h handlerWithAuthorization) GetAvailableIdentityProviders(w http.ResponseWriter, r *http.Request, params resources.GetAvailableIdentityProvidersParams) {
+	h.authorize(w, r, "GetAvailableIdentityProviders", nil, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetAvailableIdentityProviders(w, r, params)
+	})
+}
+
+// DeleteIdentityProvidersItem authorizes the request and delegates the call to the wrapped handler's `DeleteIdentityProvidersItem` method.
+func (h handlerWithAuthorization) DeleteIdentityProvidersItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.authorize(w, r, "DeleteIdentityProvidersItem", []string{id}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.DeleteIdentityProvidersItem(w, r, id)
+	})
+}
+
+// GetIdentityProvidersItem authorizes the request and delegates the call to the wrapped handler's `GetIdentityProvidersItem` method.
+func (h handlerWithAuthorization) GetIdentityProvidersItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.authorize(w, r, "GetIdentityProvidersItem", []string{id}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetIdentityProvidersItem(w, r, id)
+	})
+}
+
+// PutIdentityProvidersItem authorizes the request and delegates the call to the wrapped handler's `PutIdentityProvidersItem` method.
+func (h handlerWithAuthorization) PutIdentityProvidersItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.authorize(w, r, "PutIdentityProvidersItem", []string{id}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PutIdentityProvidersItem(w, r, id)
+	})
+}
+
+// GetCapabilities authorizes the request and delegates the call to the wrapped handler's `GetCapabilities` method.
+func (h handlerWithAuthorization) GetCapabilities(w http.ResponseWriter, r *http.Request) {
+	h.authorize(w, r, "GetCapabilities", nil, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetCapabilities(w, r)
+	})
+}
+
+// GetEntitlements authorizes the request and delegates the call to the wrapped handler's `GetEntitlements` method.
+func (h handlerWithAuthorization) GetEntitlements(w http.ResponseWriter, r *http.Request, params resources.GetEntitlementsParams) {
+	h.authorize(w, r, "GetEntitlements", nil, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetEntitlements(w, r, params)
+	})
+}
+
+// GetRawEntitlements authorizes the request and delegates the call to the wrapped handler's `GetRawEntitlements` method.
+func (h handlerWithAuthorization) GetRawEntitlements(w http.ResponseWriter, r *http.Request) {
+	h.authorize(w, r, "GetRawEntitlements", nil, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetRawEntitlements(w, r)
+	})
+}
+
+// GetGroups authorizes the request and delegates the call to the wrapped handler's `GetGroups` method.
+func (h handlerWithAuthorization) GetGroups(w http.ResponseWriter, r *http.Request, params resources.GetGroupsParams) {
+	h.authorize(w, r, "GetGroups", nil, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetGroups(w, r, params)
+	})
+}
+
+// PostGroups authorizes the request and delegates the call to the wrapped handler's `PostGroups` method.
+func (h handlerWithAuthorization) PostGroups(w http.ResponseWriter, r *http.Request) {
+	h.authorize(w, r, "PostGroups", nil, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PostGroups(w, r)
+	})
+}
+
+// DeleteGroupsItem authorizes the request and delegates the call to the wrapped handler's `DeleteGroupsItem` method.
+func (h handlerWithAuthorization) DeleteGroupsItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.authorize(w, r, "DeleteGroupsItem", []string{id}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.DeleteGroupsItem(w, r, id)
+	})
+}
+
+// GetGroupsItem authorizes the request and delegates the call to the wrapped handler's `GetGroupsItem` method.
+func (h handlerWithAuthorization) GetGroupsItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.authorize(w, r, "GetGroupsItem", []string{id}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetGroupsItem(w, r, id)
+	})
+}
+
+// PutGroupsItem authorizes the request and delegates the call to the wrapped handler's `PutGroupsItem` method.
+func (h handlerWithAuthorization) PutGroupsItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.authorize(w, r, "PutGroupsItem", []string{id}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PutGroupsItem(w, r, id)
+	})
+}
+
+// GetGroupsItemEntitlements authorizes the request and delegates the call to the wrapped handler's `GetGroupsItemEntitlements` method.
+func (h handlerWithAuthorization) GetGroupsItemEntitlements(w http.ResponseWriter, r *http.Request, id string, params resources.GetGroupsItemEntitlementsParams) {
+	h.authorize(w, r, "GetGroupsItemEntitlements", []string{id}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetGroupsItemEntitlements(w, r, id, params)
+	})
+}
+
+// PatchGroupsItemEntitlements authorizes the request and delegates the call to the wrapped handler's `PatchGroupsItemEntitlements` method.
+func (h handlerWithAuthorization) PatchGroupsItemEntitlements(w http.ResponseWriter, r *http.Request, id string) {
+	h.authorize(w, r, "PatchGroupsItemEntitlements", []string{id}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PatchGroupsItemEntitlements(w, r, id)
+	})
+}
+
+// GetGroupsItemIdentities authorizes the request and delegates the call to the wrapped handler's `GetGroupsItemIdentities` method.
+func (h handlerWithAuthorization) GetGroupsItemIdentities(w http.ResponseWriter, r *http.Request, id string, params resources.GetGroupsItemIdentitiesParams) {
+	h.authorize(w, r, "GetGroupsItemIdentities", []string{id}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetGroupsItemIdentities(w, r, id, params)
+	})
+}
+
+// PatchGroupsItemIdentities authorizes the request and delegates the call to the wrapped handler's `PatchGroupsItemIdentities` method.
+func (h handlerWithAuthorization) PatchGroupsItemIdentities(w http.ResponseWriter, r *http.Request, id string) {
+	h.authorize(w, r, "PatchGroupsItemIdentities", []string{id}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PatchGroupsItemIdentities(w, r, id)
+	})
+}
+
+// GetGroupsItemRoles authorizes the request and delegates the call to the wrapped handler's `GetGroupsItemRoles` method.
+func (h handlerWithAuthorization) GetGroupsItemRoles(w http.ResponseWriter, r *http.Request, id string, params resources.GetGroupsItemRolesParams) {
+	h.authorize(w, r, "GetGroupsItemRoles", []string{id}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetGroupsItemRoles(w, r, id, params)
+	})
+}
+
+// PatchGroupsItemRoles authorizes the request and delegates the call to the wrapped handler's `PatchGroupsItemRoles` method.
+func (h handlerWithAuthorization) PatchGroupsItemRoles(w http.ResponseWriter, r *http.Request, id string) {
+	h.authorize(w, r, "PatchGroupsItemRoles", []string{id}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PatchGroupsItemRoles(w, r, id)
+	})
+}
+
+// GetIdentities authorizes the request and delegates the call to the wrapped handler's `GetIdentities` method.
+func (h handlerWithAuthorization) GetIdentities(w http.ResponseWriter, r *http.Request, params resources.GetIdentitiesParams) {
+	h.authorize(w, r, "GetIdentities", nil, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetIdentities(w, r, params)
+	})
+}
+
+// PostIdentities authorizes the request and delegates the call to the wrapped handler's `PostIdentities` method.
+func (h handlerWithAuthorization) PostIdentities(w http.ResponseWriter, r *http.Request) {
+	h.authorize(w, r, "PostIdentities", nil, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PostIdentities(w, r)
+	})
+}
+
+// DeleteIdentitiesItem authorizes the request and delegates the call to the wrapped handler's `DeleteIdentitiesItem` method.
+func (h handlerWithAuthorization) DeleteIdentitiesItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.authorize(w, r, "DeleteIdentitiesItem", []string{id}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.DeleteIdentitiesItem(w, r, id)
+	})
+}
+
+// GetIdentitiesItem authorizes the request and delegates the call to the wrapped handler's `GetIdentitiesItem` method.
+func (h handlerWithAuthorization) GetIdentitiesItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.authorize(w, r, "GetIdentitiesItem", []string{id}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetIdentitiesItem(w, r, id)
+	})
+}
+
+// PutIdentitiesItem authorizes the request and delegates the call to the wrapped handler's `PutIdentitiesItem` method.
+func (h handlerWithAuthorization) PutIdentitiesItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.authorize(w, r, "PutIdentitiesItem", []string{id}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PutIdentitiesItem(w, r, id)
+	})
+}
+
+// GetIdentitiesItemEntitlements authorizes the request and delegates the call to the wrapped handler's `GetIdentitiesItemEntitlements` method.
+func (h handlerWithAuthorization) GetIdentitiesItemEntitlements(w http.ResponseWriter, r *http.Request, id string, params resources.GetIdentitiesItemEntitlementsParams) {
+	h.authorize(w, r, "GetIdentitiesItemEntitlements", []string{id}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetIdentitiesItemEntitlements(w, r, id, params)
+	})
+}
+
+// PatchIdentitiesItemEntitlements authorizes the request and delegates the call to the wrapped handler's `PatchIdentitiesItemEntitlements` method.
+func (h handlerWithAuthorization) PatchIdentitiesItemEntitlements(w http.ResponseWriter, r *http.Request, id string) {
+	h.authorize(w, r, "PatchIdentitiesItemEntitlements", []string{id}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PatchIdentitiesItemEntitlements(w, r, id)
+	})
+}
+
+// GetIdentitiesItemGroups authorizes the request and delegates the call to the wrapped handler's `GetIdentitiesItemGroups` method.
+func (h handlerWithAuthorization) GetIdentitiesItemGroups(w http.ResponseWriter, r *http.Request, id string, params resources.GetIdentitiesItemGroupsParams) {
+	h.authorize(w, r, "GetIdentitiesItemGroups", []string{id}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetIdentitiesItemGroups(w, r, id, params)
+	})
+}
+
+// PatchIdentitiesItemGroups authorizes the request and delegates the call to the wrapped handler's `PatchIdentitiesItemGroups` method.
+func (h handlerWithAuthorization) PatchIdentitiesItemGroups(w http.ResponseWriter, r *http.Request, id string) {
+	h.authorize(w, r, "PatchIdentitiesItemGroups", []string{id}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PatchIdentitiesItemGroups(w, r, id)
+	})
+}
+
+// GetIdentitiesItemRoles authorizes the request and delegates the call to the wrapped handler's `GetIdentitiesItemRoles` method.
+func (h handlerWithAuthorization) GetIdentitiesItemRoles(w http.ResponseWriter, r *http.Request, id string, params resources.GetIdentitiesItemRolesParams) {
+	h.authorize(w, r, "GetIdentitiesItemRoles", []string{id}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetIdentitiesItemRoles(w, r, id, params)
+	})
+}
+
+// PatchIdentitiesItemRoles authorizes the request and delegates the call to the wrapped handler's `PatchIdentitiesItemRoles` method.
+func (h handlerWithAuthorization) PatchIdentitiesItemRoles(w http.ResponseWriter, r *http.Request, id string) {
+	h.authorize(w, r, "PatchIdentitiesItemRoles", []string{id}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PatchIdentitiesItemRoles(w, r, id)
+	})
+}
+
+// GetResources authorizes the request and delegates the call to the wrapped handler's `GetResources` method.
+func (h handlerWithAuthorization) GetResources(w http.ResponseWriter, r *http.Request, params resources.GetResourcesParams) {
+	h.authorize(w, r, "GetResources", nil, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetResources(w, r, params)
+	})
+}
+
+// GetRoles authorizes the request and delegates the call to the wrapped handler's `GetRoles` method.
+func (h handlerWithAuthorization) GetRoles(w http.ResponseWriter, r *http.Request, params resources.GetRolesParams) {
+	h.authorize(w, r, "GetRoles", nil, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetRoles(w, r, params)
+	})
+}
+
+// PostRoles authorizes the request and delegates the call to the wrapped handler's `PostRoles` method.
+func (h handlerWithAuthorization) PostRoles(w http.ResponseWriter, r *http.Request) {
+	h.authorize(w, r, "PostRoles", nil, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PostRoles(w, r)
+	})
+}
+
+// DeleteRolesItem authorizes the request and delegates the call to the wrapped handler's `DeleteRolesItem` method.
+func (h handlerWithAuthorization) DeleteRolesItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.authorize(w, r, "DeleteRolesItem", []string{id}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.DeleteRolesItem(w, r, id)
+	})
+}
+
+// GetRolesItem authorizes the request and delegates the call to the wrapped handler's `GetRolesItem` method.
+func (h handlerWithAuthorization) GetRolesItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.authorize(w, r, "GetRolesItem", []string{id}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetRolesItem(w, r, id)
+	})
+}
+
+// PutRolesItem authorizes the request and delegates the call to the wrapped handler's `PutRolesItem` method.
+func (h handlerWithAuthorization) PutRolesItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.authorize(w, r, "PutRolesItem", []string{id}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PutRolesItem(w, r, id)
+	})
+}
+
+// GetRolesItemEntitlements authorizes the request and delegates the call to the wrapped handler's `GetRolesItemEntitlements` method.
+func (h handlerWithAuthorization) GetRolesItemEntitlements(w http.ResponseWriter, r *http.Request, id string, params resources.GetRolesItemEntitlementsParams) {
+	h.authorize(w, r, "GetRolesItemEntitlements", []string{id}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetRolesItemEntitlements(w, r, id, params)
+	})
+}
+
+// PatchRolesItemEntitlements authorizes the request and delegates the call to the wrapped handler's `PatchRolesItemEntitlements` method.
+func (h handlerWithAuthorization) PatchRolesItemEntitlements(w http.ResponseWriter, r *http.Request, id string) {
+	h.authorize(w, r, "PatchRolesItemEntitlements", []string{id}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PatchRolesItemEntitlements(w, r, id)
+	})
+}
+
+// PostBulk authorizes the request and delegates the call to the wrapped handler's `PostBulk` method. Since the
+// operations are only known after the request body is parsed, an operation authorizer is attached to the request
+// context, so that the inner handlers can authorize each operation as if it was requested via its own endpoint.
+func (h handlerWithAuthorization) PostBulk(w http.ResponseWriter, r *http.Request) {
+	h.authorize(w, r, "PostBulk", nil, func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), operationAuthorizerContextKey{}, operationAuthorizer(h.allow))
+		extensionsOf(h.ServerInterface).PostBulk(w, r.WithContext(ctx))
+	})
+}
+
+// PostCheck authorizes the request and delegates the call to the wrapped handler's `PostCheck` method.
+func (h handlerWithAuthorization) PostCheck(w http.ResponseWriter, r *http.Request) {
+	h.authorize(w, r, "PostCheck", nil, func(w http.ResponseWriter, r *http.Request) {
+		extensionsOf(h.ServerInterface).PostCheck(w, r)
+	})
+}
+
+// GetIdentitiesItemEffectiveEntitlements authorizes the request and delegates the call to the wrapped handler's `GetIdentitiesItemEffectiveEntitlements` method.
+func (h handlerWithAuthorization) GetIdentitiesItemEffectiveEntitlements(w http.ResponseWriter, r *http.Request, id string) {
+	h.authorize(w, r, "GetIdentitiesItemEffectiveEntitlements", []string{id}, func(w http.ResponseWriter, r *http.Request) {
+		extensionsOf(h.ServerInterface).GetIdentitiesItemEffectiveEntitlements(w, r, id)
+	})
+}
+
+// GetResourcesItemAccess authorizes the request and delegates the call to the wrapped handler's `GetResourcesItemAccess` method.
+func (h handlerWithAuthorization) GetResourcesItemAccess(w http.ResponseWriter, r *http.Request, pType string, id string, params resources.GetResourcesItemAccessParams) {
+	h.authorize(w, r, "GetResourcesItemAccess", []string{pType, id}, func(w http.ResponseWriter, r *http.Request) {
+		extensionsOf(h.ServerInterface).GetResourcesItemAccess(w, r, pType, id, params)
+	})
+}
@@ -0,0 +1,227 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+//go:generate mockgen -package interfaces -destination ./interfaces/mock_authorization.go -source=./interfaces/authorization.go
+//go:generate mockgen -package resources -destination ./resources/mock_generated_server.go -source=./resources/generated_server.go
+//go:generate mockgen -package v1 -destination ./mock_error_response.go -source=./error.go
+
+func TestHandlerWithAuthorization(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		name               string
+		identity           any
+		setupMocks         func(authorizer *interfaces.MockAuthorizer, mapper *MockErrorResponseMapper, handler *resources.MockServerInterface)
+		triggerFunc        func(sut *handlerWithAuthorization, w http.ResponseWriter, r *http.Request)
+		expectedStatusCode int
+		expectedMessage    string
+	}{{
+		name:     "allowed; collection endpoint",
+		identity: "some-identity",
+		setupMocks: func(authorizer *interfaces.MockAuthorizer, _ *MockErrorResponseMapper, handler *resources.MockServerInterface) {
+			authorizer.EXPECT().
+				Authorize(gomock.Any(), "some-identity", "GetGroups", nil).
+				Return(true, nil)
+			handler.EXPECT().
+				GetGroups(gomock.Any(), gomock.Any(), resources.GetGroupsParams{}).
+				Do(func(w http.ResponseWriter, _ *http.Request, _ resources.GetGroupsParams) {
+					w.WriteHeader(http.StatusOK)
+				})
+		},
+		triggerFunc: func(sut *handlerWithAuthorization, w http.ResponseWriter, r *http.Request) {
+			sut.GetGroups(w, r, resources.GetGroupsParams{})
+		},
+		expectedStatusCode: http.StatusOK,
+	}, {
+		name:     "allowed; item endpoint",
+		identity: "some-identity",
+		setupMocks: func(authorizer *interfaces.MockAuthorizer, _ *MockErrorResponseMapper, handler *resources.MockServerInterface) {
+			authorizer.EXPECT().
+				Authorize(gomock.Any(), "some-identity", "PatchIdentitiesItemRoles", []string{"some-id"}).
+				Return(true, nil)
+			handler.EXPECT().
+				PatchIdentitiesItemRoles(gomock.Any(), gomock.Any(), "some-id").
+				Do(func(w http.ResponseWriter, _ *http.Request, _ string) {
+					w.WriteHeader(http.StatusOK)
+				})
+		},
+		triggerFunc: func(sut *handlerWithAuthorization, w http.ResponseWriter, r *http.Request) {
+			sut.PatchIdentitiesItemRoles(w, r, "some-id")
+		},
+		expectedStatusCode: http.StatusOK,
+	}, {
+		name: "allowed; missing identity",
+		setupMocks: func(authorizer *interfaces.MockAuthorizer, _ *MockErrorResponseMapper, handler *resources.MockServerInterface) {
+			authorizer.EXPECT().
+				Authorize(gomock.Any(), nil, "GetCapabilities", nil).
+				Return(true, nil)
+			handler.EXPECT().
+				GetCapabilities(gomock.Any(), gomock.Any()).
+				Do(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				})
+		},
+		triggerFunc: func(sut *handlerWithAuthorization, w http.ResponseWriter, r *http.Request) {
+			sut.GetCapabilities(w, r)
+		},
+		expectedStatusCode: http.StatusOK,
+	}, {
+		name:     "denied",
+		identity: "some-identity",
+		setupMocks: func(authorizer *interfaces.MockAuthorizer, _ *MockErrorResponseMapper, _ *resources.MockServerInterface) {
+			authorizer.EXPECT().
+				Authorize(gomock.Any(), "some-identity", "DeleteRolesItem", []string{"some-id"}).
+				Return(false, nil)
+		},
+		triggerFunc: func(sut *handlerWithAuthorization, w http.ResponseWriter, r *http.Request) {
+			sut.DeleteRolesItem(w, r, "some-id")
+		},
+		expectedStatusCode: http.StatusForbidden,
+		expectedMessage:    "Forbidden: access denied: DeleteRolesItem",
+	}, {
+		name:     "authorizer error; default mapping",
+		identity: "some-identity",
+		setupMocks: func(authorizer *interfaces.MockAuthorizer, mapper *MockErrorResponseMapper, _ *resources.MockServerInterface) {
+			authorizer.EXPECT().
+				Authorize(gomock.Any(), "some-identity", "PostGroups", nil).
+				Return(false, errors.New("some-error"))
+			mapper.EXPECT().MapError(gomock.Any()).Return(nil)
+		},
+		triggerFunc: func(sut *handlerWithAuthorization, w http.ResponseWriter, r *http.Request) {
+			sut.PostGroups(w, r)
+		},
+		expectedStatusCode: http.StatusInternalServerError,
+		expectedMessage:    "Internal Server Error: some-error",
+	}, {
+		name:     "authorizer error; custom mapping",
+		identity: "some-identity",
+		setupMocks: func(authorizer *interfaces.MockAuthorizer, mapper *MockErrorResponseMapper, _ *resources.MockServerInterface) {
+			authorizer.EXPECT().
+				Authorize(gomock.Any(), "some-identity", "PostGroups", nil).
+				Return(false, errors.New("some-error"))
+			mapper.EXPECT().MapError(gomock.Any()).Return(&resources.Response{
+				Status:  http.StatusBadGateway,
+				Message: "mapped-error",
+			})
+		},
+		triggerFunc: func(sut *handlerWithAuthorization, w http.ResponseWriter, r *http.Request) {
+			sut.PostGroups(w, r)
+		},
+		expectedStatusCode: http.StatusBadGateway,
+		expectedMessage:    "mapped-error",
+	}, {
+		name: "swagger.json is not authorized",
+		setupMocks: func(_ *interfaces.MockAuthorizer, _ *MockErrorResponseMapper, handler *resources.MockServerInterface) {
+			handler.EXPECT().
+				SwaggerJson(gomock.Any(), gomock.Any()).
+				Do(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				})
+		},
+		triggerFunc: func(sut *handlerWithAuthorization, w http.ResponseWriter, r *http.Request) {
+			sut.SwaggerJson(w, r)
+		},
+		expectedStatusCode: http.StatusOK,
+	},
+	}
+
+	for _, t := range tests {
+		tt := t
+		c.Run(tt.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+			defer ctrl.Finish()
+
+			authorizer := interfaces.NewMockAuthorizer(ctrl)
+			mapper := NewMockErrorResponseMapper(ctrl)
+			mockHandler := resources.NewMockServerInterface(ctrl)
+			tt.setupMocks(authorizer, mapper, mockHandler)
+
+			sut := newHandlerWithAuthorization(mockHandler, authorizer, mapper)
+
+			req := httptest.NewRequest(http.MethodGet, "/blah", nil)
+			if tt.identity != nil {
+				req = req.WithContext(ContextWithIdentity(req.Context(), tt.identity))
+			}
+
+			w := httptest.NewRecorder()
+			tt.triggerFunc(sut, w, req)
+
+			response := w.Result()
+			defer response.Body.Close()
+			c.Assert(response.StatusCode, qt.Equals, tt.expectedStatusCode)
+
+			if tt.expectedMessage != "" {
+				body, err := io.ReadAll(response.Body)
+				c.Assert(err, qt.IsNil)
+
+				parsedResponse := &resources.Response{}
+				err = json.Unmarshal(body, parsedResponse)
+				c.Assert(err, qt.IsNil)
+				c.Assert(parsedResponse.Message, qt.Equals, tt.expectedMessage)
+				c.Assert(parsedResponse.Status, qt.Equals, tt.expectedStatusCode)
+			}
+		})
+	}
+}
+
+// TestAuthorizationIsWiredToTheBackend asserts that the authorizer backend is
+// consulted (with the authenticated identity) before delegating to services.
+func TestAuthorizationIsWiredToTheBackend(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	authorizer := interfaces.NewMockAuthorizer(ctrl)
+	authorizer.EXPECT().
+		Authorize(gomock.Any(), "some-identity", "GetGroupsItem", []string{"some-group"}).
+		Return(false, nil)
+
+	// No calls are expected on the service backend.
+	groups := interfaces.NewMockGroupsService(ctrl)
+
+	authenticator := interfaces.NewMockAuthenticator(ctrl)
+	authenticator.EXPECT().Authenticate(gomock.Any()).Return("some-identity", nil)
+
+	sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+		Authenticator: authenticator,
+		Authorizer:    authorizer,
+		Groups:        groups,
+	})
+
+	server := httptest.NewServer(sut.Handler(""))
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "/v1/groups/some-group")
+	c.Assert(err, qt.IsNil)
+	defer res.Body.Close()
+	c.Assert(res.StatusCode, qt.Equals, http.StatusForbidden)
+}
@@ -0,0 +1,357 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// bulkOperationIds maps the bulk operation types to the OpenAPI operation IDs
+// of their equivalent endpoints.
+var bulkOperationIds = map[resources.BulkOperationOp]string{
+	resources.BulkOperationOpCreateGroup:               "PostGroups",
+	resources.BulkOperationOpDeleteGroup:               "DeleteGroupsItem",
+	resources.BulkOperationOpPatchGroupIdentities:      "PatchGroupsItemIdentities",
+	resources.BulkOperationOpPatchGroupRoles:           "PatchGroupsItemRoles",
+	resources.BulkOperationOpPatchGroupEntitlements:    "PatchGroupsItemEntitlements",
+	resources.BulkOperationOpCreateRole:                "PostRoles",
+	resources.BulkOperationOpDeleteRole:                "DeleteRolesItem",
+	resources.BulkOperationOpPatchRoleEntitlements:     "PatchRolesItemEntitlements",
+	resources.BulkOperationOpCreateIdentity:            "PostIdentities",
+	resources.BulkOperationOpDeleteIdentity:            "DeleteIdentitiesItem",
+	resources.BulkOperationOpPatchIdentityGroups:       "PatchIdentitiesItemGroups",
+	resources.BulkOperationOpPatchIdentityRoles:        "PatchIdentitiesItemRoles",
+	resources.BulkOperationOpPatchIdentityEntitlements: "PatchIdentitiesItemEntitlements",
+}
+
+// bulkOperationTarget returns the operation ID of the equivalent endpoint of
+// the given bulk operation, along with the target entity IDs, as they would be
+// passed to the authorizer backend.
+func bulkOperationTarget(op resources.BulkOperation) (string, []string) {
+	if op.Id == nil {
+		return bulkOperationIds[op.Op], nil
+	}
+	return bulkOperationIds[op.Op], []string{*op.Id}
+}
+
+// bulkOperationRequestBody returns the request body of the equivalent endpoint
+// of the given bulk operation, as parsed by that endpoint, or nil if the
+// endpoint does not accept a request body.
+func bulkOperationRequestBody(op resources.BulkOperation) any {
+	switch op.Op {
+	case resources.BulkOperationOpCreateGroup:
+		return op.Group
+	case resources.BulkOperationOpPatchGroupIdentities:
+		return &resources.GroupIdentitiesPatchRequestBody{Patches: op.GroupIdentities}
+	case resources.BulkOperationOpPatchGroupRoles:
+		return &resources.GroupRolesPatchRequestBody{Patches: op.GroupRoles}
+	case resources.BulkOperationOpPatchGroupEntitlements:
+		return &resources.GroupEntitlementsPatchRequestBody{Patches: op.GroupEntitlements}
+	case resources.BulkOperationOpCreateRole:
+		return op.Role
+	case resources.BulkOperationOpPatchRoleEntitlements:
+		return &resources.RoleEntitlementsPatchRequestBody{Patches: op.RoleEntitlements}
+	case resources.BulkOperationOpCreateIdentity:
+		return op.Identity
+	case resources.BulkOperationOpPatchIdentityGroups:
+		return &resources.IdentityGroupsPatchRequestBody{Patches: op.IdentityGroups}
+	case resources.BulkOperationOpPatchIdentityRoles:
+		return &resources.IdentityRolesPatchRequestBody{Patches: op.IdentityRoles}
+	case resources.BulkOperationOpPatchIdentityEntitlements:
+		return &resources.IdentityEntitlementsPatchRequestBody{Patches: op.IdentityEntitlements}
+	}
+	return nil
+}
+
+// implementsBulk reports whether the bulk endpoint is available; that is, if
+// there's a bulk service backend, or the sequential execution of the operations
+// is enabled.
+func (h handler) implementsBulk() bool {
+	return h.Bulk != nil || h.BulkFallback != nil
+}
+
+// PostBulk executes a list of operations, in order. If a bulk service backend
+// is provided, the operations are executed atomically. Otherwise, they are
+// executed sequentially until the first failure, and the subsequent operations
+// are skipped.
+// (POST /bulk)
+func (h handler) PostBulk(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	body, err := getRequestBodyFromContext(req.Context())
+	if err != nil {
+		writeErrorResponse(w, err)
+		return
+	}
+
+	bulk, ok := body.(*resources.BulkRequestBody)
+	if !ok {
+		writeErrorResponse(w, NewMissingRequestBodyError(""))
+		return
+	}
+
+	// Each operation is authorized as if it was requested via its equivalent
+	// endpoint, before any of them is executed.
+	if authorize := getOperationAuthorizerFromContext(ctx); authorize != nil {
+		for _, op := range bulk.Operations {
+			operationId, targetIds := bulkOperationTarget(op)
+			if !authorize(w, req, operationId, targetIds) {
+				return
+			}
+		}
+	}
+
+	if h.Bulk != nil {
+		results, err := h.Bulk.ExecuteBulk(ctx, bulk.Operations)
+		if err != nil {
+			writeServiceErrorResponse(w, h.BulkErrorMapper, err)
+			return
+		}
+		setAuditBulkResults(ctx, bulk.Operations, results)
+		writeResponse(w, http.StatusOK, resources.BulkResponse{
+			Atomic:  true,
+			Results: results,
+			Status:  http.StatusOK,
+		})
+		return
+	}
+
+	status := http.StatusOK
+	results := make([]resources.BulkOperationResult, 0, len(bulk.Operations))
+	for _, op := range bulk.Operations {
+		if status != http.StatusOK {
+			// Subsequent operations may depend on the failed one (e.g., patching a
+			// group that failed to be created), so they are skipped.
+			message := "skipped due to a previous failure"
+			results = append(results, resources.BulkOperationResult{
+				Status:  http.StatusFailedDependency,
+				Message: &message,
+			})
+			continue
+		}
+
+		result := h.executeBulkOperation(w, req, op)
+		if result.Status >= http.StatusBadRequest || result.Status == http.StatusMultiStatus {
+			status = http.StatusMultiStatus
+		}
+		results = append(results, result)
+	}
+
+	setAuditBulkResults(ctx, bulk.Operations, results)
+	writeResponse(w, status, resources.BulkResponse{
+		Results: results,
+		Status:  status,
+	})
+}
+
+// executeBulkOperation executes a single bulk operation by serving it via the
+// handler of its equivalent endpoint, so that it's validated and executed
+// exactly as if it was requested on its own.
+func (h handler) executeBulkOperation(w http.ResponseWriter, req *http.Request, op resources.BulkOperation) resources.BulkOperationResult {
+	var id string
+	if op.Id != nil {
+		id = *op.Id
+	}
+
+	var serve func(w http.ResponseWriter, r *http.Request)
+	switch op.Op {
+	case resources.BulkOperationOpCreateGroup:
+		if h.Groups == nil {
+			return bulkNotImplementedResult(op)
+		}
+		serve = h.BulkFallback.PostGroups
+	case resources.BulkOperationOpDeleteGroup:
+		if h.Groups == nil {
+			return bulkNotImplementedResult(op)
+		}
+		serve = func(w http.ResponseWriter, r *http.Request) {
+			h.BulkFallback.DeleteGroupsItem(w, r, id)
+		}
+	case resources.BulkOperationOpPatchGroupIdentities:
+		if h.Groups == nil {
+			return bulkNotImplementedResult(op)
+		}
+		serve = func(w http.ResponseWriter, r *http.Request) {
+			h.BulkFallback.PatchGroupsItemIdentities(w, r, id)
+		}
+	case resources.BulkOperationOpPatchGroupRoles:
+		if h.Groups == nil {
+			return bulkNotImplementedResult(op)
+		}
+		serve = func(w http.ResponseWriter, r *http.Request) {
+			h.BulkFallback.PatchGroupsItemRoles(w, r, id)
+		}
+	case resources.BulkOperationOpPatchGroupEntitlements:
+		if h.Groups == nil {
+			return bulkNotImplementedResult(op)
+		}
+		serve = func(w http.ResponseWriter, r *http.Request) {
+			h.BulkFallback.PatchGroupsItemEntitlements(w, r, id)
+		}
+	case resources.BulkOperationOpCreateRole:
+		if h.Roles == nil {
+			return bulkNotImplementedResult(op)
+		}
+		serve = h.BulkFallback.PostRoles
+	case resources.BulkOperationOpDeleteRole:
+		if h.Roles == nil {
+			return bulkNotImplementedResult(op)
+		}
+		serve = func(w http.ResponseWriter, r *http.Request) {
+			h.BulkFallback.DeleteRolesItem(w, r, id)
+		}
+	case resources.BulkOperationOpPatchRoleEntitlements:
+		if h.Roles == nil {
+			return bulkNotImplementedResult(op)
+		}
+		serve = func(w http.ResponseWriter, r *http.Request) {
+			h.BulkFallback.PatchRolesItemEntitlements(w, r, id)
+		}
+	case resources.BulkOperationOpCreateIdentity:
+		if h.Identities == nil {
+			return bulkNotImplementedResult(op)
+		}
+		serve = h.BulkFallback.PostIdentities
+	case resources.BulkOperationOpDeleteIdentity:
+		if h.Identities == nil {
+			return bulkNotImplementedResult(op)
+		}
+		serve = func(w http.ResponseWriter, r *http.Request) {
+			h.BulkFallback.DeleteIdentitiesItem(w, r, id)
+		}
+	case resources.BulkOperationOpPatchIdentityGroups:
+		if h.Identities == nil {
+			return bulkNotImplementedResult(op)
+		}
+		serve = func(w http.ResponseWriter, r *http.Request) {
+			h.BulkFallback.PatchIdentitiesItemGroups(w, r, id)
+		}
+	case resources.BulkOperationOpPatchIdentityRoles:
+		if h.Identities == nil {
+			return bulkNotImplementedResult(op)
+		}
+		serve = func(w http.ResponseWriter, r *http.Request) {
+			h.BulkFallback.PatchIdentitiesItemRoles(w, r, id)
+		}
+	case resources.BulkOperationOpPatchIdentityEntitlements:
+		if h.Identities == nil {
+			return bulkNotImplementedResult(op)
+		}
+		serve = func(w http.ResponseWriter, r *http.Request) {
+			h.BulkFallback.PatchIdentitiesItemEntitlements(w, r, id)
+		}
+	default:
+		// This should not happen, as the operation types are validated beforehand.
+		return bulkErrorResult(NewValidationError("unknown operation type"))
+	}
+
+	r, err := newBulkOperationRequest(req, op, bulkOperationRequestBody(op))
+	if err != nil {
+		logInternalError(w, mapErrorResponse(err), err)
+		return bulkErrorResult(err)
+	}
+
+	// The response is buffered and turned into the operation result. Any
+	// internal error is still logged by the handler, via the underlying writer.
+	buffered := newBufferedResponseWriter(w)
+	serve(buffered, r)
+	return bulkOperationResult(op, buffered)
+}
+
+// newBulkOperationRequest returns a copy of the given bulk request, with the
+// given body and the `If-Match` header of the operation, if any.
+func newBulkOperationRequest(req *http.Request, op resources.BulkOperation, body any) (*http.Request, error) {
+	// The operations should not be recorded as the body of the bulk request's
+	// audit event.
+	r := req.Clone(withoutAuditEntry(req.Context()))
+	r.Header.Del("If-Match")
+	r.Header.Del(idempotencyKeyHeader)
+	if op.IfMatch != nil {
+		r.Header.Set("If-Match", *op.IfMatch)
+	}
+
+	if body == nil {
+		r.Body = http.NoBody
+		r.ContentLength = 0
+		return r, nil
+	}
+
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(raw))
+	r.ContentLength = int64(len(raw))
+	r.Header.Set("Content-Type", "application/json")
+	return r, nil
+}
+
+// bulkOperationResult returns the result of a bulk operation, as per the given
+// buffered response of its equivalent endpoint.
+func bulkOperationResult(op resources.BulkOperation, w *bufferedResponseWriter) resources.BulkOperationResult {
+	result := resources.BulkOperationResult{Status: w.responseStatus()}
+	if result.Status >= http.StatusBadRequest {
+		response := resources.Response{}
+		if err := json.Unmarshal(w.body.Bytes(), &response); err == nil {
+			result.Message = &response.Message
+		}
+		return result
+	}
+	if result.Status == http.StatusMultiStatus {
+		message := "some of the patch items failed"
+		result.Message = &message
+		return result
+	}
+
+	var err error
+	switch op.Op {
+	case resources.BulkOperationOpCreateGroup:
+		result.Group = &resources.Group{}
+		err = json.Unmarshal(w.body.Bytes(), result.Group)
+	case resources.BulkOperationOpCreateRole:
+		result.Role = &resources.Role{}
+		err = json.Unmarshal(w.body.Bytes(), result.Role)
+	case resources.BulkOperationOpCreateIdentity:
+		result.Identity = &resources.Identity{}
+		err = json.Unmarshal(w.body.Bytes(), result.Identity)
+	}
+	if err != nil {
+		logInternalError(w, mapErrorResponse(err), err)
+		return bulkErrorResult(err)
+	}
+	return result
+}
+
+// bulkErrorResult returns the result of a bulk operation that failed with the
+// given error.
+func bulkErrorResult(err error) resources.BulkOperationResult {
+	response := mapErrorResponse(err)
+	return resources.BulkOperationResult{
+		Status:  response.Status,
+		Message: &response.Message,
+	}
+}
+
+// bulkNotImplementedResult returns the result of a bulk operation whose
+// service backend is not provided.
+func bulkNotImplementedResult(op resources.BulkOperation) resources.BulkOperationResult {
+	return bulkErrorResult(NewNotImplementedError(string(op.Op)))
+}
@@ -0,0 +1,323 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+//go:generate mockgen -package interfaces -destination ./interfaces/mock_bulk.go -source=./interfaces/bulk.go
+
+func TestHandler_Bulk(t *testing.T) {
+	c := qt.New(t)
+
+	group := resources.Group{Id: stringPtr("some-group"), Name: "some-group"}
+	role := resources.Role{Id: stringPtr("some-role"), Name: "some-role"}
+
+	operations := []resources.BulkOperation{{
+		Op:    resources.BulkOperationOpCreateGroup,
+		Group: &resources.Group{Name: "some-group"},
+	}, {
+		Op:   resources.BulkOperationOpCreateRole,
+		Role: &resources.Role{Name: "some-role"},
+	}, {
+		Op: resources.BulkOperationOpPatchGroupRoles,
+		Id: stringPtr("some-group"),
+		GroupRoles: []resources.GroupRolesPatchItem{
+			{Role: "some-role", Op: resources.GroupRolesPatchItemOpAdd},
+		},
+	}}
+
+	tests := []struct {
+		name             string
+		setupServiceMock func(groups *interfaces.MockGroupsService, roles *interfaces.MockRolesService, bulk *interfaces.MockBulkService)
+		withBulkService  bool
+		withoutRoles     bool
+		expectedStatus   int
+		expectedBody     any
+	}{{
+		name: "sequential: success",
+		setupServiceMock: func(groups *interfaces.MockGroupsService, roles *interfaces.MockRolesService, _ *interfaces.MockBulkService) {
+			gomock.InOrder(
+				groups.EXPECT().CreateGroup(gomock.Any(), operations[0].Group).Return(&group, nil),
+				roles.EXPECT().CreateRole(gomock.Any(), operations[1].Role).Return(&role, nil),
+				groups.EXPECT().PatchGroupRoles(gomock.Any(), "some-group", operations[2].GroupRoles).Return(true, nil),
+			)
+		},
+		expectedStatus: http.StatusOK,
+		expectedBody: resources.BulkResponse{
+			Results: []resources.BulkOperationResult{
+				{Status: http.StatusCreated, Group: &group},
+				{Status: http.StatusCreated, Role: &role},
+				{Status: http.StatusOK},
+			},
+			Status: http.StatusOK,
+		},
+	}, {
+		name: "sequential: failure halfway",
+		setupServiceMock: func(groups *interfaces.MockGroupsService, roles *interfaces.MockRolesService, _ *interfaces.MockBulkService) {
+			groups.EXPECT().CreateGroup(gomock.Any(), operations[0].Group).Return(&group, nil)
+			roles.EXPECT().CreateRole(gomock.Any(), operations[1].Role).Return(nil, NewInvalidRequestError("role already exists"))
+		},
+		expectedStatus: http.StatusMultiStatus,
+		expectedBody: resources.BulkResponse{
+			Results: []resources.BulkOperationResult{
+				{Status: http.StatusCreated, Group: &group},
+				{Status: http.StatusBadRequest, Message: stringPtr("Bad Request: invalid request: role already exists")},
+				{Status: http.StatusFailedDependency, Message: stringPtr("skipped due to a previous failure")},
+			},
+			Status: http.StatusMultiStatus,
+		},
+	}, {
+		name:         "sequential: missing service",
+		withoutRoles: true,
+		setupServiceMock: func(groups *interfaces.MockGroupsService, _ *interfaces.MockRolesService, _ *interfaces.MockBulkService) {
+			groups.EXPECT().CreateGroup(gomock.Any(), operations[0].Group).Return(&group, nil)
+		},
+		expectedStatus: http.StatusMultiStatus,
+		expectedBody: resources.BulkResponse{
+			Results: []resources.BulkOperationResult{
+				{Status: http.StatusCreated, Group: &group},
+				{Status: http.StatusNotImplemented, Message: stringPtr("Not Implemented: not implemented: createRole")},
+				{Status: http.StatusFailedDependency, Message: stringPtr("skipped due to a previous failure")},
+			},
+			Status: http.StatusMultiStatus,
+		},
+	}, {
+		name:            "atomic: success",
+		withBulkService: true,
+		setupServiceMock: func(_ *interfaces.MockGroupsService, _ *interfaces.MockRolesService, bulk *interfaces.MockBulkService) {
+			bulk.EXPECT().ExecuteBulk(gomock.Any(), operations).Return([]resources.BulkOperationResult{
+				{Status: http.StatusCreated, Group: &group},
+				{Status: http.StatusCreated, Role: &role},
+				{Status: http.StatusOK},
+			}, nil)
+		},
+		expectedStatus: http.StatusOK,
+		expectedBody: resources.BulkResponse{
+			Atomic: true,
+			Results: []resources.BulkOperationResult{
+				{Status: http.StatusCreated, Group: &group},
+				{Status: http.StatusCreated, Role: &role},
+				{Status: http.StatusOK},
+			},
+			Status: http.StatusOK,
+		},
+	}, {
+		name:            "atomic: failure",
+		withBulkService: true,
+		setupServiceMock: func(_ *interfaces.MockGroupsService, _ *interfaces.MockRolesService, bulk *interfaces.MockBulkService) {
+			bulk.EXPECT().ExecuteBulk(gomock.Any(), operations).Return(nil, NewNotFoundError("role not found"))
+		},
+		expectedStatus: http.StatusNotFound,
+		expectedBody: resources.Response{
+			Message: "Not Found: role not found",
+			Status:  http.StatusNotFound,
+		},
+	}}
+
+	for _, test := range tests {
+		tt := test
+		c.Run(tt.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+			defer ctrl.Finish()
+
+			groups := interfaces.NewMockGroupsService(ctrl)
+			roles := interfaces.NewMockRolesService(ctrl)
+			bulk := interfaces.NewMockBulkService(ctrl)
+			tt.setupServiceMock(groups, roles, bulk)
+
+			sut := handler{Groups: groups, Roles: roles}
+			if tt.withoutRoles {
+				sut.Roles = nil
+			}
+			if tt.withBulkService {
+				sut.Bulk = bulk
+			} else {
+				sut.BulkFallback = newHandlerWithValidation(&sut, RequestDecodingPolicy{}, PaginationPolicy{})
+			}
+
+			w := httptest.NewRecorder()
+			req := newTestRequest(http.MethodPost, "/bulk", &resources.BulkRequestBody{Operations: operations})
+			sut.PostBulk(w, req)
+
+			result := w.Result()
+			defer result.Body.Close()
+			c.Assert(result.StatusCode, qt.Equals, tt.expectedStatus)
+
+			body, err := io.ReadAll(result.Body)
+			c.Assert(err, qt.IsNil)
+			c.Assert(string(body), qt.JSONEquals, tt.expectedBody)
+		})
+	}
+}
+
+func TestBulkOperationsAreAuthorizedIndividually(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	groups := interfaces.NewMockGroupsService(ctrl)
+	authorizer := interfaces.NewMockAuthorizer(ctrl)
+
+	// The second operation is denied, so no operation should be executed.
+	gomock.InOrder(
+		authorizer.EXPECT().Authorize(gomock.Any(), gomock.Any(), "PostBulk", nil).Return(true, nil),
+		authorizer.EXPECT().Authorize(gomock.Any(), gomock.Any(), "PostGroups", nil).Return(true, nil),
+		authorizer.EXPECT().Authorize(gomock.Any(), gomock.Any(), "DeleteGroupsItem", []string{"some-group"}).Return(false, nil),
+	)
+
+	sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+		Groups:         groups,
+		Authorizer:     authorizer,
+		SequentialBulk: true,
+	})
+	server := httptest.NewServer(sut.Handler(""))
+	defer server.Close()
+
+	raw, _ := json.Marshal(resources.BulkRequestBody{
+		Operations: []resources.BulkOperation{{
+			Op:    resources.BulkOperationOpCreateGroup,
+			Group: &resources.Group{Name: "some-group"},
+		}, {
+			Op: resources.BulkOperationOpDeleteGroup,
+			Id: stringPtr("some-group"),
+		}},
+	})
+	res, err := http.Post(server.URL+"/v1/bulk", "application/json", bytes.NewReader(raw))
+	c.Assert(err, qt.IsNil)
+	defer res.Body.Close()
+	c.Assert(res.StatusCode, qt.Equals, http.StatusForbidden)
+
+	body, err := io.ReadAll(res.Body)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(body), qt.JSONEquals, resources.Response{
+		Message: "Forbidden: access denied: DeleteGroupsItem",
+		Status:  http.StatusForbidden,
+	})
+}
+
+func TestBulkIsNotImplementedByDefault(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	// Without a bulk service backend, the sequential execution is opt-in.
+	sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+		Groups:       interfaces.NewMockGroupsService(ctrl),
+		Entitlements: interfaces.NewMockEntitlementsService(ctrl),
+	})
+	server := httptest.NewServer(sut.Handler(""))
+	defer server.Close()
+
+	res, err := http.Post(server.URL+"/v1/bulk", "application/json", bytes.NewReader([]byte(`{}`)))
+	c.Assert(err, qt.IsNil)
+	res.Body.Close()
+	c.Assert(res.StatusCode, qt.Equals, http.StatusNotImplemented)
+
+	res, err = http.Get(server.URL + "/v1/capabilities")
+	c.Assert(err, qt.IsNil)
+	defer res.Body.Close()
+	capabilities := resources.GetCapabilitiesResponse{}
+	err = json.NewDecoder(res.Body).Decode(&capabilities)
+	c.Assert(err, qt.IsNil)
+	for _, capability := range capabilities.Data {
+		c.Assert(capability.Endpoint, qt.Not(qt.Equals), "/bulk")
+	}
+}
+
+func TestSequentialBulkOperationsAreValidated(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	group := resources.Group{Id: stringPtr("some-group"), Name: "some-group"}
+
+	// The second operation lacks the `If-Match` header required by the
+	// equivalent endpoint, so it should not reach the service backend.
+	groups := interfaces.NewMockGroupsService(ctrl)
+	groups.EXPECT().CreateGroup(gomock.Any(), &resources.Group{Name: "some-group"}).Return(&group, nil)
+
+	sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+		Groups:         groups,
+		RequireIfMatch: true,
+		SequentialBulk: true,
+	})
+
+	raw, _ := json.Marshal(resources.BulkRequestBody{
+		Operations: []resources.BulkOperation{{
+			Op:    resources.BulkOperationOpCreateGroup,
+			Group: &resources.Group{Name: "some-group"},
+		}, {
+			Op: resources.BulkOperationOpDeleteGroup,
+			Id: stringPtr("some-group"),
+		}},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/v1/bulk", bytes.NewReader(raw))
+	w := httptest.NewRecorder()
+	sut.Handler("").ServeHTTP(w, req)
+	c.Assert(w.Code, qt.Equals, http.StatusMultiStatus)
+
+	response := resources.BulkResponse{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	c.Assert(err, qt.IsNil)
+	c.Assert(response.Results, qt.HasLen, 2)
+	c.Assert(response.Results[0], qt.DeepEquals, resources.BulkOperationResult{Status: http.StatusCreated, Group: &group})
+	c.Assert(response.Results[1].Status, qt.Equals, http.StatusPreconditionRequired)
+}
+
+func TestBulkServiceIsWrapped(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	bulk := interfaces.NewMockBulkService(ctrl)
+	bulk.EXPECT().ExecuteBulk(gomock.Any(), gomock.Any()).Return(nil, errors.New("test-error"))
+
+	recorder := interfaces.NewMockMetricsRecorder(ctrl)
+	recorder.EXPECT().ObserveServiceCall(gomock.Any(), "BulkService.ExecuteBulk", gomock.Any(), gomock.Any())
+	recorder.EXPECT().ObserveRequest(gomock.Any(), "PostBulk", http.StatusInternalServerError, "5xx", gomock.Any())
+
+	sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+		Bulk:    bulk,
+		Metrics: recorder,
+	})
+	server := httptest.NewServer(sut.Handler(""))
+	defer server.Close()
+
+	raw, _ := json.Marshal(resources.BulkRequestBody{
+		Operations: []resources.BulkOperation{{
+			Op: resources.BulkOperationOpDeleteRole,
+			Id: stringPtr("some-role"),
+		}},
+	})
+	res, err := http.Post(server.URL+"/v1/bulk", "application/json", bytes.NewReader(raw))
+	c.Assert(err, qt.IsNil)
+	res.Body.Close()
+	c.Assert(res.StatusCode, qt.Equals, http.StatusInternalServerError)
+}
@@ -0,0 +1,198 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// PostBulk validates request body for the PostBulk method and delegates to the underlying handler.
+func (v handlerWithValidation) PostBulk(w http.ResponseWriter, r *http.Request) {
+	body := &resources.BulkRequestBody{}
+	v.validateRequestBody(body, w, r, func(w http.ResponseWriter, r *http.Request) {
+		for i, op := range body.Operations {
+			if message := validateBulkOperation(op); message != "" {
+				writeErrorResponse(w, NewRequestBodyValidationError(fmt.Sprintf("operation %d (%s): %s", i, op.Op, message)))
+				return
+			}
+		}
+
+		// The operations may be executed atomically by the bulk service, in
+		// which case they never reach the validation of their equivalent
+		// endpoints; so the entitlement schema and the references are checked
+		// here, before any of them is executed.
+		created := map[string]bool{}
+		for i, op := range body.Operations {
+			problem, mapper, err := v.checkBulkOperation(r.Context(), fmt.Sprintf("operation %d (%s): ", i, op.Op), op, created)
+			if err != nil {
+				writeServiceErrorResponse(w, mapper, err)
+				return
+			}
+			if problem != nil {
+				writeErrorResponse(w, problem)
+				return
+			}
+		}
+		extensionsOf(v.ServerInterface).PostBulk(w, r)
+	})
+}
+
+// validateBulkOperation checks if the fields required by the type of the given
+// bulk operation are set. If not, it returns the reason; otherwise, an empty
+// string.
+func validateBulkOperation(op resources.BulkOperation) string {
+	switch op.Op {
+	case resources.BulkOperationOpCreateGroup:
+		if op.Group == nil {
+			return "missing group"
+		}
+		return ""
+	case resources.BulkOperationOpCreateRole:
+		if op.Role == nil {
+			return "missing role"
+		}
+		return ""
+	case resources.BulkOperationOpCreateIdentity:
+		if op.Identity == nil {
+			return "missing identity"
+		}
+		return ""
+	}
+
+	if op.Id == nil || *op.Id == "" {
+		return "missing entity ID"
+	}
+
+	var patches int
+	switch op.Op {
+	case resources.BulkOperationOpDeleteGroup, resources.BulkOperationOpDeleteRole, resources.BulkOperationOpDeleteIdentity:
+		return ""
+	case resources.BulkOperationOpPatchGroupIdentities:
+		patches = len(op.GroupIdentities)
+	case resources.BulkOperationOpPatchGroupRoles:
+		patches = len(op.GroupRoles)
+	case resources.BulkOperationOpPatchGroupEntitlements:
+		patches = len(op.GroupEntitlements)
+	case resources.BulkOperationOpPatchRoleEntitlements:
+		patches = len(op.RoleEntitlements)
+	case resources.BulkOperationOpPatchIdentityGroups:
+		patches = len(op.IdentityGroups)
+	case resources.BulkOperationOpPatchIdentityRoles:
+		patches = len(op.IdentityRoles)
+	case resources.BulkOperationOpPatchIdentityEntitlements:
+		patches = len(op.IdentityEntitlements)
+	}
+	if patches == 0 {
+		return "missing patches"
+	}
+	return ""
+}
+
+// checkBulkOperation checks the entitlements of the given bulk operation
+// against the entitlement schema, and resolves the entities it references, the
+// same way as the validation of its equivalent endpoint. The returned values
+// are as described in checkReferences.
+//
+// Since the IDs of the entities created by the operations are assigned by the
+// services, references to entities of a kind (e.g., "group") created by an
+// earlier operation of the same request cannot be resolved beforehand, and are
+// assumed to exist. The given map keeps track of the created kinds, and is
+// updated accordingly.
+func (v handlerWithValidation) checkBulkOperation(ctx context.Context, prefix string, op resources.BulkOperation, created map[string]bool) (problem error, mapper ErrorResponseMapper, err error) {
+	resolvable := func(refs references) references {
+		if created[refs.singular] {
+			refs.get = nil
+		}
+		return refs
+	}
+	checkEntitlements := func(receiverType entitlementReceiverType, entitlements []addedEntitlement) (error, ErrorResponseMapper, error) {
+		problem, err := v.checkEntitlementPatches(ctx, prefix, receiverType, entitlements)
+		if err != nil {
+			return nil, v.entitlementSchema.errorMapper, err
+		}
+		return problem, nil, nil
+	}
+
+	switch op.Op {
+	case resources.BulkOperationOpCreateGroup:
+		created["group"] = true
+	case resources.BulkOperationOpCreateRole:
+		created["role"] = true
+	case resources.BulkOperationOpCreateIdentity:
+		created["identity"] = true
+	case resources.BulkOperationOpPatchGroupIdentities:
+		added := make([]string, 0, len(op.GroupIdentities))
+		for _, p := range op.GroupIdentities {
+			if p.Op == resources.GroupIdentitiesPatchItemOpAdd {
+				added = append(added, p.Identity)
+			}
+		}
+		return checkReferences(ctx, prefix, resolvable(v.references.groupRefs(*op.Id)), resolvable(v.references.identityRefs(added...)))
+	case resources.BulkOperationOpPatchGroupRoles:
+		added := make([]string, 0, len(op.GroupRoles))
+		for _, p := range op.GroupRoles {
+			if p.Op == resources.GroupRolesPatchItemOpAdd {
+				added = append(added, p.Role)
+			}
+		}
+		return checkReferences(ctx, prefix, resolvable(v.references.groupRefs(*op.Id)), resolvable(v.references.roleRefs(added...)))
+	case resources.BulkOperationOpPatchGroupEntitlements:
+		entitlements := make([]addedEntitlement, 0, len(op.GroupEntitlements))
+		for i, p := range op.GroupEntitlements {
+			if p.Op == resources.GroupEntitlementsPatchItemOpAdd {
+				entitlements = append(entitlements, addedEntitlement{index: i, entitlement: p.Entitlement})
+			}
+		}
+		return checkEntitlements(entitlementReceiverTypeGroup, entitlements)
+	case resources.BulkOperationOpPatchRoleEntitlements:
+		entitlements := make([]addedEntitlement, 0, len(op.RoleEntitlements))
+		for i, p := range op.RoleEntitlements {
+			if p.Op == resources.Add {
+				entitlements = append(entitlements, addedEntitlement{index: i, entitlement: p.Entitlement})
+			}
+		}
+		return checkEntitlements(entitlementReceiverTypeRole, entitlements)
+	case resources.BulkOperationOpPatchIdentityGroups:
+		added := make([]string, 0, len(op.IdentityGroups))
+		for _, p := range op.IdentityGroups {
+			if p.Op == resources.IdentityGroupsPatchItemOpAdd {
+				added = append(added, p.Group)
+			}
+		}
+		return checkReferences(ctx, prefix, resolvable(v.references.identityRefs(*op.Id)), resolvable(v.references.groupRefs(added...)))
+	case resources.BulkOperationOpPatchIdentityRoles:
+		added := make([]string, 0, len(op.IdentityRoles))
+		for _, p := range op.IdentityRoles {
+			if p.Op == resources.IdentityRolesPatchItemOpAdd {
+				added = append(added, p.Role)
+			}
+		}
+		return checkReferences(ctx, prefix, resolvable(v.references.identityRefs(*op.Id)), resolvable(v.references.roleRefs(added...)))
+	case resources.BulkOperationOpPatchIdentityEntitlements:
+		entitlements := make([]addedEntitlement, 0, len(op.IdentityEntitlements))
+		for i, p := range op.IdentityEntitlements {
+			if p.Op == resources.IdentityEntitlementsPatchItemOpAdd {
+				entitlements = append(entitlements, addedEntitlement{index: i, entitlement: p.Entitlement})
+			}
+		}
+		return checkEntitlements(entitlementReceiverTypeIdentity, entitlements)
+	}
+	return nil, nil, nil
+}
@@ -0,0 +1,324 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+func TestValidateBulkOperation(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		name     string
+		op       resources.BulkOperation
+		expected string
+	}{{
+		name: "create: success",
+		op: resources.BulkOperation{
+			Op:       resources.BulkOperationOpCreateIdentity,
+			Identity: &resources.Identity{Email: "foo"},
+		},
+	}, {
+		name:     "create: missing entity",
+		op:       resources.BulkOperation{Op: resources.BulkOperationOpCreateGroup},
+		expected: "missing group",
+	}, {
+		name: "delete: success",
+		op: resources.BulkOperation{
+			Op: resources.BulkOperationOpDeleteRole,
+			Id: stringPtr("foo"),
+		},
+	}, {
+		name:     "delete: missing ID",
+		op:       resources.BulkOperation{Op: resources.BulkOperationOpDeleteRole},
+		expected: "missing entity ID",
+	}, {
+		name: "delete: empty ID",
+		op: resources.BulkOperation{
+			Op: resources.BulkOperationOpDeleteIdentity,
+			Id: stringPtr(""),
+		},
+		expected: "missing entity ID",
+	}, {
+		name: "patch: success",
+		op: resources.BulkOperation{
+			Op: resources.BulkOperationOpPatchIdentityRoles,
+			Id: stringPtr("foo"),
+			IdentityRoles: []resources.IdentityRolesPatchItem{
+				{Role: "bar", Op: resources.IdentityRolesPatchItemOpAdd},
+			},
+		},
+	}, {
+		name: "patch: patches of another operation",
+		op: resources.BulkOperation{
+			Op: resources.BulkOperationOpPatchIdentityGroups,
+			Id: stringPtr("foo"),
+			IdentityRoles: []resources.IdentityRolesPatchItem{
+				{Role: "bar", Op: resources.IdentityRolesPatchItemOpAdd},
+			},
+		},
+		expected: "missing patches",
+	}}
+
+	for _, test := range tests {
+		tt := test
+		c.Run(tt.name, func(c *qt.C) {
+			c.Assert(validateBulkOperation(tt.op), qt.Equals, tt.expected)
+		})
+	}
+}
+
+func TestHandlerWithValidation_Bulk(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		name            string
+		body            any
+		expectedMessage string
+	}{{
+		name:            "no operations",
+		body:            resources.BulkRequestBody{},
+		expectedMessage: "'Operations' failed on the 'required' tag",
+	}, {
+		name: "unknown operation type",
+		body: resources.BulkRequestBody{
+			Operations: []resources.BulkOperation{{Op: "renameGroup"}},
+		},
+		expectedMessage: "'Op' failed on the 'oneof' tag",
+	}, {
+		name: "invalid entity",
+		body: resources.BulkRequestBody{
+			Operations: []resources.BulkOperation{{
+				Op:    resources.BulkOperationOpCreateGroup,
+				Group: &resources.Group{},
+			}},
+		},
+		expectedMessage: "'Name' failed on the 'required' tag",
+	}, {
+		name: "invalid patch",
+		body: resources.BulkRequestBody{
+			Operations: []resources.BulkOperation{{
+				Op: resources.BulkOperationOpPatchRoleEntitlements,
+				Id: stringPtr("foo"),
+				RoleEntitlements: []resources.RoleEntitlementsPatchItem{
+					{Op: "replace"},
+				},
+			}},
+		},
+		expectedMessage: "'Op' failed on the 'oneof' tag",
+	}, {
+		name: "missing fields",
+		body: resources.BulkRequestBody{
+			Operations: []resources.BulkOperation{{
+				Op: resources.BulkOperationOpDeleteGroup,
+				Id: stringPtr("foo"),
+			}, {
+				Op: resources.BulkOperationOpPatchGroupRoles,
+				Id: stringPtr("foo"),
+			}},
+		},
+		expectedMessage: "Bad Request: invalid request body: operation 1 (patchGroupRoles): missing patches",
+	}}
+
+	for _, test := range tests {
+		tt := test
+		c.Run(tt.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+			defer ctrl.Finish()
+
+			// No calls are expected on the service backend.
+			sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+				Groups:         interfaces.NewMockGroupsService(ctrl),
+				SequentialBulk: true,
+			})
+
+			raw, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/v1/bulk", bytes.NewReader(raw))
+			w := httptest.NewRecorder()
+			sut.Handler("").ServeHTTP(w, req)
+			c.Assert(w.Code, qt.Equals, http.StatusBadRequest)
+
+			response := resources.Response{}
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			c.Assert(err, qt.IsNil)
+			c.Assert(response.Message, qt.Contains, tt.expectedMessage)
+		})
+	}
+}
+
+func TestHandlerWithValidation_BulkReferencesAndEntitlements(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		name            string
+		operations      []resources.BulkOperation
+		expectedStatus  int
+		expectedMessage string
+	}{{
+		name: "success",
+		operations: []resources.BulkOperation{{
+			Op: resources.BulkOperationOpPatchGroupRoles,
+			Id: stringPtr("foo"),
+			GroupRoles: []resources.GroupRolesPatchItem{
+				{Op: resources.GroupRolesPatchItemOpAdd, Role: "foo"},
+				{Op: resources.GroupRolesPatchItemOpRemove, Role: "baz"},
+			},
+		}, {
+			Op: resources.BulkOperationOpPatchGroupEntitlements,
+			Id: stringPtr("foo"),
+			GroupEntitlements: []resources.GroupEntitlementsPatchItem{{
+				Op:          resources.GroupEntitlementsPatchItemOpAdd,
+				Entitlement: resources.EntityEntitlement{Entitlement: "writer", EntityType: "model", EntityId: "m"},
+			}},
+		}},
+		expectedStatus: http.StatusOK,
+	}, {
+		name: "unknown patched entity",
+		operations: []resources.BulkOperation{{
+			Op: resources.BulkOperationOpPatchIdentityGroups,
+			Id: stringPtr("baz"),
+			IdentityGroups: []resources.IdentityGroupsPatchItem{
+				{Op: resources.IdentityGroupsPatchItemOpAdd, Group: "foo"},
+			},
+		}},
+		expectedStatus:  http.StatusNotFound,
+		expectedMessage: `Not Found: operation 0 (patchIdentityGroups): identity "baz" not found`,
+	}, {
+		name: "unknown added entities",
+		operations: []resources.BulkOperation{{
+			Op: resources.BulkOperationOpDeleteGroup,
+			Id: stringPtr("foo"),
+		}, {
+			Op: resources.BulkOperationOpPatchIdentityRoles,
+			Id: stringPtr("foo"),
+			IdentityRoles: []resources.IdentityRolesPatchItem{
+				{Op: resources.IdentityRolesPatchItemOpAdd, Role: "baz"},
+			},
+		}},
+		expectedStatus:  http.StatusBadRequest,
+		expectedMessage: `Bad Request: invalid request body: operation 1 (patchIdentityRoles): unknown roles: "baz"`,
+	}, {
+		name: "references to entities created by earlier operations",
+		operations: []resources.BulkOperation{{
+			Op:    resources.BulkOperationOpCreateGroup,
+			Group: &resources.Group{Name: "baz"},
+		}, {
+			Op: resources.BulkOperationOpPatchIdentityGroups,
+			Id: stringPtr("foo"),
+			IdentityGroups: []resources.IdentityGroupsPatchItem{
+				{Op: resources.IdentityGroupsPatchItemOpAdd, Group: "baz"},
+			},
+		}},
+		expectedStatus: http.StatusOK,
+	}, {
+		name: "undefined entitlement",
+		operations: []resources.BulkOperation{{
+			Op: resources.BulkOperationOpPatchRoleEntitlements,
+			Id: stringPtr("foo"),
+			RoleEntitlements: []resources.RoleEntitlementsPatchItem{{
+				Op:          resources.Add,
+				Entitlement: resources.EntityEntitlement{Entitlement: "admin", EntityType: "controller", EntityId: "c"},
+			}},
+		}},
+		expectedStatus:  http.StatusBadRequest,
+		expectedMessage: `Bad Request: invalid request body: operation 0 (patchRoleEntitlements): patches[0]: entitlement "admin" on entity type "controller" is not defined for receiver type "role"`,
+	}, {
+		name: "removal of an undefined entitlement",
+		operations: []resources.BulkOperation{{
+			Op: resources.BulkOperationOpPatchRoleEntitlements,
+			Id: stringPtr("foo"),
+			RoleEntitlements: []resources.RoleEntitlementsPatchItem{{
+				Op:          resources.Remove,
+				Entitlement: resources.EntityEntitlement{Entitlement: "admin", EntityType: "controller", EntityId: "c"},
+			}},
+		}},
+		expectedStatus: http.StatusOK,
+	}}
+
+	for _, test := range tests {
+		tt := test
+		c.Run(tt.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+			defer ctrl.Finish()
+
+			existing := map[string]bool{"foo": true}
+			groups := interfaces.NewMockGroupsService(ctrl)
+			groups.EXPECT().GetGroup(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, id string) (*resources.Group, error) {
+				if !existing[id] {
+					return nil, NewNotFoundError(fmt.Sprintf("group %q not found", id))
+				}
+				return &resources.Group{Id: &id, Name: id}, nil
+			}).AnyTimes()
+			identities := interfaces.NewMockIdentitiesService(ctrl)
+			identities.EXPECT().GetIdentity(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, id string) (*resources.Identity, error) {
+				if !existing[id] {
+					return nil, NewNotFoundError(fmt.Sprintf("identity %q not found", id))
+				}
+				return &resources.Identity{Id: &id, Email: id}, nil
+			}).AnyTimes()
+			roles := interfaces.NewMockRolesService(ctrl)
+			roles.EXPECT().GetRole(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, id string) (*resources.Role, error) {
+				if !existing[id] {
+					return nil, NewNotFoundError(fmt.Sprintf("role %q not found", id))
+				}
+				return &resources.Role{Id: &id, Name: id}, nil
+			}).AnyTimes()
+			entitlements := interfaces.NewMockEntitlementsService(ctrl)
+			entitlements.EXPECT().ListEntitlements(gomock.Any(), gomock.Any()).Return(testEntitlementSchema, nil).AnyTimes()
+			bulk := interfaces.NewMockBulkService(ctrl)
+			if tt.expectedStatus == http.StatusOK {
+				bulk.EXPECT().ExecuteBulk(gomock.Any(), gomock.Any()).Return([]resources.BulkOperationResult{}, nil)
+			}
+
+			sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+				Groups:                      groups,
+				Identities:                  identities,
+				Roles:                       roles,
+				Entitlements:                entitlements,
+				Bulk:                        bulk,
+				ReferentialIntegrity:        true,
+				EntitlementSchemaValidation: true,
+			})
+
+			raw, _ := json.Marshal(resources.BulkRequestBody{Operations: tt.operations})
+			req := httptest.NewRequest(http.MethodPost, "/v1/bulk", bytes.NewReader(raw))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			sut.Handler("").ServeHTTP(w, req)
+			c.Assert(w.Code, qt.Equals, tt.expectedStatus)
+
+			if tt.expectedMessage != "" {
+				response := resources.Response{}
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				c.Assert(err, qt.IsNil)
+				c.Assert(response.Message, qt.Equals, tt.expectedMessage)
+			}
+		})
+	}
+}
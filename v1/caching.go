@@ -0,0 +1,211 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheControlPolicy determines the `Cache-Control` header of responses to
+// endpoints that serve rarely changing data. Each field is used verbatim as the
+// header value (e.g., "private, max-age=60" or "no-cache"). An empty value
+// means the header is not set.
+//
+// Regardless of the policy, these endpoints return an `ETag` header and honour
+// the `If-None-Match` header of requests, by responding with 304 Not Modified if
+// the content has not changed.
+type CacheControlPolicy struct {
+	// SwaggerJson is used for the `GET /swagger.json` endpoint.
+	SwaggerJson string
+	// Capabilities is used for the `GET /capabilities` endpoint.
+	Capabilities string
+	// Entitlements is used for the `GET /entitlements` endpoint.
+	Entitlements string
+	// RawEntitlements is used for the `GET /entitlements/raw` endpoint.
+	RawEntitlements string
+}
+
+// maxCachedResponses is the maximum number of entries of a responseCache. If
+// exceeded, the expired entries are evicted, or all of them, if none has
+// expired (e.g., due to many distinct filters).
+const maxCachedResponses = 256
+
+// cachedResponse is the marshalled result of a service call, along with its
+// `ETag` and `Last-Modified` time.
+type cachedResponse struct {
+	data         []byte
+	tag          string
+	lastModified time.Time
+	expiresAt    time.Time
+}
+
+// responseCache holds the results of the service calls behind the endpoints
+// that serve rarely changing data, keyed by the request (i.e., the operation
+// and its query parameters), so that repeated requests do not reach the
+// services until the TTL expires. Results are kept per caller, since the
+// services may return different ones to different callers. It also keeps
+// track of when the results last changed, which is used as their
+// `Last-Modified` time.
+//
+// With a zero TTL, the services are called on each request, and only the
+// `Last-Modified` times are kept.
+type responseCache struct {
+	ttl time.Duration
+
+	// now returns the current time; overridden in tests.
+	now func() time.Time
+
+	mutex   sync.Mutex
+	entries map[string]cachedResponse
+}
+
+// newResponseCache returns a new responseCache instance, with the given TTL.
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[string]cachedResponse{},
+	}
+}
+
+// get returns the cached result for the given key, if not expired; otherwise,
+// it calls the given function and caches its result, marshalled as JSON.
+// Errors are not cached. A nil cache calls the function every time, and
+// returns no `Last-Modified` time.
+//
+// The function is called without holding the lock, so that a slow service
+// does not block the requests that can be served from the cache.
+func (c *responseCache) get(key string, fetch func() (any, error)) (cachedResponse, error) {
+	now := time.Now()
+	if c != nil {
+		now = c.now()
+		c.mutex.Lock()
+		entry, ok := c.entries[key]
+		c.mutex.Unlock()
+		if ok && now.Before(entry.expiresAt) {
+			return entry, nil
+		}
+	}
+
+	result, err := fetch()
+	if err != nil {
+		return cachedResponse{}, err
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return cachedResponse{}, err
+	}
+	entry := cachedResponse{data: data, tag: contentEntityTag(data)}
+	if c == nil {
+		return entry, nil
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// HTTP dates have a precision of one second.
+	entry.lastModified = now.Truncate(time.Second)
+	entry.expiresAt = now.Add(c.ttl)
+	previous, ok := c.entries[key]
+	if ok && previous.tag == entry.tag {
+		entry.lastModified = previous.lastModified
+	}
+	if !ok && len(c.entries) >= maxCachedResponses {
+		c.evict(now)
+	}
+	c.entries[key] = entry
+	return entry, nil
+}
+
+// evict removes the expired entries, or all of them if none has expired. The
+// caller should hold the lock.
+func (c *responseCache) evict(now time.Time) {
+	for key, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+	if len(c.entries) >= maxCachedResponses {
+		clear(c.entries)
+	}
+}
+
+// responseCacheKey returns the key of the given request in a responseCache.
+// Since the services may return different results (or errors) to different
+// callers, the results are cached per caller.
+func responseCacheKey(operationId string, req *http.Request) string {
+	return operationId + ":" + callerScope(req.Context()) + "?" + req.URL.Query().Encode()
+}
+
+// writeCacheableResponse writes the given cached response, along with its
+// `ETag`, `Last-Modified` (if known) and `Cache-Control` headers. If the
+// conditional request headers of the request (i.e., `If-None-Match`, or
+// `If-Modified-Since` in its absence) match, a 304 Not Modified response (with
+// no body) is written instead.
+func writeCacheableResponse(w http.ResponseWriter, req *http.Request, cacheControl string, response cachedResponse) {
+	w.Header().Set("ETag", response.tag)
+	if !response.lastModified.IsZero() {
+		w.Header().Set("Last-Modified", response.lastModified.UTC().Format(http.TimeFormat))
+	}
+	if cacheControl != "" {
+		w.Header().Set("Cache-Control", cacheControl)
+	}
+
+	if notModified(req, response.tag) || notModifiedSince(req, response.lastModified) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	setJSONContentTypeHeader(w)
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(response.data); err != nil {
+		getLogger(w).Error("failed to write response body", "error", err)
+	}
+}
+
+// notModifiedSince checks if the given `Last-Modified` time is not after the
+// `If-Modified-Since` header of the request. As per RFC 9110, the header is
+// ignored if the request has an `If-None-Match` header.
+func notModifiedSince(req *http.Request, lastModified time.Time) bool {
+	if lastModified.IsZero() || req.Header.Get("If-None-Match") != "" {
+		return false
+	}
+	since, err := http.ParseTime(req.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	return !lastModified.After(since)
+}
+
+// notModified checks if the `If-None-Match` header of the request matches the
+// given entity tag. As per RFC 9110, the weak comparison is used.
+func notModified(req *http.Request, tag string) bool {
+	ifNoneMatch := strings.Join(req.Header.Values("If-None-Match"), ",")
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(tag, "W/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,288 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+func TestNotModified(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		ifNoneMatch []string
+		tag         string
+		expected    bool
+	}{{
+		tag:      `"foo"`,
+		expected: false,
+	}, {
+		ifNoneMatch: []string{`"foo"`},
+		tag:         `"foo"`,
+		expected:    true,
+	}, {
+		ifNoneMatch: []string{`"bar"`},
+		tag:         `"foo"`,
+		expected:    false,
+	}, {
+		ifNoneMatch: []string{`W/"foo"`},
+		tag:         `"foo"`,
+		expected:    true,
+	}, {
+		ifNoneMatch: []string{`"bar", "foo"`},
+		tag:         `"foo"`,
+		expected:    true,
+	}, {
+		ifNoneMatch: []string{`"bar"`, `"foo"`},
+		tag:         `"foo"`,
+		expected:    true,
+	}, {
+		ifNoneMatch: []string{`*`},
+		tag:         `"foo"`,
+		expected:    true,
+	}}
+
+	for _, t := range tests {
+		c.Run("", func(c *qt.C) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for _, v := range t.ifNoneMatch {
+				req.Header.Add("If-None-Match", v)
+			}
+			c.Assert(notModified(req, t.tag), qt.Equals, t.expected)
+		})
+	}
+}
+
+func TestHandler_ConditionalGet(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		name                 string
+		setupServiceMock     func(mockService *interfaces.MockEntitlementsService)
+		triggerFunc          func(h handler, w http.ResponseWriter, req *http.Request)
+		expectedCacheControl string
+	}{{
+		name: "capabilities",
+		triggerFunc: func(h handler, w http.ResponseWriter, req *http.Request) {
+			h.GetCapabilities(w, req)
+		},
+		expectedCacheControl: "private, max-age=10",
+	}, {
+		name: "entitlements",
+		setupServiceMock: func(mockService *interfaces.MockEntitlementsService) {
+			mockService.EXPECT().ListEntitlements(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+		},
+		triggerFunc: func(h handler, w http.ResponseWriter, req *http.Request) {
+			h.GetEntitlements(w, req, resources.GetEntitlementsParams{})
+		},
+		expectedCacheControl: "private, max-age=20",
+	}, {
+		name: "raw entitlements",
+		setupServiceMock: func(mockService *interfaces.MockEntitlementsService) {
+			mockService.EXPECT().RawEntitlements(gomock.Any()).Return("foo", nil).Times(2)
+		},
+		triggerFunc: func(h handler, w http.ResponseWriter, req *http.Request) {
+			h.GetRawEntitlements(w, req)
+		},
+		expectedCacheControl: "no-cache",
+	}, {
+		name: "swagger",
+		triggerFunc: func(h handler, w http.ResponseWriter, req *http.Request) {
+			h.SwaggerJson(w, req)
+		},
+		expectedCacheControl: "public, max-age=3600",
+	}}
+
+	for _, test := range tests {
+		tt := test
+		c.Run(tt.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+			defer ctrl.Finish()
+
+			mockEntitlementsService := interfaces.NewMockEntitlementsService(ctrl)
+			if tt.setupServiceMock != nil {
+				tt.setupServiceMock(mockEntitlementsService)
+			}
+
+			sut := handler{
+				Entitlements: mockEntitlementsService,
+				CacheControl: CacheControlPolicy{
+					SwaggerJson:     "public, max-age=3600",
+					Capabilities:    "private, max-age=10",
+					Entitlements:    "private, max-age=20",
+					RawEntitlements: "no-cache",
+				},
+			}
+
+			// The first request gets the full response, along with the tag.
+			w := httptest.NewRecorder()
+			tt.triggerFunc(sut, w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			result := w.Result()
+			defer result.Body.Close()
+			c.Assert(result.StatusCode, qt.Equals, http.StatusOK)
+			c.Assert(result.Header.Get("Cache-Control"), qt.Equals, tt.expectedCacheControl)
+			c.Assert(result.Header.Get("Content-Type"), qt.Equals, "application/json")
+			tag := result.Header.Get("ETag")
+			c.Assert(tag, qt.Not(qt.Equals), "")
+
+			// The second request, with the tag, gets an empty 304 response.
+			w = httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("If-None-Match", tag)
+			tt.triggerFunc(sut, w, req)
+
+			result = w.Result()
+			defer result.Body.Close()
+			c.Assert(result.StatusCode, qt.Equals, http.StatusNotModified)
+			c.Assert(result.Header.Get("ETag"), qt.Equals, tag)
+			c.Assert(result.Header.Get("Cache-Control"), qt.Equals, tt.expectedCacheControl)
+			body, err := io.ReadAll(result.Body)
+			c.Assert(err, qt.IsNil)
+			c.Assert(body, qt.HasLen, 0)
+		})
+	}
+}
+
+func TestResponseCache(t *testing.T) {
+	c := qt.New(t)
+
+	cache := newResponseCache(time.Minute)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	calls := 0
+	content := "foo"
+	fetch := func() (any, error) {
+		calls++
+		return content, nil
+	}
+
+	// Errors are not cached.
+	_, err := cache.get("key", func() (any, error) { return nil, errors.New("some-error") })
+	c.Assert(err, qt.ErrorMatches, "some-error")
+
+	response, err := cache.get("key", fetch)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(response.data), qt.Equals, `"foo"`)
+	c.Assert(response.lastModified, qt.Equals, now)
+	c.Assert(calls, qt.Equals, 1)
+
+	// The result is reused until it expires.
+	now = now.Add(time.Minute - time.Second)
+	_, err = cache.get("key", fetch)
+	c.Assert(err, qt.IsNil)
+	c.Assert(calls, qt.Equals, 1)
+
+	// If the result does not change, neither does the `Last-Modified` time.
+	now = now.Add(time.Second)
+	response, err = cache.get("key", fetch)
+	c.Assert(err, qt.IsNil)
+	c.Assert(calls, qt.Equals, 2)
+	c.Assert(response.lastModified, qt.Equals, now.Add(-time.Minute))
+
+	now = now.Add(time.Minute)
+	content = "bar"
+	response, err = cache.get("key", fetch)
+	c.Assert(err, qt.IsNil)
+	c.Assert(calls, qt.Equals, 3)
+	c.Assert(string(response.data), qt.Equals, `"bar"`)
+	c.Assert(response.lastModified, qt.Equals, now)
+
+	// Keys are cached independently.
+	_, err = cache.get("other-key", fetch)
+	c.Assert(err, qt.IsNil)
+	c.Assert(calls, qt.Equals, 4)
+}
+
+func TestResponseCacheTTL(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	// The entitlements service is only called once.
+	entitlements := interfaces.NewMockEntitlementsService(ctrl)
+	entitlements.EXPECT().ListEntitlements(gomock.Any(), gomock.Any()).Return([]resources.EntitlementSchema{
+		{Entitlement: "admin", EntityType: "controller", ReceiverType: "identity"},
+	}, nil)
+
+	sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+		Entitlements:     entitlements,
+		ResponseCacheTTL: time.Minute,
+	})
+	handler := sut.Handler("")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/entitlements", nil))
+	c.Assert(w.Code, qt.Equals, http.StatusOK)
+	lastModified := w.Header().Get("Last-Modified")
+	c.Assert(lastModified, qt.Not(qt.Equals), "")
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/entitlements", nil)
+	req.Header.Set("If-Modified-Since", lastModified)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	c.Assert(w.Code, qt.Equals, http.StatusNotModified)
+	c.Assert(w.Header().Get("Last-Modified"), qt.Equals, lastModified)
+}
+
+func TestResponseCacheIsScopedToCallers(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	authenticator := interfaces.NewMockAuthenticator(ctrl)
+	authenticator.EXPECT().Authenticate(gomock.Any()).DoAndReturn(func(r *http.Request) (any, error) {
+		return r.Header.Get("X-Caller"), nil
+	}).AnyTimes()
+
+	// Each caller sees a different set of capabilities, so the service is
+	// called once per caller.
+	capabilities := interfaces.NewMockCapabilitiesService(ctrl)
+	capabilities.EXPECT().ListCapabilities(gomock.Any()).DoAndReturn(func(ctx context.Context) ([]resources.Capability, error) {
+		identity, err := GetIdentityFromContext(ctx)
+		c.Assert(err, qt.IsNil)
+		return []resources.Capability{{Endpoint: "/" + identity.(string), Methods: []resources.CapabilityMethods{"GET"}}}, nil
+	}).Times(2)
+
+	sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+		Authenticator:    authenticator,
+		Capabilities:     capabilities,
+		ResponseCacheTTL: time.Minute,
+	})
+	handler := sut.Handler("")
+
+	for _, caller := range []string{"alice", "bob", "alice", "bob"} {
+		req := httptest.NewRequest(http.MethodGet, "/v1/capabilities", nil)
+		req.Header.Set("X-Caller", caller)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		c.Assert(w.Code, qt.Equals, http.StatusOK)
+		c.Assert(w.Body.String(), qt.Contains, `"/`+caller+`"`)
+	}
+}
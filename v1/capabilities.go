@@ -26,27 +26,31 @@ import (
 func (h handler) GetCapabilities(w http.ResponseWriter, req *http.Request) {
 	ctx := req.Context()
 
-	var capabilities []resources.Capability
-	var err error
-	if h.Capabilities != nil {
-		capabilities, err = h.Capabilities.ListCapabilities(ctx)
-		if err != nil {
-			writeServiceErrorResponse(w, h.CapabilitiesErrorMapper, err)
-			return
+	response, err := h.Responses.get(responseCacheKey("GetCapabilities", req), func() (any, error) {
+		var capabilities []resources.Capability
+		if h.Capabilities != nil {
+			var err error
+			capabilities, err = h.Capabilities.ListCapabilities(ctx)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			capabilities = h.inferCapabilities()
 		}
-	} else {
-		capabilities = h.inferCapabilities()
+		return resources.GetCapabilitiesResponse{
+			Meta: resources.ResponseMeta{
+				Size: len(capabilities),
+			},
+			Data:   capabilities,
+			Status: http.StatusOK,
+		}, nil
+	})
+	if err != nil {
+		writeServiceErrorResponse(w, h.CapabilitiesErrorMapper, err)
+		return
 	}
 
-	response := resources.GetCapabilitiesResponse{
-		Meta: resources.ResponseMeta{
-			Size: len(capabilities),
-		},
-		Data:   capabilities,
-		Status: http.StatusOK,
-	}
-
-	writeResponse(w, http.StatusOK, response)
+	writeCacheableResponse(w, req, h.CacheControl.Capabilities, response)
 }
 
 // inferCapabilities infers the handler capabilities based on the provided
@@ -72,6 +76,7 @@ func (h handler) inferCapabilities() []resources.Capability {
 			{Endpoint: "/identities/{id}/groups", Methods: []resources.CapabilityMethods{"GET", "PATCH"}},
 			{Endpoint: "/identities/{id}/roles", Methods: []resources.CapabilityMethods{"GET", "PATCH"}},
 			{Endpoint: "/identities/{id}/entitlements", Methods: []resources.CapabilityMethods{"GET", "PATCH"}},
+			{Endpoint: "/identities/{id}/effective-entitlements", Methods: []resources.CapabilityMethods{"GET"}},
 		}...)
 	}
 
@@ -106,5 +111,23 @@ func (h handler) inferCapabilities() []resources.Capability {
 		}...)
 	}
 
+	if h.ResourcesAccess != nil {
+		result = append(result, []resources.Capability{
+			{Endpoint: "/resources/{type}/{id}/access", Methods: []resources.CapabilityMethods{"GET"}},
+		}...)
+	}
+
+	if h.AccessCheck != nil {
+		result = append(result, []resources.Capability{
+			{Endpoint: "/check", Methods: []resources.CapabilityMethods{"POST"}},
+		}...)
+	}
+
+	if h.implementsBulk() {
+		result = append(result, []resources.Capability{
+			{Endpoint: "/bulk", Methods: []resources.CapabilityMethods{"POST"}},
+		}...)
+	}
+
 	return result
 }
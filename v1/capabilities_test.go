@@ -97,6 +97,7 @@ func TestHandler_Capabilities_GetCapabilitiesSuccess_WithInference(t *testing.T)
 		{Endpoint: "/identities/{id}/groups", Methods: []resources.CapabilityMethods{"GET", "PATCH"}},
 		{Endpoint: "/identities/{id}/roles", Methods: []resources.CapabilityMethods{"GET", "PATCH"}},
 		{Endpoint: "/identities/{id}/entitlements", Methods: []resources.CapabilityMethods{"GET", "PATCH"}},
+		{Endpoint: "/identities/{id}/effective-entitlements", Methods: []resources.CapabilityMethods{"GET"}},
 		{Endpoint: "/groups", Methods: []resources.CapabilityMethods{"GET", "POST"}},
 		{Endpoint: "/groups/{id}", Methods: []resources.CapabilityMethods{"GET", "PUT", "DELETE"}},
 		{Endpoint: "/groups/{id}/identities", Methods: []resources.CapabilityMethods{"GET", "PATCH"}},
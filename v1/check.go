@@ -0,0 +1,63 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"net/http"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// PostCheck checks whether an identity has an entitlement, and optionally,
+// returns the paths that grant it.
+// (POST /check)
+func (h handler) PostCheck(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	body, err := getRequestBodyFromContext(req.Context())
+	if err != nil {
+		writeErrorResponse(w, err)
+		return
+	}
+
+	check, ok := body.(*resources.CheckRequestBody)
+	if !ok {
+		writeErrorResponse(w, NewMissingRequestBodyError(""))
+		return
+	}
+
+	result, err := h.AccessCheck.CheckAccess(ctx, check.IdentityId, check.Entitlement, check.Explain)
+	if err != nil {
+		writeServiceErrorResponse(w, h.AccessCheckErrorMapper, err)
+		return
+	}
+	if result == nil {
+		writeErrorResponse(w, NewUnknownError("access check service returned no result"))
+		return
+	}
+
+	response := resources.CheckResponse{
+		AccessCheck: resources.AccessCheck{
+			Allowed: result.Allowed,
+		},
+		Status: http.StatusOK,
+	}
+	if check.Explain {
+		response.Paths = result.Paths
+	}
+
+	writeResponse(w, http.StatusOK, response)
+}
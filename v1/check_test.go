@@ -0,0 +1,191 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+//go:generate mockgen -package interfaces -destination ./interfaces/mock_access_check.go -source=./interfaces/access_check.go
+
+func TestHandler_Check(t *testing.T) {
+	c := qt.New(t)
+
+	entitlement := resources.EntityEntitlement{
+		Entitlement: "can_view",
+		EntityId:    "some-entity",
+		EntityType:  "some-type",
+	}
+	paths := []resources.AccessPath{{
+		Via: []resources.AccessPathStep{},
+	}, {
+		Via: []resources.AccessPathStep{
+			{Type: resources.AccessPathStepTypeGroup, Id: "some-group"},
+			{Type: resources.AccessPathStepTypeRole, Id: "some-role"},
+		},
+	}}
+
+	tests := []struct {
+		name             string
+		explain          bool
+		setupServiceMock func(mockService *interfaces.MockAccessCheckService)
+		expectedStatus   int
+		expectedBody     any
+	}{{
+		name:    "allowed",
+		explain: true,
+		setupServiceMock: func(mockService *interfaces.MockAccessCheckService) {
+			mockService.EXPECT().
+				CheckAccess(gomock.Any(), "some-identity", entitlement, true).
+				Return(&resources.AccessCheck{Allowed: true, Paths: paths}, nil)
+		},
+		expectedStatus: http.StatusOK,
+		expectedBody: resources.CheckResponse{
+			AccessCheck: resources.AccessCheck{Allowed: true, Paths: paths},
+			Status:      http.StatusOK,
+		},
+	}, {
+		name: "allowed; not explained",
+		setupServiceMock: func(mockService *interfaces.MockAccessCheckService) {
+			mockService.EXPECT().
+				CheckAccess(gomock.Any(), "some-identity", entitlement, false).
+				Return(&resources.AccessCheck{Allowed: true, Paths: paths}, nil)
+		},
+		expectedStatus: http.StatusOK,
+		expectedBody: resources.CheckResponse{
+			AccessCheck: resources.AccessCheck{Allowed: true},
+			Status:      http.StatusOK,
+		},
+	}, {
+		name:    "denied",
+		explain: true,
+		setupServiceMock: func(mockService *interfaces.MockAccessCheckService) {
+			mockService.EXPECT().
+				CheckAccess(gomock.Any(), "some-identity", entitlement, true).
+				Return(&resources.AccessCheck{Allowed: false}, nil)
+		},
+		expectedStatus: http.StatusOK,
+		expectedBody: resources.CheckResponse{
+			AccessCheck: resources.AccessCheck{Allowed: false},
+			Status:      http.StatusOK,
+		},
+	}, {
+		name: "missing identity",
+		setupServiceMock: func(mockService *interfaces.MockAccessCheckService) {
+			mockService.EXPECT().
+				CheckAccess(gomock.Any(), "some-identity", entitlement, false).
+				Return(nil, NewNotFoundError("identity not found"))
+		},
+		expectedStatus: http.StatusNotFound,
+		expectedBody: resources.Response{
+			Message: "Not Found: identity not found",
+			Status:  http.StatusNotFound,
+		},
+	}, {
+		name: "missing result",
+		setupServiceMock: func(mockService *interfaces.MockAccessCheckService) {
+			mockService.EXPECT().
+				CheckAccess(gomock.Any(), "some-identity", entitlement, false).
+				Return(nil, nil)
+		},
+		expectedStatus: http.StatusInternalServerError,
+		expectedBody: resources.Response{
+			Message: "Internal Server Error: access check service returned no result",
+			Status:  http.StatusInternalServerError,
+		},
+	}}
+
+	for _, test := range tests {
+		tt := test
+		c.Run(tt.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+			defer ctrl.Finish()
+
+			mockService := interfaces.NewMockAccessCheckService(ctrl)
+			tt.setupServiceMock(mockService)
+
+			sut := handler{AccessCheck: mockService}
+
+			w := httptest.NewRecorder()
+			req := newTestRequest(http.MethodPost, "/check", &resources.CheckRequestBody{
+				IdentityId:  "some-identity",
+				Entitlement: entitlement,
+				Explain:     tt.explain,
+			})
+			sut.PostCheck(w, req)
+
+			result := w.Result()
+			defer result.Body.Close()
+			c.Assert(result.StatusCode, qt.Equals, tt.expectedStatus)
+
+			body, err := io.ReadAll(result.Body)
+			c.Assert(err, qt.IsNil)
+			c.Assert(string(body), qt.JSONEquals, tt.expectedBody)
+		})
+	}
+}
+
+func TestCheckIsWiredToTheBackend(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name           string
+		withService    bool
+		body           any
+		expectedStatus int
+	}{{
+		name:           "not implemented",
+		body:           resources.CheckRequestBody{IdentityId: "foo"},
+		expectedStatus: http.StatusNotImplemented,
+	}, {
+		name:           "implemented",
+		withService:    true,
+		body:           resources.CheckRequestBody{IdentityId: "foo", Entitlement: resources.EntityEntitlement{Entitlement: "can_view", EntityId: "bar", EntityType: "baz"}},
+		expectedStatus: http.StatusOK,
+	}}
+
+	for _, test := range tests {
+		tt := test
+		c.Run(tt.name, func(c *qt.C) {
+			params := ReBACAdminBackendParams{}
+			if tt.withService {
+				mockService := interfaces.NewMockAccessCheckService(ctrl)
+				mockService.EXPECT().CheckAccess(gomock.Any(), "foo", gomock.Any(), false).Return(&resources.AccessCheck{}, nil)
+				params.AccessCheck = mockService
+			}
+			sut, _ := NewReBACAdminBackend(params)
+
+			raw, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/v1/check", bytes.NewReader(raw))
+			w := httptest.NewRecorder()
+			sut.Handler("").ServeHTTP(w, req)
+			c.Assert(w.Code, qt.Equals, tt.expectedStatus)
+		})
+	}
+}
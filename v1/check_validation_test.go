@@ -0,0 +1,81 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+func TestHandlerWithValidation_Check(t *testing.T) {
+	c := qt.New(t)
+
+	entitlement := resources.EntityEntitlement{Entitlement: "can_view", EntityId: "bar", EntityType: "baz"}
+
+	tests := []struct {
+		name            string
+		body            any
+		expectedMessage string
+	}{{
+		name:            "missing identity ID",
+		body:            resources.CheckRequestBody{Entitlement: entitlement},
+		expectedMessage: "'IdentityId' failed on the 'required' tag",
+	}, {
+		name: "missing entitlement",
+		body: resources.CheckRequestBody{
+			IdentityId:  "foo",
+			Entitlement: resources.EntityEntitlement{EntityId: "bar", EntityType: "baz"},
+		},
+		expectedMessage: "'Entitlement' failed on the 'required' tag",
+	}, {
+		name:            "missing entity",
+		body:            resources.CheckRequestBody{IdentityId: "foo"},
+		expectedMessage: "'EntityId' failed on the 'required' tag",
+	}}
+
+	for _, test := range tests {
+		tt := test
+		c.Run(tt.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+			defer ctrl.Finish()
+
+			// No calls are expected on the service backend.
+			sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+				AccessCheck: interfaces.NewMockAccessCheckService(ctrl),
+			})
+
+			raw, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/v1/check", bytes.NewReader(raw))
+			w := httptest.NewRecorder()
+			sut.Handler("").ServeHTTP(w, req)
+			c.Assert(w.Code, qt.Equals, http.StatusBadRequest)
+
+			response := resources.Response{}
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			c.Assert(err, qt.IsNil)
+			c.Assert(response.Message, qt.Contains, tt.expectedMessage)
+		})
+	}
+}
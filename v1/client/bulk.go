@@ -0,0 +1,35 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// PostBulk executes a list of operations, in order. Note that a partial
+// failure (i.e., a `207 Multi-Status` response) is not returned as an error;
+// the results of the operations should be checked instead.
+// (POST /bulk)
+func (c *Client) PostBulk(ctx context.Context, body *resources.BulkRequestBody) (*resources.BulkResponse, error) {
+	response := &resources.BulkResponse{}
+	if _, err := c.do(ctx, newRequest(http.MethodPost, "bulk").withBody(body), response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/mock/gomock"
+
+	v1 "github.com/canonical/rebac-admin-ui-handlers/v1"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+func TestClient_Bulk(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	groupId := "some-group"
+	group := resources.Group{Id: &groupId, Name: "some-group-name"}
+
+	groups := interfaces.NewMockGroupsService(ctrl)
+	groups.EXPECT().
+		CreateGroup(gomock.Any(), &resources.Group{Name: "some-group-name"}).
+		Return(&group, nil)
+	groups.EXPECT().
+		PatchGroupIdentities(gomock.Any(), groupId, gomock.Any()).
+		Return(false, errors.New("some-error"))
+
+	client := newTestClient(c, v1.ReBACAdminBackendParams{Groups: groups, SequentialBulk: true})
+
+	response, err := client.PostBulk(context.Background(), &resources.BulkRequestBody{
+		Operations: []resources.BulkOperation{{
+			Op:    resources.BulkOperationOpCreateGroup,
+			Group: &resources.Group{Name: "some-group-name"},
+		}, {
+			Op: resources.BulkOperationOpPatchGroupIdentities,
+			Id: &groupId,
+			GroupIdentities: []resources.GroupIdentitiesPatchItem{
+				{Identity: "some-identity", Op: resources.GroupIdentitiesPatchItemOpAdd},
+			},
+		}, {
+			Op: resources.BulkOperationOpDeleteGroup,
+			Id: &groupId,
+		}},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(response.Status, qt.Equals, http.StatusMultiStatus)
+	c.Assert(response.Atomic, qt.IsFalse)
+	c.Assert(response.Results, qt.HasLen, 3)
+	c.Assert(response.Results[0].Status, qt.Equals, http.StatusCreated)
+	c.Assert(response.Results[0].Group, qt.DeepEquals, &group)
+	c.Assert(response.Results[1].Status, qt.Equals, http.StatusInternalServerError)
+	c.Assert(response.Results[2].Status, qt.Equals, http.StatusFailedDependency)
+}
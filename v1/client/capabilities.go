@@ -0,0 +1,33 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// GetCapabilities returns the list of endpoints implemented by this API.
+// (GET /capabilities)
+func (c *Client) GetCapabilities(ctx context.Context) (*resources.GetCapabilitiesResponse, error) {
+	response := &resources.GetCapabilitiesResponse{}
+	if _, err := c.do(ctx, newRequest(http.MethodGet, "capabilities"), response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
@@ -0,0 +1,50 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/mock/gomock"
+
+	v1 "github.com/canonical/rebac-admin-ui-handlers/v1"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+func TestClient_Capabilities(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	capability := resources.Capability{
+		Endpoint: "/groups",
+		Methods:  []resources.CapabilityMethods{"GET"},
+	}
+
+	capabilities := interfaces.NewMockCapabilitiesService(ctrl)
+	capabilities.EXPECT().
+		ListCapabilities(gomock.Any()).
+		Return([]resources.Capability{capability}, nil)
+
+	client := newTestClient(c, v1.ReBACAdminBackendParams{Capabilities: capabilities})
+
+	response, err := client.GetCapabilities(context.Background())
+	c.Assert(err, qt.IsNil)
+	c.Assert(response.Data, qt.DeepEquals, []resources.Capability{capability})
+}
@@ -0,0 +1,34 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// PostCheck checks whether an identity has an entitlement, and optionally,
+// returns the paths that grant it.
+// (POST /check)
+func (c *Client) PostCheck(ctx context.Context, body *resources.CheckRequestBody) (*resources.CheckResponse, error) {
+	response := &resources.CheckResponse{}
+	if _, err := c.do(ctx, newRequest(http.MethodPost, "check").withBody(body), response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
@@ -0,0 +1,58 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/mock/gomock"
+
+	v1 "github.com/canonical/rebac-admin-ui-handlers/v1"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+func TestClient_Check(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	entitlement := resources.EntityEntitlement{Entitlement: "can_view", EntityId: "some-entity", EntityType: "some-type"}
+	paths := []resources.AccessPath{{
+		Via: []resources.AccessPathStep{{Type: resources.AccessPathStepTypeGroup, Id: "some-group"}},
+	}}
+
+	accessCheck := interfaces.NewMockAccessCheckService(ctrl)
+	accessCheck.EXPECT().
+		CheckAccess(gomock.Any(), "some-identity", entitlement, true).
+		Return(&resources.AccessCheck{Allowed: true, Paths: paths}, nil)
+
+	client := newTestClient(c, v1.ReBACAdminBackendParams{AccessCheck: accessCheck})
+
+	response, err := client.PostCheck(context.Background(), &resources.CheckRequestBody{
+		IdentityId:  "some-identity",
+		Entitlement: entitlement,
+		Explain:     true,
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(response, qt.DeepEquals, &resources.CheckResponse{
+		AccessCheck: resources.AccessCheck{Allowed: true, Paths: paths},
+		Status:      http.StatusOK,
+	})
+}
@@ -0,0 +1,198 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package client provides a typed Go client for the ReBAC Admin API, as served
+// by the handlers of the `v1` package. It's meant to be used by integration
+// tests and tools that talk to services exposing the API.
+//
+// There is a method for every operation of the API, named after its operation
+// ID (e.g., `GetGroups`). Error responses are returned as `*Error` values,
+// and paginated lists can be iterated over via the `*Pager` methods.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// nextPageTokenHeader is the HTTP header used to pass the continuation token
+// of the next page of results.
+const nextPageTokenHeader = "Next-Page-Token"
+
+// ClientParams contains the configuration of a ReBAC Admin API client.
+type ClientParams struct {
+	// BaseURL is the URL the ReBAC Admin handlers are served at, i.e., the
+	// server address followed by the base URL passed to
+	// `ReBACAdminBackend.Handler` (e.g., `http://localhost:8080/rebac`). The
+	// `/v1` suffix is added by the client.
+	BaseURL string
+
+	// Client is the HTTP client used to send requests. If nil, the
+	// `http.DefaultClient` is used.
+	Client *http.Client
+
+	// RequestEditor, if not nil, is called on every request before it's sent.
+	// It can be used to add authentication headers. If it returns an error,
+	// the request is not sent.
+	RequestEditor func(req *http.Request) error
+}
+
+// Client is a ReBAC Admin API client.
+type Client struct {
+	params  ClientParams
+	baseURL *url.URL
+}
+
+// NewClient returns a new Client instance, configured with the given
+// parameters.
+func NewClient(params ClientParams) (*Client, error) {
+	if params.BaseURL == "" {
+		return nil, errors.New("missing base URL")
+	}
+	baseURL, err := url.Parse(params.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if baseURL.Scheme == "" || baseURL.Host == "" {
+		return nil, fmt.Errorf("invalid base URL: %q is not absolute", params.BaseURL)
+	}
+	if params.Client == nil {
+		params.Client = http.DefaultClient
+	}
+
+	// The trailing slash is required so that relative references are resolved
+	// under the `/v1` path.
+	baseURL.Path = strings.TrimSuffix(baseURL.Path, "/") + "/v1/"
+	baseURL.RawPath = ""
+	return &Client{
+		params:  params,
+		baseURL: baseURL,
+	}, nil
+}
+
+// request represents an API request, before being sent.
+type request struct {
+	method string
+	// ref is the request URL, relative to the API base URL (i.e., the one
+	// ending with `/v1/`).
+	ref    *url.URL
+	header http.Header
+	body   any
+}
+
+// newRequest returns a new request with the given method, targeting the
+// endpoint made of the given path segments. The path segments are escaped.
+func newRequest(method string, segments ...string) *request {
+	escaped := make([]string, 0, len(segments))
+	for _, s := range segments {
+		escaped = append(escaped, url.PathEscape(s))
+	}
+	return &request{
+		method: method,
+		ref: &url.URL{
+			Path:    strings.Join(segments, "/"),
+			RawPath: strings.Join(escaped, "/"),
+		},
+		header: http.Header{},
+	}
+}
+
+// withBody sets the given value as the JSON request body.
+func (r *request) withBody(body any) *request {
+	r.body = body
+	return r
+}
+
+// setQuery sets the given query parameter, if the value is not nil.
+func setQuery[T any](r *request, key string, value *T) {
+	if value == nil {
+		return
+	}
+	query := r.ref.Query()
+	query.Set(key, fmt.Sprint(*value))
+	r.ref.RawQuery = query.Encode()
+}
+
+// setHeader sets the given request header, if the value is not nil.
+func setHeader(r *request, key string, value *string) {
+	if value == nil {
+		return
+	}
+	r.header.Set(key, *value)
+}
+
+// do sends the given request and decodes the response into the given value
+// (if not nil). Values of type `*[]byte` receive the raw response body. The
+// response headers are returned on success. Error responses are returned as
+// `*Error` values.
+func (c *Client) do(ctx context.Context, r *request, response any) (http.Header, error) {
+	var body io.Reader
+	if r.body != nil {
+		raw, err := json.Marshal(r.body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		body = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.method, c.baseURL.ResolveReference(r.ref).String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for key, values := range r.header {
+		req.Header[key] = values
+	}
+	if r.body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.params.RequestEditor != nil {
+		if err := c.params.RequestEditor(req); err != nil {
+			return nil, fmt.Errorf("failed to edit request: %w", err)
+		}
+	}
+
+	res, err := c.params.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, newError(res.StatusCode, resBody)
+	}
+
+	switch v := response.(type) {
+	case nil:
+	case *[]byte:
+		*v = resBody
+	default:
+		if err := json.Unmarshal(resBody, response); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+	}
+	return res.Header, nil
+}
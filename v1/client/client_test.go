@@ -0,0 +1,200 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/mock/gomock"
+
+	v1 "github.com/canonical/rebac-admin-ui-handlers/v1"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// newTestClient returns a client talking to a test server that serves the
+// ReBAC Admin handlers, configured with the given backends, under the
+// `/rebac` base URL.
+func newTestClient(c *qt.C, params v1.ReBACAdminBackendParams) *Client {
+	backend, err := v1.NewReBACAdminBackend(params)
+	c.Assert(err, qt.IsNil)
+
+	server := httptest.NewServer(backend.Handler("/rebac"))
+	c.Cleanup(server.Close)
+
+	client, err := NewClient(ClientParams{BaseURL: server.URL + "/rebac"})
+	c.Assert(err, qt.IsNil)
+	return client
+}
+
+func TestNewClient(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		name            string
+		baseURL         string
+		expectedError   string
+		expectedBaseURL string
+	}{{
+		name:            "valid",
+		baseURL:         "http://localhost:8080/rebac",
+		expectedBaseURL: "http://localhost:8080/rebac/v1/",
+	}, {
+		name:            "valid; trailing slash",
+		baseURL:         "http://localhost:8080/rebac/",
+		expectedBaseURL: "http://localhost:8080/rebac/v1/",
+	}, {
+		name:            "valid; no path",
+		baseURL:         "http://localhost:8080",
+		expectedBaseURL: "http://localhost:8080/v1/",
+	}, {
+		name:          "missing base URL",
+		expectedError: "missing base URL",
+	}, {
+		name:          "invalid base URL",
+		baseURL:       "http://local host",
+		expectedError: "invalid base URL: .*",
+	}, {
+		name:          "relative base URL",
+		baseURL:       "/rebac",
+		expectedError: `invalid base URL: "/rebac" is not absolute`,
+	}}
+
+	for _, t := range tests {
+		tt := t
+		c.Run(tt.name, func(c *qt.C) {
+			client, err := NewClient(ClientParams{BaseURL: tt.baseURL})
+			if tt.expectedError != "" {
+				c.Assert(err, qt.ErrorMatches, tt.expectedError)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+			c.Assert(client.baseURL.String(), qt.Equals, tt.expectedBaseURL)
+			c.Assert(client.params.Client, qt.Equals, http.DefaultClient)
+		})
+	}
+}
+
+func TestClient_RequestEditor(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	authenticator := interfaces.NewMockAuthenticator(ctrl)
+	authenticator.EXPECT().
+		Authenticate(gomock.Any()).
+		DoAndReturn(func(r *http.Request) (any, error) {
+			if r.Header.Get("Authorization") != "Bearer some-token" {
+				return nil, v1.NewAuthenticationError("invalid token")
+			}
+			return "some-identity", nil
+		}).
+		Times(2)
+
+	groups := interfaces.NewMockGroupsService(ctrl)
+	groups.EXPECT().
+		ListGroups(gomock.Any(), gomock.Any()).
+		Return(&resources.PaginatedResponse[resources.Group]{}, nil)
+
+	backend, err := v1.NewReBACAdminBackend(v1.ReBACAdminBackendParams{
+		Authenticator: authenticator,
+		Groups:        groups,
+	})
+	c.Assert(err, qt.IsNil)
+	server := httptest.NewServer(backend.Handler(""))
+	defer server.Close()
+
+	unauthenticated, err := NewClient(ClientParams{BaseURL: server.URL})
+	c.Assert(err, qt.IsNil)
+	_, err = unauthenticated.GetGroups(context.Background(), nil)
+	c.Assert(err, qt.ErrorMatches, "Unauthorized: authentication failed: invalid token")
+	c.Assert(StatusCode(err), qt.Equals, http.StatusUnauthorized)
+
+	authenticated, err := NewClient(ClientParams{
+		BaseURL: server.URL,
+		RequestEditor: func(req *http.Request) error {
+			req.Header.Set("Authorization", "Bearer some-token")
+			return nil
+		},
+	})
+	c.Assert(err, qt.IsNil)
+	_, err = authenticated.GetGroups(context.Background(), nil)
+	c.Assert(err, qt.IsNil)
+
+	failing, err := NewClient(ClientParams{
+		BaseURL: server.URL,
+		RequestEditor: func(req *http.Request) error {
+			return errors.New("some-error")
+		},
+	})
+	c.Assert(err, qt.IsNil)
+	_, err = failing.GetGroups(context.Background(), nil)
+	c.Assert(err, qt.ErrorMatches, "failed to edit request: some-error")
+}
+
+func TestClient_RequestParameters(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	size := 10
+	page := 2
+	nextToken := "some-token"
+	filter := "some filter"
+	nextPageToken := "some-header-token"
+
+	// The page number and next page tokens are mutually exclusive, so they are
+	// sent in separate requests.
+	params := []*resources.GetGroupsParams{{
+		Size:   &size,
+		Page:   &page,
+		Filter: &filter,
+	}, {
+		Size:      &size,
+		NextToken: &nextToken,
+		Filter:    &filter,
+	}, {
+		Size:          &size,
+		Filter:        &filter,
+		NextPageToken: &nextPageToken,
+	}}
+
+	groups := interfaces.NewMockGroupsService(ctrl)
+	for _, p := range params {
+		groups.EXPECT().
+			ListGroups(gomock.Any(), p).
+			Return(&resources.PaginatedResponse[resources.Group]{}, nil)
+	}
+	groups.EXPECT().
+		GetGroup(gomock.Any(), "some group/with:special?chars").
+		Return(&resources.Group{Name: "some-group"}, nil)
+
+	client := newTestClient(c, v1.ReBACAdminBackendParams{Groups: groups})
+
+	for _, p := range params {
+		_, err := client.GetGroups(context.Background(), p)
+		c.Assert(err, qt.IsNil)
+	}
+
+	group, err := client.GetGroupsItem(context.Background(), "some group/with:special?chars")
+	c.Assert(err, qt.IsNil)
+	c.Assert(group, qt.DeepEquals, &resources.Group{Name: "some-group"})
+}
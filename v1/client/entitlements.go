@@ -0,0 +1,52 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// GetEntitlements returns the list of known entitlements in JSON format.
+// (GET /entitlements)
+func (c *Client) GetEntitlements(ctx context.Context, params *resources.GetEntitlementsParams) (*resources.GetEntitlementsResponse, error) {
+	response := &resources.GetEntitlementsResponse{}
+	if _, err := c.do(ctx, getEntitlementsRequest(params), response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// getEntitlementsRequest returns the request of the GetEntitlements operation.
+func getEntitlementsRequest(params *resources.GetEntitlementsParams) *request {
+	r := newRequest(http.MethodGet, "entitlements")
+	if params != nil {
+		setQuery(r, "filter", params.Filter)
+	}
+	return r
+}
+
+// GetRawEntitlements returns the list of known entitlements as raw text.
+// (GET /entitlements/raw)
+func (c *Client) GetRawEntitlements(ctx context.Context) (string, error) {
+	var response string
+	if _, err := c.do(ctx, newRequest(http.MethodGet, "entitlements", "raw"), &response); err != nil {
+		return "", err
+	}
+	return response, nil
+}
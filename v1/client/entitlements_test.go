@@ -0,0 +1,61 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/mock/gomock"
+
+	v1 "github.com/canonical/rebac-admin-ui-handlers/v1"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+func TestClient_Entitlements(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	filter := "some-filter"
+	schema := resources.EntitlementSchema{Entitlement: "can_view", EntityType: "some-type", ReceiverType: "group"}
+	rawModel := "type user\ntype group\n"
+
+	entitlements := interfaces.NewMockEntitlementsService(ctrl)
+	entitlements.EXPECT().
+		ListEntitlements(gomock.Any(), &resources.GetEntitlementsParams{Filter: &filter}).
+		Return([]resources.EntitlementSchema{schema}, nil)
+	entitlements.EXPECT().
+		RawEntitlements(gomock.Any()).
+		Return(rawModel, nil)
+
+	client := newTestClient(c, v1.ReBACAdminBackendParams{Entitlements: entitlements})
+
+	response, err := client.GetEntitlements(context.Background(), &resources.GetEntitlementsParams{Filter: &filter})
+	c.Assert(err, qt.IsNil)
+	c.Assert(response, qt.DeepEquals, &resources.GetEntitlementsResponse{
+		Meta:   resources.ResponseMeta{Size: 1},
+		Data:   []resources.EntitlementSchema{schema},
+		Status: http.StatusOK,
+	})
+
+	raw, err := client.GetRawEntitlements(context.Background())
+	c.Assert(err, qt.IsNil)
+	c.Assert(raw, qt.Equals, rawModel)
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// Error represents an error response returned by the API. The embedded
+// response carries the HTTP status code and the message of the error (e.g.,
+// `Not Found: group not found`), as written by the handlers.
+type Error struct {
+	resources.Response
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	if statusText := http.StatusText(e.Status); statusText != "" {
+		return statusText
+	}
+	return "[Unknown error]"
+}
+
+// newError returns a new Error instance, decoded from the given error response
+// body. If the body is not a valid error response, only the status is kept.
+func newError(status int, body []byte) *Error {
+	e := &Error{}
+	if err := json.Unmarshal(body, &e.Response); err != nil {
+		e.Response = resources.Response{}
+	}
+	// The status code of the HTTP response takes precedence, since the body
+	// may be written by a proxy or other middlewares.
+	e.Status = status
+	return e
+}
+
+// StatusCode returns the HTTP status code of the given error, if it is (or
+// wraps) an `*Error`. Otherwise, it returns 0.
+func StatusCode(err error) int {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Status
+	}
+	return 0
+}
+
+// IsNotFound reports whether the given error is a "Not Found" error response.
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
@@ -0,0 +1,135 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/mock/gomock"
+
+	v1 "github.com/canonical/rebac-admin-ui-handlers/v1"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+func TestError(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		name            string
+		status          int
+		body            string
+		expectedMessage string
+		expectedError   string
+	}{{
+		name:            "error response",
+		status:          http.StatusNotFound,
+		body:            `{"status":404,"message":"Not Found: some-error"}`,
+		expectedMessage: "Not Found: some-error",
+		expectedError:   "Not Found: some-error",
+	}, {
+		name:          "error response; no message",
+		status:        http.StatusBadRequest,
+		body:          `{"status":400}`,
+		expectedError: "Bad Request",
+	}, {
+		name:          "non-JSON body",
+		status:        http.StatusBadGateway,
+		body:          "<html>bad gateway</html>",
+		expectedError: "Bad Gateway",
+	}, {
+		name:          "unknown status",
+		status:        599,
+		expectedError: `\[Unknown error\]`,
+	}}
+
+	for _, t := range tests {
+		tt := t
+		c.Run(tt.name, func(c *qt.C) {
+			err := newError(tt.status, []byte(tt.body))
+			c.Assert(err, qt.ErrorMatches, tt.expectedError)
+			c.Assert(err.Status, qt.Equals, tt.status)
+			c.Assert(err.Message, qt.Equals, tt.expectedMessage)
+		})
+	}
+}
+
+func TestStatusCode(t *testing.T) {
+	c := qt.New(t)
+
+	notFound := &Error{Response: resources.Response{Status: http.StatusNotFound}}
+	c.Assert(StatusCode(notFound), qt.Equals, http.StatusNotFound)
+	c.Assert(StatusCode(fmt.Errorf("wrapped: %w", notFound)), qt.Equals, http.StatusNotFound)
+	c.Assert(StatusCode(errors.New("some-error")), qt.Equals, 0)
+	c.Assert(StatusCode(nil), qt.Equals, 0)
+
+	c.Assert(IsNotFound(notFound), qt.IsTrue)
+	c.Assert(IsNotFound(&Error{Response: resources.Response{Status: http.StatusBadRequest}}), qt.IsFalse)
+}
+
+func TestClient_ErrorResponses(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	groups := interfaces.NewMockGroupsService(ctrl)
+	groups.EXPECT().
+		GetGroup(gomock.Any(), "some-group").
+		Return(nil, v1.NewNotFoundError("group not found"))
+	groups.EXPECT().
+		DeleteGroup(gomock.Any(), "some-group").
+		Return(false, errors.New("some-error"))
+
+	client := newTestClient(c, v1.ReBACAdminBackendParams{Groups: groups})
+
+	_, err := client.GetGroupsItem(context.Background(), "some-group")
+	c.Assert(err, qt.ErrorMatches, "Not Found: group not found")
+	c.Assert(IsNotFound(err), qt.IsTrue)
+
+	err = client.DeleteGroupsItem(context.Background(), "some-group")
+	c.Assert(err, qt.ErrorMatches, "Internal Server Error: some-error")
+	c.Assert(StatusCode(err), qt.Equals, http.StatusInternalServerError)
+
+	// Request body validation errors.
+	_, err = client.PostGroups(context.Background(), &resources.Group{})
+	c.Assert(StatusCode(err), qt.Equals, http.StatusBadRequest)
+
+	// Unimplemented endpoints.
+	_, err = client.GetRoles(context.Background(), nil)
+	c.Assert(StatusCode(err), qt.Equals, http.StatusNotImplemented)
+}
+
+func TestClient_NonAPIErrorResponse(t *testing.T) {
+	c := qt.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "upstream unavailable", http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientParams{BaseURL: server.URL})
+	c.Assert(err, qt.IsNil)
+
+	_, err = client.GetCapabilities(context.Background())
+	c.Assert(err, qt.ErrorMatches, "Bad Gateway")
+	c.Assert(StatusCode(err), qt.Equals, http.StatusBadGateway)
+}
@@ -0,0 +1,190 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// GetGroups returns the list of known groups.
+// (GET /groups)
+func (c *Client) GetGroups(ctx context.Context, params *resources.GetGroupsParams) (*resources.GetGroupsResponse, error) {
+	response := &resources.GetGroupsResponse{}
+	if _, err := c.do(ctx, getGroupsRequest(params), response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetGroupsPager returns a pager over all the pages of the GetGroups results.
+func (c *Client) GetGroupsPager(params *resources.GetGroupsParams) *Pager[resources.Group] {
+	return newPager[resources.Group](c, getGroupsRequest(params))
+}
+
+// getGroupsRequest returns the request of the GetGroups operation.
+func getGroupsRequest(params *resources.GetGroupsParams) *request {
+	r := newRequest(http.MethodGet, "groups")
+	if params != nil {
+		setQuery(r, "size", params.Size)
+		setQuery(r, "page", params.Page)
+		setQuery(r, "nextToken", params.NextToken)
+		setQuery(r, "filter", params.Filter)
+		setHeader(r, nextPageTokenHeader, params.NextPageToken)
+	}
+	return r
+}
+
+// PostGroups adds a new group.
+// (POST /groups)
+func (c *Client) PostGroups(ctx context.Context, group *resources.Group) (*resources.Group, error) {
+	response := &resources.Group{}
+	if _, err := c.do(ctx, newRequest(http.MethodPost, "groups").withBody(group), response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// DeleteGroupsItem deletes the specified group identified by the provided ID.
+// (DELETE /groups/{id})
+func (c *Client) DeleteGroupsItem(ctx context.Context, id string) error {
+	_, err := c.do(ctx, newRequest(http.MethodDelete, "groups", id), nil)
+	return err
+}
+
+// GetGroupsItem returns the group identified by the provided ID.
+// (GET /groups/{id})
+func (c *Client) GetGroupsItem(ctx context.Context, id string) (*resources.Group, error) {
+	response := &resources.Group{}
+	if _, err := c.do(ctx, newRequest(http.MethodGet, "groups", id), response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// PutGroupsItem updates the group identified by the provided ID.
+// (PUT /groups/{id})
+func (c *Client) PutGroupsItem(ctx context.Context, id string, group *resources.Group) (*resources.Group, error) {
+	response := &resources.Group{}
+	if _, err := c.do(ctx, newRequest(http.MethodPut, "groups", id).withBody(group), response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetGroupsItemEntitlements returns the list of entitlements for a group identified by the provided ID.
+// (GET /groups/{id}/entitlements)
+func (c *Client) GetGroupsItemEntitlements(ctx context.Context, id string, params *resources.GetGroupsItemEntitlementsParams) (*resources.GetGroupEntitlementsResponse, error) {
+	response := &resources.GetGroupEntitlementsResponse{}
+	if _, err := c.do(ctx, getGroupsItemEntitlementsRequest(id, params), response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetGroupsItemEntitlementsPager returns a pager over all the pages of the GetGroupsItemEntitlements results.
+func (c *Client) GetGroupsItemEntitlementsPager(id string, params *resources.GetGroupsItemEntitlementsParams) *Pager[resources.EntityEntitlement] {
+	return newPager[resources.EntityEntitlement](c, getGroupsItemEntitlementsRequest(id, params))
+}
+
+// getGroupsItemEntitlementsRequest returns the request of the GetGroupsItemEntitlements operation.
+func getGroupsItemEntitlementsRequest(id string, params *resources.GetGroupsItemEntitlementsParams) *request {
+	r := newRequest(http.MethodGet, "groups", id, "entitlements")
+	if params != nil {
+		setQuery(r, "size", params.Size)
+		setQuery(r, "page", params.Page)
+		setQuery(r, "nextToken", params.NextToken)
+		setHeader(r, nextPageTokenHeader, params.NextPageToken)
+	}
+	return r
+}
+
+// PatchGroupsItemEntitlements adds or removes entitlements to/from a group identified by the provided ID.
+// (PATCH /groups/{id}/entitlements)
+func (c *Client) PatchGroupsItemEntitlements(ctx context.Context, id string, patch *resources.GroupEntitlementsPatchRequestBody) error {
+	_, err := c.do(ctx, newRequest(http.MethodPatch, "groups", id, "entitlements").withBody(patch), nil)
+	return err
+}
+
+// GetGroupsItemIdentities returns the list of identities within a group identified by given id.
+// (GET /groups/{id}/identities)
+func (c *Client) GetGroupsItemIdentities(ctx context.Context, id string, params *resources.GetGroupsItemIdentitiesParams) (*resources.GetGroupIdentitiesResponse, error) {
+	response := &resources.GetGroupIdentitiesResponse{}
+	if _, err := c.do(ctx, getGroupsItemIdentitiesRequest(id, params), response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetGroupsItemIdentitiesPager returns a pager over all the pages of the GetGroupsItemIdentities results.
+func (c *Client) GetGroupsItemIdentitiesPager(id string, params *resources.GetGroupsItemIdentitiesParams) *Pager[resources.Identity] {
+	return newPager[resources.Identity](c, getGroupsItemIdentitiesRequest(id, params))
+}
+
+// getGroupsItemIdentitiesRequest returns the request of the GetGroupsItemIdentities operation.
+func getGroupsItemIdentitiesRequest(id string, params *resources.GetGroupsItemIdentitiesParams) *request {
+	r := newRequest(http.MethodGet, "groups", id, "identities")
+	if params != nil {
+		setQuery(r, "size", params.Size)
+		setQuery(r, "page", params.Page)
+		setQuery(r, "nextToken", params.NextToken)
+		setHeader(r, nextPageTokenHeader, params.NextPageToken)
+	}
+	return r
+}
+
+// PatchGroupsItemIdentities adds or removes identities to/from the group identified by given ID.
+// (PATCH /groups/{id}/identities)
+func (c *Client) PatchGroupsItemIdentities(ctx context.Context, id string, patch *resources.GroupIdentitiesPatchRequestBody) error {
+	_, err := c.do(ctx, newRequest(http.MethodPatch, "groups", id, "identities").withBody(patch), nil)
+	return err
+}
+
+// GetGroupsItemRoles returns the list of roles assigned to a group identified by given ID.
+// (GET /groups/{id}/roles)
+func (c *Client) GetGroupsItemRoles(ctx context.Context, id string, params *resources.GetGroupsItemRolesParams) (*resources.GetGroupRolesResponse, error) {
+	response := &resources.GetGroupRolesResponse{}
+	if _, err := c.do(ctx, getGroupsItemRolesRequest(id, params), response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetGroupsItemRolesPager returns a pager over all the pages of the GetGroupsItemRoles results.
+func (c *Client) GetGroupsItemRolesPager(id string, params *resources.GetGroupsItemRolesParams) *Pager[resources.Role] {
+	return newPager[resources.Role](c, getGroupsItemRolesRequest(id, params))
+}
+
+// getGroupsItemRolesRequest returns the request of the GetGroupsItemRoles operation.
+func getGroupsItemRolesRequest(id string, params *resources.GetGroupsItemRolesParams) *request {
+	r := newRequest(http.MethodGet, "groups", id, "roles")
+	if params != nil {
+		setQuery(r, "size", params.Size)
+		setQuery(r, "page", params.Page)
+		setQuery(r, "nextToken", params.NextToken)
+		setHeader(r, nextPageTokenHeader, params.NextPageToken)
+	}
+	return r
+}
+
+// PatchGroupsItemRoles adds or removes roles assigned to/from a group identified by given ID.
+// (PATCH /groups/{id}/roles)
+func (c *Client) PatchGroupsItemRoles(ctx context.Context, id string, patch *resources.GroupRolesPatchRequestBody) error {
+	_, err := c.do(ctx, newRequest(http.MethodPatch, "groups", id, "roles").withBody(patch), nil)
+	return err
+}
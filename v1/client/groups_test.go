@@ -0,0 +1,225 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/mock/gomock"
+
+	v1 "github.com/canonical/rebac-admin-ui-handlers/v1"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+func TestClient_Groups(t *testing.T) {
+	c := qt.New(t)
+
+	groupId := "some-group"
+	group := resources.Group{Id: &groupId, Name: "some-group-name"}
+	identityId := "some-identity"
+	identity := resources.Identity{Id: &identityId, Email: "some-email", AddedBy: "some-user", Source: "some-source"}
+	roleId := "some-role"
+	role := resources.Role{Id: &roleId, Name: "some-role-name"}
+	entitlement := resources.EntityEntitlement{Entitlement: "can_view", EntityId: "some-entity", EntityType: "some-type"}
+
+	tests := []struct {
+		name             string
+		setupServiceMock func(mockService *interfaces.MockGroupsService)
+		triggerFunc      func(client *Client) (any, error)
+		expected         any
+	}{{
+		name: "GetGroups",
+		setupServiceMock: func(mockService *interfaces.MockGroupsService) {
+			mockService.EXPECT().
+				ListGroups(gomock.Any(), &resources.GetGroupsParams{}).
+				Return(&resources.PaginatedResponse[resources.Group]{
+					Meta: resources.ResponseMeta{Size: 1},
+					Data: []resources.Group{group},
+				}, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return client.GetGroups(context.Background(), nil)
+		},
+		expected: &resources.GetGroupsResponse{
+			Meta:   resources.ResponseMeta{Size: 1},
+			Data:   []resources.Group{group},
+			Status: http.StatusOK,
+		},
+	}, {
+		name: "PostGroups",
+		setupServiceMock: func(mockService *interfaces.MockGroupsService) {
+			mockService.EXPECT().
+				CreateGroup(gomock.Any(), &resources.Group{Name: "some-group-name"}).
+				Return(&group, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return client.PostGroups(context.Background(), &resources.Group{Name: "some-group-name"})
+		},
+		expected: &group,
+	}, {
+		name: "DeleteGroupsItem",
+		setupServiceMock: func(mockService *interfaces.MockGroupsService) {
+			mockService.EXPECT().
+				DeleteGroup(gomock.Any(), groupId).
+				Return(true, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return nil, client.DeleteGroupsItem(context.Background(), groupId)
+		},
+	}, {
+		name: "GetGroupsItem",
+		setupServiceMock: func(mockService *interfaces.MockGroupsService) {
+			mockService.EXPECT().
+				GetGroup(gomock.Any(), groupId).
+				Return(&group, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return client.GetGroupsItem(context.Background(), groupId)
+		},
+		expected: &group,
+	}, {
+		name: "PutGroupsItem",
+		setupServiceMock: func(mockService *interfaces.MockGroupsService) {
+			mockService.EXPECT().
+				UpdateGroup(gomock.Any(), &group).
+				Return(&group, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return client.PutGroupsItem(context.Background(), groupId, &group)
+		},
+		expected: &group,
+	}, {
+		name: "GetGroupsItemEntitlements",
+		setupServiceMock: func(mockService *interfaces.MockGroupsService) {
+			mockService.EXPECT().
+				GetGroupEntitlements(gomock.Any(), groupId, &resources.GetGroupsItemEntitlementsParams{}).
+				Return(&resources.PaginatedResponse[resources.EntityEntitlement]{
+					Data: []resources.EntityEntitlement{entitlement},
+				}, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return client.GetGroupsItemEntitlementsPager(groupId, nil).All(context.Background())
+		},
+		expected: []resources.EntityEntitlement{entitlement},
+	}, {
+		name: "PatchGroupsItemEntitlements",
+		setupServiceMock: func(mockService *interfaces.MockGroupsService) {
+			mockService.EXPECT().
+				PatchGroupEntitlements(gomock.Any(), groupId, []resources.GroupEntitlementsPatchItem{{
+					Entitlement: entitlement,
+					Op:          "add",
+				}}).
+				Return(true, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return nil, client.PatchGroupsItemEntitlements(context.Background(), groupId, &resources.GroupEntitlementsPatchRequestBody{
+				Patches: []resources.GroupEntitlementsPatchItem{{
+					Entitlement: entitlement,
+					Op:          "add",
+				}},
+			})
+		},
+	}, {
+		name: "GetGroupsItemIdentities",
+		setupServiceMock: func(mockService *interfaces.MockGroupsService) {
+			mockService.EXPECT().
+				GetGroupIdentities(gomock.Any(), groupId, &resources.GetGroupsItemIdentitiesParams{}).
+				Return(&resources.PaginatedResponse[resources.Identity]{
+					Data: []resources.Identity{identity},
+				}, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return client.GetGroupsItemIdentitiesPager(groupId, nil).All(context.Background())
+		},
+		expected: []resources.Identity{identity},
+	}, {
+		name: "PatchGroupsItemIdentities",
+		setupServiceMock: func(mockService *interfaces.MockGroupsService) {
+			mockService.EXPECT().
+				PatchGroupIdentities(gomock.Any(), groupId, []resources.GroupIdentitiesPatchItem{{
+					Identity: identityId,
+					Op:       "remove",
+				}}).
+				Return(true, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return nil, client.PatchGroupsItemIdentities(context.Background(), groupId, &resources.GroupIdentitiesPatchRequestBody{
+				Patches: []resources.GroupIdentitiesPatchItem{{
+					Identity: identityId,
+					Op:       "remove",
+				}},
+			})
+		},
+	}, {
+		name: "GetGroupsItemRoles",
+		setupServiceMock: func(mockService *interfaces.MockGroupsService) {
+			mockService.EXPECT().
+				GetGroupRoles(gomock.Any(), groupId, &resources.GetGroupsItemRolesParams{}).
+				Return(&resources.PaginatedResponse[resources.Role]{
+					Data: []resources.Role{role},
+				}, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			response, err := client.GetGroupsItemRoles(context.Background(), groupId, nil)
+			if err != nil {
+				return nil, err
+			}
+			return response.Data, nil
+		},
+		expected: []resources.Role{role},
+	}, {
+		name: "PatchGroupsItemRoles",
+		setupServiceMock: func(mockService *interfaces.MockGroupsService) {
+			mockService.EXPECT().
+				PatchGroupRoles(gomock.Any(), groupId, []resources.GroupRolesPatchItem{{
+					Role: roleId,
+					Op:   "add",
+				}}).
+				Return(true, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return nil, client.PatchGroupsItemRoles(context.Background(), groupId, &resources.GroupRolesPatchRequestBody{
+				Patches: []resources.GroupRolesPatchItem{{
+					Role: roleId,
+					Op:   "add",
+				}},
+			})
+		},
+	}}
+
+	for _, t := range tests {
+		tt := t
+		c.Run(tt.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+			defer ctrl.Finish()
+
+			mockGroupsService := interfaces.NewMockGroupsService(ctrl)
+			tt.setupServiceMock(mockGroupsService)
+
+			client := newTestClient(c, v1.ReBACAdminBackendParams{Groups: mockGroupsService})
+
+			result, err := tt.triggerFunc(client)
+			c.Assert(err, qt.IsNil)
+			if tt.expected != nil {
+				c.Assert(result, qt.DeepEquals, tt.expected)
+			}
+		})
+	}
+}
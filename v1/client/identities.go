@@ -0,0 +1,200 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// GetIdentities returns the list of known identities.
+// (GET /identities)
+func (c *Client) GetIdentities(ctx context.Context, params *resources.GetIdentitiesParams) (*resources.GetIdentitiesResponse, error) {
+	response := &resources.GetIdentitiesResponse{}
+	if _, err := c.do(ctx, getIdentitiesRequest(params), response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetIdentitiesPager returns a pager over all the pages of the GetIdentities results.
+func (c *Client) GetIdentitiesPager(params *resources.GetIdentitiesParams) *Pager[resources.Identity] {
+	return newPager[resources.Identity](c, getIdentitiesRequest(params))
+}
+
+// getIdentitiesRequest returns the request of the GetIdentities operation.
+func getIdentitiesRequest(params *resources.GetIdentitiesParams) *request {
+	r := newRequest(http.MethodGet, "identities")
+	if params != nil {
+		setQuery(r, "size", params.Size)
+		setQuery(r, "page", params.Page)
+		setQuery(r, "nextToken", params.NextToken)
+		setQuery(r, "filter", params.Filter)
+		setHeader(r, nextPageTokenHeader, params.NextPageToken)
+	}
+	return r
+}
+
+// PostIdentities adds a new identity.
+// (POST /identities)
+func (c *Client) PostIdentities(ctx context.Context, identity *resources.Identity) (*resources.Identity, error) {
+	response := &resources.Identity{}
+	if _, err := c.do(ctx, newRequest(http.MethodPost, "identities").withBody(identity), response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// DeleteIdentitiesItem deletes the specified identity.
+// (DELETE /identities/{id})
+func (c *Client) DeleteIdentitiesItem(ctx context.Context, id string) error {
+	_, err := c.do(ctx, newRequest(http.MethodDelete, "identities", id), nil)
+	return err
+}
+
+// GetIdentitiesItem returns the identity identified by the provided ID.
+// (GET /identities/{id})
+func (c *Client) GetIdentitiesItem(ctx context.Context, id string) (*resources.Identity, error) {
+	response := &resources.Identity{}
+	if _, err := c.do(ctx, newRequest(http.MethodGet, "identities", id), response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// PutIdentitiesItem updates the identity identified by the provided ID.
+// (PUT /identities/{id})
+func (c *Client) PutIdentitiesItem(ctx context.Context, id string, identity *resources.Identity) (*resources.Identity, error) {
+	response := &resources.Identity{}
+	if _, err := c.do(ctx, newRequest(http.MethodPut, "identities", id).withBody(identity), response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetIdentitiesItemEntitlements returns the list of entitlements for an identity identified by the provided ID.
+// (GET /identities/{id}/entitlements)
+func (c *Client) GetIdentitiesItemEntitlements(ctx context.Context, id string, params *resources.GetIdentitiesItemEntitlementsParams) (*resources.GetIdentityEntitlementsResponse, error) {
+	response := &resources.GetIdentityEntitlementsResponse{}
+	if _, err := c.do(ctx, getIdentitiesItemEntitlementsRequest(id, params), response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetIdentitiesItemEntitlementsPager returns a pager over all the pages of the GetIdentitiesItemEntitlements results.
+func (c *Client) GetIdentitiesItemEntitlementsPager(id string, params *resources.GetIdentitiesItemEntitlementsParams) *Pager[resources.EntityEntitlement] {
+	return newPager[resources.EntityEntitlement](c, getIdentitiesItemEntitlementsRequest(id, params))
+}
+
+// getIdentitiesItemEntitlementsRequest returns the request of the GetIdentitiesItemEntitlements operation.
+func getIdentitiesItemEntitlementsRequest(id string, params *resources.GetIdentitiesItemEntitlementsParams) *request {
+	r := newRequest(http.MethodGet, "identities", id, "entitlements")
+	if params != nil {
+		setQuery(r, "size", params.Size)
+		setQuery(r, "page", params.Page)
+		setQuery(r, "nextToken", params.NextToken)
+		setHeader(r, nextPageTokenHeader, params.NextPageToken)
+	}
+	return r
+}
+
+// PatchIdentitiesItemEntitlements adds or removes entitlements to/from an identity.
+// (PATCH /identities/{id}/entitlements)
+func (c *Client) PatchIdentitiesItemEntitlements(ctx context.Context, id string, patch *resources.IdentityEntitlementsPatchRequestBody) error {
+	_, err := c.do(ctx, newRequest(http.MethodPatch, "identities", id, "entitlements").withBody(patch), nil)
+	return err
+}
+
+// GetIdentitiesItemEffectiveEntitlements returns the entitlements of an identity identified by the provided ID, including the ones inherited via its groups and roles.
+// (GET /identities/{id}/effective-entitlements)
+func (c *Client) GetIdentitiesItemEffectiveEntitlements(ctx context.Context, id string) (*resources.GetIdentityEffectiveEntitlementsResponse, error) {
+	response := &resources.GetIdentityEffectiveEntitlementsResponse{}
+	if _, err := c.do(ctx, newRequest(http.MethodGet, "identities", id, "effective-entitlements"), response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetIdentitiesItemGroups returns the list of groups the identity is a member of.
+// (GET /identities/{id}/groups)
+func (c *Client) GetIdentitiesItemGroups(ctx context.Context, id string, params *resources.GetIdentitiesItemGroupsParams) (*resources.GetIdentityGroupsResponse, error) {
+	response := &resources.GetIdentityGroupsResponse{}
+	if _, err := c.do(ctx, getIdentitiesItemGroupsRequest(id, params), response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetIdentitiesItemGroupsPager returns a pager over all the pages of the GetIdentitiesItemGroups results.
+func (c *Client) GetIdentitiesItemGroupsPager(id string, params *resources.GetIdentitiesItemGroupsParams) *Pager[resources.Group] {
+	return newPager[resources.Group](c, getIdentitiesItemGroupsRequest(id, params))
+}
+
+// getIdentitiesItemGroupsRequest returns the request of the GetIdentitiesItemGroups operation.
+func getIdentitiesItemGroupsRequest(id string, params *resources.GetIdentitiesItemGroupsParams) *request {
+	r := newRequest(http.MethodGet, "identities", id, "groups")
+	if params != nil {
+		setQuery(r, "size", params.Size)
+		setQuery(r, "page", params.Page)
+		setQuery(r, "nextToken", params.NextToken)
+		setHeader(r, nextPageTokenHeader, params.NextPageToken)
+	}
+	return r
+}
+
+// PatchIdentitiesItemGroups adds or removes the identity to/from groups.
+// (PATCH /identities/{id}/groups)
+func (c *Client) PatchIdentitiesItemGroups(ctx context.Context, id string, patch *resources.IdentityGroupsPatchRequestBody) error {
+	_, err := c.do(ctx, newRequest(http.MethodPatch, "identities", id, "groups").withBody(patch), nil)
+	return err
+}
+
+// GetIdentitiesItemRoles returns the list of roles assigned to the identity.
+// (GET /identities/{id}/roles)
+func (c *Client) GetIdentitiesItemRoles(ctx context.Context, id string, params *resources.GetIdentitiesItemRolesParams) (*resources.GetIdentityRolesResponse, error) {
+	response := &resources.GetIdentityRolesResponse{}
+	if _, err := c.do(ctx, getIdentitiesItemRolesRequest(id, params), response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetIdentitiesItemRolesPager returns a pager over all the pages of the GetIdentitiesItemRoles results.
+func (c *Client) GetIdentitiesItemRolesPager(id string, params *resources.GetIdentitiesItemRolesParams) *Pager[resources.Role] {
+	return newPager[resources.Role](c, getIdentitiesItemRolesRequest(id, params))
+}
+
+// getIdentitiesItemRolesRequest returns the request of the GetIdentitiesItemRoles operation.
+func getIdentitiesItemRolesRequest(id string, params *resources.GetIdentitiesItemRolesParams) *request {
+	r := newRequest(http.MethodGet, "identities", id, "roles")
+	if params != nil {
+		setQuery(r, "size", params.Size)
+		setQuery(r, "page", params.Page)
+		setQuery(r, "nextToken", params.NextToken)
+		setHeader(r, nextPageTokenHeader, params.NextPageToken)
+	}
+	return r
+}
+
+// PatchIdentitiesItemRoles adds or removes the identity to/from roles.
+// (PATCH /identities/{id}/roles)
+func (c *Client) PatchIdentitiesItemRoles(ctx context.Context, id string, patch *resources.IdentityRolesPatchRequestBody) error {
+	_, err := c.do(ctx, newRequest(http.MethodPatch, "identities", id, "roles").withBody(patch), nil)
+	return err
+}
@@ -0,0 +1,246 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/mock/gomock"
+
+	v1 "github.com/canonical/rebac-admin-ui-handlers/v1"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+func TestClient_Identities(t *testing.T) {
+	c := qt.New(t)
+
+	identityId := "some-identity"
+	identity := resources.Identity{Id: &identityId, Email: "some-email", AddedBy: "some-user", Source: "some-source"}
+	groupId := "some-group"
+	group := resources.Group{Id: &groupId, Name: "some-group-name"}
+	roleId := "some-role"
+	role := resources.Role{Id: &roleId, Name: "some-role-name"}
+	entitlement := resources.EntityEntitlement{Entitlement: "can_view", EntityId: "some-entity", EntityType: "some-type"}
+
+	tests := []struct {
+		name             string
+		setupServiceMock func(mockService *interfaces.MockIdentitiesService)
+		triggerFunc      func(client *Client) (any, error)
+		expected         any
+	}{{
+		name: "GetIdentities",
+		setupServiceMock: func(mockService *interfaces.MockIdentitiesService) {
+			mockService.EXPECT().
+				ListIdentities(gomock.Any(), &resources.GetIdentitiesParams{}).
+				Return(&resources.PaginatedResponse[resources.Identity]{
+					Meta: resources.ResponseMeta{Size: 1},
+					Data: []resources.Identity{identity},
+				}, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return client.GetIdentities(context.Background(), nil)
+		},
+		expected: &resources.GetIdentitiesResponse{
+			Meta:   resources.ResponseMeta{Size: 1},
+			Data:   []resources.Identity{identity},
+			Status: http.StatusOK,
+		},
+	}, {
+		name: "PostIdentities",
+		setupServiceMock: func(mockService *interfaces.MockIdentitiesService) {
+			mockService.EXPECT().
+				CreateIdentity(gomock.Any(), &identity).
+				Return(&identity, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return client.PostIdentities(context.Background(), &identity)
+		},
+		expected: &identity,
+	}, {
+		name: "DeleteIdentitiesItem",
+		setupServiceMock: func(mockService *interfaces.MockIdentitiesService) {
+			mockService.EXPECT().
+				DeleteIdentity(gomock.Any(), identityId).
+				Return(true, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return nil, client.DeleteIdentitiesItem(context.Background(), identityId)
+		},
+	}, {
+		name: "GetIdentitiesItem",
+		setupServiceMock: func(mockService *interfaces.MockIdentitiesService) {
+			mockService.EXPECT().
+				GetIdentity(gomock.Any(), identityId).
+				Return(&identity, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return client.GetIdentitiesItem(context.Background(), identityId)
+		},
+		expected: &identity,
+	}, {
+		name: "PutIdentitiesItem",
+		setupServiceMock: func(mockService *interfaces.MockIdentitiesService) {
+			mockService.EXPECT().
+				UpdateIdentity(gomock.Any(), &identity).
+				Return(&identity, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return client.PutIdentitiesItem(context.Background(), identityId, &identity)
+		},
+		expected: &identity,
+	}, {
+		name: "GetIdentitiesItemEntitlements",
+		setupServiceMock: func(mockService *interfaces.MockIdentitiesService) {
+			mockService.EXPECT().
+				GetIdentityEntitlements(gomock.Any(), identityId, &resources.GetIdentitiesItemEntitlementsParams{}).
+				Return(&resources.PaginatedResponse[resources.EntityEntitlement]{
+					Data: []resources.EntityEntitlement{entitlement},
+				}, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return client.GetIdentitiesItemEntitlementsPager(identityId, nil).All(context.Background())
+		},
+		expected: []resources.EntityEntitlement{entitlement},
+	}, {
+		name: "PatchIdentitiesItemEntitlements",
+		setupServiceMock: func(mockService *interfaces.MockIdentitiesService) {
+			mockService.EXPECT().
+				PatchIdentityEntitlements(gomock.Any(), identityId, []resources.IdentityEntitlementsPatchItem{{
+					Entitlement: entitlement,
+					Op:          "add",
+				}}).
+				Return(true, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return nil, client.PatchIdentitiesItemEntitlements(context.Background(), identityId, &resources.IdentityEntitlementsPatchRequestBody{
+				Patches: []resources.IdentityEntitlementsPatchItem{{
+					Entitlement: entitlement,
+					Op:          "add",
+				}},
+			})
+		},
+	}, {
+		name: "GetIdentitiesItemEffectiveEntitlements",
+		setupServiceMock: func(mockService *interfaces.MockIdentitiesService) {
+			mockService.EXPECT().
+				GetIdentityEntitlements(gomock.Any(), identityId, &resources.GetIdentitiesItemEntitlementsParams{}).
+				Return(&resources.PaginatedResponse[resources.EntityEntitlement]{
+					Data: []resources.EntityEntitlement{entitlement},
+				}, nil)
+			mockService.EXPECT().
+				GetIdentityGroups(gomock.Any(), identityId, &resources.GetIdentitiesItemGroupsParams{}).
+				Return(&resources.PaginatedResponse[resources.Group]{}, nil)
+			mockService.EXPECT().
+				GetIdentityRoles(gomock.Any(), identityId, &resources.GetIdentitiesItemRolesParams{}).
+				Return(&resources.PaginatedResponse[resources.Role]{}, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return client.GetIdentitiesItemEffectiveEntitlements(context.Background(), identityId)
+		},
+		expected: &resources.GetIdentityEffectiveEntitlementsResponse{
+			Data: []resources.EffectiveEntitlement{{
+				Entitlement: entitlement,
+				Sources:     []resources.AccessPath{{Via: []resources.AccessPathStep{}}},
+			}},
+			Status: http.StatusOK,
+		},
+	}, {
+		name: "GetIdentitiesItemGroups",
+		setupServiceMock: func(mockService *interfaces.MockIdentitiesService) {
+			mockService.EXPECT().
+				GetIdentityGroups(gomock.Any(), identityId, &resources.GetIdentitiesItemGroupsParams{}).
+				Return(&resources.PaginatedResponse[resources.Group]{
+					Data: []resources.Group{group},
+				}, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return client.GetIdentitiesItemGroupsPager(identityId, nil).All(context.Background())
+		},
+		expected: []resources.Group{group},
+	}, {
+		name: "PatchIdentitiesItemGroups",
+		setupServiceMock: func(mockService *interfaces.MockIdentitiesService) {
+			mockService.EXPECT().
+				PatchIdentityGroups(gomock.Any(), identityId, []resources.IdentityGroupsPatchItem{{
+					Group: groupId,
+					Op:    "remove",
+				}}).
+				Return(true, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return nil, client.PatchIdentitiesItemGroups(context.Background(), identityId, &resources.IdentityGroupsPatchRequestBody{
+				Patches: []resources.IdentityGroupsPatchItem{{
+					Group: groupId,
+					Op:    "remove",
+				}},
+			})
+		},
+	}, {
+		name: "GetIdentitiesItemRoles",
+		setupServiceMock: func(mockService *interfaces.MockIdentitiesService) {
+			mockService.EXPECT().
+				GetIdentityRoles(gomock.Any(), identityId, &resources.GetIdentitiesItemRolesParams{}).
+				Return(&resources.PaginatedResponse[resources.Role]{
+					Data: []resources.Role{role},
+				}, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return client.GetIdentitiesItemRolesPager(identityId, nil).All(context.Background())
+		},
+		expected: []resources.Role{role},
+	}, {
+		name: "PatchIdentitiesItemRoles",
+		setupServiceMock: func(mockService *interfaces.MockIdentitiesService) {
+			mockService.EXPECT().
+				PatchIdentityRoles(gomock.Any(), identityId, []resources.IdentityRolesPatchItem{{
+					Role: roleId,
+					Op:   "add",
+				}}).
+				Return(true, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return nil, client.PatchIdentitiesItemRoles(context.Background(), identityId, &resources.IdentityRolesPatchRequestBody{
+				Patches: []resources.IdentityRolesPatchItem{{
+					Role: roleId,
+					Op:   "add",
+				}},
+			})
+		},
+	}}
+
+	for _, t := range tests {
+		tt := t
+		c.Run(tt.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+			defer ctrl.Finish()
+
+			mockIdentitiesService := interfaces.NewMockIdentitiesService(ctrl)
+			tt.setupServiceMock(mockIdentitiesService)
+
+			client := newTestClient(c, v1.ReBACAdminBackendParams{Identities: mockIdentitiesService})
+
+			result, err := tt.triggerFunc(client)
+			c.Assert(err, qt.IsNil)
+			if tt.expected != nil {
+				c.Assert(result, qt.DeepEquals, tt.expected)
+			}
+		})
+	}
+}
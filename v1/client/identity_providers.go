@@ -0,0 +1,114 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// GetIdentityProviders returns a list of registered authentication providers configurations.
+// (GET /authentication)
+func (c *Client) GetIdentityProviders(ctx context.Context, params *resources.GetIdentityProvidersParams) (*resources.GetIdentityProvidersResponse, error) {
+	response := &resources.GetIdentityProvidersResponse{}
+	if _, err := c.do(ctx, getIdentityProvidersRequest(params), response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetIdentityProvidersPager returns a pager over all the pages of the GetIdentityProviders results.
+func (c *Client) GetIdentityProvidersPager(params *resources.GetIdentityProvidersParams) *Pager[resources.IdentityProvider] {
+	return newPager[resources.IdentityProvider](c, getIdentityProvidersRequest(params))
+}
+
+// getIdentityProvidersRequest returns the request of the GetIdentityProviders operation.
+func getIdentityProvidersRequest(params *resources.GetIdentityProvidersParams) *request {
+	r := newRequest(http.MethodGet, "authentication")
+	if params != nil {
+		setQuery(r, "size", params.Size)
+		setQuery(r, "page", params.Page)
+		setQuery(r, "nextToken", params.NextToken)
+		setHeader(r, nextPageTokenHeader, params.NextPageToken)
+	}
+	return r
+}
+
+// PostIdentityProviders registers a new authentication provider configuration.
+// (POST /authentication)
+func (c *Client) PostIdentityProviders(ctx context.Context, identityProvider *resources.IdentityProvider) (*resources.IdentityProvider, error) {
+	response := &resources.IdentityProvider{}
+	if _, err := c.do(ctx, newRequest(http.MethodPost, "authentication").withBody(identityProvider), response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetAvailableIdentityProviders returns the list of supported identity providers.
+// (GET /authentication/providers)
+func (c *Client) GetAvailableIdentityProviders(ctx context.Context, params *resources.GetAvailableIdentityProvidersParams) (*resources.GetAvailableIdentityProvidersResponse, error) {
+	response := &resources.GetAvailableIdentityProvidersResponse{}
+	if _, err := c.do(ctx, getAvailableIdentityProvidersRequest(params), response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetAvailableIdentityProvidersPager returns a pager over all the pages of the GetAvailableIdentityProviders results.
+func (c *Client) GetAvailableIdentityProvidersPager(params *resources.GetAvailableIdentityProvidersParams) *Pager[resources.AvailableIdentityProvider] {
+	return newPager[resources.AvailableIdentityProvider](c, getAvailableIdentityProvidersRequest(params))
+}
+
+// getAvailableIdentityProvidersRequest returns the request of the GetAvailableIdentityProviders operation.
+func getAvailableIdentityProvidersRequest(params *resources.GetAvailableIdentityProvidersParams) *request {
+	r := newRequest(http.MethodGet, "authentication", "providers")
+	if params != nil {
+		setQuery(r, "size", params.Size)
+		setQuery(r, "page", params.Page)
+		setQuery(r, "nextToken", params.NextToken)
+		setHeader(r, nextPageTokenHeader, params.NextPageToken)
+	}
+	return r
+}
+
+// DeleteIdentityProvidersItem removes an authentication provider configuration identified by `id`.
+// (DELETE /authentication/{id})
+func (c *Client) DeleteIdentityProvidersItem(ctx context.Context, id string) error {
+	_, err := c.do(ctx, newRequest(http.MethodDelete, "authentication", id), nil)
+	return err
+}
+
+// GetIdentityProvidersItem returns the authentication provider configuration identified by `id`.
+// (GET /authentication/{id})
+func (c *Client) GetIdentityProvidersItem(ctx context.Context, id string) (*resources.IdentityProvider, error) {
+	response := &resources.IdentityProvider{}
+	if _, err := c.do(ctx, newRequest(http.MethodGet, "authentication", id), response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// PutIdentityProvidersItem updates the authentication provider configuration identified by `id`.
+// (PUT /authentication/{id})
+func (c *Client) PutIdentityProvidersItem(ctx context.Context, id string, identityProvider *resources.IdentityProvider) (*resources.IdentityProvider, error) {
+	response := &resources.IdentityProvider{}
+	if _, err := c.do(ctx, newRequest(http.MethodPut, "authentication", id).withBody(identityProvider), response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
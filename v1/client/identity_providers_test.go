@@ -0,0 +1,138 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/mock/gomock"
+
+	v1 "github.com/canonical/rebac-admin-ui-handlers/v1"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+func TestClient_IdentityProviders(t *testing.T) {
+	c := qt.New(t)
+
+	providerId := "some-provider"
+	provider := resources.IdentityProvider{Id: &providerId}
+	providerName := "some-provider-name"
+	availableProvider := resources.AvailableIdentityProvider{Id: "some-available-provider", Name: &providerName}
+
+	tests := []struct {
+		name             string
+		setupServiceMock func(mockService *interfaces.MockIdentityProvidersService)
+		triggerFunc      func(client *Client) (any, error)
+		expected         any
+	}{{
+		name: "GetIdentityProviders",
+		setupServiceMock: func(mockService *interfaces.MockIdentityProvidersService) {
+			mockService.EXPECT().
+				ListIdentityProviders(gomock.Any(), &resources.GetIdentityProvidersParams{}).
+				Return(&resources.PaginatedResponse[resources.IdentityProvider]{
+					Meta: resources.ResponseMeta{Size: 1},
+					Data: []resources.IdentityProvider{provider},
+				}, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return client.GetIdentityProviders(context.Background(), nil)
+		},
+		expected: &resources.GetIdentityProvidersResponse{
+			Meta:   resources.ResponseMeta{Size: 1},
+			Data:   []resources.IdentityProvider{provider},
+			Status: http.StatusOK,
+		},
+	}, {
+		name: "PostIdentityProviders",
+		setupServiceMock: func(mockService *interfaces.MockIdentityProvidersService) {
+			mockService.EXPECT().
+				RegisterConfiguration(gomock.Any(), &provider).
+				Return(&provider, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return client.PostIdentityProviders(context.Background(), &provider)
+		},
+		expected: &provider,
+	}, {
+		name: "GetAvailableIdentityProviders",
+		setupServiceMock: func(mockService *interfaces.MockIdentityProvidersService) {
+			mockService.EXPECT().
+				ListAvailableIdentityProviders(gomock.Any(), &resources.GetAvailableIdentityProvidersParams{}).
+				Return(&resources.PaginatedResponse[resources.AvailableIdentityProvider]{
+					Data: []resources.AvailableIdentityProvider{availableProvider},
+				}, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return client.GetAvailableIdentityProvidersPager(nil).All(context.Background())
+		},
+		expected: []resources.AvailableIdentityProvider{availableProvider},
+	}, {
+		name: "DeleteIdentityProvidersItem",
+		setupServiceMock: func(mockService *interfaces.MockIdentityProvidersService) {
+			mockService.EXPECT().
+				DeleteConfiguration(gomock.Any(), providerId).
+				Return(true, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return nil, client.DeleteIdentityProvidersItem(context.Background(), providerId)
+		},
+	}, {
+		name: "GetIdentityProvidersItem",
+		setupServiceMock: func(mockService *interfaces.MockIdentityProvidersService) {
+			mockService.EXPECT().
+				GetConfiguration(gomock.Any(), providerId).
+				Return(&provider, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return client.GetIdentityProvidersItem(context.Background(), providerId)
+		},
+		expected: &provider,
+	}, {
+		name: "PutIdentityProvidersItem",
+		setupServiceMock: func(mockService *interfaces.MockIdentityProvidersService) {
+			mockService.EXPECT().
+				UpdateConfiguration(gomock.Any(), &provider).
+				Return(&provider, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return client.PutIdentityProvidersItem(context.Background(), providerId, &provider)
+		},
+		expected: &provider,
+	}}
+
+	for _, t := range tests {
+		tt := t
+		c.Run(tt.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+			defer ctrl.Finish()
+
+			mockIdentityProvidersService := interfaces.NewMockIdentityProvidersService(ctrl)
+			tt.setupServiceMock(mockIdentityProvidersService)
+
+			client := newTestClient(c, v1.ReBACAdminBackendParams{IdentityProviders: mockIdentityProvidersService})
+
+			result, err := tt.triggerFunc(client)
+			c.Assert(err, qt.IsNil)
+			if tt.expected != nil {
+				c.Assert(result, qt.DeepEquals, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// page represents the common fields of paginated list responses.
+type page[T any] struct {
+	Links resources.ResponseLinks `json:"_links"`
+	Data  []T                     `json:"data"`
+}
+
+// Pager iterates over the pages of a paginated list operation. The next page
+// is retrieved by following the `_links.next.href` field of the response, or,
+// if missing, by passing the `Next-Page-Token` response header (if any) back
+// to the server.
+//
+// A Pager is not safe for concurrent use.
+type Pager[T any] struct {
+	client *Client
+	next   *request
+}
+
+// newPager returns a new Pager instance that starts with the given request.
+func newPager[T any](client *Client, first *request) *Pager[T] {
+	return &Pager[T]{
+		client: client,
+		next:   first,
+	}
+}
+
+// More reports whether there are more pages to retrieve.
+func (p *Pager[T]) More() bool {
+	return p.next != nil
+}
+
+// NextPage retrieves the next page of results. It returns an error if there
+// are no more pages to retrieve.
+func (p *Pager[T]) NextPage(ctx context.Context) ([]T, error) {
+	if p.next == nil {
+		return nil, errors.New("no more pages")
+	}
+
+	response := page[T]{}
+	header, err := p.client.do(ctx, p.next, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	next, err := p.nextRequest(response.Links.Next.Href, header.Get(nextPageTokenHeader))
+	if err != nil {
+		return nil, err
+	}
+	p.next = next
+	return response.Data, nil
+}
+
+// All retrieves all the remaining pages of results, and returns their
+// concatenated entries.
+func (p *Pager[T]) All(ctx context.Context) ([]T, error) {
+	result := []T{}
+	for p.More() {
+		data, err := p.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, data...)
+	}
+	return result, nil
+}
+
+// nextRequest returns the request of the page after the current one, given the
+// next page link and continuation token of the current page's response. It
+// returns nil if there is no next page.
+func (p *Pager[T]) nextRequest(href, token string) (*request, error) {
+	switch {
+	case href != "":
+		// The link is an absolute path, so it's resolved against the host of the
+		// base URL.
+		ref, err := url.Parse(href)
+		if err != nil {
+			return nil, fmt.Errorf("invalid next page link: %w", err)
+		}
+		return &request{
+			method: http.MethodGet,
+			ref:    ref,
+			header: http.Header{},
+		}, nil
+	case token != "":
+		// The server rejects page numbers along with continuation tokens, as well
+		// as mismatched tokens, so the query parameters of the previous request
+		// are dropped.
+		ref := *p.next.ref
+		query := ref.Query()
+		query.Del("page")
+		query.Del("nextToken")
+		ref.RawQuery = query.Encode()
+
+		header := p.next.header.Clone()
+		header.Set(nextPageTokenHeader, token)
+		return &request{
+			method: p.next.method,
+			ref:    &ref,
+			header: header,
+		}, nil
+	}
+	return nil, nil
+}
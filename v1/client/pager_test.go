@@ -0,0 +1,145 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	v1 "github.com/canonical/rebac-admin-ui-handlers/v1"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/openfga"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+func TestPager_NextLink(t *testing.T) {
+	c := qt.New(t)
+
+	// The OpenFGA-backed groups service returns continuation tokens, which are
+	// turned into `_links.next.href` by the handlers.
+	groups := openfga.NewGroupsService(openfga.Params{Store: openfga.NewMemoryStore()})
+	client := newTestClient(c, v1.ReBACAdminBackendParams{Groups: groups})
+
+	for i := 0; i < 5; i++ {
+		_, err := client.PostGroups(context.Background(), &resources.Group{Name: fmt.Sprintf("group-%d", i)})
+		c.Assert(err, qt.IsNil)
+	}
+
+	size := 2
+	pager := client.GetGroupsPager(&resources.GetGroupsParams{Size: &size})
+
+	var pages [][]string
+	for pager.More() {
+		page, err := pager.NextPage(context.Background())
+		c.Assert(err, qt.IsNil)
+
+		names := []string{}
+		for _, group := range page {
+			names = append(names, group.Name)
+		}
+		pages = append(pages, names)
+	}
+	c.Assert(pages, qt.DeepEquals, [][]string{
+		{"group-0", "group-1"},
+		{"group-2", "group-3"},
+		{"group-4"},
+	})
+
+	_, err := pager.NextPage(context.Background())
+	c.Assert(err, qt.ErrorMatches, "no more pages")
+
+	all, err := client.GetGroupsPager(&resources.GetGroupsParams{Size: &size}).All(context.Background())
+	c.Assert(err, qt.IsNil)
+	c.Assert(all, qt.HasLen, 5)
+}
+
+func TestPager_NextPageTokenHeader(t *testing.T) {
+	c := qt.New(t)
+
+	// A server that passes continuation tokens via the `Next-Page-Token`
+	// header, instead of the `_links.next.href` field.
+	pages := map[string]struct {
+		next string
+		body string
+	}{
+		"":   {next: "p2", body: `{"data":[{"name":"group-0"}]}`},
+		"p2": {next: "p3", body: `{"data":[{"name":"group-1"}]}`},
+		"p3": {body: `{"data":[{"name":"group-2"}]}`},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.URL.Path, qt.Equals, "/v1/groups")
+		c.Check(r.URL.Query().Get("filter"), qt.Equals, "some-filter")
+
+		// The page number of the first request must not be sent along with
+		// the continuation tokens.
+		token := r.Header.Get("Next-Page-Token")
+		if token != "" && r.URL.Query().Has("page") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		page, ok := pages[token]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if page.next != "" {
+			w.Header().Set("Next-Page-Token", page.next)
+		}
+		fmt.Fprint(w, page.body)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientParams{BaseURL: server.URL})
+	c.Assert(err, qt.IsNil)
+
+	filter := "some-filter"
+	page := 0
+	all, err := client.GetGroupsPager(&resources.GetGroupsParams{Filter: &filter, Page: &page}).All(context.Background())
+	c.Assert(err, qt.IsNil)
+	c.Assert(all, qt.DeepEquals, []resources.Group{
+		{Name: "group-0"},
+		{Name: "group-1"},
+		{Name: "group-2"},
+	})
+}
+
+func TestPager_Error(t *testing.T) {
+	c := qt.New(t)
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests > 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"status":500,"message":"Internal Server Error: some-error"}`)
+			return
+		}
+		fmt.Fprint(w, `{"_links":{"next":{"href":"/v1/roles?nextToken=p2"}},"data":[{"name":"role-0"}]}`)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientParams{BaseURL: server.URL})
+	c.Assert(err, qt.IsNil)
+
+	_, err = client.GetRolesPager(nil).All(context.Background())
+	c.Assert(err, qt.ErrorMatches, "Internal Server Error: some-error")
+	c.Assert(requests, qt.Equals, 2)
+}
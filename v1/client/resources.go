@@ -0,0 +1,79 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// GetResources returns the list of known resources.
+// (GET /resources)
+func (c *Client) GetResources(ctx context.Context, params *resources.GetResourcesParams) (*resources.GetResourcesResponse, error) {
+	response := &resources.GetResourcesResponse{}
+	if _, err := c.do(ctx, getResourcesRequest(params), response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetResourcesPager returns a pager over all the pages of the GetResources results.
+func (c *Client) GetResourcesPager(params *resources.GetResourcesParams) *Pager[resources.Resource] {
+	return newPager[resources.Resource](c, getResourcesRequest(params))
+}
+
+// getResourcesRequest returns the request of the GetResources operation.
+func getResourcesRequest(params *resources.GetResourcesParams) *request {
+	r := newRequest(http.MethodGet, "resources")
+	if params != nil {
+		setQuery(r, "size", params.Size)
+		setQuery(r, "page", params.Page)
+		setQuery(r, "nextToken", params.NextToken)
+		setQuery(r, "entityType", params.EntityType)
+		setQuery(r, "entityName", params.EntityName)
+		setHeader(r, nextPageTokenHeader, params.NextPageToken)
+	}
+	return r
+}
+
+// GetResourcesItemAccess returns the list of entitlements held on a resource, by identities, groups and roles.
+// (GET /resources/{type}/{id}/access)
+func (c *Client) GetResourcesItemAccess(ctx context.Context, entityType string, entityId string, params *resources.GetResourcesItemAccessParams) (*resources.GetResourceAccessResponse, error) {
+	response := &resources.GetResourceAccessResponse{}
+	if _, err := c.do(ctx, getResourcesItemAccessRequest(entityType, entityId, params), response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetResourcesItemAccessPager returns a pager over all the pages of the GetResourcesItemAccess results.
+func (c *Client) GetResourcesItemAccessPager(entityType string, entityId string, params *resources.GetResourcesItemAccessParams) *Pager[resources.ResourceAccess] {
+	return newPager[resources.ResourceAccess](c, getResourcesItemAccessRequest(entityType, entityId, params))
+}
+
+// getResourcesItemAccessRequest returns the request of the GetResourcesItemAccess operation.
+func getResourcesItemAccessRequest(entityType string, entityId string, params *resources.GetResourcesItemAccessParams) *request {
+	r := newRequest(http.MethodGet, "resources", entityType, entityId, "access")
+	if params != nil {
+		setQuery(r, "size", params.Size)
+		setQuery(r, "page", params.Page)
+		setQuery(r, "nextToken", params.NextToken)
+		setHeader(r, nextPageTokenHeader, params.NextPageToken)
+	}
+	return r
+}
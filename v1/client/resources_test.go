@@ -0,0 +1,90 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/mock/gomock"
+
+	v1 "github.com/canonical/rebac-admin-ui-handlers/v1"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+func TestClient_Resources(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	entityType := "some-type"
+	resource := resources.Resource{
+		Entity: resources.Entity{Id: "some-id", Name: "some-name", Type: entityType},
+	}
+
+	resourcesService := interfaces.NewMockResourcesService(ctrl)
+	resourcesService.EXPECT().
+		ListResources(gomock.Any(), &resources.GetResourcesParams{EntityType: &entityType}).
+		Return(&resources.PaginatedResponse[resources.Resource]{
+			Data: []resources.Resource{resource},
+		}, nil)
+
+	client := newTestClient(c, v1.ReBACAdminBackendParams{Resources: resourcesService})
+
+	result, err := client.GetResourcesPager(&resources.GetResourcesParams{EntityType: &entityType}).All(context.Background())
+	c.Assert(err, qt.IsNil)
+	c.Assert(result, qt.DeepEquals, []resources.Resource{resource})
+}
+
+// resourcesServiceWithAccess is a resources service that lists who has access
+// to resources.
+type resourcesServiceWithAccess struct {
+	*interfaces.MockResourcesService
+	*interfaces.MockResourceAccessLister
+}
+
+func TestClient_ResourcesItemAccess(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	entries := []resources.ResourceAccess{{
+		ReceiverType: resources.ResourceAccessReceiverTypeIdentity,
+		ReceiverId:   "some-identity",
+		Entitlement:  "can_view",
+		Via:          []resources.AccessPathStep{{Type: resources.AccessPathStepTypeGroup, Id: "some-group"}},
+	}}
+
+	accessLister := interfaces.NewMockResourceAccessLister(ctrl)
+	accessLister.EXPECT().
+		ListResourceAccess(gomock.Any(), "some-type", "some-id", &resources.GetResourcesItemAccessParams{}).
+		Return(&resources.PaginatedResponse[resources.ResourceAccess]{
+			Data: entries,
+		}, nil)
+
+	client := newTestClient(c, v1.ReBACAdminBackendParams{
+		Resources: resourcesServiceWithAccess{
+			MockResourcesService:     interfaces.NewMockResourcesService(ctrl),
+			MockResourceAccessLister: accessLister,
+		},
+	})
+
+	result, err := client.GetResourcesItemAccessPager("some-type", "some-id", nil).All(context.Background())
+	c.Assert(err, qt.IsNil)
+	c.Assert(result, qt.DeepEquals, entries)
+}
@@ -0,0 +1,122 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// GetRoles returns the list of known roles.
+// (GET /roles)
+func (c *Client) GetRoles(ctx context.Context, params *resources.GetRolesParams) (*resources.GetRolesResponse, error) {
+	response := &resources.GetRolesResponse{}
+	if _, err := c.do(ctx, getRolesRequest(params), response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetRolesPager returns a pager over all the pages of the GetRoles results.
+func (c *Client) GetRolesPager(params *resources.GetRolesParams) *Pager[resources.Role] {
+	return newPager[resources.Role](c, getRolesRequest(params))
+}
+
+// getRolesRequest returns the request of the GetRoles operation.
+func getRolesRequest(params *resources.GetRolesParams) *request {
+	r := newRequest(http.MethodGet, "roles")
+	if params != nil {
+		setQuery(r, "size", params.Size)
+		setQuery(r, "page", params.Page)
+		setQuery(r, "nextToken", params.NextToken)
+		setQuery(r, "filter", params.Filter)
+		setHeader(r, nextPageTokenHeader, params.NextPageToken)
+	}
+	return r
+}
+
+// PostRoles adds a new role.
+// (POST /roles)
+func (c *Client) PostRoles(ctx context.Context, role *resources.Role) (*resources.Role, error) {
+	response := &resources.Role{}
+	if _, err := c.do(ctx, newRequest(http.MethodPost, "roles").withBody(role), response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// DeleteRolesItem deletes the specified role.
+// (DELETE /roles/{id})
+func (c *Client) DeleteRolesItem(ctx context.Context, id string) error {
+	_, err := c.do(ctx, newRequest(http.MethodDelete, "roles", id), nil)
+	return err
+}
+
+// GetRolesItem returns the role identified by the provided ID.
+// (GET /roles/{id})
+func (c *Client) GetRolesItem(ctx context.Context, id string) (*resources.Role, error) {
+	response := &resources.Role{}
+	if _, err := c.do(ctx, newRequest(http.MethodGet, "roles", id), response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// PutRolesItem updates the role identified by the provided ID.
+// (PUT /roles/{id})
+func (c *Client) PutRolesItem(ctx context.Context, id string, role *resources.Role) (*resources.Role, error) {
+	response := &resources.Role{}
+	if _, err := c.do(ctx, newRequest(http.MethodPut, "roles", id).withBody(role), response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetRolesItemEntitlements returns the list of entitlements for a role identified by the provided ID.
+// (GET /roles/{id}/entitlements)
+func (c *Client) GetRolesItemEntitlements(ctx context.Context, id string, params *resources.GetRolesItemEntitlementsParams) (*resources.GetRoleEntitlementsResponse, error) {
+	response := &resources.GetRoleEntitlementsResponse{}
+	if _, err := c.do(ctx, getRolesItemEntitlementsRequest(id, params), response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetRolesItemEntitlementsPager returns a pager over all the pages of the GetRolesItemEntitlements results.
+func (c *Client) GetRolesItemEntitlementsPager(id string, params *resources.GetRolesItemEntitlementsParams) *Pager[resources.EntityEntitlement] {
+	return newPager[resources.EntityEntitlement](c, getRolesItemEntitlementsRequest(id, params))
+}
+
+// getRolesItemEntitlementsRequest returns the request of the GetRolesItemEntitlements operation.
+func getRolesItemEntitlementsRequest(id string, params *resources.GetRolesItemEntitlementsParams) *request {
+	r := newRequest(http.MethodGet, "roles", id, "entitlements")
+	if params != nil {
+		setQuery(r, "size", params.Size)
+		setQuery(r, "page", params.Page)
+		setQuery(r, "nextToken", params.NextToken)
+		setHeader(r, nextPageTokenHeader, params.NextPageToken)
+	}
+	return r
+}
+
+// PatchRolesItemEntitlements adds or removes entitlements to/from a role.
+// (PATCH /roles/{id}/entitlements)
+func (c *Client) PatchRolesItemEntitlements(ctx context.Context, id string, patch *resources.RoleEntitlementsPatchRequestBody) error {
+	_, err := c.do(ctx, newRequest(http.MethodPatch, "roles", id, "entitlements").withBody(patch), nil)
+	return err
+}
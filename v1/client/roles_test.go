@@ -0,0 +1,155 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/mock/gomock"
+
+	v1 "github.com/canonical/rebac-admin-ui-handlers/v1"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+func TestClient_Roles(t *testing.T) {
+	c := qt.New(t)
+
+	roleId := "some-role"
+	role := resources.Role{Id: &roleId, Name: "some-role-name"}
+	entitlement := resources.EntityEntitlement{Entitlement: "can_view", EntityId: "some-entity", EntityType: "some-type"}
+
+	tests := []struct {
+		name             string
+		setupServiceMock func(mockService *interfaces.MockRolesService)
+		triggerFunc      func(client *Client) (any, error)
+		expected         any
+	}{{
+		name: "GetRoles",
+		setupServiceMock: func(mockService *interfaces.MockRolesService) {
+			mockService.EXPECT().
+				ListRoles(gomock.Any(), &resources.GetRolesParams{}).
+				Return(&resources.PaginatedResponse[resources.Role]{
+					Meta: resources.ResponseMeta{Size: 1},
+					Data: []resources.Role{role},
+				}, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return client.GetRoles(context.Background(), nil)
+		},
+		expected: &resources.GetRolesResponse{
+			Meta:   resources.ResponseMeta{Size: 1},
+			Data:   []resources.Role{role},
+			Status: http.StatusOK,
+		},
+	}, {
+		name: "PostRoles",
+		setupServiceMock: func(mockService *interfaces.MockRolesService) {
+			mockService.EXPECT().
+				CreateRole(gomock.Any(), &resources.Role{Name: "some-role-name"}).
+				Return(&role, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return client.PostRoles(context.Background(), &resources.Role{Name: "some-role-name"})
+		},
+		expected: &role,
+	}, {
+		name: "DeleteRolesItem",
+		setupServiceMock: func(mockService *interfaces.MockRolesService) {
+			mockService.EXPECT().
+				DeleteRole(gomock.Any(), roleId).
+				Return(true, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return nil, client.DeleteRolesItem(context.Background(), roleId)
+		},
+	}, {
+		name: "GetRolesItem",
+		setupServiceMock: func(mockService *interfaces.MockRolesService) {
+			mockService.EXPECT().
+				GetRole(gomock.Any(), roleId).
+				Return(&role, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return client.GetRolesItem(context.Background(), roleId)
+		},
+		expected: &role,
+	}, {
+		name: "PutRolesItem",
+		setupServiceMock: func(mockService *interfaces.MockRolesService) {
+			mockService.EXPECT().
+				UpdateRole(gomock.Any(), &role).
+				Return(&role, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return client.PutRolesItem(context.Background(), roleId, &role)
+		},
+		expected: &role,
+	}, {
+		name: "GetRolesItemEntitlements",
+		setupServiceMock: func(mockService *interfaces.MockRolesService) {
+			mockService.EXPECT().
+				GetRoleEntitlements(gomock.Any(), roleId, &resources.GetRolesItemEntitlementsParams{}).
+				Return(&resources.PaginatedResponse[resources.EntityEntitlement]{
+					Data: []resources.EntityEntitlement{entitlement},
+				}, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return client.GetRolesItemEntitlementsPager(roleId, nil).All(context.Background())
+		},
+		expected: []resources.EntityEntitlement{entitlement},
+	}, {
+		name: "PatchRolesItemEntitlements",
+		setupServiceMock: func(mockService *interfaces.MockRolesService) {
+			mockService.EXPECT().
+				PatchRoleEntitlements(gomock.Any(), roleId, []resources.RoleEntitlementsPatchItem{{
+					Entitlement: entitlement,
+					Op:          "add",
+				}}).
+				Return(true, nil)
+		},
+		triggerFunc: func(client *Client) (any, error) {
+			return nil, client.PatchRolesItemEntitlements(context.Background(), roleId, &resources.RoleEntitlementsPatchRequestBody{
+				Patches: []resources.RoleEntitlementsPatchItem{{
+					Entitlement: entitlement,
+					Op:          "add",
+				}},
+			})
+		},
+	}}
+
+	for _, t := range tests {
+		tt := t
+		c.Run(tt.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+			defer ctrl.Finish()
+
+			mockRolesService := interfaces.NewMockRolesService(ctrl)
+			tt.setupServiceMock(mockRolesService)
+
+			client := newTestClient(c, v1.ReBACAdminBackendParams{Roles: mockRolesService})
+
+			result, err := tt.triggerFunc(client)
+			c.Assert(err, qt.IsNil)
+			if tt.expected != nil {
+				c.Assert(result, qt.DeepEquals, tt.expected)
+			}
+		})
+	}
+}
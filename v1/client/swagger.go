@@ -0,0 +1,31 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// SwaggerJson returns the OpenAPI spec as a JSON file.
+// (GET /swagger.json)
+func (c *Client) SwaggerJson(ctx context.Context) ([]byte, error) {
+	var response []byte
+	if _, err := c.do(ctx, newRequest(http.MethodGet, "swagger.json"), &response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
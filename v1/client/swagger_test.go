@@ -0,0 +1,39 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	v1 "github.com/canonical/rebac-admin-ui-handlers/v1"
+)
+
+func TestClient_SwaggerJson(t *testing.T) {
+	c := qt.New(t)
+
+	client := newTestClient(c, v1.ReBACAdminBackendParams{})
+
+	spec, err := client.SwaggerJson(context.Background())
+	c.Assert(err, qt.IsNil)
+
+	parsed := map[string]any{}
+	c.Assert(json.Unmarshal(spec, &parsed), qt.IsNil)
+	c.Assert(parsed["openapi"], qt.Not(qt.IsNil))
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package conformance
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// alwaysAvailable are the endpoints that are served regardless of the
+// provided backends.
+var alwaysAvailable = []string{"/capabilities", "/swagger.json"}
+
+// extensions are the endpoints that are served on top of the ones defined in
+// the spec (see `resources.ExtensionServerInterface`).
+var extensions = map[string][]string{
+	"/bulk":  {http.MethodPost},
+	"/check": {http.MethodPost},
+	"/identities/{id}/effective-entitlements": {http.MethodGet},
+	"/resources/{type}/{id}/access":           {http.MethodGet},
+}
+
+// testCapabilities checks that the API capabilities are consistent with the
+// endpoints actually served: every listed endpoint/method must be served, and
+// every other endpoint/method of the spec must respond with `501 Not
+// Implemented`.
+//
+// The endpoints are probed with a non-existent ID, and, if applicable, with a
+// malformed request body, so that no entity is created or modified.
+func (s *suite) testCapabilities(c *qt.C) {
+	swagger, err := resources.GetSwagger()
+	c.Assert(err, qt.IsNil)
+
+	spec := map[string][]string{}
+	for endpoint, item := range swagger.Paths.Map() {
+		for method := range item.Operations() {
+			spec[endpoint] = append(spec[endpoint], method)
+		}
+	}
+	for endpoint, methods := range extensions {
+		spec[endpoint] = append(spec[endpoint], methods...)
+	}
+
+	for endpoint, methods := range s.capabilities {
+		for _, method := range methods {
+			c.Check(slices.Contains(spec[endpoint], method), qt.IsTrue, qt.Commentf("capability %s %s is not in the spec", method, endpoint))
+		}
+	}
+
+	missingId := s.name("missing")
+	for endpoint, methods := range spec {
+		if slices.Contains(alwaysAvailable, endpoint) {
+			continue
+		}
+		path := strings.NewReplacer("{id}", missingId, "{type}", missingId).Replace(endpoint)
+		for _, method := range methods {
+			body := ""
+			if method == http.MethodPost || method == http.MethodPut || method == http.MethodPatch {
+				body = "malformed"
+			}
+			status := s.do(c, method, path, body)
+			if s.implements(method, endpoint) {
+				c.Check(status, qt.Not(qt.Equals), http.StatusNotImplemented, qt.Commentf("%s %s is listed in capabilities, but not implemented", method, endpoint))
+			} else {
+				c.Check(status, qt.Equals, http.StatusNotImplemented, qt.Commentf("%s %s is not listed in capabilities, but implemented", method, endpoint))
+			}
+		}
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package conformance provides a black-box test suite for ReBAC Admin backends.
+// It sends HTTP requests to the handlers, configured with the backend under
+// test, and checks that the responses are the ones the ReBAC Admin UI expects.
+//
+// Typical usage, in a test of a backend implementation:
+//
+//	func TestConformance(t *testing.T) {
+//		conformance.RunBackend(t, v1.ReBACAdminBackendParams{
+//			Groups:     myGroupsService,
+//			Identities: myIdentitiesService,
+//		})
+//	}
+//
+// Scenarios that involve endpoints not listed in the API capabilities (i.e.,
+// `GET /capabilities`) are skipped. The scenarios create (and afterwards
+// delete) entities with a random `conformance-` name prefix, so they can be
+// run against non-empty backends.
+package conformance
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	v1 "github.com/canonical/rebac-admin-ui-handlers/v1"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/client"
+)
+
+// Params contains the configuration of a conformance test run.
+type Params struct {
+	// Handler is the HTTP handler under test, as returned by
+	// `ReBACAdminBackend.Handler(BaseURL)`.
+	Handler http.Handler
+
+	// BaseURL is the base URL passed to `ReBACAdminBackend.Handler` (e.g.,
+	// `/rebac`).
+	BaseURL string
+
+	// RequestEditor, if not nil, is called on every request before it's sent.
+	// It can be used to add authentication headers.
+	RequestEditor func(req *http.Request) error
+}
+
+// Run runs the conformance scenarios against the given handler, each as a
+// subtest of the given test.
+func Run(t *testing.T, params Params) {
+	c := qt.New(t)
+
+	server := httptest.NewServer(params.Handler)
+	defer server.Close()
+
+	s := newSuite(c, server.URL, params)
+	s.run(c)
+}
+
+// RunBackend runs the conformance scenarios against the handlers of a
+// ReBACAdminBackend, configured with the given backends. If requests need to
+// be authenticated, use Run with a request editor instead.
+func RunBackend(t *testing.T, params v1.ReBACAdminBackendParams) {
+	c := qt.New(t)
+
+	backend, err := v1.NewReBACAdminBackend(params)
+	c.Assert(err, qt.IsNil)
+
+	Run(t, Params{Handler: backend.Handler("")})
+}
+
+// suite holds the state shared by the conformance scenarios.
+type suite struct {
+	params Params
+	client *client.Client
+	// apiURL is the URL of the API, including the `/v1` suffix.
+	apiURL string
+	// capabilities maps the endpoints listed in the API capabilities to their
+	// methods.
+	capabilities map[string][]string
+	// prefix is the random prefix of the entities created by the scenarios.
+	prefix string
+	count  int
+}
+
+// newSuite returns a new suite instance for the API served at the given server
+// URL. It fetches the API capabilities.
+func newSuite(c *qt.C, serverURL string, params Params) *suite {
+	baseURL := serverURL + params.BaseURL
+
+	apiClient, err := client.NewClient(client.ClientParams{
+		BaseURL:       baseURL,
+		RequestEditor: params.RequestEditor,
+	})
+	c.Assert(err, qt.IsNil)
+
+	suffix := make([]byte, 4)
+	_, err = rand.Read(suffix)
+	c.Assert(err, qt.IsNil)
+
+	s := &suite{
+		params:       params,
+		client:       apiClient,
+		apiURL:       baseURL + "/v1",
+		capabilities: map[string][]string{},
+		prefix:       "conformance-" + hex.EncodeToString(suffix),
+	}
+
+	response, err := apiClient.GetCapabilities(context.Background())
+	c.Assert(err, qt.IsNil, qt.Commentf("GET /capabilities must always succeed"))
+	for _, capability := range response.Data {
+		for _, method := range capability.Methods {
+			s.capabilities[capability.Endpoint] = append(s.capabilities[capability.Endpoint], string(method))
+		}
+	}
+	return s
+}
+
+// run runs all the conformance scenarios.
+func (s *suite) run(c *qt.C) {
+	c.Run("capabilities", s.testCapabilities)
+	c.Run("groups", s.testGroups)
+	c.Run("identities", s.testIdentities)
+	c.Run("roles", s.testRoles)
+	c.Run("identity providers", s.testIdentityProviders)
+	c.Run("group identities", s.testGroupIdentities)
+	c.Run("group roles", s.testGroupRoles)
+	c.Run("identity roles", s.testIdentityRoles)
+	c.Run("pagination", s.testPagination)
+}
+
+// implements reports whether the given endpoint and method are listed in the
+// API capabilities.
+func (s *suite) implements(method, endpoint string) bool {
+	return slices.Contains(s.capabilities[endpoint], method)
+}
+
+// require skips the current scenario, unless all the given endpoints, in the
+// form of `<METHOD> <endpoint>`, are listed in the API capabilities.
+func (s *suite) require(c *qt.C, endpoints ...string) {
+	for _, e := range endpoints {
+		var method, endpoint string
+		fmt.Sscan(e, &method, &endpoint)
+		if !s.implements(method, endpoint) {
+			c.Skipf("%s is not implemented", e)
+		}
+	}
+}
+
+// name returns a new unique name for an entity of the given kind.
+func (s *suite) name(kind string) string {
+	s.count++
+	return fmt.Sprintf("%s-%s-%d", s.prefix, kind, s.count)
+}
+
+// do sends a raw request to the given endpoint (relative to the API URL) and
+// returns the response status code.
+func (s *suite) do(c *qt.C, method, endpoint string, body string) int {
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	req, err := http.NewRequest(method, s.apiURL+endpoint, reader)
+	c.Assert(err, qt.IsNil)
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res := s.send(c, req)
+	defer res.Body.Close()
+	return res.StatusCode
+}
+
+// send calls the request editor (if any) on the given request, and sends it.
+func (s *suite) send(c *qt.C, req *http.Request) *http.Response {
+	if s.params.RequestEditor != nil {
+		c.Assert(s.params.RequestEditor(req), qt.IsNil)
+	}
+	res, err := http.DefaultClient.Do(req)
+	c.Assert(err, qt.IsNil)
+	return res
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package conformance
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	v1 "github.com/canonical/rebac-admin-ui-handlers/v1"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/openfga"
+)
+
+// newOpenFGABackendParams returns backend parameters with the OpenFGA-backed
+// reference services, on top of an in-memory store.
+func newOpenFGABackendParams() v1.ReBACAdminBackendParams {
+	params := openfga.Params{Store: openfga.NewMemoryStore()}
+	return v1.ReBACAdminBackendParams{
+		Groups:       openfga.NewGroupsService(params),
+		Identities:   openfga.NewIdentitiesService(params),
+		Roles:        openfga.NewRolesService(params),
+		Entitlements: openfga.NewEntitlementsService(params),
+		// Probing unimplemented endpoints results in server errors, which
+		// are not interesting here.
+		Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+}
+
+func TestRunBackend_OpenFGA(t *testing.T) {
+	RunBackend(t, newOpenFGABackendParams())
+}
+
+func TestRun_BaseURLAndRequestEditor(t *testing.T) {
+	backend, err := v1.NewReBACAdminBackend(newOpenFGABackendParams())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handler := backend.Handler("/rebac")
+	Run(t, Params{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer some-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			handler.ServeHTTP(w, r)
+		}),
+		BaseURL: "/rebac",
+		RequestEditor: func(req *http.Request) error {
+			req.Header.Set("Authorization", "Bearer some-token")
+			return nil
+		},
+	})
+}
@@ -0,0 +1,226 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package conformance
+
+import (
+	"context"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/client"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// entityOps contains the client operations of an entity type (e.g., groups),
+// as used by the CRUD scenario.
+type entityOps[T any] struct {
+	// endpoint is the collection endpoint (e.g., `/groups`).
+	endpoint string
+
+	// input returns a new entity, with the given name, to be created.
+	input func(name string) *T
+	// withId returns the given input entity, with its ID set to the given one.
+	withId func(entity *T, id string) *T
+	// id returns the ID of the given entity, if any.
+	id func(entity *T) *string
+
+	create func(ctx context.Context, entity *T) (*T, error)
+	get    func(ctx context.Context, id string) (*T, error)
+	update func(ctx context.Context, id string, entity *T) (*T, error)
+	delete func(ctx context.Context, id string) error
+	list   func(ctx context.Context) ([]T, error)
+}
+
+// testCRUD checks the create/read/update/delete round-trip of an entity type:
+//   - created entities can be retrieved, and are listed;
+//   - updated entities can be retrieved as returned by the update;
+//   - deleted entities are not found anymore, and are not listed;
+//   - deleting a missing entity succeeds (i.e., deletes are idempotent);
+//   - retrieving a missing entity responds with `404 Not Found`.
+func testCRUD[T any](c *qt.C, s *suite, ops entityOps[T]) {
+	s.require(c,
+		"POST "+ops.endpoint,
+		"GET "+ops.endpoint,
+		"GET "+ops.endpoint+"/{id}",
+		"PUT "+ops.endpoint+"/{id}",
+		"DELETE "+ops.endpoint+"/{id}",
+	)
+	ctx := context.Background()
+	input := ops.input(s.name("entity"))
+
+	created, err := ops.create(ctx, input)
+	c.Assert(err, qt.IsNil)
+	id := ops.id(created)
+	c.Assert(id, qt.Not(qt.IsNil), qt.Commentf("created entity has no ID"))
+	c.Assert(*id, qt.Not(qt.Equals), "", qt.Commentf("created entity has no ID"))
+	c.Cleanup(func() {
+		_ = ops.delete(ctx, *id)
+	})
+
+	retrieved, err := ops.get(ctx, *id)
+	c.Assert(err, qt.IsNil)
+	c.Check(retrieved, qt.DeepEquals, created, qt.Commentf("retrieved entity differs from the created one"))
+
+	listed, err := ops.list(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(containsId(listed, ops.id, *id), qt.IsTrue, qt.Commentf("created entity %q is not listed", *id))
+
+	updated, err := ops.update(ctx, *id, ops.withId(input, *id))
+	c.Assert(err, qt.IsNil)
+	c.Check(ops.id(updated), qt.DeepEquals, id, qt.Commentf("updated entity has a different ID"))
+
+	retrieved, err = ops.get(ctx, *id)
+	c.Assert(err, qt.IsNil)
+	c.Check(retrieved, qt.DeepEquals, updated, qt.Commentf("retrieved entity differs from the updated one"))
+
+	err = ops.delete(ctx, *id)
+	c.Assert(err, qt.IsNil)
+
+	_, err = ops.get(ctx, *id)
+	c.Check(client.IsNotFound(err), qt.IsTrue, qt.Commentf("deleted entity %q: expected not found error, got %v", *id, err))
+
+	listed, err = ops.list(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(containsId(listed, ops.id, *id), qt.IsFalse, qt.Commentf("deleted entity %q is still listed", *id))
+
+	err = ops.delete(ctx, *id)
+	c.Check(err, qt.IsNil, qt.Commentf("deleting a missing entity must succeed"))
+
+	_, err = ops.get(ctx, s.name("missing"))
+	c.Check(client.IsNotFound(err), qt.IsTrue, qt.Commentf("missing entity: expected not found error, got %v", err))
+}
+
+// containsId reports whether the given entities contain one with the given ID.
+func containsId[T any](entities []T, idFunc func(*T) *string, id string) bool {
+	for i := range entities {
+		if current := idFunc(&entities[i]); current != nil && *current == id {
+			return true
+		}
+	}
+	return false
+}
+
+// groupIdOf returns the ID of the given group.
+func groupIdOf(group *resources.Group) *string {
+	return group.Id
+}
+
+// identityIdOf returns the ID of the given identity.
+func identityIdOf(identity *resources.Identity) *string {
+	return identity.Id
+}
+
+// roleIdOf returns the ID of the given role.
+func roleIdOf(role *resources.Role) *string {
+	return role.Id
+}
+
+// identityProviderIdOf returns the ID of the given identity provider.
+func identityProviderIdOf(provider *resources.IdentityProvider) *string {
+	return provider.Id
+}
+
+func (s *suite) testGroups(c *qt.C) {
+	testCRUD(c, s, entityOps[resources.Group]{
+		endpoint: "/groups",
+		input: func(name string) *resources.Group {
+			return &resources.Group{Name: name}
+		},
+		withId: func(group *resources.Group, id string) *resources.Group {
+			result := *group
+			result.Id = &id
+			return &result
+		},
+		id:     groupIdOf,
+		create: s.client.PostGroups,
+		get:    s.client.GetGroupsItem,
+		update: s.client.PutGroupsItem,
+		delete: s.client.DeleteGroupsItem,
+		list: func(ctx context.Context) ([]resources.Group, error) {
+			return s.client.GetGroupsPager(nil).All(ctx)
+		},
+	})
+}
+
+func (s *suite) testIdentities(c *qt.C) {
+	testCRUD(c, s, entityOps[resources.Identity]{
+		endpoint: "/identities",
+		input: func(name string) *resources.Identity {
+			return &resources.Identity{
+				Email:   name + "@example.com",
+				AddedBy: "conformance",
+				Source:  "conformance",
+			}
+		},
+		withId: func(identity *resources.Identity, id string) *resources.Identity {
+			result := *identity
+			result.Id = &id
+			return &result
+		},
+		id:     identityIdOf,
+		create: s.client.PostIdentities,
+		get:    s.client.GetIdentitiesItem,
+		update: s.client.PutIdentitiesItem,
+		delete: s.client.DeleteIdentitiesItem,
+		list: func(ctx context.Context) ([]resources.Identity, error) {
+			return s.client.GetIdentitiesPager(nil).All(ctx)
+		},
+	})
+}
+
+func (s *suite) testRoles(c *qt.C) {
+	testCRUD(c, s, entityOps[resources.Role]{
+		endpoint: "/roles",
+		input: func(name string) *resources.Role {
+			return &resources.Role{Name: name}
+		},
+		withId: func(role *resources.Role, id string) *resources.Role {
+			result := *role
+			result.Id = &id
+			return &result
+		},
+		id:     roleIdOf,
+		create: s.client.PostRoles,
+		get:    s.client.GetRolesItem,
+		update: s.client.PutRolesItem,
+		delete: s.client.DeleteRolesItem,
+		list: func(ctx context.Context) ([]resources.Role, error) {
+			return s.client.GetRolesPager(nil).All(ctx)
+		},
+	})
+}
+
+func (s *suite) testIdentityProviders(c *qt.C) {
+	testCRUD(c, s, entityOps[resources.IdentityProvider]{
+		endpoint: "/authentication",
+		input: func(name string) *resources.IdentityProvider {
+			return &resources.IdentityProvider{Name: &name}
+		},
+		withId: func(provider *resources.IdentityProvider, id string) *resources.IdentityProvider {
+			result := *provider
+			result.Id = &id
+			return &result
+		},
+		id:     identityProviderIdOf,
+		create: s.client.PostIdentityProviders,
+		get:    s.client.GetIdentityProvidersItem,
+		update: s.client.PutIdentityProvidersItem,
+		delete: s.client.DeleteIdentityProvidersItem,
+		list: func(ctx context.Context) ([]resources.IdentityProvider, error) {
+			return s.client.GetIdentityProvidersPager(nil).All(ctx)
+		},
+	})
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package conformance
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+const (
+	// paginationEntities is the number of entities created by the pagination
+	// scenario.
+	paginationEntities = 5
+	// paginationSize is the page size requested by the pagination scenario.
+	paginationSize = 2
+	// maxPages is the maximum number of pages followed by the pagination
+	// scenario, to avoid looping forever on broken next page links.
+	maxPages = 1000
+)
+
+// testPagination checks that paginated lists can be traversed by following the
+// `_links.next.href` field of the responses:
+//   - pages contain at most the requested number of entries;
+//   - next page links target the same endpoint, and keep the requested page
+//     size;
+//   - no entry is listed twice, and all entries are eventually listed.
+func (s *suite) testPagination(c *qt.C) {
+	s.require(c,
+		"POST /groups",
+		"GET /groups",
+		"DELETE /groups/{id}",
+	)
+
+	created := []string{}
+	for i := 0; i < paginationEntities; i++ {
+		created = append(created, s.createGroup(c))
+	}
+
+	endpoint, err := url.Parse(s.apiURL + "/groups")
+	c.Assert(err, qt.IsNil)
+
+	next := endpoint.String() + "?size=" + strconv.Itoa(paginationSize)
+	listed := map[string]bool{}
+	for pages := 0; next != ""; pages++ {
+		c.Assert(pages < maxPages, qt.IsTrue, qt.Commentf("too many pages; next page links may be looping"))
+
+		response := resources.GetGroupsResponse{}
+		s.get(c, next, &response)
+
+		c.Check(len(response.Data) <= paginationSize, qt.IsTrue, qt.Commentf("page %d has %d entries; requested size is %d", pages, len(response.Data), paginationSize))
+		for _, group := range response.Data {
+			if group.Id == nil {
+				continue
+			}
+			c.Check(listed[*group.Id], qt.IsFalse, qt.Commentf("group %q is listed twice", *group.Id))
+			listed[*group.Id] = true
+		}
+
+		next = ""
+		if href := response.Links.Next.Href; href != "" {
+			link, err := url.Parse(href)
+			c.Assert(err, qt.IsNil, qt.Commentf("invalid next page link %q", href))
+			c.Check(link.Path, qt.Equals, endpoint.Path, qt.Commentf("next page link %q targets a different endpoint", href))
+			c.Check(link.Query().Get("size"), qt.Equals, strconv.Itoa(paginationSize), qt.Commentf("next page link %q does not keep the page size", href))
+			next = endpoint.ResolveReference(link).String()
+		}
+	}
+
+	for _, id := range created {
+		c.Check(listed[id], qt.IsTrue, qt.Commentf("group %q is never listed", id))
+	}
+}
+
+// get sends a GET request to the given URL and decodes the JSON response into
+// the given value.
+func (s *suite) get(c *qt.C, url string, response any) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	c.Assert(err, qt.IsNil)
+
+	res := s.send(c, req)
+	defer res.Body.Close()
+	c.Assert(res.StatusCode, qt.Equals, http.StatusOK, qt.Commentf("GET %s", url))
+	c.Assert(json.NewDecoder(res.Body).Decode(response), qt.IsNil)
+}
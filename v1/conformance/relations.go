@@ -0,0 +1,178 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package conformance
+
+import (
+	"context"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// testGroupIdentities checks that group memberships are symmetric, i.e.,
+// adding/removing an identity to/from a group (via `/groups/{id}/identities`)
+// is reflected by the groups of the identity (`/identities/{id}/groups`), and
+// vice versa.
+func (s *suite) testGroupIdentities(c *qt.C) {
+	s.require(c,
+		"POST /groups",
+		"POST /identities",
+		"GET /groups/{id}/identities",
+		"PATCH /groups/{id}/identities",
+		"GET /identities/{id}/groups",
+		"PATCH /identities/{id}/groups",
+	)
+	ctx := context.Background()
+	groupId := s.createGroup(c)
+	identityId := s.createIdentity(c)
+
+	check := func(member bool) {
+		identities, err := s.client.GetGroupsItemIdentitiesPager(groupId, nil).All(ctx)
+		c.Assert(err, qt.IsNil)
+		c.Check(containsId(identities, identityIdOf, identityId), qt.Equals, member, qt.Commentf("identities of group %q", groupId))
+
+		groups, err := s.client.GetIdentitiesItemGroupsPager(identityId, nil).All(ctx)
+		c.Assert(err, qt.IsNil)
+		c.Check(containsId(groups, groupIdOf, groupId), qt.Equals, member, qt.Commentf("groups of identity %q", identityId))
+	}
+	patchGroup := func(op string) {
+		err := s.client.PatchGroupsItemIdentities(ctx, groupId, &resources.GroupIdentitiesPatchRequestBody{
+			Patches: []resources.GroupIdentitiesPatchItem{{Identity: identityId, Op: resources.GroupIdentitiesPatchItemOp(op)}},
+		})
+		c.Assert(err, qt.IsNil)
+	}
+	patchIdentity := func(op string) {
+		err := s.client.PatchIdentitiesItemGroups(ctx, identityId, &resources.IdentityGroupsPatchRequestBody{
+			Patches: []resources.IdentityGroupsPatchItem{{Group: groupId, Op: resources.IdentityGroupsPatchItemOp(op)}},
+		})
+		c.Assert(err, qt.IsNil)
+	}
+
+	check(false)
+	patchGroup("add")
+	check(true)
+	patchIdentity("remove")
+	check(false)
+	patchIdentity("add")
+	check(true)
+	patchGroup("remove")
+	check(false)
+}
+
+// testGroupRoles checks that adding and then removing a role to/from a group
+// is reflected by the roles of the group.
+func (s *suite) testGroupRoles(c *qt.C) {
+	s.require(c,
+		"POST /groups",
+		"POST /roles",
+		"GET /groups/{id}/roles",
+		"PATCH /groups/{id}/roles",
+	)
+	ctx := context.Background()
+	groupId := s.createGroup(c)
+	roleId := s.createRole(c)
+
+	check := func(assigned bool) {
+		roles, err := s.client.GetGroupsItemRolesPager(groupId, nil).All(ctx)
+		c.Assert(err, qt.IsNil)
+		c.Check(containsId(roles, roleIdOf, roleId), qt.Equals, assigned, qt.Commentf("roles of group %q", groupId))
+	}
+	patch := func(op string) {
+		err := s.client.PatchGroupsItemRoles(ctx, groupId, &resources.GroupRolesPatchRequestBody{
+			Patches: []resources.GroupRolesPatchItem{{Role: roleId, Op: resources.GroupRolesPatchItemOp(op)}},
+		})
+		c.Assert(err, qt.IsNil)
+	}
+
+	check(false)
+	patch("add")
+	check(true)
+	patch("remove")
+	check(false)
+}
+
+// testIdentityRoles checks that adding and then removing a role to/from an
+// identity is reflected by the roles of the identity.
+func (s *suite) testIdentityRoles(c *qt.C) {
+	s.require(c,
+		"POST /identities",
+		"POST /roles",
+		"GET /identities/{id}/roles",
+		"PATCH /identities/{id}/roles",
+	)
+	ctx := context.Background()
+	identityId := s.createIdentity(c)
+	roleId := s.createRole(c)
+
+	check := func(assigned bool) {
+		roles, err := s.client.GetIdentitiesItemRolesPager(identityId, nil).All(ctx)
+		c.Assert(err, qt.IsNil)
+		c.Check(containsId(roles, roleIdOf, roleId), qt.Equals, assigned, qt.Commentf("roles of identity %q", identityId))
+	}
+	patch := func(op string) {
+		err := s.client.PatchIdentitiesItemRoles(ctx, identityId, &resources.IdentityRolesPatchRequestBody{
+			Patches: []resources.IdentityRolesPatchItem{{Role: roleId, Op: resources.IdentityRolesPatchItemOp(op)}},
+		})
+		c.Assert(err, qt.IsNil)
+	}
+
+	check(false)
+	patch("add")
+	check(true)
+	patch("remove")
+	check(false)
+}
+
+// createGroup creates a new group, to be deleted when the current scenario
+// ends, and returns its ID.
+func (s *suite) createGroup(c *qt.C) string {
+	group, err := s.client.PostGroups(context.Background(), &resources.Group{Name: s.name("group")})
+	c.Assert(err, qt.IsNil)
+	c.Assert(group.Id, qt.Not(qt.IsNil))
+	c.Cleanup(func() {
+		_ = s.client.DeleteGroupsItem(context.Background(), *group.Id)
+	})
+	return *group.Id
+}
+
+// createIdentity creates a new identity, to be deleted when the current
+// scenario ends, and returns its ID.
+func (s *suite) createIdentity(c *qt.C) string {
+	identity, err := s.client.PostIdentities(context.Background(), &resources.Identity{
+		Email:   s.name("identity") + "@example.com",
+		AddedBy: "conformance",
+		Source:  "conformance",
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(identity.Id, qt.Not(qt.IsNil))
+	c.Cleanup(func() {
+		_ = s.client.DeleteIdentitiesItem(context.Background(), *identity.Id)
+	})
+	return *identity.Id
+}
+
+// createRole creates a new role, to be deleted when the current scenario ends,
+// and returns its ID.
+func (s *suite) createRole(c *qt.C) string {
+	role, err := s.client.PostRoles(context.Background(), &resources.Role{Name: s.name("role")})
+	c.Assert(err, qt.IsNil)
+	c.Assert(role.Id, qt.Not(qt.IsNil))
+	c.Cleanup(func() {
+		_ = s.client.DeleteRolesItem(context.Background(), *role.Id)
+	})
+	return *role.Id
+}
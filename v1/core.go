@@ -19,8 +19,13 @@
 package v1
 
 import (
+	"log/slog"
 	"net/http"
 	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
 	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
@@ -32,6 +37,27 @@ type ReBACAdminBackendParams struct {
 	Authenticator            interfaces.Authenticator
 	AuthenticatorErrorMapper ErrorResponseMapper
 
+	Authorizer            interfaces.Authorizer
+	AuthorizerErrorMapper ErrorResponseMapper
+
+	// AuditSink receives an audit event for every mutating operation (i.e.,
+	// `Post*`, `Put*`, `Delete*` and `Patch*`), whether successful or not.
+	AuditSink interfaces.AuditSink
+	// AuditSinkFailClosed determines how audit sink failures are handled. If
+	// true, a pending audit event is recorded before each mutating operation,
+	// and if that fails, the request fails with an internal server error
+	// without performing the operation. Otherwise, failures are just logged.
+	AuditSinkFailClosed bool
+
+	// IdempotencyStore, if set, enables the `Idempotency-Key` header of creation
+	// requests (i.e., `POST /groups`, `POST /roles`, `POST /identities` and
+	// `POST /authentication`). The first response for a key is recorded in the
+	// store, along with a hash of the request body, and replayed for retries
+	// with the same key. Reusing a key with a different request body results in
+	// a 422 Unprocessable Entity response. See `NewMemoryIdempotencyStore` for
+	// an in-memory implementation.
+	IdempotencyStore interfaces.IdempotencyStore
+
 	Identities            interfaces.IdentitiesService
 	IdentitiesErrorMapper ErrorResponseMapper
 
@@ -52,6 +78,98 @@ type ReBACAdminBackendParams struct {
 
 	Resources            interfaces.ResourcesService
 	ResourcesErrorMapper ErrorResponseMapper
+
+	// Bulk executes the operations of bulk requests (i.e., `POST /bulk`)
+	// atomically. If nil, the endpoint is not available, unless SequentialBulk
+	// is set.
+	Bulk            interfaces.BulkService
+	BulkErrorMapper ErrorResponseMapper
+
+	// SequentialBulk enables the `POST /bulk` endpoint without a Bulk backend.
+	// The operations are then executed one by one via their equivalent
+	// endpoints, and a failure halfway leaves the effects of the previous
+	// operations in place.
+	SequentialBulk bool
+
+	// AccessCheck checks whether an identity has an entitlement, and why (i.e.,
+	// `POST /check`). If nil, the endpoint is not available.
+	AccessCheck            interfaces.AccessCheckService
+	AccessCheckErrorMapper ErrorResponseMapper
+
+	// RequestDecoding determines how request bodies are decoded (e.g., the
+	// maximum body size, or whether unknown fields are rejected).
+	RequestDecoding RequestDecodingPolicy
+
+	// Pagination determines the accepted page sizes of list operations (e.g.,
+	// `GET /groups`), and the default page size passed to the services.
+	Pagination PaginationPolicy
+
+	// ReferentialIntegrity determines whether the entities referenced by
+	// relationship patches (i.e., `PATCH /groups/{id}/identities`,
+	// `PATCH /groups/{id}/roles`, `PATCH /identities/{id}/groups` and
+	// `PATCH /identities/{id}/roles`) are resolved before the patches are
+	// passed to the services. If true, the patched entity and the added
+	// entities are looked up via the `Get*` methods of the respective services;
+	// requests on a non-existent entity are rejected with 404 Not Found, and
+	// requests adding non-existent entities are rejected with 400 Bad Request,
+	// listing the unknown IDs. Removals are not checked, so that dangling
+	// relationships can still be cleaned up.
+	ReferentialIntegrity bool
+
+	// EntitlementSchemaValidation determines whether the entitlement patches
+	// of groups, identities and roles (i.e., `PATCH /groups/{id}/entitlements`,
+	// `PATCH /identities/{id}/entitlements` and `PATCH /roles/{id}/entitlements`)
+	// are checked against the entitlement schema (i.e., the output of
+	// `Entitlements.ListEntitlements`) before being passed to the services. If
+	// true, patch items whose entitlement/entity type pair is not defined for
+	// the receiver type (i.e., `identity`, `group` or `role`) are rejected with
+	// 400 Bad Request. The schema is cached for a minute, per caller. It has no
+	// effect if `Entitlements` is nil.
+	EntitlementSchemaValidation bool
+
+	// RequireIfMatch determines whether the `If-Match` header is required for
+	// updating/deleting groups, roles, identities and identity providers (i.e.,
+	// `Put*Item` and `Delete*Item` operations). If true, requests without the
+	// header are rejected with 428 Precondition Required.
+	//
+	// The `ETag`s are derived from the versions reported by services that
+	// implement the optional `EntityVersioner` interface, or from the content
+	// of the entities otherwise.
+	RequireIfMatch bool
+
+	// CacheControl determines the `Cache-Control` header of the responses to
+	// endpoints that serve rarely changing data (i.e., the OpenAPI spec, the
+	// capabilities and the entitlements). These endpoints also support
+	// conditional requests via the `If-None-Match` header.
+	CacheControl CacheControlPolicy
+
+	// ResponseCacheTTL determines for how long the results of the services
+	// behind the endpoints that serve rarely changing data (i.e., the
+	// capabilities and the entitlements) are reused, before the services are
+	// called again. The results are cached per caller (i.e., the authenticated
+	// identity), so they are not shared between callers. If zero, the services
+	// are called on each request, so they should cache their results themselves
+	// if these are expensive to compute.
+	ResponseCacheTTL time.Duration
+
+	// Logger is used to log internal errors (e.g., failures in writing
+	// responses, or service errors that result in 5xx responses). If nil, the
+	// default slog logger is used.
+	Logger *slog.Logger
+
+	// AccessLog enables logging a line per request, containing the method,
+	// route pattern, response status, latency and caller identity.
+	AccessLog bool
+
+	// Metrics is used to record metrics of the served API operations and the
+	// calls to the backends above (e.g., `GroupsService.ListGroups`). If nil,
+	// no metrics are recorded.
+	Metrics interfaces.MetricsRecorder
+
+	// TracerProvider is used to create spans for each request, the stages of
+	// the handler chain, and the calls to the backends above. If nil, no spans
+	// are created.
+	TracerProvider trace.TracerProvider
 }
 
 // ReBACAdminBackend represents the ReBAC admin backend as a whole package.
@@ -64,22 +182,62 @@ type ReBACAdminBackend struct {
 // with given backends.
 func NewReBACAdminBackend(params ReBACAdminBackendParams) (*ReBACAdminBackend, error) {
 	// Handlers wrapping one another in this order:
-	//   Dispatcher(Validator(Core))
+	//   Dispatcher(Audit(Authorizer(Idempotency(Validator(Core)))))
 	//
 	// Here:
-	// - Dispatcher: determines the availability of the requested HTTP endpoint.
-	// - Validator:  validates the request body/parameters.
-	// - Core:       delegates the control to the service interface implementation.
+	// - Dispatcher:  determines the availability of the requested HTTP endpoint.
+	// - Audit:       records audit events of mutating operations (only if an
+	//                audit sink backend is provided).
+	// - Authorizer:  checks whether the caller is allowed to perform the operation
+	//                (only if an authorizer backend is provided).
+	// - Idempotency: replays the responses of retried creation requests (only if
+	//                an idempotency store backend is provided).
+	// - Validator:   validates the request body/parameters.
+	// - Core:        delegates the control to the service interface implementation.
+
+	// The versioning, effective entitlements, resource access and patch results
+	// hooks are detected on the raw backends, as the wrappers below do not
+	// implement the optional interfaces. Hence, the hooks are wrapped separately.
+	hooks := detectServiceHooks(params)
+
+	// If a metrics recorder is provided, the backends are wrapped to record the
+	// latency and errors of their method calls.
+	if params.Metrics != nil {
+		params = withServiceMetrics(params, params.Metrics)
+		hooks = withServiceHooksMetrics(hooks, params.Metrics)
+	}
+
+	// If a tracer provider is given, the backends are wrapped to trace their
+	// method calls, and each handler stage is wrapped in its own span.
+	traced := func(h resources.ServerInterface, stage string) resources.ServerInterface {
+		return h
+	}
+	if params.TracerProvider != nil {
+		tracer := params.TracerProvider.Tracer(tracerName)
+		params = withServiceTracing(params, tracer)
+		hooks = withServiceHooksTracing(hooks, tracer)
+		traced = func(h resources.ServerInterface, stage string) resources.ServerInterface {
+			return newHandlerWithTracing(h, tracer, stage)
+		}
+	}
 
 	core := &handler{
 		Identities:            params.Identities,
 		IdentitiesErrorMapper: params.IdentitiesErrorMapper,
+		IdentitiesVersioner:   hooks.identitiesVersioner,
+
+		IdentitiesEffectiveEntitlements: hooks.identitiesEffectiveEntitlements,
+		IdentitiesPatchReporter:         hooks.identitiesPatchReporter,
 
 		Roles:            params.Roles,
 		RolesErrorMapper: params.RolesErrorMapper,
+		RolesVersioner:   hooks.rolesVersioner,
+
+		RolesPatchReporter: hooks.rolesPatchReporter,
 
 		IdentityProviders:            params.IdentityProviders,
 		IdentityProvidersErrorMapper: params.IdentityProvidersErrorMapper,
+		IdentityProvidersVersioner:   hooks.identityProvidersVersioner,
 
 		Capabilities:            params.Capabilities,
 		CapabilitiesErrorMapper: params.CapabilitiesErrorMapper,
@@ -89,19 +247,69 @@ func NewReBACAdminBackend(params ReBACAdminBackendParams) (*ReBACAdminBackend, e
 
 		Groups:            params.Groups,
 		GroupsErrorMapper: params.GroupsErrorMapper,
+		GroupsVersioner:   hooks.groupsVersioner,
+
+		GroupsPatchReporter: hooks.groupsPatchReporter,
 
 		Resources:            params.Resources,
 		ResourcesErrorMapper: params.ResourcesErrorMapper,
+		ResourcesAccess:      hooks.resourcesAccess,
+
+		Bulk:            params.Bulk,
+		BulkErrorMapper: params.BulkErrorMapper,
+
+		AccessCheck:            params.AccessCheck,
+		AccessCheckErrorMapper: params.AccessCheckErrorMapper,
+
+		RequireIfMatch: params.RequireIfMatch,
+		CacheControl:   params.CacheControl,
+		Responses:      newResponseCache(params.ResponseCacheTTL),
+	}
+	validation := newHandlerWithValidation(traced(core, "core"), params.RequestDecoding, params.Pagination)
+	if params.Entitlements != nil && params.EntitlementSchemaValidation {
+		validation.entitlementSchema = newEntitlementSchemaCache(params.Entitlements, params.EntitlementsErrorMapper)
+	}
+	if params.ReferentialIntegrity {
+		validation.references = &referenceResolver{
+			identities:            params.Identities,
+			identitiesErrorMapper: params.IdentitiesErrorMapper,
+			groups:                params.Groups,
+			groupsErrorMapper:     params.GroupsErrorMapper,
+			roles:                 params.Roles,
+			rolesErrorMapper:      params.RolesErrorMapper,
+		}
+	}
+	validator := traced(validation, "validator")
+	if params.Bulk == nil && params.SequentialBulk {
+		core.BulkFallback = validator
+	}
+
+	idempotency := validator
+	if params.IdempotencyStore != nil {
+		idempotency = traced(newHandlerWithIdempotency(validator, params.IdempotencyStore, params.RequestDecoding), "idempotency")
+	}
+
+	authorizer := idempotency
+	if params.Authorizer != nil {
+		authorizer = traced(newHandlerWithAuthorization(idempotency, params.Authorizer, params.AuthorizerErrorMapper), "authorizer")
+	}
+
+	audit := authorizer
+	if params.AuditSink != nil {
+		audit = traced(newHandlerWithAudit(authorizer, params.AuditSink, params.AuditSinkFailClosed), "audit")
 	}
-	validator := newHandlerWithValidation(core)
-	dispatcher := newHandlerDispatcher(validator, handlerDispatcherParams{
+
+	dispatcher := traced(newHandlerDispatcher(audit, handlerDispatcherParams{
+		ImplementsBulk:              core.implementsBulk(),
+		ImplementsAccessCheck:       params.AccessCheck != nil,
 		ImplementsIdentities:        params.Identities != nil,
 		ImplementsRoles:             params.Roles != nil,
 		ImplementsIdentityProviders: params.IdentityProviders != nil,
 		ImplementsEntitlements:      params.Entitlements != nil,
 		ImplementsGroups:            params.Groups != nil,
 		ImplementsResources:         params.Resources != nil,
-	})
+		ImplementsResourceAccess:    hooks.resourcesAccess != nil,
+	}), "dispatcher")
 
 	return newReBACAdminBackendWithService(params, dispatcher), nil
 }
@@ -122,16 +330,68 @@ func (b *ReBACAdminBackend) Handler(baseURL string) http.Handler {
 	baseURL, _ = strings.CutSuffix(baseURL, "/")
 	baseURL = baseURL + "/v1"
 
+	// Note that middlewares are applied in order, so the first one is the
+	// innermost. The identity logging middleware should run after the
+	// authentication middleware.
 	var middlewares []resources.MiddlewareFunc
+	if b.params.AccessLog {
+		middlewares = append(middlewares, identityLoggingMiddleware())
+	}
 	if b.params.Authenticator != nil {
 		middlewares = append(middlewares, b.authenticationMiddleware(baseURL))
 	}
 
-	return resources.HandlerWithOptions(b.handler, resources.ChiServerOptions{
+	options := resources.ChiServerOptions{
 		BaseURL:     baseURL,
+		BaseRouter:  chi.NewRouter(),
 		Middlewares: middlewares,
 		ErrorHandlerFunc: func(w http.ResponseWriter, _ *http.Request, err error) {
 			writeErrorResponse(w, err)
 		},
-	})
+	}
+	var h http.Handler = resources.HandlerWithOptions(b.handler, options)
+
+	// The operations that are not part of the OpenAPI spec (e.g., `POST /bulk`)
+	// are served on the same router.
+	resources.ExtensionHandlerWithOptions(extensionsOf(b.handler), options)
+	if b.params.Metrics != nil {
+		h = b.metricsHandler(baseURL, h)
+	}
+	h = b.loggingHandler(h)
+	if b.params.TracerProvider != nil {
+		h = b.tracingHandler(baseURL, h)
+	}
+	return h
+}
+
+// serviceHooks holds the optional interfaces implemented by the backends.
+type serviceHooks struct {
+	groupsVersioner            interfaces.EntityVersioner
+	rolesVersioner             interfaces.EntityVersioner
+	identitiesVersioner        interfaces.EntityVersioner
+	identityProvidersVersioner interfaces.EntityVersioner
+
+	identitiesEffectiveEntitlements interfaces.EffectiveEntitlementsResolver
+	resourcesAccess                 interfaces.ResourceAccessLister
+
+	groupsPatchReporter     interfaces.GroupsPatchReporter
+	identitiesPatchReporter interfaces.IdentitiesPatchReporter
+	rolesPatchReporter      interfaces.RolesPatchReporter
+}
+
+// detectServiceHooks returns the optional interfaces implemented by the given
+// backends. Hooks not implemented are left nil.
+func detectServiceHooks(params ReBACAdminBackendParams) serviceHooks {
+	hooks := serviceHooks{
+		groupsVersioner:            asEntityVersioner(params.Groups),
+		rolesVersioner:             asEntityVersioner(params.Roles),
+		identitiesVersioner:        asEntityVersioner(params.Identities),
+		identityProvidersVersioner: asEntityVersioner(params.IdentityProviders),
+	}
+	hooks.identitiesEffectiveEntitlements, _ = params.Identities.(interfaces.EffectiveEntitlementsResolver)
+	hooks.resourcesAccess, _ = params.Resources.(interfaces.ResourceAccessLister)
+	hooks.groupsPatchReporter, _ = params.Groups.(interfaces.GroupsPatchReporter)
+	hooks.identitiesPatchReporter, _ = params.Identities.(interfaces.IdentitiesPatchReporter)
+	hooks.rolesPatchReporter, _ = params.Roles.(interfaces.RolesPatchReporter)
+	return hooks
 }
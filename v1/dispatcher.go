@@ -27,7 +27,10 @@ type handlerDispatcherParams struct {
 	ImplementsIdentityProviders bool
 	ImplementsRoles             bool
 	ImplementsResources         bool
+	ImplementsResourceAccess    bool
 	ImplementsEntitlements      bool
+	ImplementsBulk              bool
+	ImplementsAccessCheck       bool
 }
 
 type handlerDispatcher struct {
@@ -398,3 +401,39 @@ func (h handlerDispatcher) PatchRolesItemEntitlements(w http.ResponseWriter, r *
 	}
 	h.ServerInterface.PatchRolesItemEntitlements(w, r, id)
 }
+
+// PostBulk delegates the call to the wrapped handler's `PostBulk` method, if it is allowed; otherwise returns a `501 Unimplemented` status code.
+func (h handlerDispatcher) PostBulk(w http.ResponseWriter, r *http.Request) {
+	if !h.params.ImplementsBulk {
+		writeErrorResponse(w, NewNotImplementedError(""))
+		return
+	}
+	extensionsOf(h.ServerInterface).PostBulk(w, r)
+}
+
+// PostCheck delegates the call to the wrapped handler's `PostCheck` method, if it is allowed; otherwise returns a `501 Unimplemented` status code.
+func (h handlerDispatcher) PostCheck(w http.ResponseWriter, r *http.Request) {
+	if !h.params.ImplementsAccessCheck {
+		writeErrorResponse(w, NewNotImplementedError(""))
+		return
+	}
+	extensionsOf(h.ServerInterface).PostCheck(w, r)
+}
+
+// GetIdentitiesItemEffectiveEntitlements delegates the call to the wrapped handler's `GetIdentitiesItemEffectiveEntitlements` method, if it is allowed; otherwise returns a `501 Unimplemented` status code.
+func (h handlerDispatcher) GetIdentitiesItemEffectiveEntitlements(w http.ResponseWriter, r *http.Request, id string) {
+	if !h.params.ImplementsIdentities {
+		writeErrorResponse(w, NewNotImplementedError(""))
+		return
+	}
+	extensionsOf(h.ServerInterface).GetIdentitiesItemEffectiveEntitlements(w, r, id)
+}
+
+// GetResourcesItemAccess delegates the call to the wrapped handler's `GetResourcesItemAccess` method, if it is allowed; otherwise returns a `501 Unimplemented` status code.
+func (h handlerDispatcher) GetResourcesItemAccess(w http.ResponseWriter, r *http.Request, pType string, id string, params resources.GetResourcesItemAccessParams) {
+	if !h.params.ImplementsResourceAccess {
+		writeErrorResponse(w, NewNotImplementedError(""))
+		return
+	}
+	extensionsOf(h.ServerInterface).GetResourcesItemAccess(w, r, pType, id, params)
+}
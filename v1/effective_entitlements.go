@@ -0,0 +1,221 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// GetIdentitiesItemEffectiveEntitlements returns the entitlements of an
+// identity, including the ones inherited via its groups and roles.
+// (GET /identities/{id}/effective-entitlements)
+func (h handler) GetIdentitiesItemEffectiveEntitlements(w http.ResponseWriter, req *http.Request, id string) {
+	ctx := req.Context()
+
+	var entitlements []resources.EffectiveEntitlement
+	var err error
+	mapper := h.IdentitiesErrorMapper
+	if h.IdentitiesEffectiveEntitlements != nil {
+		entitlements, err = h.IdentitiesEffectiveEntitlements.GetIdentityEffectiveEntitlements(ctx, id)
+	} else {
+		entitlements, mapper, err = h.composeEffectiveEntitlements(ctx, id)
+	}
+	if err != nil {
+		writeServiceErrorResponse(w, mapper, err)
+		return
+	}
+
+	if entitlements == nil {
+		entitlements = []resources.EffectiveEntitlement{}
+	}
+
+	response := resources.GetIdentityEffectiveEntitlementsResponse{
+		Data:   entitlements,
+		Status: http.StatusOK,
+	}
+
+	writeResponse(w, http.StatusOK, response)
+}
+
+// composeEffectiveEntitlements computes the effective entitlements of an
+// identity by walking through its direct entitlements and roles, its groups,
+// and the entitlements and roles of its groups. Group and role entitlements
+// are skipped if the respective services are not provided.
+//
+// On failure, it also returns the error mapper of the failed service.
+func (h handler) composeEffectiveEntitlements(ctx context.Context, identityId string) ([]resources.EffectiveEntitlement, ErrorResponseMapper, error) {
+	result := newEffectiveEntitlements()
+
+	direct, err := listAll(func(page *int, token *string) (*resources.PaginatedResponse[resources.EntityEntitlement], error) {
+		return h.Identities.GetIdentityEntitlements(ctx, identityId, &resources.GetIdentitiesItemEntitlementsParams{Page: page, NextToken: token})
+	})
+	if err != nil {
+		return nil, h.IdentitiesErrorMapper, err
+	}
+	result.add(direct)
+
+	groups, err := listAll(func(page *int, token *string) (*resources.PaginatedResponse[resources.Group], error) {
+		return h.Identities.GetIdentityGroups(ctx, identityId, &resources.GetIdentitiesItemGroupsParams{Page: page, NextToken: token})
+	})
+	if err != nil {
+		return nil, h.IdentitiesErrorMapper, err
+	}
+
+	roles, err := listAll(func(page *int, token *string) (*resources.PaginatedResponse[resources.Role], error) {
+		return h.Identities.GetIdentityRoles(ctx, identityId, &resources.GetIdentitiesItemRolesParams{Page: page, NextToken: token})
+	})
+	if err != nil {
+		return nil, h.IdentitiesErrorMapper, err
+	}
+
+	// Roles can be reached via multiple paths, so their entitlements are
+	// fetched once.
+	roleEntitlements := map[string][]resources.EntityEntitlement{}
+	addRoles := func(roles []resources.Role, via ...resources.AccessPathStep) error {
+		if h.Roles == nil {
+			return nil
+		}
+		for _, role := range roles {
+			if role.Id == nil {
+				continue
+			}
+			roleId := *role.Id
+			if _, ok := roleEntitlements[roleId]; !ok {
+				entitlements, err := listAll(func(page *int, token *string) (*resources.PaginatedResponse[resources.EntityEntitlement], error) {
+					return h.Roles.GetRoleEntitlements(ctx, roleId, &resources.GetRolesItemEntitlementsParams{Page: page, NextToken: token})
+				})
+				if err != nil {
+					return err
+				}
+				roleEntitlements[roleId] = entitlements
+			}
+			result.add(roleEntitlements[roleId], append(via, resources.AccessPathStep{Type: resources.AccessPathStepTypeRole, Id: roleId})...)
+		}
+		return nil
+	}
+
+	if h.Groups != nil {
+		for _, group := range groups {
+			if group.Id == nil {
+				continue
+			}
+			groupId := *group.Id
+			step := resources.AccessPathStep{Type: resources.AccessPathStepTypeGroup, Id: groupId}
+
+			entitlements, err := listAll(func(page *int, token *string) (*resources.PaginatedResponse[resources.EntityEntitlement], error) {
+				return h.Groups.GetGroupEntitlements(ctx, groupId, &resources.GetGroupsItemEntitlementsParams{Page: page, NextToken: token})
+			})
+			if err != nil {
+				return nil, h.GroupsErrorMapper, err
+			}
+			result.add(entitlements, step)
+
+			groupRoles, err := listAll(func(page *int, token *string) (*resources.PaginatedResponse[resources.Role], error) {
+				return h.Groups.GetGroupRoles(ctx, groupId, &resources.GetGroupsItemRolesParams{Page: page, NextToken: token})
+			})
+			if err != nil {
+				return nil, h.GroupsErrorMapper, err
+			}
+			if err := addRoles(groupRoles, step); err != nil {
+				return nil, h.RolesErrorMapper, err
+			}
+		}
+	}
+
+	if err := addRoles(roles); err != nil {
+		return nil, h.RolesErrorMapper, err
+	}
+	return result.entitlements, nil, nil
+}
+
+// effectiveEntitlements accumulates effective entitlements, merging the
+// sources of the same entitlement. Entitlements are kept in the order they
+// are first added.
+type effectiveEntitlements struct {
+	index        map[resources.EntityEntitlement]int
+	entitlements []resources.EffectiveEntitlement
+}
+
+// newEffectiveEntitlements returns a new, empty effectiveEntitlements instance.
+func newEffectiveEntitlements() *effectiveEntitlements {
+	return &effectiveEntitlements{
+		index:        map[resources.EntityEntitlement]int{},
+		entitlements: []resources.EffectiveEntitlement{},
+	}
+}
+
+// add adds the given entitlements, granted via the given steps (none for
+// direct entitlements).
+func (e *effectiveEntitlements) add(entitlements []resources.EntityEntitlement, via ...resources.AccessPathStep) {
+	for _, entitlement := range entitlements {
+		source := resources.AccessPath{Via: append([]resources.AccessPathStep{}, via...)}
+		i, ok := e.index[entitlement]
+		if !ok {
+			i = len(e.entitlements)
+			e.index[entitlement] = i
+			e.entitlements = append(e.entitlements, resources.EffectiveEntitlement{Entitlement: entitlement})
+		}
+		e.entitlements[i].Sources = append(e.entitlements[i].Sources, source)
+	}
+}
+
+// maxListAllItems is the maximum number of entries listAll collects before
+// giving up, to bound the work (and memory) spent on a single request.
+const maxListAllItems = 10000
+
+// listAll calls the given paginated list function, starting from the first
+// page, until there are no more pages, and returns all the entries. The
+// function is given the page number or the token of the page to return
+// (both nil for the first page).
+//
+// It fails if a page points to itself (or to the previous page) as the next
+// one, which would otherwise loop forever, or if there are more than
+// maxListAllItems entries.
+func listAll[T any](list func(page *int, token *string) (*resources.PaginatedResponse[T], error)) ([]T, error) {
+	var result []T
+	var page *int
+	var token *string
+	for {
+		response, err := list(page, token)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, response.Data...)
+		if len(response.Data) == 0 || (response.Next.Page == nil && response.Next.PageToken == nil) {
+			return result, nil
+		}
+		if len(result) > maxListAllItems {
+			return nil, NewUnknownError(fmt.Sprintf("too many entries to list (more than %d)", maxListAllItems))
+		}
+		if equalPtr(response.Next.Page, page) && equalPtr(response.Next.PageToken, token) {
+			return nil, NewUnknownError("pagination cursor did not advance")
+		}
+		page, token = response.Next.Page, response.Next.PageToken
+	}
+}
+
+// equalPtr reports whether the given pointers are both nil, or point to equal
+// values.
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
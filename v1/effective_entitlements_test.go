@@ -0,0 +1,280 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+//go:generate mockgen -package interfaces -destination ./interfaces/mock_effective_entitlements.go -source=./interfaces/effective_entitlements.go
+
+func TestHandler_EffectiveEntitlements_Composed(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	identityId := "some-identity"
+	group1, group2, role := "group-1", "group-2", "role"
+	e1 := resources.EntityEntitlement{Entitlement: "can_view", EntityType: "model", EntityId: "foo"}
+	e2 := resources.EntityEntitlement{Entitlement: "can_edit", EntityType: "model", EntityId: "foo"}
+	e3 := resources.EntityEntitlement{Entitlement: "can_view", EntityType: "model", EntityId: "bar"}
+	nextToken := "next"
+
+	mockIdentitiesService := interfaces.NewMockIdentitiesService(ctrl)
+	mockIdentitiesService.EXPECT().
+		GetIdentityEntitlements(gomock.Any(), identityId, &resources.GetIdentitiesItemEntitlementsParams{}).
+		Return(&resources.PaginatedResponse[resources.EntityEntitlement]{Data: []resources.EntityEntitlement{e1}}, nil)
+	// Groups are returned in two pages.
+	mockIdentitiesService.EXPECT().
+		GetIdentityGroups(gomock.Any(), identityId, &resources.GetIdentitiesItemGroupsParams{}).
+		Return(&resources.PaginatedResponse[resources.Group]{
+			Data: []resources.Group{{Id: &group1, Name: group1}},
+			Next: resources.Next{PageToken: &nextToken},
+		}, nil)
+	mockIdentitiesService.EXPECT().
+		GetIdentityGroups(gomock.Any(), identityId, &resources.GetIdentitiesItemGroupsParams{NextToken: &nextToken}).
+		Return(&resources.PaginatedResponse[resources.Group]{
+			Data: []resources.Group{{Id: &group2, Name: group2}},
+		}, nil)
+	mockIdentitiesService.EXPECT().
+		GetIdentityRoles(gomock.Any(), identityId, &resources.GetIdentitiesItemRolesParams{}).
+		Return(&resources.PaginatedResponse[resources.Role]{Data: []resources.Role{{Id: &role, Name: role}}}, nil)
+
+	mockGroupsService := interfaces.NewMockGroupsService(ctrl)
+	mockGroupsService.EXPECT().
+		GetGroupEntitlements(gomock.Any(), group1, &resources.GetGroupsItemEntitlementsParams{}).
+		Return(&resources.PaginatedResponse[resources.EntityEntitlement]{Data: []resources.EntityEntitlement{e2}}, nil)
+	mockGroupsService.EXPECT().
+		GetGroupRoles(gomock.Any(), group1, &resources.GetGroupsItemRolesParams{}).
+		Return(&resources.PaginatedResponse[resources.Role]{Data: []resources.Role{{Id: &role, Name: role}}}, nil)
+	mockGroupsService.EXPECT().
+		GetGroupEntitlements(gomock.Any(), group2, &resources.GetGroupsItemEntitlementsParams{}).
+		Return(&resources.PaginatedResponse[resources.EntityEntitlement]{}, nil)
+	mockGroupsService.EXPECT().
+		GetGroupRoles(gomock.Any(), group2, &resources.GetGroupsItemRolesParams{}).
+		Return(&resources.PaginatedResponse[resources.Role]{}, nil)
+
+	// The role is reached via two paths, but its entitlements are fetched once.
+	mockRolesService := interfaces.NewMockRolesService(ctrl)
+	mockRolesService.EXPECT().
+		GetRoleEntitlements(gomock.Any(), role, &resources.GetRolesItemEntitlementsParams{}).
+		Return(&resources.PaginatedResponse[resources.EntityEntitlement]{Data: []resources.EntityEntitlement{e1, e3}}, nil)
+
+	sut := handler{
+		Identities: mockIdentitiesService,
+		Groups:     mockGroupsService,
+		Roles:      mockRolesService,
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/identities/some-identity/effective-entitlements", nil)
+	sut.GetIdentitiesItemEffectiveEntitlements(w, req, identityId)
+
+	result := w.Result()
+	defer result.Body.Close()
+	c.Assert(result.StatusCode, qt.Equals, http.StatusOK)
+
+	direct := resources.AccessPath{Via: []resources.AccessPathStep{}}
+	viaGroup := resources.AccessPath{Via: []resources.AccessPathStep{
+		{Type: resources.AccessPathStepTypeGroup, Id: group1},
+	}}
+	viaGroupRole := resources.AccessPath{Via: []resources.AccessPathStep{
+		{Type: resources.AccessPathStepTypeGroup, Id: group1},
+		{Type: resources.AccessPathStepTypeRole, Id: role},
+	}}
+	viaRole := resources.AccessPath{Via: []resources.AccessPathStep{
+		{Type: resources.AccessPathStepTypeRole, Id: role},
+	}}
+
+	body, err := io.ReadAll(result.Body)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(body), qt.JSONEquals, resources.GetIdentityEffectiveEntitlementsResponse{
+		Data: []resources.EffectiveEntitlement{{
+			Entitlement: e1,
+			Sources:     []resources.AccessPath{direct, viaGroupRole, viaRole},
+		}, {
+			Entitlement: e2,
+			Sources:     []resources.AccessPath{viaGroup},
+		}, {
+			Entitlement: e3,
+			Sources:     []resources.AccessPath{viaGroupRole, viaRole},
+		}},
+		Status: http.StatusOK,
+	})
+}
+
+func TestHandler_EffectiveEntitlements_Errors(t *testing.T) {
+	c := qt.New(t)
+
+	identityId := "some-identity"
+	groupId := "some-group"
+
+	tests := []struct {
+		name            string
+		setupMocks      func(identities *interfaces.MockIdentitiesService, groups *interfaces.MockGroupsService)
+		expectedStatus  int
+		expectedMessage string
+	}{{
+		name: "identity not found",
+		setupMocks: func(identities *interfaces.MockIdentitiesService, groups *interfaces.MockGroupsService) {
+			identities.EXPECT().
+				GetIdentityEntitlements(gomock.Any(), identityId, gomock.Any()).
+				Return(nil, NewNotFoundError("identity not found"))
+		},
+		expectedStatus:  http.StatusNotFound,
+		expectedMessage: "Not Found: identity not found",
+	}, {
+		name: "group service error",
+		setupMocks: func(identities *interfaces.MockIdentitiesService, groups *interfaces.MockGroupsService) {
+			identities.EXPECT().
+				GetIdentityEntitlements(gomock.Any(), identityId, gomock.Any()).
+				Return(&resources.PaginatedResponse[resources.EntityEntitlement]{}, nil)
+			identities.EXPECT().
+				GetIdentityGroups(gomock.Any(), identityId, gomock.Any()).
+				Return(&resources.PaginatedResponse[resources.Group]{Data: []resources.Group{{Id: &groupId}}}, nil)
+			identities.EXPECT().
+				GetIdentityRoles(gomock.Any(), identityId, gomock.Any()).
+				Return(&resources.PaginatedResponse[resources.Role]{}, nil)
+			groups.EXPECT().
+				GetGroupEntitlements(gomock.Any(), groupId, gomock.Any()).
+				Return(nil, errors.New("test-error"))
+		},
+		expectedStatus:  http.StatusInternalServerError,
+		expectedMessage: "Internal Server Error: test-error",
+	}, {
+		name: "repeated page token",
+		setupMocks: func(identities *interfaces.MockIdentitiesService, groups *interfaces.MockGroupsService) {
+			identities.EXPECT().
+				GetIdentityEntitlements(gomock.Any(), identityId, gomock.Any()).
+				Return(&resources.PaginatedResponse[resources.EntityEntitlement]{
+					Data: []resources.EntityEntitlement{{Entitlement: "viewer", EntityType: "client", EntityId: "foo"}},
+					Next: resources.Next{PageToken: stringPtr("same-token")},
+				}, nil).
+				Times(2)
+		},
+		expectedStatus:  http.StatusInternalServerError,
+		expectedMessage: "Internal Server Error: pagination cursor did not advance",
+	}, {
+		name: "too many entries",
+		setupMocks: func(identities *interfaces.MockIdentitiesService, groups *interfaces.MockGroupsService) {
+			entitlements := make([]resources.EntityEntitlement, 1000)
+			identities.EXPECT().
+				GetIdentityEntitlements(gomock.Any(), identityId, gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string, params *resources.GetIdentitiesItemEntitlementsParams) (*resources.PaginatedResponse[resources.EntityEntitlement], error) {
+					page := 1
+					if params.Page != nil {
+						page = *params.Page + 1
+					}
+					return &resources.PaginatedResponse[resources.EntityEntitlement]{
+						Data: entitlements,
+						Next: resources.Next{Page: &page},
+					}, nil
+				}).
+				Times(maxListAllItems/len(entitlements) + 1)
+		},
+		expectedStatus:  http.StatusInternalServerError,
+		expectedMessage: "Internal Server Error: too many entries to list (more than 10000)",
+	}}
+
+	for _, test := range tests {
+		tt := test
+		c.Run(tt.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+			defer ctrl.Finish()
+
+			mockIdentitiesService := interfaces.NewMockIdentitiesService(ctrl)
+			mockGroupsService := interfaces.NewMockGroupsService(ctrl)
+			tt.setupMocks(mockIdentitiesService, mockGroupsService)
+
+			sut := handler{
+				Identities: mockIdentitiesService,
+				Groups:     mockGroupsService,
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/identities/some-identity/effective-entitlements", nil)
+			sut.GetIdentitiesItemEffectiveEntitlements(w, req, identityId)
+
+			result := w.Result()
+			defer result.Body.Close()
+			c.Assert(result.StatusCode, qt.Equals, tt.expectedStatus)
+
+			body, err := io.ReadAll(result.Body)
+			c.Assert(err, qt.IsNil)
+			c.Assert(string(body), qt.JSONEquals, resources.Response{
+				Message: tt.expectedMessage,
+				Status:  tt.expectedStatus,
+			})
+		})
+	}
+}
+
+// identitiesServiceWithResolver is an identities service that computes the
+// effective entitlements natively.
+type identitiesServiceWithResolver struct {
+	*interfaces.MockIdentitiesService
+	*interfaces.MockEffectiveEntitlementsResolver
+}
+
+func TestEffectiveEntitlementsResolverIsWiredToTheBackend(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	entitlements := []resources.EffectiveEntitlement{{
+		Entitlement: resources.EntityEntitlement{Entitlement: "can_view", EntityType: "model", EntityId: "foo"},
+		Sources:     []resources.AccessPath{{Via: []resources.AccessPathStep{}}},
+	}}
+
+	// No calls are expected on the identities service itself.
+	mockResolver := interfaces.NewMockEffectiveEntitlementsResolver(ctrl)
+	mockResolver.EXPECT().
+		GetIdentityEffectiveEntitlements(gomock.Any(), "some-identity").
+		Return(entitlements, nil)
+
+	sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+		Identities: identitiesServiceWithResolver{
+			MockIdentitiesService:             interfaces.NewMockIdentitiesService(ctrl),
+			MockEffectiveEntitlementsResolver: mockResolver,
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/identities/some-identity/effective-entitlements", nil)
+	w := httptest.NewRecorder()
+	sut.Handler("").ServeHTTP(w, req)
+	c.Assert(w.Code, qt.Equals, http.StatusOK)
+	c.Assert(w.Body.String(), qt.JSONEquals, resources.GetIdentityEffectiveEntitlementsResponse{
+		Data:   entitlements,
+		Status: http.StatusOK,
+	})
+
+	// Without an identities service, the endpoint is not implemented.
+	sut, _ = NewReBACAdminBackend(ReBACAdminBackendParams{})
+	w = httptest.NewRecorder()
+	sut.Handler("").ServeHTTP(w, req)
+	c.Assert(w.Code, qt.Equals, http.StatusNotImplemented)
+}
@@ -0,0 +1,197 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// entitlementSchemaTTL is the duration for which the entitlement schema is
+// cached, before it's fetched again from the entitlements service.
+const entitlementSchemaTTL = time.Minute
+
+// entitlementReceiverType is the type of the receivers of entitlements, as
+// referred to by the `receiver_type` field of the entitlement schema.
+type entitlementReceiverType string
+
+const (
+	entitlementReceiverTypeIdentity entitlementReceiverType = "identity"
+	entitlementReceiverTypeGroup    entitlementReceiverType = "group"
+	entitlementReceiverTypeRole     entitlementReceiverType = "role"
+)
+
+// entitlementSchemaKey identifies an entry of the entitlement schema.
+type entitlementSchemaKey struct {
+	entitlement  string
+	entityType   string
+	receiverType string
+}
+
+// entitlementSchema is a cached entitlement schema, along with its expiry
+// time.
+type entitlementSchema struct {
+	entries   map[entitlementSchemaKey]struct{}
+	expiresAt time.Time
+}
+
+// entitlementSchemaCache holds the entitlement schema (i.e., the output of
+// `EntitlementsService.ListEntitlements`), which is used to validate the
+// entitlement patches of groups, identities and roles. Since the service may
+// return different schemas (or errors) to different callers, the schema is
+// cached per caller.
+type entitlementSchemaCache struct {
+	service     interfaces.EntitlementsService
+	errorMapper ErrorResponseMapper
+	ttl         time.Duration
+
+	// now returns the current time; overridden in tests.
+	now func() time.Time
+
+	mutex   sync.Mutex
+	schemas map[string]entitlementSchema
+}
+
+// newEntitlementSchemaCache returns a new entitlementSchemaCache instance,
+// which fetches the schema from the given service. Errors of the service are
+// mapped via the given mapper.
+func newEntitlementSchemaCache(service interfaces.EntitlementsService, errorMapper ErrorResponseMapper) *entitlementSchemaCache {
+	return &entitlementSchemaCache{
+		service:     service,
+		errorMapper: errorMapper,
+		ttl:         entitlementSchemaTTL,
+		now:         time.Now,
+		schemas:     map[string]entitlementSchema{},
+	}
+}
+
+// get returns the entitlement schema of the caller associated with the given
+// context, fetching it from the service if it's not cached, or the cached
+// schema has expired. Errors are not cached.
+//
+// The schema is fetched without holding the lock, so that a slow service does
+// not block the requests that can be served from the cache. Hence, concurrent
+// requests may fetch the schema more than once when it expires.
+func (c *entitlementSchemaCache) get(ctx context.Context) (map[entitlementSchemaKey]struct{}, error) {
+	key := callerScope(ctx)
+
+	c.mutex.Lock()
+	schema, ok := c.schemas[key]
+	c.mutex.Unlock()
+
+	if ok && c.now().Before(schema.expiresAt) {
+		return schema.entries, nil
+	}
+
+	entries, err := c.service.ListEntitlements(ctx, &resources.GetEntitlementsParams{})
+	if err != nil {
+		return nil, err
+	}
+	schema = entitlementSchema{
+		entries:   make(map[entitlementSchemaKey]struct{}, len(entries)),
+		expiresAt: c.now().Add(c.ttl),
+	}
+	for _, e := range entries {
+		schema.entries[entitlementSchemaKey{entitlement: e.Entitlement, entityType: e.EntityType, receiverType: e.ReceiverType}] = struct{}{}
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, ok := c.schemas[key]; !ok && len(c.schemas) >= maxCachedResponses {
+		c.evict()
+	}
+	c.schemas[key] = schema
+	return schema.entries, nil
+}
+
+// evict removes the expired schemas, or all of them if none has expired. The
+// caller should hold the lock.
+func (c *entitlementSchemaCache) evict() {
+	now := c.now()
+	for key, schema := range c.schemas {
+		if !now.Before(schema.expiresAt) {
+			delete(c.schemas, key)
+		}
+	}
+	if len(c.schemas) >= maxCachedResponses {
+		clear(c.schemas)
+	}
+}
+
+// addedEntitlement is an entitlement added by the patch item at the given
+// index of a request body.
+type addedEntitlement struct {
+	index       int
+	entitlement resources.EntityEntitlement
+}
+
+// validateEntitlementPatches is a helper method to avoid repetition. If
+// entitlement schema validation is enabled, it checks that the given
+// entitlements (i.e., those added by the patch items of the request body) are
+// defined in the entitlement schema for the given receiver type, and if it's
+// okay, will delegate to the provided callback.
+//
+// Removals are not checked, so that entitlements that are no longer defined in
+// the schema can still be revoked.
+func (v handlerWithValidation) validateEntitlementPatches(receiverType entitlementReceiverType, entitlements []addedEntitlement, w http.ResponseWriter, r *http.Request, f func(w http.ResponseWriter, r *http.Request)) {
+	problem, err := v.checkEntitlementPatches(r.Context(), "", receiverType, entitlements)
+	if err != nil {
+		writeServiceErrorResponse(w, v.entitlementSchema.errorMapper, err)
+		return
+	}
+	if problem != nil {
+		writeErrorResponse(w, problem)
+		return
+	}
+	f(w, r)
+}
+
+// checkEntitlementPatches checks the given entitlements against the
+// entitlement schema, as described in validateEntitlementPatches. If any of
+// them is not defined, it returns a request body validation error as the
+// problem, with the given prefix prepended to its message. Errors returned by
+// the entitlements service are returned as is.
+func (v handlerWithValidation) checkEntitlementPatches(ctx context.Context, prefix string, receiverType entitlementReceiverType, entitlements []addedEntitlement) (problem error, err error) {
+	if v.entitlementSchema == nil {
+		return nil, nil
+	}
+
+	schema, err := v.entitlementSchema.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []string
+	for _, added := range entitlements {
+		e := added.entitlement
+		key := entitlementSchemaKey{entitlement: e.Entitlement, entityType: e.EntityType, receiverType: string(receiverType)}
+		if _, ok := schema[key]; !ok {
+			problems = append(problems, fmt.Sprintf("patches[%d]: entitlement %q on entity type %q is not defined for receiver type %q", added.index, e.Entitlement, e.EntityType, receiverType))
+		}
+	}
+	if len(problems) > 0 {
+		return NewRequestBodyValidationError(prefix + strings.Join(problems, "; ")), nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,253 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+var testEntitlementSchema = []resources.EntitlementSchema{
+	{Entitlement: "admin", EntityType: "controller", ReceiverType: "identity"},
+	{Entitlement: "admin", EntityType: "controller", ReceiverType: "group"},
+	{Entitlement: "writer", EntityType: "model", ReceiverType: "group"},
+	{Entitlement: "writer", EntityType: "model", ReceiverType: "role"},
+}
+
+func TestEntitlementSchemaCache(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	entitlements := interfaces.NewMockEntitlementsService(ctrl)
+	cache := newEntitlementSchemaCache(entitlements, nil)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	// Errors are not cached.
+	entitlements.EXPECT().ListEntitlements(gomock.Any(), gomock.Any()).Return(nil, errors.New("some-error"))
+	_, err := cache.get(ctx)
+	c.Assert(err, qt.ErrorMatches, "some-error")
+
+	entitlements.EXPECT().ListEntitlements(gomock.Any(), gomock.Any()).Return(testEntitlementSchema, nil)
+	schema, err := cache.get(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(schema, qt.HasLen, 4)
+	_, ok := schema[entitlementSchemaKey{entitlement: "writer", entityType: "model", receiverType: "role"}]
+	c.Assert(ok, qt.IsTrue)
+
+	// The schema is served from the cache until it expires.
+	now = now.Add(entitlementSchemaTTL - time.Second)
+	_, err = cache.get(ctx)
+	c.Assert(err, qt.IsNil)
+
+	now = now.Add(time.Second)
+	entitlements.EXPECT().ListEntitlements(gomock.Any(), gomock.Any()).Return(testEntitlementSchema[:1], nil)
+	schema, err = cache.get(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(schema, qt.HasLen, 1)
+
+	// The schema is cached per caller.
+	entitlements.EXPECT().ListEntitlements(gomock.Any(), gomock.Any()).Return(nil, errors.New("forbidden"))
+	_, err = cache.get(ContextWithIdentity(ctx, "some-other-caller"))
+	c.Assert(err, qt.ErrorMatches, "forbidden")
+
+	schema, err = cache.get(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(schema, qt.HasLen, 1)
+}
+
+func TestHandlerWithValidation_EntitlementPatches(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		name            string
+		path            string
+		body            string
+		withoutSchema   bool
+		disabled        bool
+		schemaError     error
+		setupMocks      func(groups *interfaces.MockGroupsService, identities *interfaces.MockIdentitiesService, roles *interfaces.MockRolesService)
+		expectedStatus  int
+		expectedMessage string
+	}{{
+		name: "PatchGroupsItemEntitlements: success",
+		path: "/v1/groups/foo/entitlements",
+		body: `{"patches":[{"op":"add","entitlement":{"entitlement":"writer","entity_type":"model","entity_id":"m"}},{"op":"remove","entitlement":{"entitlement":"admin","entity_type":"controller","entity_id":"c"}}]}`,
+		setupMocks: func(groups *interfaces.MockGroupsService, _ *interfaces.MockIdentitiesService, _ *interfaces.MockRolesService) {
+			groups.EXPECT().PatchGroupEntitlements(gomock.Any(), "foo", gomock.Any()).Return(true, nil)
+		},
+		expectedStatus: http.StatusOK,
+	}, {
+		name:            "PatchGroupsItemEntitlements: unknown entitlements",
+		path:            "/v1/groups/foo/entitlements",
+		body:            `{"patches":[{"op":"add","entitlement":{"entitlement":"reader","entity_type":"model","entity_id":"m"}},{"op":"add","entitlement":{"entitlement":"writer","entity_type":"model","entity_id":"m"}},{"op":"remove","entitlement":{"entitlement":"admin","entity_type":"model","entity_id":"m"}},{"op":"add","entitlement":{"entitlement":"admin","entity_type":"model","entity_id":"m"}}]}`,
+		expectedStatus:  http.StatusBadRequest,
+		expectedMessage: `Bad Request: invalid request body: patches[0]: entitlement "reader" on entity type "model" is not defined for receiver type "group"; patches[3]: entitlement "admin" on entity type "model" is not defined for receiver type "group"`,
+	}, {
+		name: "PatchGroupsItemEntitlements: removal of an entitlement not in the schema",
+		path: "/v1/groups/foo/entitlements",
+		body: `{"patches":[{"op":"remove","entitlement":{"entitlement":"reader","entity_type":"model","entity_id":"m"}}]}`,
+		setupMocks: func(groups *interfaces.MockGroupsService, _ *interfaces.MockIdentitiesService, _ *interfaces.MockRolesService) {
+			groups.EXPECT().PatchGroupEntitlements(gomock.Any(), "foo", gomock.Any()).Return(true, nil)
+		},
+		expectedStatus: http.StatusOK,
+	}, {
+		name: "PatchRolesItemEntitlements: removal of an entitlement not in the schema",
+		path: "/v1/roles/foo/entitlements",
+		body: `{"patches":[{"op":"remove","entitlement":{"entitlement":"admin","entity_type":"controller","entity_id":"c"}}]}`,
+		setupMocks: func(_ *interfaces.MockGroupsService, _ *interfaces.MockIdentitiesService, roles *interfaces.MockRolesService) {
+			roles.EXPECT().PatchRoleEntitlements(gomock.Any(), "foo", gomock.Any()).Return(true, nil)
+		},
+		expectedStatus: http.StatusOK,
+	}, {
+		name:            "PatchIdentitiesItemEntitlements: entitlement of another receiver type",
+		path:            "/v1/identities/foo/entitlements",
+		body:            `{"patches":[{"op":"add","entitlement":{"entitlement":"writer","entity_type":"model","entity_id":"m"}}]}`,
+		expectedStatus:  http.StatusBadRequest,
+		expectedMessage: `Bad Request: invalid request body: patches[0]: entitlement "writer" on entity type "model" is not defined for receiver type "identity"`,
+	}, {
+		name: "PatchIdentitiesItemEntitlements: success",
+		path: "/v1/identities/foo/entitlements",
+		body: `{"patches":[{"op":"add","entitlement":{"entitlement":"admin","entity_type":"controller","entity_id":"c"}}]}`,
+		setupMocks: func(_ *interfaces.MockGroupsService, identities *interfaces.MockIdentitiesService, _ *interfaces.MockRolesService) {
+			identities.EXPECT().PatchIdentityEntitlements(gomock.Any(), "foo", gomock.Any()).Return(true, nil)
+		},
+		expectedStatus: http.StatusOK,
+	}, {
+		name:            "PatchRolesItemEntitlements: unknown entitlement",
+		path:            "/v1/roles/foo/entitlements",
+		body:            `{"patches":[{"op":"add","entitlement":{"entitlement":"admin","entity_type":"controller","entity_id":"c"}}]}`,
+		expectedStatus:  http.StatusBadRequest,
+		expectedMessage: `Bad Request: invalid request body: patches[0]: entitlement "admin" on entity type "controller" is not defined for receiver type "role"`,
+	}, {
+		name:          "PatchRolesItemEntitlements: no entitlements service",
+		path:          "/v1/roles/foo/entitlements",
+		body:          `{"patches":[{"op":"add","entitlement":{"entitlement":"admin","entity_type":"controller","entity_id":"c"}}]}`,
+		withoutSchema: true,
+		setupMocks: func(_ *interfaces.MockGroupsService, _ *interfaces.MockIdentitiesService, roles *interfaces.MockRolesService) {
+			roles.EXPECT().PatchRoleEntitlements(gomock.Any(), "foo", gomock.Any()).Return(true, nil)
+		},
+		expectedStatus: http.StatusOK,
+	}, {
+		name:     "PatchRolesItemEntitlements: validation disabled",
+		path:     "/v1/roles/foo/entitlements",
+		body:     `{"patches":[{"op":"add","entitlement":{"entitlement":"admin","entity_type":"controller","entity_id":"c"}}]}`,
+		disabled: true,
+		setupMocks: func(_ *interfaces.MockGroupsService, _ *interfaces.MockIdentitiesService, roles *interfaces.MockRolesService) {
+			roles.EXPECT().PatchRoleEntitlements(gomock.Any(), "foo", gomock.Any()).Return(true, nil)
+		},
+		expectedStatus: http.StatusOK,
+	}, {
+		name:            "PatchRolesItemEntitlements: schema error",
+		path:            "/v1/roles/foo/entitlements",
+		body:            `{"patches":[{"op":"add","entitlement":{"entitlement":"writer","entity_type":"model","entity_id":"m"}}]}`,
+		schemaError:     errors.New("some-error"),
+		expectedStatus:  http.StatusInternalServerError,
+		expectedMessage: "Internal Server Error: some-error",
+	}}
+
+	for _, test := range tests {
+		tt := test
+		c.Run(tt.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+			defer ctrl.Finish()
+
+			groups := interfaces.NewMockGroupsService(ctrl)
+			identities := interfaces.NewMockIdentitiesService(ctrl)
+			roles := interfaces.NewMockRolesService(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(groups, identities, roles)
+			}
+
+			params := ReBACAdminBackendParams{
+				Groups:                      groups,
+				Identities:                  identities,
+				Roles:                       roles,
+				EntitlementSchemaValidation: !tt.disabled,
+			}
+			if !tt.withoutSchema {
+				entitlements := interfaces.NewMockEntitlementsService(ctrl)
+				if !tt.disabled {
+					entitlements.EXPECT().ListEntitlements(gomock.Any(), gomock.Any()).Return(testEntitlementSchema, tt.schemaError)
+				}
+				params.Entitlements = entitlements
+			}
+			sut, _ := NewReBACAdminBackend(params)
+
+			req := httptest.NewRequest(http.MethodPatch, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			sut.Handler("").ServeHTTP(w, req)
+			c.Assert(w.Code, qt.Equals, tt.expectedStatus)
+
+			if tt.expectedMessage != "" {
+				response := resources.Response{}
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				c.Assert(err, qt.IsNil)
+				c.Assert(response.Message, qt.Equals, tt.expectedMessage)
+			}
+		})
+	}
+}
+
+func TestHandlerWithValidation_EntitlementSchemaErrorIsMapped(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	schemaError := errors.New("some-error")
+	entitlements := interfaces.NewMockEntitlementsService(ctrl)
+	entitlements.EXPECT().ListEntitlements(gomock.Any(), gomock.Any()).Return(nil, schemaError)
+
+	mapper := NewMockErrorResponseMapper(ctrl)
+	mapper.EXPECT().MapError(schemaError).Return(&resources.Response{
+		Message: "model unavailable",
+		Status:  http.StatusServiceUnavailable,
+	})
+
+	sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+		Roles:                       interfaces.NewMockRolesService(ctrl),
+		Entitlements:                entitlements,
+		EntitlementsErrorMapper:     mapper,
+		EntitlementSchemaValidation: true,
+	})
+
+	body := `{"patches":[{"op":"add","entitlement":{"entitlement":"writer","entity_type":"model","entity_id":"m"}}]}`
+	req := httptest.NewRequest(http.MethodPatch, "/v1/roles/foo/entitlements", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	sut.Handler("").ServeHTTP(w, req)
+	c.Assert(w.Code, qt.Equals, http.StatusServiceUnavailable)
+
+	response := resources.Response{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	c.Assert(err, qt.IsNil)
+	c.Assert(response.Message, qt.Equals, "model unavailable")
+}
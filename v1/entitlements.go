@@ -26,21 +26,25 @@ import (
 func (h handler) GetEntitlements(w http.ResponseWriter, req *http.Request, params resources.GetEntitlementsParams) {
 	ctx := req.Context()
 
-	entitlements, err := h.Entitlements.ListEntitlements(ctx, &params)
+	response, err := h.Responses.get(responseCacheKey("GetEntitlements", req), func() (any, error) {
+		entitlements, err := h.Entitlements.ListEntitlements(ctx, &params)
+		if err != nil {
+			return nil, err
+		}
+		return resources.GetEntitlementsResponse{
+			Meta: resources.ResponseMeta{
+				Size: len(entitlements),
+			},
+			Data:   entitlements,
+			Status: http.StatusOK,
+		}, nil
+	})
 	if err != nil {
 		writeServiceErrorResponse(w, h.EntitlementsErrorMapper, err)
 		return
 	}
 
-	response := resources.GetEntitlementsResponse{
-		Meta: resources.ResponseMeta{
-			Size: len(entitlements),
-		},
-		Data:   entitlements,
-		Status: http.StatusOK,
-	}
-
-	writeResponse(w, http.StatusOK, response)
+	writeCacheableResponse(w, req, h.CacheControl.Entitlements, response)
 }
 
 // GetRawEntitlements returns the list of known entitlements as raw text.
@@ -48,13 +52,14 @@ func (h handler) GetEntitlements(w http.ResponseWriter, req *http.Request, param
 func (h handler) GetRawEntitlements(w http.ResponseWriter, req *http.Request) {
 	ctx := req.Context()
 
-	entitlementsRawString, err := h.Entitlements.RawEntitlements(ctx)
+	response, err := h.Responses.get(responseCacheKey("GetRawEntitlements", req), func() (any, error) {
+		return h.Entitlements.RawEntitlements(ctx)
+	})
 	if err != nil {
 		writeServiceErrorResponse(w, h.EntitlementsErrorMapper, err)
 		return
 	}
 
 	w.Header().Add("Content-Type", "text/plain")
-	writeResponse(w, http.StatusOK, entitlementsRawString)
-
+	writeCacheableResponse(w, req, h.CacheControl.RawEntitlements, response)
 }
@@ -59,6 +59,15 @@ func NewAuthorizationError(message string) error {
 	}
 }
 
+// NewForbiddenError returns an error instance that represents a forbidden access error (i.e., the caller is
+// authenticated, but not allowed to perform the operation).
+func NewForbiddenError(message string) error {
+	return &errorWithStatus{
+		status:  http.StatusForbidden,
+		message: fmt.Sprintf("access denied: %s", message),
+	}
+}
+
 // NewNotFoundError returns an error instance that represents a not-found error.
 func NewNotFoundError(message string) error {
 	return &errorWithStatus{
@@ -91,6 +100,75 @@ func NewRequestBodyValidationError(message string) error {
 	}
 }
 
+// NewQueryParameterValidationError returns an error instance that represents an invalid query parameter.
+func NewQueryParameterValidationError(name, message string) error {
+	return &errorWithStatus{
+		status:  http.StatusBadRequest,
+		message: fmt.Sprintf("invalid query parameter %q: %s", name, message),
+	}
+}
+
+// NewHeaderValidationError returns an error instance that represents an invalid request header.
+func NewHeaderValidationError(name, message string) error {
+	return &errorWithStatus{
+		status:  http.StatusBadRequest,
+		message: fmt.Sprintf("invalid header %q: %s", name, message),
+	}
+}
+
+// NewRequestBodyTooLargeError returns an error instance that represents a request body exceeding the size limit.
+func NewRequestBodyTooLargeError(message string) error {
+	return &errorWithStatus{
+		status:  http.StatusRequestEntityTooLarge,
+		message: fmt.Sprintf("request body too large: %s", message),
+	}
+}
+
+// NewUnsupportedMediaTypeError returns an error instance that represents a request body with an unsupported media
+// type (i.e., not JSON).
+func NewUnsupportedMediaTypeError(message string) error {
+	return &errorWithStatus{
+		status:  http.StatusUnsupportedMediaType,
+		message: fmt.Sprintf("unsupported media type: %s", message),
+	}
+}
+
+// NewPreconditionFailedError returns an error instance that represents a failed `If-Match` precondition (i.e., the
+// entity has been modified since it was read).
+func NewPreconditionFailedError(message string) error {
+	return &errorWithStatus{
+		status:  http.StatusPreconditionFailed,
+		message: fmt.Sprintf("precondition failed: %s", message),
+	}
+}
+
+// NewPreconditionRequiredError returns an error instance that represents a missing, but required, `If-Match`
+// precondition.
+func NewPreconditionRequiredError(message string) error {
+	return &errorWithStatus{
+		status:  http.StatusPreconditionRequired,
+		message: fmt.Sprintf("precondition required: %s", message),
+	}
+}
+
+// NewConflictError returns an error instance that represents a request conflicting with the current state of the
+// target resource (e.g., a concurrent request with the same idempotency key).
+func NewConflictError(message string) error {
+	return &errorWithStatus{
+		status:  http.StatusConflict,
+		message: message,
+	}
+}
+
+// NewUnprocessableEntityError returns an error instance that represents a well-formed request that cannot be
+// processed (e.g., an idempotency key reused with a different request body).
+func NewUnprocessableEntityError(message string) error {
+	return &errorWithStatus{
+		status:  http.StatusUnprocessableEntity,
+		message: message,
+	}
+}
+
 // NewInvalidRequestError returns an error instance that represents a problem with the input (e.g., when trying to add
 // an entry which already exists).
 func NewInvalidRequestError(message string) error {
@@ -0,0 +1,204 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+)
+
+// entityTagger computes `ETag`s of the entities of a service, and checks the
+// `If-Match` preconditions of requests against them.
+type entityTagger struct {
+	// versioner is the optional versioning hook of the service. If nil, the
+	// `ETag`s are derived from the content of the entities.
+	versioner interfaces.EntityVersioner
+
+	// get fetches the entity identified by the given ID.
+	get func(ctx context.Context, id string) (any, error)
+
+	// mapper is the error mapper of the service.
+	mapper ErrorResponseMapper
+
+	// requireIfMatch determines whether the `If-Match` header is required.
+	requireIfMatch bool
+}
+
+// asEntityVersioner returns the given service as an EntityVersioner, if it
+// implements the interface; otherwise, it returns nil.
+func asEntityVersioner(service any) interfaces.EntityVersioner {
+	versioner, _ := service.(interfaces.EntityVersioner)
+	return versioner
+}
+
+// tag returns the `ETag` of the entity identified by the given ID. If the
+// entity is nil, and there's no versioning hook, the entity is fetched.
+func (t entityTagger) tag(ctx context.Context, id string, entity any) (string, error) {
+	if t.versioner != nil {
+		version, err := t.versioner.EntityVersion(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		return versionEntityTag(version), nil
+	}
+
+	if entity == nil {
+		var err error
+		if entity, err = t.get(ctx, id); err != nil {
+			return "", err
+		}
+	}
+	raw, err := json.Marshal(entity)
+	if err != nil {
+		return "", err
+	}
+	return contentEntityTag(raw), nil
+}
+
+// setTag sets the `ETag` header of the response for the given entity. Since
+// it's not critical, failures are only logged.
+func (t entityTagger) setTag(w http.ResponseWriter, req *http.Request, id string, entity any) {
+	tag, err := t.tag(req.Context(), id, entity)
+	if err != nil {
+		getLogger(w).Error("failed to compute entity tag", "error", err)
+		return
+	}
+	w.Header().Set("ETag", tag)
+}
+
+// checkPreconditions checks the `If-Match` header of the request against the
+// current `ETag` of the entity identified by the given ID. If the check fails,
+// the error response is written and false is returned.
+//
+// Note that the check is not atomic with the subsequent operation, unless the
+// service itself enforces the version (e.g., in a transaction).
+func (t entityTagger) checkPreconditions(w http.ResponseWriter, req *http.Request, id string) bool {
+	ifMatch := strings.Join(req.Header.Values("If-Match"), ",")
+	if ifMatch == "" {
+		if t.requireIfMatch {
+			writeErrorResponse(w, NewPreconditionRequiredError("missing If-Match header"))
+			return false
+		}
+		return true
+	}
+
+	current, err := t.tag(req.Context(), id, nil)
+	if err != nil {
+		if response := mapServiceErrorResponse(t.mapper, err); response.Status != http.StatusNotFound {
+			writeServiceErrorResponse(w, t.mapper, err)
+			return false
+		}
+		// There's no current representation to match against.
+		writeErrorResponse(w, NewPreconditionFailedError(fmt.Sprintf("entity %q does not exist", id)))
+		return false
+	}
+
+	if !matchEntityTag(ifMatch, current) {
+		writeErrorResponse(w, NewPreconditionFailedError(fmt.Sprintf("entity %q has been modified", id)))
+		return false
+	}
+	return true
+}
+
+// quoteEntityTag returns the given opaque value as a (strong) entity tag. The
+// value should only contain characters allowed in entity tags by RFC 9110
+// (e.g., no double quotes or non-ASCII characters).
+func quoteEntityTag(value string) string {
+	return `"` + value + `"`
+}
+
+// versionEntityTag returns a (strong) entity tag for the given version, as
+// returned by an EntityVersioner. Since versions are arbitrary strings, they
+// are base64url-encoded.
+func versionEntityTag(version string) string {
+	return quoteEntityTag(base64.RawURLEncoding.EncodeToString([]byte(version)))
+}
+
+// contentEntityTag returns a (strong) entity tag derived from the given
+// content.
+func contentEntityTag(content []byte) string {
+	sum := sha256.Sum256(content)
+	return quoteEntityTag(hex.EncodeToString(sum[:16]))
+}
+
+// matchEntityTag checks if the given `If-Match` header value matches the
+// given entity tag. As per RFC 9110, the strong comparison is used, so weak
+// entity tags never match.
+func matchEntityTag(ifMatch, tag string) bool {
+	for _, candidate := range strings.Split(ifMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// groupsTagger returns the entityTagger for groups.
+func (h handler) groupsTagger() entityTagger {
+	return entityTagger{
+		versioner: h.GroupsVersioner,
+		get: func(ctx context.Context, id string) (any, error) {
+			return h.Groups.GetGroup(ctx, id)
+		},
+		mapper:         h.GroupsErrorMapper,
+		requireIfMatch: h.RequireIfMatch,
+	}
+}
+
+// rolesTagger returns the entityTagger for roles.
+func (h handler) rolesTagger() entityTagger {
+	return entityTagger{
+		versioner: h.RolesVersioner,
+		get: func(ctx context.Context, id string) (any, error) {
+			return h.Roles.GetRole(ctx, id)
+		},
+		mapper:         h.RolesErrorMapper,
+		requireIfMatch: h.RequireIfMatch,
+	}
+}
+
+// identitiesTagger returns the entityTagger for identities.
+func (h handler) identitiesTagger() entityTagger {
+	return entityTagger{
+		versioner: h.IdentitiesVersioner,
+		get: func(ctx context.Context, id string) (any, error) {
+			return h.Identities.GetIdentity(ctx, id)
+		},
+		mapper:         h.IdentitiesErrorMapper,
+		requireIfMatch: h.RequireIfMatch,
+	}
+}
+
+// identityProvidersTagger returns the entityTagger for identity providers.
+func (h handler) identityProvidersTagger() entityTagger {
+	return entityTagger{
+		versioner: h.IdentityProvidersVersioner,
+		get: func(ctx context.Context, id string) (any, error) {
+			return h.IdentityProviders.GetConfiguration(ctx, id)
+		},
+		mapper:         h.IdentityProvidersErrorMapper,
+		requireIfMatch: h.RequireIfMatch,
+	}
+}
@@ -0,0 +1,301 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+//go:generate mockgen -package interfaces -destination ./interfaces/mock_versioning.go -source=./interfaces/versioning.go
+
+func TestMatchEntityTag(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		ifMatch  string
+		tag      string
+		expected bool
+	}{{
+		ifMatch:  `"foo"`,
+		tag:      `"foo"`,
+		expected: true,
+	}, {
+		ifMatch:  `"bar"`,
+		tag:      `"foo"`,
+		expected: false,
+	}, {
+		ifMatch:  `*`,
+		tag:      `"foo"`,
+		expected: true,
+	}, {
+		ifMatch:  `"bar", "foo"`,
+		tag:      `"foo"`,
+		expected: true,
+	}, {
+		ifMatch:  `W/"foo"`,
+		tag:      `"foo"`,
+		expected: false,
+	}, {
+		ifMatch:  `foo`,
+		tag:      `"foo"`,
+		expected: false,
+	}}
+
+	for _, t := range tests {
+		c.Run(t.ifMatch, func(c *qt.C) {
+			c.Assert(matchEntityTag(t.ifMatch, t.tag), qt.Equals, t.expected)
+		})
+	}
+}
+
+func TestVersionEntityTag(t *testing.T) {
+	c := qt.New(t)
+
+	// Versions are encoded, so that the tags only contain characters allowed
+	// by RFC 9110.
+	for _, version := range []string{"v2", `"quoted"`, "naïve", "a\\b", ""} {
+		tag := versionEntityTag(version)
+		c.Assert(tag, qt.Matches, `"[A-Za-z0-9_-]*"`, qt.Commentf("version %q", version))
+		c.Assert(matchEntityTag(tag, tag), qt.IsTrue)
+	}
+	c.Assert(versionEntityTag("v2"), qt.Equals, `"djI"`)
+	c.Assert(versionEntityTag("v2"), qt.Not(qt.Equals), versionEntityTag("v3"))
+}
+
+func TestHandler_EntityTags_ContentHash(t *testing.T) {
+	c := qt.New(t)
+
+	group := resources.Group{
+		Id:   &mockGroupId,
+		Name: mockGroupName,
+	}
+	modified := resources.Group{
+		Id:   &mockGroupId,
+		Name: "modified",
+	}
+
+	tag, err := entityTagger{}.tag(context.Background(), mockGroupId, &group)
+	c.Assert(err, qt.IsNil)
+	modifiedTag, err := entityTagger{}.tag(context.Background(), mockGroupId, &modified)
+	c.Assert(err, qt.IsNil)
+	c.Assert(tag, qt.Not(qt.Equals), modifiedTag)
+
+	tests := []struct {
+		name             string
+		requireIfMatch   bool
+		setupServiceMock func(mockService *interfaces.MockGroupsService)
+		triggerFunc      func(h handler, w *httptest.ResponseRecorder)
+		expectedStatus   int
+		expectedETag     string
+		expectedMessage  string
+	}{{
+		name: "get sets etag",
+		setupServiceMock: func(mockService *interfaces.MockGroupsService) {
+			mockService.EXPECT().GetGroup(gomock.Any(), mockGroupId).Return(&group, nil)
+		},
+		triggerFunc: func(h handler, w *httptest.ResponseRecorder) {
+			req := httptest.NewRequest(http.MethodGet, "/groups/"+mockGroupId, nil)
+			h.GetGroupsItem(w, req, mockGroupId)
+		},
+		expectedStatus: http.StatusOK,
+		expectedETag:   tag,
+	}, {
+		name: "put without if-match",
+		setupServiceMock: func(mockService *interfaces.MockGroupsService) {
+			mockService.EXPECT().UpdateGroup(gomock.Any(), &modified).Return(&modified, nil)
+		},
+		triggerFunc: func(h handler, w *httptest.ResponseRecorder) {
+			req := newTestRequest(http.MethodPut, "/groups/"+mockGroupId, &modified)
+			h.PutGroupsItem(w, req, mockGroupId)
+		},
+		expectedStatus: http.StatusOK,
+		expectedETag:   modifiedTag,
+	}, {
+		name: "put with matching if-match",
+		setupServiceMock: func(mockService *interfaces.MockGroupsService) {
+			mockService.EXPECT().GetGroup(gomock.Any(), mockGroupId).Return(&group, nil)
+			mockService.EXPECT().UpdateGroup(gomock.Any(), &modified).Return(&modified, nil)
+		},
+		triggerFunc: func(h handler, w *httptest.ResponseRecorder) {
+			req := newTestRequest(http.MethodPut, "/groups/"+mockGroupId, &modified)
+			req.Header.Set("If-Match", tag)
+			h.PutGroupsItem(w, req, mockGroupId)
+		},
+		expectedStatus: http.StatusOK,
+		expectedETag:   modifiedTag,
+	}, {
+		name: "put with stale if-match",
+		setupServiceMock: func(mockService *interfaces.MockGroupsService) {
+			mockService.EXPECT().GetGroup(gomock.Any(), mockGroupId).Return(&modified, nil)
+		},
+		triggerFunc: func(h handler, w *httptest.ResponseRecorder) {
+			req := newTestRequest(http.MethodPut, "/groups/"+mockGroupId, &modified)
+			req.Header.Set("If-Match", tag)
+			h.PutGroupsItem(w, req, mockGroupId)
+		},
+		expectedStatus:  http.StatusPreconditionFailed,
+		expectedMessage: `Precondition Failed: precondition failed: entity "test-id" has been modified`,
+	}, {
+		name:           "put without if-match when required",
+		requireIfMatch: true,
+		triggerFunc: func(h handler, w *httptest.ResponseRecorder) {
+			req := newTestRequest(http.MethodPut, "/groups/"+mockGroupId, &modified)
+			h.PutGroupsItem(w, req, mockGroupId)
+		},
+		expectedStatus:  http.StatusPreconditionRequired,
+		expectedMessage: "Precondition Required: precondition required: missing If-Match header",
+	}, {
+		name:           "delete with wildcard if-match when required",
+		requireIfMatch: true,
+		setupServiceMock: func(mockService *interfaces.MockGroupsService) {
+			mockService.EXPECT().GetGroup(gomock.Any(), mockGroupId).Return(&group, nil)
+			mockService.EXPECT().DeleteGroup(gomock.Any(), mockGroupId).Return(true, nil)
+		},
+		triggerFunc: func(h handler, w *httptest.ResponseRecorder) {
+			req := httptest.NewRequest(http.MethodDelete, "/groups/"+mockGroupId, nil)
+			req.Header.Set("If-Match", "*")
+			h.DeleteGroupsItem(w, req, mockGroupId)
+		},
+		expectedStatus: http.StatusOK,
+	}, {
+		name: "delete with if-match of missing entity",
+		setupServiceMock: func(mockService *interfaces.MockGroupsService) {
+			mockService.EXPECT().GetGroup(gomock.Any(), mockGroupId).Return(nil, NewNotFoundError("not found"))
+		},
+		triggerFunc: func(h handler, w *httptest.ResponseRecorder) {
+			req := httptest.NewRequest(http.MethodDelete, "/groups/"+mockGroupId, nil)
+			req.Header.Set("If-Match", tag)
+			h.DeleteGroupsItem(w, req, mockGroupId)
+		},
+		expectedStatus:  http.StatusPreconditionFailed,
+		expectedMessage: `Precondition Failed: precondition failed: entity "test-id" does not exist`,
+	}, {
+		name: "delete with if-match and service failure",
+		setupServiceMock: func(mockService *interfaces.MockGroupsService) {
+			mockService.EXPECT().GetGroup(gomock.Any(), mockGroupId).Return(nil, errors.New("test-error"))
+		},
+		triggerFunc: func(h handler, w *httptest.ResponseRecorder) {
+			req := httptest.NewRequest(http.MethodDelete, "/groups/"+mockGroupId, nil)
+			req.Header.Set("If-Match", tag)
+			h.DeleteGroupsItem(w, req, mockGroupId)
+		},
+		expectedStatus:  http.StatusInternalServerError,
+		expectedMessage: "Internal Server Error: test-error",
+	}}
+
+	for _, test := range tests {
+		tt := test
+		c.Run(tt.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+			defer ctrl.Finish()
+
+			mockGroupService := interfaces.NewMockGroupsService(ctrl)
+			if tt.setupServiceMock != nil {
+				tt.setupServiceMock(mockGroupService)
+			}
+
+			sut := handler{
+				Groups:         mockGroupService,
+				RequireIfMatch: tt.requireIfMatch,
+			}
+
+			w := httptest.NewRecorder()
+			tt.triggerFunc(sut, w)
+
+			result := w.Result()
+			defer result.Body.Close()
+			c.Assert(result.StatusCode, qt.Equals, tt.expectedStatus)
+			c.Assert(result.Header.Get("ETag"), qt.Equals, tt.expectedETag)
+
+			if tt.expectedMessage != "" {
+				body, err := io.ReadAll(result.Body)
+				c.Assert(err, qt.IsNil)
+				response := resources.Response{}
+				err = json.Unmarshal(body, &response)
+				c.Assert(err, qt.IsNil)
+				c.Assert(response.Message, qt.Equals, tt.expectedMessage)
+			}
+		})
+	}
+}
+
+// versionedRolesService is a roles service that implements the optional
+// EntityVersioner interface.
+type versionedRolesService struct {
+	*interfaces.MockRolesService
+	*interfaces.MockEntityVersioner
+}
+
+func TestEntityVersionerIsWiredToTheBackend(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	role := resources.Role{
+		Id:   &mockRoleId,
+		Name: mockRoleName,
+	}
+
+	versioner := interfaces.NewMockEntityVersioner(ctrl)
+	roles := interfaces.NewMockRolesService(ctrl)
+
+	versioner.EXPECT().EntityVersion(gomock.Any(), mockRoleId).Return("v2", nil)
+	roles.EXPECT().GetRole(gomock.Any(), mockRoleId).Return(&role, nil)
+
+	recorder := interfaces.NewMockMetricsRecorder(ctrl)
+	recorder.EXPECT().ObserveServiceCall(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	recorder.EXPECT().ObserveRequest(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	// Wrapping the service for metrics should not hide the versioning hook.
+	sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+		Roles:          versionedRolesService{roles, versioner},
+		Metrics:        recorder,
+		RequireIfMatch: true,
+	})
+	server := httptest.NewServer(sut.Handler(""))
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "/v1/roles/" + mockRoleId)
+	c.Assert(err, qt.IsNil)
+	res.Body.Close()
+	c.Assert(res.StatusCode, qt.Equals, http.StatusOK)
+	c.Assert(res.Header.Get("ETag"), qt.Equals, `"djI"`)
+
+	// A stale version is rejected before calling the service.
+	versioner.EXPECT().EntityVersion(gomock.Any(), mockRoleId).Return("v3", nil)
+
+	raw, _ := json.Marshal(role)
+	req, _ := http.NewRequest(http.MethodPut, server.URL+"/v1/roles/"+mockRoleId, bytes.NewReader(raw))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"djI"`)
+	res, err = http.DefaultClient.Do(req)
+	c.Assert(err, qt.IsNil)
+	res.Body.Close()
+	c.Assert(res.StatusCode, qt.Equals, http.StatusPreconditionFailed)
+}
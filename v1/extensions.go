@@ -0,0 +1,60 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"net/http"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// extensionsOf returns the operations of the given (wrapped) handler that are
+// not part of the OpenAPI spec (e.g., `POST /bulk`). If the handler does not
+// implement them (e.g., a custom decorator in the chain), the returned
+// instance responds with a 501 status code instead.
+func extensionsOf(handler resources.ServerInterface) resources.ExtensionServerInterface {
+	if extensions, ok := handler.(resources.ExtensionServerInterface); ok {
+		return extensions
+	}
+	return notImplementedExtensions{}
+}
+
+// notImplementedExtensions responds to all extension operations with a 501
+// status code.
+type notImplementedExtensions struct{}
+
+// For doc/test sake, to hint that the struct needs to implement a specific interface.
+var _ resources.ExtensionServerInterface = notImplementedExtensions{}
+
+// PostBulk responds with a 501 status code.
+func (notImplementedExtensions) PostBulk(w http.ResponseWriter, r *http.Request) {
+	writeErrorResponse(w, NewNotImplementedError("bulk operations are not supported by the handler chain"))
+}
+
+// PostCheck responds with a 501 status code.
+func (notImplementedExtensions) PostCheck(w http.ResponseWriter, r *http.Request) {
+	writeErrorResponse(w, NewNotImplementedError("access checks are not supported by the handler chain"))
+}
+
+// GetIdentitiesItemEffectiveEntitlements responds with a 501 status code.
+func (notImplementedExtensions) GetIdentitiesItemEffectiveEntitlements(w http.ResponseWriter, r *http.Request, id string) {
+	writeErrorResponse(w, NewNotImplementedError("effective entitlements are not supported by the handler chain"))
+}
+
+// GetResourcesItemAccess responds with a 501 status code.
+func (notImplementedExtensions) GetResourcesItemAccess(w http.ResponseWriter, r *http.Request, pType string, id string, params resources.GetResourcesItemAccessParams) {
+	writeErrorResponse(w, NewNotImplementedError("resource access listing is not supported by the handler chain"))
+}
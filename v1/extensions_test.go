@@ -0,0 +1,73 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// specOnlyHandler implements the operations of the OpenAPI spec, but none of
+// the extension operations (e.g., a custom decorator).
+type specOnlyHandler struct {
+	resources.ServerInterface
+}
+
+func TestExtensionsAreNotImplementedByCustomDecorators(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+	}{{
+		name:   "PostBulk",
+		method: http.MethodPost,
+		path:   "/v1/bulk",
+	}, {
+		name:   "PostCheck",
+		method: http.MethodPost,
+		path:   "/v1/check",
+	}, {
+		name:   "GetIdentitiesItemEffectiveEntitlements",
+		method: http.MethodGet,
+		path:   "/v1/identities/foo/effective-entitlements",
+	}, {
+		name:   "GetResourcesItemAccess",
+		method: http.MethodGet,
+		path:   "/v1/resources/model/foo/access",
+	}}
+
+	// The decorators should not assume the wrapped handler implements the
+	// extension operations.
+	handler := newHandlerWithIdempotency(specOnlyHandler{}, NewMemoryIdempotencyStore(MemoryIdempotencyStoreParams{}), RequestDecodingPolicy{})
+	sut := newReBACAdminBackendWithService(ReBACAdminBackendParams{}, handler)
+
+	for _, test := range tests {
+		tt := test
+		c.Run(tt.name, func(c *qt.C) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+			sut.Handler("").ServeHTTP(w, req)
+			c.Assert(w.Code, qt.Equals, http.StatusNotImplemented)
+		})
+	}
+}
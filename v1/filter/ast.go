@@ -0,0 +1,101 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package filter
+
+import (
+	"strings"
+)
+
+// Operator defines the operator of a Comparison.
+type Operator string
+
+// Defines values for Operator.
+const (
+	OperatorEq         Operator = "eq"
+	OperatorNe         Operator = "ne"
+	OperatorContains   Operator = "contains"
+	OperatorStartsWith Operator = "startsWith"
+)
+
+// Expr represents a node of the syntax tree of a filter expression. It's one
+// of `*Or`, `*And`, `*Comparison` and `*Text`.
+type Expr interface {
+	// String returns the expression in the canonical form, which parses to
+	// an equal expression.
+	String() string
+}
+
+// Or matches if any of its operands match.
+type Or struct {
+	Operands []Expr
+}
+
+// And matches if all of its operands match.
+type And struct {
+	Operands []Expr
+}
+
+// Comparison compares the value of an entity field with a literal value.
+type Comparison struct {
+	Field    string
+	Operator Operator
+	Value    string
+}
+
+// Text matches entities whose default fields contain the given value.
+type Text struct {
+	Value string
+}
+
+// String implements the Expr interface.
+func (e *Or) String() string {
+	return joinOperands(e.Operands, " or ")
+}
+
+// String implements the Expr interface.
+func (e *And) String() string {
+	return joinOperands(e.Operands, " and ")
+}
+
+// String implements the Expr interface.
+func (e *Comparison) String() string {
+	return e.Field + " " + string(e.Operator) + " " + quote(e.Value)
+}
+
+// String implements the Expr interface.
+func (e *Text) String() string {
+	return quote(e.Value)
+}
+
+// joinOperands returns the canonical form of the given operands, joined with
+// the given separator. Compound operands are enclosed in parentheses.
+func joinOperands(operands []Expr, separator string) string {
+	parts := make([]string, 0, len(operands))
+	for _, operand := range operands {
+		switch operand.(type) {
+		case *Or, *And:
+			parts = append(parts, "("+operand.String()+")")
+		default:
+			parts = append(parts, operand.String())
+		}
+	}
+	return strings.Join(parts, separator)
+}
+
+// quote returns the given value as a double-quoted string.
+func quote(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
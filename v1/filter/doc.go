@@ -0,0 +1,54 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package filter implements the expression language of the `filter` query
+// parameter of list endpoints (e.g., `GET /groups?filter=...`), along with an
+// in-memory evaluator for `resources.Identity`, `resources.Group`,
+// `resources.Role` and `resources.EntitlementSchema` values.
+//
+// The grammar is as follows:
+//
+//	filter     = or
+//	or         = and { "or" and }
+//	and        = term { [ "and" ] term }
+//	term       = "(" or ")" | comparison | text
+//	comparison = field operator value
+//	operator   = "eq" | "ne" | "contains" | "startsWith"
+//	field      = word
+//	value      = word | quoted
+//	text       = word | quoted
+//
+// A word is a run of characters other than whitespace and parentheses, which
+// does not start with a quote (i.e., quotes within words, as in `O'Brien`, are
+// literal). A quoted string is enclosed in single or double quotes, and may
+// contain the enclosing quote or a backslash if escaped with a backslash.
+// Keywords (i.e., `and`, `or` and the operators) are case-insensitive, and
+// must be quoted to be used as values or fields; a keyword where a term is
+// expected is treated as text (e.g., the filter `and` is a text term).
+// Parentheses can be nested up to 32 levels deep.
+//
+// Fields are referred to by their JSON names (e.g., `email` or
+// `entity_type`). A text term, which is not followed by an operator, matches
+// entities whose default fields contain the text (i.e., `email` for
+// identities, `name` for groups and roles, and `entitlement` or `entity_type`
+// for entitlements). Terms separated by whitespace only are combined with
+// `and`, which has a higher precedence than `or`. All comparisons are
+// case-sensitive. For example:
+//
+//	email contains "@example.com" and (firstName eq Jane or lastName startsWith D)
+//	admin or name eq "Site Reliability"
+//
+// An empty (or all-whitespace) filter matches everything.
+package filter
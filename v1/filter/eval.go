@@ -0,0 +1,198 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package filter
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// defaultFields are the fields that text terms are matched against, per
+// supported entity type.
+var defaultFields = map[reflect.Type][]string{
+	reflect.TypeOf(resources.Identity{}):          {"email"},
+	reflect.TypeOf(resources.Group{}):             {"name"},
+	reflect.TypeOf(resources.Role{}):              {"name"},
+	reflect.TypeOf(resources.EntitlementSchema{}): {"entitlement", "entity_type"},
+}
+
+// Validate checks that the given expression only refers to fields of the
+// given entity, which should be a `resources.Identity`, `resources.Group`,
+// `resources.Role` or `resources.EntitlementSchema` (or a pointer to one).
+func Validate(expr Expr, entity any) error {
+	v, err := entityValue(entity)
+	if err != nil {
+		return err
+	}
+	return walk(expr, func(c *Comparison) error {
+		if _, ok := fieldValue(v, c.Field); !ok {
+			return fmt.Errorf("unknown field %q", c.Field)
+		}
+		return nil
+	})
+}
+
+// Match reports whether the given entity matches the expression. A nil
+// expression matches any entity. The supported entity types are the same as
+// for Validate.
+func Match(expr Expr, entity any) (bool, error) {
+	v, err := entityValue(entity)
+	if err != nil {
+		return false, err
+	}
+	return match(expr, v)
+}
+
+// Apply returns the entities that match the given expression, in order.
+func Apply[T any](expr Expr, entities []T) ([]T, error) {
+	result := []T{}
+	for _, entity := range entities {
+		ok, err := Match(expr, entity)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			result = append(result, entity)
+		}
+	}
+	return result, nil
+}
+
+// entityValue returns the struct value of the given entity, if its type is
+// supported.
+func entityValue(entity any) (reflect.Value, error) {
+	v := reflect.ValueOf(entity)
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return reflect.Value{}, fmt.Errorf("unsupported entity type %T", entity)
+	}
+	if _, ok := defaultFields[v.Type()]; !ok {
+		return reflect.Value{}, fmt.Errorf("unsupported entity type %T", entity)
+	}
+	return v, nil
+}
+
+func match(expr Expr, v reflect.Value) (bool, error) {
+	switch e := expr.(type) {
+	case nil:
+		return true, nil
+	case *Or:
+		for _, operand := range e.Operands {
+			if ok, err := match(operand, v); err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+	case *And:
+		for _, operand := range e.Operands {
+			if ok, err := match(operand, v); err != nil || !ok {
+				return ok, err
+			}
+		}
+		return true, nil
+	case *Comparison:
+		value, ok := fieldValue(v, e.Field)
+		if !ok {
+			return false, fmt.Errorf("unknown field %q", e.Field)
+		}
+		return compare(value, e.Operator, e.Value)
+	case *Text:
+		for _, field := range defaultFields[v.Type()] {
+			if value, _ := fieldValue(v, field); strings.Contains(value, e.Value) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	return false, fmt.Errorf("unsupported expression %T", expr)
+}
+
+func compare(value string, operator Operator, operand string) (bool, error) {
+	switch operator {
+	case OperatorEq:
+		return value == operand, nil
+	case OperatorNe:
+		return value != operand, nil
+	case OperatorContains:
+		return strings.Contains(value, operand), nil
+	case OperatorStartsWith:
+		return strings.HasPrefix(value, operand), nil
+	}
+	return false, fmt.Errorf("unsupported operator %q", operator)
+}
+
+// walk calls f for each comparison of the given expression.
+func walk(expr Expr, f func(c *Comparison) error) error {
+	switch e := expr.(type) {
+	case *Or:
+		for _, operand := range e.Operands {
+			if err := walk(operand, f); err != nil {
+				return err
+			}
+		}
+	case *And:
+		for _, operand := range e.Operands {
+			if err := walk(operand, f); err != nil {
+				return err
+			}
+		}
+	case *Comparison:
+		return f(e)
+	}
+	return nil
+}
+
+// fieldValue returns the string form of the struct field with the given JSON
+// name. Only string and integer fields (or pointers to them) are supported;
+// nil pointers are treated as empty strings.
+func fieldValue(v reflect.Value, name string) (string, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		tag, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if tag != name {
+			continue
+		}
+		f := v.Field(i)
+		if f.Kind() == reflect.Pointer {
+			if f.IsNil() {
+				return "", isScalar(f.Type().Elem().Kind())
+			}
+			f = f.Elem()
+		}
+		switch f.Kind() {
+		case reflect.String:
+			return f.String(), true
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return strconv.FormatInt(f.Int(), 10), true
+		}
+		return "", false
+	}
+	return "", false
+}
+
+func isScalar(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
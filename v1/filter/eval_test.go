@@ -0,0 +1,104 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package filter
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+func TestMatch(t *testing.T) {
+	c := qt.New(t)
+
+	firstName := "Jane"
+	groups := 3
+	identity := resources.Identity{
+		Email:     "jane@example.com",
+		FirstName: &firstName,
+		Groups:    &groups,
+		Source:    "local",
+	}
+
+	tests := []struct {
+		filter   string
+		entity   any
+		expected bool
+	}{
+		{filter: "", entity: identity, expected: true},
+		{filter: "example", entity: identity, expected: true},
+		{filter: "jane example", entity: &identity, expected: true},
+		{filter: "john", entity: identity, expected: false},
+		{filter: "firstName eq Jane", entity: identity, expected: true},
+		{filter: "firstName ne Jane", entity: identity, expected: false},
+		{filter: "lastName eq ''", entity: identity, expected: true},
+		{filter: "groups eq 3", entity: identity, expected: true},
+		{filter: `email startsWith "jane@" and source eq local`, entity: identity, expected: true},
+		{filter: "source eq ldap or email contains example", entity: identity, expected: true},
+		{filter: "source eq ldap or (email contains example and firstName eq John)", entity: identity, expected: false},
+		{filter: "adm", entity: resources.Group{Name: "admins"}, expected: true},
+		{filter: "name eq admins", entity: resources.Role{Name: "admins"}, expected: true},
+		{filter: "model", entity: resources.EntitlementSchema{Entitlement: "can_view", EntityType: "model"}, expected: true},
+		{filter: "receiver_type eq user", entity: resources.EntitlementSchema{ReceiverType: "group"}, expected: false},
+	}
+
+	for _, test := range tests {
+		tt := test
+		c.Run(tt.filter, func(c *qt.C) {
+			expr, err := Parse(tt.filter)
+			c.Assert(err, qt.IsNil)
+			c.Assert(Validate(expr, tt.entity), qt.IsNil)
+
+			ok, err := Match(expr, tt.entity)
+			c.Assert(err, qt.IsNil)
+			c.Assert(ok, qt.Equals, tt.expected)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	c := qt.New(t)
+
+	expr, err := Parse("name eq foo or email eq bar")
+	c.Assert(err, qt.IsNil)
+
+	c.Assert(Validate(expr, resources.Identity{}), qt.ErrorMatches, `unknown field "name"`)
+	c.Assert(Validate(expr, resources.Group{}), qt.ErrorMatches, `unknown field "email"`)
+	c.Assert(Validate(expr, resources.Resource{}), qt.ErrorMatches, `unsupported entity type resources.Resource`)
+
+	// Non-scalar fields cannot be compared.
+	expr, err = Parse("entitlements eq foo")
+	c.Assert(err, qt.IsNil)
+	c.Assert(Validate(expr, resources.Role{}), qt.ErrorMatches, `unknown field "entitlements"`)
+
+	_, err = Match(expr, resources.Role{})
+	c.Assert(err, qt.ErrorMatches, `unknown field "entitlements"`)
+}
+
+func TestApply(t *testing.T) {
+	c := qt.New(t)
+
+	groups := []resources.Group{{Name: "admins"}, {Name: "viewers"}, {Name: "administrators"}}
+
+	expr, err := Parse("name startsWith admin")
+	c.Assert(err, qt.IsNil)
+
+	result, err := Apply(expr, groups)
+	c.Assert(err, qt.IsNil)
+	c.Assert(result, qt.DeepEquals, []resources.Group{{Name: "admins"}, {Name: "administrators"}})
+}
@@ -0,0 +1,292 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SyntaxError represents a malformed filter expression.
+type SyntaxError struct {
+	// Offset is the byte offset of the error in the expression.
+	Offset int
+	// Message describes the error.
+	Message string
+}
+
+// Error implements the error interface.
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at offset %d", e.Message, e.Offset)
+}
+
+// Parse parses the given filter expression. It returns a nil expression if
+// the filter is empty, and a `*SyntaxError` if it's malformed.
+func Parse(filter string) (Expr, error) {
+	tokens, err := tokenize(filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 1 {
+		return nil, nil
+	}
+
+	p := parser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, p.unexpected(t)
+	}
+	return expr, nil
+}
+
+// tokenKind is the kind of a lexical token.
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenQuoted
+	tokenLeftParen
+	tokenRightParen
+)
+
+// token represents a lexical token of a filter expression.
+type token struct {
+	kind   tokenKind
+	value  string
+	offset int
+}
+
+// keyword returns the keyword that the token represents (in lower case), or
+// an empty string if it's not a keyword. Quoted strings are never keywords.
+func (t token) keyword() string {
+	if t.kind != tokenWord {
+		return ""
+	}
+	switch k := strings.ToLower(t.value); k {
+	case "and", "or", "eq", "ne", "contains", "startswith":
+		return k
+	}
+	return ""
+}
+
+// operator returns the operator that the token represents, if any.
+func (t token) operator() (Operator, bool) {
+	switch t.keyword() {
+	case "eq":
+		return OperatorEq, true
+	case "ne":
+		return OperatorNe, true
+	case "contains":
+		return OperatorContains, true
+	case "startswith":
+		return OperatorStartsWith, true
+	}
+	return "", false
+}
+
+// tokenize splits the given filter expression into tokens. The last token is
+// always of the tokenEOF kind.
+func tokenize(filter string) ([]token, error) {
+	tokens := []token{}
+	for i := 0; i < len(filter); {
+		r, size := utf8.DecodeRuneInString(filter[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLeftParen, value: "(", offset: i})
+			i += size
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRightParen, value: ")", offset: i})
+			i += size
+		case r == '"' || r == '\'':
+			// Quotes only start a quoted string at the beginning of a token;
+			// elsewhere (e.g., `O'Brien`) they are part of the word.
+			value, n, err := unquote(filter[i:])
+			if err != nil {
+				return nil, &SyntaxError{Offset: i, Message: err.Error()}
+			}
+			tokens = append(tokens, token{kind: tokenQuoted, value: value, offset: i})
+			i += n
+		default:
+			start := i
+			for i < len(filter) {
+				r, size := utf8.DecodeRuneInString(filter[i:])
+				if unicode.IsSpace(r) || r == '(' || r == ')' {
+					break
+				}
+				i += size
+			}
+			tokens = append(tokens, token{kind: tokenWord, value: filter[start:i], offset: start})
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, offset: len(filter)}), nil
+}
+
+// unquote returns the content of the quoted string at the beginning of s, and
+// the length of the quoted string (including the quotes).
+func unquote(s string) (string, int, error) {
+	quote := s[0]
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case quote:
+			return b.String(), i + 1, nil
+		case '\\':
+			if i+1 < len(s) && (s[i+1] == quote || s[i+1] == '\\') {
+				i++
+				b.WriteByte(s[i])
+				continue
+			}
+			return "", 0, fmt.Errorf("invalid escape sequence")
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated quoted string")
+}
+
+// maxDepth is the maximum nesting depth of parentheses, which bounds the
+// recursion of the parser.
+const maxDepth = 32
+
+// parser is a recursive descent parser of filter expressions.
+type parser struct {
+	tokens []token
+	pos    int
+	depth  int
+}
+
+// peek returns the current token, without consuming it.
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+// next consumes and returns the current token. The tokenEOF token is never
+// consumed, so it's returned by any subsequent call.
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// unexpected returns a syntax error about the given token.
+func (p *parser) unexpected(t token) error {
+	if t.kind == tokenEOF {
+		return &SyntaxError{Offset: t.offset, Message: "unexpected end of filter"}
+	}
+	return &SyntaxError{Offset: t.offset, Message: fmt.Sprintf("unexpected %q", t.value)}
+}
+
+// parseOr parses an `or` production, i.e., one or more `and` productions
+// separated by the `or` keyword.
+func (p *parser) parseOr() (Expr, error) {
+	operands := []Expr{}
+	for {
+		operand, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+		if p.peek().keyword() != "or" {
+			break
+		}
+		p.next()
+	}
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+	return &Or{Operands: operands}, nil
+}
+
+// parseAnd parses an `and` production, i.e., one or more terms separated by
+// the `and` keyword or whitespace.
+func (p *parser) parseAnd() (Expr, error) {
+	operands := []Expr{}
+	for {
+		operand, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+
+		t := p.peek()
+		if t.keyword() == "and" {
+			p.next()
+			continue
+		}
+		// Terms separated by whitespace only are implicitly combined with
+		// `and`.
+		if t.kind == tokenEOF || t.kind == tokenRightParen || t.keyword() == "or" {
+			break
+		}
+	}
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+	return &And{Operands: operands}, nil
+}
+
+// parseTerm parses a term, i.e., a parenthesized expression, a comparison or
+// a text.
+func (p *parser) parseTerm() (Expr, error) {
+	t := p.next()
+	switch {
+	case t.kind == tokenLeftParen:
+		if p.depth == maxDepth {
+			return nil, &SyntaxError{Offset: t.offset, Message: fmt.Sprintf("parentheses nested more than %d levels deep", maxDepth)}
+		}
+		p.depth++
+		defer func() { p.depth-- }()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRightParen {
+			if closing.kind == tokenEOF {
+				return nil, &SyntaxError{Offset: closing.offset, Message: "missing closing parenthesis"}
+			}
+			return nil, p.unexpected(closing)
+		}
+		return expr, nil
+	case t.kind == tokenQuoted:
+		return &Text{Value: t.value}, nil
+	case t.kind == tokenWord && t.keyword() != "":
+		// A keyword where a term is expected cannot be anything but text
+		// (e.g., the filter `and`).
+		return &Text{Value: t.value}, nil
+	case t.kind == tokenWord && t.keyword() == "":
+		operator, ok := p.peek().operator()
+		if !ok {
+			return &Text{Value: t.value}, nil
+		}
+		p.next()
+		value := p.next()
+		if value.kind != tokenQuoted && (value.kind != tokenWord || value.keyword() != "") {
+			return nil, p.unexpected(value)
+		}
+		return &Comparison{Field: t.value, Operator: operator, Value: value.value}, nil
+	}
+	return nil, p.unexpected(t)
+}
@@ -0,0 +1,178 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package filter
+
+import (
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParse(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		name          string
+		filter        string
+		expected      Expr
+		expectedError string
+	}{{
+		name:   "empty",
+		filter: "  ",
+	}, {
+		name:     "text",
+		filter:   "admin",
+		expected: &Text{Value: "admin"},
+	}, {
+		name:     "quoted text",
+		filter:   `"site reliability"`,
+		expected: &Text{Value: "site reliability"},
+	}, {
+		name:     "comparison",
+		filter:   "name eq admins",
+		expected: &Comparison{Field: "name", Operator: OperatorEq, Value: "admins"},
+	}, {
+		name:     "case-insensitive operator",
+		filter:   "name STARTSWITH adm",
+		expected: &Comparison{Field: "name", Operator: OperatorStartsWith, Value: "adm"},
+	}, {
+		name:     "quoted value with escapes",
+		filter:   `name ne 'it\'s a \\ test'`,
+		expected: &Comparison{Field: "name", Operator: OperatorNe, Value: `it's a \ test`},
+	}, {
+		name:     "quoted keyword",
+		filter:   `name contains "and"`,
+		expected: &Comparison{Field: "name", Operator: OperatorContains, Value: "and"},
+	}, {
+		name:   "precedence",
+		filter: "a eq 1 or b eq 2 and c eq 3",
+		expected: &Or{Operands: []Expr{
+			&Comparison{Field: "a", Operator: OperatorEq, Value: "1"},
+			&And{Operands: []Expr{
+				&Comparison{Field: "b", Operator: OperatorEq, Value: "2"},
+				&Comparison{Field: "c", Operator: OperatorEq, Value: "3"},
+			}},
+		}},
+	}, {
+		name:   "parentheses",
+		filter: "(a eq 1 or b eq 2) and c eq 3",
+		expected: &And{Operands: []Expr{
+			&Or{Operands: []Expr{
+				&Comparison{Field: "a", Operator: OperatorEq, Value: "1"},
+				&Comparison{Field: "b", Operator: OperatorEq, Value: "2"},
+			}},
+			&Comparison{Field: "c", Operator: OperatorEq, Value: "3"},
+		}},
+	}, {
+		name:   "implicit and",
+		filter: "john smith",
+		expected: &And{Operands: []Expr{
+			&Text{Value: "john"},
+			&Text{Value: "smith"},
+		}},
+	}, {
+		name:          "missing value",
+		filter:        "name eq",
+		expectedError: "unexpected end of filter at offset 7",
+	}, {
+		name:          "keyword as value",
+		filter:        "name eq or",
+		expectedError: `unexpected "or" at offset 8`,
+	}, {
+		name:          "dangling operator",
+		filter:        "admin or",
+		expectedError: "unexpected end of filter at offset 8",
+	}, {
+		name:          "missing closing parenthesis",
+		filter:        "(name eq admins",
+		expectedError: "missing closing parenthesis at offset 15",
+	}, {
+		name:          "unexpected closing parenthesis",
+		filter:        "name eq admins)",
+		expectedError: `unexpected ")" at offset 14`,
+	}, {
+		name:          "unterminated quoted string",
+		filter:        `name eq "admins`,
+		expectedError: "unterminated quoted string at offset 8",
+	}, {
+		name:          "invalid escape sequence",
+		filter:        `name eq "\n"`,
+		expectedError: "invalid escape sequence at offset 8",
+	}, {
+		name:   "operator without field",
+		filter: "eq admins",
+		expected: &And{Operands: []Expr{
+			&Text{Value: "eq"},
+			&Text{Value: "admins"},
+		}},
+	}, {
+		name:     "lone keyword",
+		filter:   "and",
+		expected: &Text{Value: "and"},
+	}, {
+		name:     "quote within word",
+		filter:   "O'Brien",
+		expected: &Text{Value: "O'Brien"},
+	}, {
+		name:     "quote within value",
+		filter:   `lastName eq O'Brien`,
+		expected: &Comparison{Field: "lastName", Operator: OperatorEq, Value: "O'Brien"},
+	}, {
+		name:     "maximum nesting depth",
+		filter:   strings.Repeat("(", 32) + "admin" + strings.Repeat(")", 32),
+		expected: &Text{Value: "admin"},
+	}, {
+		name:          "too deeply nested parentheses",
+		filter:        strings.Repeat("(", 10000) + "admin" + strings.Repeat(")", 10000),
+		expectedError: "parentheses nested more than 32 levels deep at offset 32",
+	}}
+
+	for _, test := range tests {
+		tt := test
+		c.Run(tt.name, func(c *qt.C) {
+			expr, err := Parse(tt.filter)
+			if tt.expectedError != "" {
+				c.Assert(err, qt.ErrorAs, new(*SyntaxError))
+				c.Assert(err.Error(), qt.Equals, tt.expectedError)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+			c.Assert(expr, qt.DeepEquals, tt.expected)
+		})
+	}
+}
+
+func TestExprString(t *testing.T) {
+	c := qt.New(t)
+
+	filters := []string{
+		`"admin"`,
+		`name eq "it's \"quoted\""`,
+		`(a eq "1" or b eq "2") and c startsWith "3"`,
+		`a contains "1" or (b ne "2" and "text")`,
+	}
+	for _, filter := range filters {
+		expr, err := Parse(filter)
+		c.Assert(err, qt.IsNil)
+		c.Assert(expr.String(), qt.Equals, filter)
+
+		// The canonical form should parse to the same expression.
+		reparsed, err := Parse(expr.String())
+		c.Assert(err, qt.IsNil)
+		c.Assert(reparsed, qt.DeepEquals, expr)
+	}
+}
@@ -73,6 +73,10 @@ func (h handler) PostGroups(w http.ResponseWriter, req *http.Request) {
 func (h handler) DeleteGroupsItem(w http.ResponseWriter, req *http.Request, id string) {
 	ctx := req.Context()
 
+	if !h.groupsTagger().checkPreconditions(w, req, id) {
+		return
+	}
+
 	_, err := h.Groups.DeleteGroup(ctx, id)
 	if err != nil {
 		writeServiceErrorResponse(w, h.GroupsErrorMapper, err)
@@ -93,6 +97,7 @@ func (h handler) GetGroupsItem(w http.ResponseWriter, req *http.Request, id stri
 		return
 	}
 
+	h.groupsTagger().setTag(w, req, id, group)
 	writeResponse(w, http.StatusOK, group)
 }
 
@@ -113,12 +118,17 @@ func (h handler) PutGroupsItem(w http.ResponseWriter, req *http.Request, id stri
 		return
 	}
 
+	if !h.groupsTagger().checkPreconditions(w, req, id) {
+		return
+	}
+
 	result, err := h.Groups.UpdateGroup(ctx, group)
 	if err != nil {
 		writeServiceErrorResponse(w, h.GroupsErrorMapper, err)
 		return
 	}
 
+	h.groupsTagger().setTag(w, req, id, result)
 	writeResponse(w, http.StatusOK, result)
 }
 
@@ -160,6 +170,16 @@ func (h handler) PatchGroupsItemEntitlements(w http.ResponseWriter, req *http.Re
 		return
 	}
 
+	if h.GroupsPatchReporter != nil {
+		results, err := h.GroupsPatchReporter.PatchGroupEntitlementsWithResults(ctx, id, groupEntitlements.Patches)
+		if err != nil {
+			writeServiceErrorResponse(w, h.GroupsErrorMapper, err)
+			return
+		}
+		writePatchResults(w, h.GroupsErrorMapper, len(groupEntitlements.Patches), results)
+		return
+	}
+
 	_, err = h.Groups.PatchGroupEntitlements(ctx, id, groupEntitlements.Patches)
 	if err != nil {
 		writeServiceErrorResponse(w, h.GroupsErrorMapper, err)
@@ -207,6 +227,16 @@ func (h handler) PatchGroupsItemIdentities(w http.ResponseWriter, req *http.Requ
 		return
 	}
 
+	if h.GroupsPatchReporter != nil {
+		results, err := h.GroupsPatchReporter.PatchGroupIdentitiesWithResults(ctx, id, groupIdentities.Patches)
+		if err != nil {
+			writeServiceErrorResponse(w, h.GroupsErrorMapper, err)
+			return
+		}
+		writePatchResults(w, h.GroupsErrorMapper, len(groupIdentities.Patches), results)
+		return
+	}
+
 	_, err = h.Groups.PatchGroupIdentities(ctx, id, groupIdentities.Patches)
 	if err != nil {
 		writeServiceErrorResponse(w, h.GroupsErrorMapper, err)
@@ -254,6 +284,16 @@ func (h handler) PatchGroupsItemRoles(w http.ResponseWriter, req *http.Request,
 		return
 	}
 
+	if h.GroupsPatchReporter != nil {
+		results, err := h.GroupsPatchReporter.PatchGroupRolesWithResults(ctx, id, groupRoles.Patches)
+		if err != nil {
+			writeServiceErrorResponse(w, h.GroupsErrorMapper, err)
+			return
+		}
+		writePatchResults(w, h.GroupsErrorMapper, len(groupRoles.Patches), results)
+		return
+	}
+
 	_, err = h.Groups.PatchGroupRoles(ctx, id, groupRoles.Patches)
 	if err != nil {
 		writeServiceErrorResponse(w, h.GroupsErrorMapper, err)
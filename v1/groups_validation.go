@@ -21,6 +21,17 @@ import (
 	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
 )
 
+// GetGroups validates the pagination parameters, filter and sort order of the GetGroups method and delegates to the underlying handler.
+func (v handlerWithValidation) GetGroups(w http.ResponseWriter, r *http.Request, params resources.GetGroupsParams) {
+	v.validatePagination(&params.Size, params.Page, params.NextToken, params.NextPageToken, w, r, func(w http.ResponseWriter, r *http.Request) {
+		v.validateFilter(params.Filter, resources.Group{}, w, r, func(w http.ResponseWriter, r *http.Request) {
+			v.validateSort(groupsSortableFields, w, r, func(w http.ResponseWriter, r *http.Request) {
+				v.ServerInterface.GetGroups(w, r, params)
+			})
+		})
+	})
+}
+
 // PostGroups validates request body for the PostGroups method and delegates to the underlying handler.
 func (v handlerWithValidation) PostGroups(w http.ResponseWriter, r *http.Request) {
 	body := &resources.Group{}
@@ -41,11 +52,33 @@ func (v handlerWithValidation) PutGroupsItem(w http.ResponseWriter, r *http.Requ
 	})
 }
 
+// GetGroupsItemEntitlements validates the pagination parameters of the GetGroupsItemEntitlements method and delegates to the underlying handler.
+func (v handlerWithValidation) GetGroupsItemEntitlements(w http.ResponseWriter, r *http.Request, id string, params resources.GetGroupsItemEntitlementsParams) {
+	v.validatePagination(&params.Size, params.Page, params.NextToken, params.NextPageToken, w, r, func(w http.ResponseWriter, r *http.Request) {
+		v.ServerInterface.GetGroupsItemEntitlements(w, r, id, params)
+	})
+}
+
 // PatchGroupsItemEntitlements validates request body for the PatchGroupsItemEntitlements method and delegates to the underlying handler.
 func (v handlerWithValidation) PatchGroupsItemEntitlements(w http.ResponseWriter, r *http.Request, id string) {
 	body := &resources.GroupEntitlementsPatchRequestBody{}
 	v.validateRequestBody(body, w, r, func(w http.ResponseWriter, r *http.Request) {
-		v.ServerInterface.PatchGroupsItemEntitlements(w, r, id)
+		entitlements := make([]addedEntitlement, 0, len(body.Patches))
+		for i, p := range body.Patches {
+			if p.Op == resources.GroupEntitlementsPatchItemOpAdd {
+				entitlements = append(entitlements, addedEntitlement{index: i, entitlement: p.Entitlement})
+			}
+		}
+		v.validateEntitlementPatches(entitlementReceiverTypeGroup, entitlements, w, r, func(w http.ResponseWriter, r *http.Request) {
+			v.ServerInterface.PatchGroupsItemEntitlements(w, r, id)
+		})
+	})
+}
+
+// GetGroupsItemIdentities validates the pagination parameters of the GetGroupsItemIdentities method and delegates to the underlying handler.
+func (v handlerWithValidation) GetGroupsItemIdentities(w http.ResponseWriter, r *http.Request, id string, params resources.GetGroupsItemIdentitiesParams) {
+	v.validatePagination(&params.Size, params.Page, params.NextToken, params.NextPageToken, w, r, func(w http.ResponseWriter, r *http.Request) {
+		v.ServerInterface.GetGroupsItemIdentities(w, r, id, params)
 	})
 }
 
@@ -53,7 +86,22 @@ func (v handlerWithValidation) PatchGroupsItemEntitlements(w http.ResponseWriter
 func (v handlerWithValidation) PatchGroupsItemIdentities(w http.ResponseWriter, r *http.Request, id string) {
 	body := &resources.GroupIdentitiesPatchRequestBody{}
 	v.validateRequestBody(body, w, r, func(w http.ResponseWriter, r *http.Request) {
-		v.ServerInterface.PatchGroupsItemIdentities(w, r, id)
+		added := make([]string, 0, len(body.Patches))
+		for _, p := range body.Patches {
+			if p.Op == resources.GroupIdentitiesPatchItemOpAdd {
+				added = append(added, p.Identity)
+			}
+		}
+		v.validateReferences(v.references.groupRefs(id), v.references.identityRefs(added...), w, r, func(w http.ResponseWriter, r *http.Request) {
+			v.ServerInterface.PatchGroupsItemIdentities(w, r, id)
+		})
+	})
+}
+
+// GetGroupsItemRoles validates the pagination parameters of the GetGroupsItemRoles method and delegates to the underlying handler.
+func (v handlerWithValidation) GetGroupsItemRoles(w http.ResponseWriter, r *http.Request, id string, params resources.GetGroupsItemRolesParams) {
+	v.validatePagination(&params.Size, params.Page, params.NextToken, params.NextPageToken, w, r, func(w http.ResponseWriter, r *http.Request) {
+		v.ServerInterface.GetGroupsItemRoles(w, r, id, params)
 	})
 }
 
@@ -61,6 +109,14 @@ func (v handlerWithValidation) PatchGroupsItemIdentities(w http.ResponseWriter,
 func (v handlerWithValidation) PatchGroupsItemRoles(w http.ResponseWriter, r *http.Request, id string) {
 	body := &resources.GroupRolesPatchRequestBody{}
 	v.validateRequestBody(body, w, r, func(w http.ResponseWriter, r *http.Request) {
-		v.ServerInterface.PatchGroupsItemRoles(w, r, id)
+		added := make([]string, 0, len(body.Patches))
+		for _, p := range body.Patches {
+			if p.Op == resources.GroupRolesPatchItemOpAdd {
+				added = append(added, p.Role)
+			}
+		}
+		v.validateReferences(v.references.groupRefs(id), v.references.roleRefs(added...), w, r, func(w http.ResponseWriter, r *http.Request) {
+			v.ServerInterface.PatchGroupsItemRoles(w, r, id)
+		})
 	})
 }
@@ -353,7 +353,7 @@ func TestHandlerWithValidation_Groups(t *testing.T) {
 				tt.setupHandlerMock(mockHandler)
 			}
 
-			sut := newHandlerWithValidation(mockHandler)
+			sut := newHandlerWithValidation(mockHandler, RequestDecodingPolicy{}, PaginationPolicy{})
 
 			var req *http.Request
 			if tt.requestBody != nil {
@@ -17,6 +17,7 @@ package v1
 
 import (
 	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
 )
 
 // handler is the innermost handler that calls the methods defined on the
@@ -24,12 +25,28 @@ import (
 type handler struct {
 	Identities            interfaces.IdentitiesService
 	IdentitiesErrorMapper ErrorResponseMapper
+	IdentitiesVersioner   interfaces.EntityVersioner
+
+	// IdentitiesEffectiveEntitlements, if set, computes the effective
+	// entitlements of identities; otherwise, they are composed from the
+	// relations of identities, groups and roles.
+	IdentitiesEffectiveEntitlements interfaces.EffectiveEntitlementsResolver
+
+	// IdentitiesPatchReporter, if set, patches the relationships of identities
+	// and reports the outcome of each patch item.
+	IdentitiesPatchReporter interfaces.IdentitiesPatchReporter
 
 	Roles            interfaces.RolesService
 	RolesErrorMapper ErrorResponseMapper
+	RolesVersioner   interfaces.EntityVersioner
+
+	// RolesPatchReporter, if set, patches the relationships of roles and
+	// reports the outcome of each patch item.
+	RolesPatchReporter interfaces.RolesPatchReporter
 
 	IdentityProviders            interfaces.IdentityProvidersService
 	IdentityProvidersErrorMapper ErrorResponseMapper
+	IdentityProvidersVersioner   interfaces.EntityVersioner
 
 	Capabilities            interfaces.CapabilitiesService
 	CapabilitiesErrorMapper ErrorResponseMapper
@@ -39,7 +56,38 @@ type handler struct {
 
 	Groups            interfaces.GroupsService
 	GroupsErrorMapper ErrorResponseMapper
+	GroupsVersioner   interfaces.EntityVersioner
+
+	// GroupsPatchReporter, if set, patches the relationships of groups and
+	// reports the outcome of each patch item.
+	GroupsPatchReporter interfaces.GroupsPatchReporter
 
 	Resources            interfaces.ResourcesService
 	ResourcesErrorMapper ErrorResponseMapper
+
+	// ResourcesAccess, if set, lists who has access to a resource.
+	ResourcesAccess interfaces.ResourceAccessLister
+
+	Bulk            interfaces.BulkService
+	BulkErrorMapper ErrorResponseMapper
+
+	// BulkFallback, if set, serves the operations of bulk requests one by one,
+	// when there's no bulk service backend. It should be the handler that
+	// validates the requests of the equivalent endpoints.
+	BulkFallback resources.ServerInterface
+
+	AccessCheck            interfaces.AccessCheckService
+	AccessCheckErrorMapper ErrorResponseMapper
+
+	// RequireIfMatch determines whether the `If-Match` header is required for
+	// `Put*Item` and `Delete*Item` operations.
+	RequireIfMatch bool
+
+	// CacheControl determines the `Cache-Control` header of the responses to
+	// rarely changing endpoints (e.g., `GET /capabilities`).
+	CacheControl CacheControlPolicy
+
+	// Responses, if set, caches the results of the services behind rarely
+	// changing endpoints, along with their `Last-Modified` times.
+	Responses *responseCache
 }
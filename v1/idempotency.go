@@ -0,0 +1,216 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+const (
+	// idempotencyKeyHeader is the request header that carries the idempotency
+	// key of creation requests.
+	idempotencyKeyHeader = "Idempotency-Key"
+
+	// idempotentReplayedHeader is the response header that marks replayed
+	// responses.
+	idempotentReplayedHeader = "Idempotent-Replayed"
+
+	// maxIdempotencyKeyLength is the maximum length of idempotency keys.
+	maxIdempotencyKeyLength = 255
+)
+
+// handlerWithIdempotency decorates a given handler with idempotency logic.
+// The responses of creation requests (i.e., `Post*` operations) made with an
+// `Idempotency-Key` header are recorded in the provided store, and replayed
+// for retries with the same key.
+type handlerWithIdempotency struct {
+	// Wrapped/decorated handler
+	resources.ServerInterface
+
+	store    interfaces.IdempotencyStore
+	decoding RequestDecodingPolicy
+}
+
+// newHandlerWithIdempotency returns a new instance of the handlerWithIdempotency struct.
+func newHandlerWithIdempotency(handler resources.ServerInterface, store interfaces.IdempotencyStore, decoding RequestDecodingPolicy) *handlerWithIdempotency {
+	return &handlerWithIdempotency{
+		ServerInterface: handler,
+		store:           store,
+		decoding:        decoding,
+	}
+}
+
+// idempotent is a helper method to avoid repetition. If the request has an
+// `Idempotency-Key` header, it reserves the key (scoped to the operation and
+// the caller identity) for
+// the hash of the request body, delegates to the provided callback, and records
+// the response. If the key is already in use, it responds with:
+//   - the recorded response, if the request body is the same.
+//   - 422 Unprocessable Entity, if the request body is different.
+//   - 409 Conflict, if the original request is still being handled.
+//
+// Server errors (i.e., 5xx status codes) and panics are not recorded, so that
+// the request can be retried.
+func (h handlerWithIdempotency) idempotent(w http.ResponseWriter, r *http.Request, operationId string, f func(w http.ResponseWriter, r *http.Request)) {
+	key := r.Header.Get(idempotencyKeyHeader)
+	if key == "" {
+		f(w, r)
+		return
+	}
+	if len(key) > maxIdempotencyKeyLength {
+		writeErrorResponse(w, NewHeaderValidationError(idempotencyKeyHeader, fmt.Sprintf("must not be longer than %d characters", maxIdempotencyKeyLength)))
+		return
+	}
+
+	// The body is read up to the size limit (plus one byte, so that the
+	// validator can tell it's too large), and then restored for the inner
+	// handlers.
+	var reader io.Reader = r.Body
+	if maxBodySize := h.decoding.maxBodySize(); maxBodySize > 0 {
+		reader = io.LimitReader(r.Body, maxBodySize+1)
+	}
+	body, err := io.ReadAll(reader)
+	r.Body.Close()
+	if err != nil {
+		writeErrorResponse(w, NewValidationError(fmt.Sprintf("failed to read request body: %s", err)))
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	hash := sha256.Sum256(body)
+	requestHash := hex.EncodeToString(hash[:])
+	ctx := r.Context()
+	storeKey := operationId + ":" + callerScope(ctx) + ":" + key
+
+	record, err := h.store.Reserve(ctx, storeKey, requestHash)
+	if err != nil {
+		writeServiceErrorResponse(w, nil, err)
+		return
+	}
+	if record != nil {
+		switch {
+		case record.RequestHash != requestHash:
+			writeErrorResponse(w, NewUnprocessableEntityError(fmt.Sprintf("idempotency key %q was used with a different request body", key)))
+		case !record.Completed:
+			writeErrorResponse(w, NewConflictError(fmt.Sprintf("a request with idempotency key %q is in progress", key)))
+		default:
+			setAuditReplayed(ctx)
+			replayIdempotentResponse(w, record)
+		}
+		return
+	}
+
+	// If the handler panics, the key is released; otherwise, retries would be
+	// rejected as in progress until the key expires.
+	recorded := false
+	defer func() {
+		if recorded {
+			return
+		}
+		if err := h.store.Release(ctx, storeKey); err != nil {
+			getLogger(w).Error("failed to release idempotency key", "operation", operationId, "error", err)
+		}
+	}()
+
+	bw := newBufferedResponseWriter(w)
+	f(bw, r)
+
+	status := bw.responseStatus()
+	if status < http.StatusInternalServerError {
+		recorded = true
+		err := h.store.Complete(ctx, storeKey, resources.IdempotencyRecord{
+			RequestHash: requestHash,
+			Completed:   true,
+			Status:      status,
+			Header:      bw.header.Clone(),
+			Body:        bytes.Clone(bw.body.Bytes()),
+		})
+		if err != nil {
+			getLogger(w).Error("failed to record idempotent response", "operation", operationId, "error", err)
+		}
+	}
+	bw.flush()
+}
+
+// replayIdempotentResponse writes the given recorded response to the HTTP
+// response stream.
+func replayIdempotentResponse(w http.ResponseWriter, record *resources.IdempotencyRecord) {
+	header := w.Header()
+	for k, v := range record.Header {
+		header[k] = v
+	}
+	header.Set(idempotentReplayedHeader, "true")
+	w.WriteHeader(record.Status)
+	if _, err := w.Write(record.Body); err != nil {
+		getLogger(w).Error("failed to write response body", "error", err)
+	}
+}
+
+// PostIdentityProviders handles idempotency keys and delegates the call to the wrapped handler's `PostIdentityProviders` method.
+func (h handlerWithIdempotency) PostIdentityProviders(w http.ResponseWriter, r *http.Request) {
+	h.idempotent(w, r, "PostIdentityProviders", func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PostIdentityProviders(w, r)
+	})
+}
+
+// PostGroups handles idempotency keys and delegates the call to the wrapped handler's `PostGroups` method.
+func (h handlerWithIdempotency) PostGroups(w http.ResponseWriter, r *http.Request) {
+	h.idempotent(w, r, "PostGroups", func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PostGroups(w, r)
+	})
+}
+
+// PostIdentities handles idempotency keys and delegates the call to the wrapped handler's `PostIdentities` method.
+func (h handlerWithIdempotency) PostIdentities(w http.ResponseWriter, r *http.Request) {
+	h.idempotent(w, r, "PostIdentities", func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PostIdentities(w, r)
+	})
+}
+
+// PostRoles handles idempotency keys and delegates the call to the wrapped handler's `PostRoles` method.
+func (h handlerWithIdempotency) PostRoles(w http.ResponseWriter, r *http.Request) {
+	h.idempotent(w, r, "PostRoles", func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PostRoles(w, r)
+	})
+}
+
+// PostBulk delegates the call to the wrapped handler's `PostBulk` method.
+func (h handlerWithIdempotency) PostBulk(w http.ResponseWriter, r *http.Request) {
+	extensionsOf(h.ServerInterface).PostBulk(w, r)
+}
+
+// PostCheck delegates the call to the wrapped handler's `PostCheck` method.
+func (h handlerWithIdempotency) PostCheck(w http.ResponseWriter, r *http.Request) {
+	extensionsOf(h.ServerInterface).PostCheck(w, r)
+}
+
+// GetIdentitiesItemEffectiveEntitlements delegates the call to the wrapped handler's `GetIdentitiesItemEffectiveEntitlements` method.
+func (h handlerWithIdempotency) GetIdentitiesItemEffectiveEntitlements(w http.ResponseWriter, r *http.Request, id string) {
+	extensionsOf(h.ServerInterface).GetIdentitiesItemEffectiveEntitlements(w, r, id)
+}
+
+// GetResourcesItemAccess delegates the call to the wrapped handler's `GetResourcesItemAccess` method.
+func (h handlerWithIdempotency) GetResourcesItemAccess(w http.ResponseWriter, r *http.Request, pType string, id string, params resources.GetResourcesItemAccessParams) {
+	extensionsOf(h.ServerInterface).GetResourcesItemAccess(w, r, pType, id, params)
+}
@@ -0,0 +1,129 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// DefaultIdempotencyKeyTTL is the time to keep idempotency records for, used
+// when `MemoryIdempotencyStoreParams.TTL` is not set.
+const DefaultIdempotencyKeyTTL = 24 * time.Hour
+
+// MemoryIdempotencyStoreParams holds the parameters of a MemoryIdempotencyStore.
+type MemoryIdempotencyStoreParams struct {
+	// TTL is the time to keep records for, after the key is reserved. If zero,
+	// the `DefaultIdempotencyKeyTTL` is used.
+	TTL time.Duration
+}
+
+// MemoryIdempotencyStore is an in-memory implementation of the
+// `IdempotencyStore` interface. As the records are not shared, it's only
+// suitable for a single replica of the service.
+type MemoryIdempotencyStore struct {
+	ttl time.Duration
+
+	// now returns the current time; it's replaced in tests.
+	now func() time.Time
+
+	mutex     sync.Mutex
+	records   map[string]memoryIdempotencyEntry
+	nextSweep time.Time
+}
+
+// memoryIdempotencyEntry is a record of a MemoryIdempotencyStore, along with
+// its expiry time.
+type memoryIdempotencyEntry struct {
+	record    resources.IdempotencyRecord
+	expiresAt time.Time
+}
+
+// For doc/test sake, to hint that the struct needs to implement a specific interface.
+var _ interfaces.IdempotencyStore = &MemoryIdempotencyStore{}
+
+// NewMemoryIdempotencyStore returns a new MemoryIdempotencyStore instance.
+func NewMemoryIdempotencyStore(params MemoryIdempotencyStoreParams) *MemoryIdempotencyStore {
+	ttl := params.TTL
+	if ttl == 0 {
+		ttl = DefaultIdempotencyKeyTTL
+	}
+	return &MemoryIdempotencyStore{
+		ttl:     ttl,
+		now:     time.Now,
+		records: map[string]memoryIdempotencyEntry{},
+	}
+}
+
+// Reserve implements the `IdempotencyStore` interface.
+func (s *MemoryIdempotencyStore) Reserve(ctx context.Context, key string, requestHash string) (*resources.IdempotencyRecord, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+	if entry, ok := s.records[key]; ok && now.Before(entry.expiresAt) {
+		record := entry.record
+		return &record, nil
+	}
+	s.records[key] = memoryIdempotencyEntry{
+		record:    resources.IdempotencyRecord{RequestHash: requestHash},
+		expiresAt: now.Add(s.ttl),
+	}
+	return nil, nil
+}
+
+// Complete implements the `IdempotencyStore` interface.
+func (s *MemoryIdempotencyStore) Complete(ctx context.Context, key string, record resources.IdempotencyRecord) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entry, ok := s.records[key]
+	if !ok {
+		// The reservation has expired in the meantime.
+		entry.expiresAt = s.now().Add(s.ttl)
+	}
+	entry.record = record
+	s.records[key] = entry
+	return nil
+}
+
+// Release implements the `IdempotencyStore` interface.
+func (s *MemoryIdempotencyStore) Release(ctx context.Context, key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
+
+// sweep removes the expired records, at most once a minute (or once per TTL,
+// if shorter). It should be called while holding the lock.
+func (s *MemoryIdempotencyStore) sweep(now time.Time) {
+	if now.Before(s.nextSweep) {
+		return
+	}
+	for key, entry := range s.records {
+		if !now.Before(entry.expiresAt) {
+			delete(s.records, key)
+		}
+	}
+	s.nextSweep = now.Add(min(s.ttl, time.Minute))
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+func TestMemoryIdempotencyStore(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	store := NewMemoryIdempotencyStore(MemoryIdempotencyStoreParams{TTL: time.Hour})
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	record, err := store.Reserve(ctx, "foo", "hash")
+	c.Assert(err, qt.IsNil)
+	c.Assert(record, qt.IsNil)
+
+	// The key is reserved until the request is completed.
+	record, err = store.Reserve(ctx, "foo", "other-hash")
+	c.Assert(err, qt.IsNil)
+	c.Assert(record, qt.DeepEquals, &resources.IdempotencyRecord{RequestHash: "hash"})
+
+	completed := resources.IdempotencyRecord{
+		RequestHash: "hash",
+		Completed:   true,
+		Status:      http.StatusCreated,
+		Header:      http.Header{"Content-Type": {"application/json"}},
+		Body:        []byte(`{}`),
+	}
+	err = store.Complete(ctx, "foo", completed)
+	c.Assert(err, qt.IsNil)
+	record, err = store.Reserve(ctx, "foo", "hash")
+	c.Assert(err, qt.IsNil)
+	c.Assert(record, qt.DeepEquals, &completed)
+
+	// Released keys can be reserved again.
+	_, err = store.Reserve(ctx, "bar", "hash")
+	c.Assert(err, qt.IsNil)
+	err = store.Release(ctx, "bar")
+	c.Assert(err, qt.IsNil)
+	record, err = store.Reserve(ctx, "bar", "hash")
+	c.Assert(err, qt.IsNil)
+	c.Assert(record, qt.IsNil)
+
+	// Records expire after the TTL.
+	now = now.Add(time.Hour - time.Second)
+	record, err = store.Reserve(ctx, "foo", "hash")
+	c.Assert(err, qt.IsNil)
+	c.Assert(record, qt.IsNotNil)
+
+	now = now.Add(time.Second)
+	record, err = store.Reserve(ctx, "foo", "other-hash")
+	c.Assert(err, qt.IsNil)
+	c.Assert(record, qt.IsNil)
+
+	// Expired records are swept at most once a minute.
+	c.Assert(store.records, qt.HasLen, 2)
+	now = now.Add(time.Minute)
+	_, err = store.Reserve(ctx, "baz", "hash")
+	c.Assert(err, qt.IsNil)
+	c.Assert(store.records, qt.HasLen, 2)
+	_, ok := store.records["bar"]
+	c.Assert(ok, qt.IsFalse)
+}
@@ -0,0 +1,225 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+func TestHandlerWithIdempotency(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	groups := interfaces.NewMockGroupsService(ctrl)
+	roles := interfaces.NewMockRolesService(ctrl)
+	store := NewMemoryIdempotencyStore(MemoryIdempotencyStoreParams{})
+	sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+		Groups:           groups,
+		Roles:            roles,
+		IdempotencyStore: store,
+		RequestDecoding:  RequestDecodingPolicy{MaxBodySize: 64},
+	})
+	handler := sut.Handler("")
+
+	post := func(path, key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Set("Idempotency-Key", key)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	createGroup := func(ctx context.Context, group *resources.Group) (*resources.Group, error) {
+		return &resources.Group{Id: &group.Name, Name: group.Name}, nil
+	}
+
+	// Without a key, every request is handled.
+	groups.EXPECT().CreateGroup(gomock.Any(), gomock.Any()).DoAndReturn(createGroup).Times(2)
+	c.Assert(post("/v1/groups", "", `{"name":"foo"}`).Code, qt.Equals, http.StatusCreated)
+	c.Assert(post("/v1/groups", "", `{"name":"foo"}`).Code, qt.Equals, http.StatusCreated)
+
+	// With a key, retries are answered with the recorded response.
+	groups.EXPECT().CreateGroup(gomock.Any(), gomock.Any()).DoAndReturn(createGroup)
+	w := post("/v1/groups", "key-1", `{"name":"foo"}`)
+	c.Assert(w.Code, qt.Equals, http.StatusCreated)
+	c.Assert(w.Header().Get("Idempotent-Replayed"), qt.Equals, "")
+	body := w.Body.String()
+
+	w = post("/v1/groups", "key-1", `{"name":"foo"}`)
+	c.Assert(w.Code, qt.Equals, http.StatusCreated)
+	c.Assert(w.Header().Get("Idempotent-Replayed"), qt.Equals, "true")
+	c.Assert(w.Header().Get("Content-Type"), qt.Equals, "application/json")
+	c.Assert(w.Body.String(), qt.Equals, body)
+
+	// Reusing the key with a different body is rejected.
+	w = post("/v1/groups", "key-1", `{"name":"bar"}`)
+	c.Assert(w.Code, qt.Equals, http.StatusUnprocessableEntity)
+	c.Assert(w.Body.String(), qt.JSONEquals, resources.Response{
+		Message: `Unprocessable Entity: idempotency key "key-1" was used with a different request body`,
+		Status:  http.StatusUnprocessableEntity,
+	})
+
+	// Keys are scoped to the operation.
+	roles.EXPECT().CreateRole(gomock.Any(), gomock.Any()).Return(&resources.Role{Name: "foo"}, nil)
+	c.Assert(post("/v1/roles", "key-1", `{"name":"foo"}`).Code, qt.Equals, http.StatusCreated)
+
+	// Server errors are not recorded, so the request can be retried.
+	groups.EXPECT().CreateGroup(gomock.Any(), gomock.Any()).Return(nil, errors.New("test-error"))
+	c.Assert(post("/v1/groups", "key-2", `{"name":"foo"}`).Code, qt.Equals, http.StatusInternalServerError)
+	groups.EXPECT().CreateGroup(gomock.Any(), gomock.Any()).DoAndReturn(createGroup)
+	c.Assert(post("/v1/groups", "key-2", `{"name":"foo"}`).Code, qt.Equals, http.StatusCreated)
+
+	// Client errors are recorded, though.
+	w = post("/v1/groups", "key-3", `{"name":`)
+	c.Assert(w.Code, qt.Equals, http.StatusBadRequest)
+	w = post("/v1/groups", "key-3", `{"name":`)
+	c.Assert(w.Code, qt.Equals, http.StatusBadRequest)
+	c.Assert(w.Header().Get("Idempotent-Replayed"), qt.Equals, "true")
+
+	// Concurrent requests with the same key are rejected.
+	hash := sha256.Sum256([]byte(`{"name":"foo"}`))
+	_, err := store.Reserve(context.Background(), "PostGroups::key-4", hex.EncodeToString(hash[:]))
+	c.Assert(err, qt.IsNil)
+	w = post("/v1/groups", "key-4", `{"name":"foo"}`)
+	c.Assert(w.Code, qt.Equals, http.StatusConflict)
+	c.Assert(w.Body.String(), qt.JSONEquals, resources.Response{
+		Message: `Conflict: a request with idempotency key "key-4" is in progress`,
+		Status:  http.StatusConflict,
+	})
+
+	// The size limit of request bodies still applies.
+	w = post("/v1/groups", "key-5", `{"name":"`+strings.Repeat("x", 64)+`"}`)
+	c.Assert(w.Code, qt.Equals, http.StatusRequestEntityTooLarge)
+
+	w = post("/v1/groups", strings.Repeat("x", 256), `{"name":"foo"}`)
+	c.Assert(w.Code, qt.Equals, http.StatusBadRequest)
+	c.Assert(w.Body.String(), qt.JSONEquals, resources.Response{
+		Message: `Bad Request: invalid header "Idempotency-Key": must not be longer than 255 characters`,
+		Status:  http.StatusBadRequest,
+	})
+}
+
+func TestHandlerWithIdempotency_KeysAreScopedToCallers(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	groups := interfaces.NewMockGroupsService(ctrl)
+	groups.EXPECT().CreateGroup(gomock.Any(), gomock.Any()).Return(&resources.Group{Name: "foo"}, nil).Times(2)
+
+	sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+		Groups:           groups,
+		IdempotencyStore: NewMemoryIdempotencyStore(MemoryIdempotencyStoreParams{}),
+	})
+	handler := sut.Handler("")
+
+	for _, identity := range []string{"alice", "bob"} {
+		req := httptest.NewRequest(http.MethodPost, "/v1/groups", strings.NewReader(`{"name":"foo"}`))
+		req = req.WithContext(ContextWithIdentity(req.Context(), identity))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", "key-1")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		c.Assert(w.Code, qt.Equals, http.StatusCreated)
+		c.Assert(w.Header().Get("Idempotent-Replayed"), qt.Equals, "")
+	}
+}
+
+func TestHandlerWithIdempotency_ReplaysAreAudited(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	groups := interfaces.NewMockGroupsService(ctrl)
+	groups.EXPECT().CreateGroup(gomock.Any(), gomock.Any()).Return(&resources.Group{Name: "foo"}, nil)
+
+	sink := interfaces.NewMockAuditSink(ctrl)
+	gomock.InOrder(
+		sink.EXPECT().Record(gomock.Any(), gomock.Cond(func(x any) bool {
+			return !x.(interfaces.AuditEvent).Replayed
+		})),
+		sink.EXPECT().Record(gomock.Any(), gomock.Cond(func(x any) bool {
+			return x.(interfaces.AuditEvent).Replayed
+		})),
+	)
+
+	sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+		Groups:           groups,
+		AuditSink:        sink,
+		IdempotencyStore: NewMemoryIdempotencyStore(MemoryIdempotencyStoreParams{}),
+	})
+	handler := sut.Handler("")
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/v1/groups", strings.NewReader(`{"name":"foo"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", "key-1")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		c.Assert(w.Code, qt.Equals, http.StatusCreated)
+	}
+}
+
+func TestHandlerWithIdempotency_PanicsReleaseTheKey(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	groups := interfaces.NewMockGroupsService(ctrl)
+	gomock.InOrder(
+		groups.EXPECT().CreateGroup(gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, *resources.Group) (*resources.Group, error) {
+			panic("test-panic")
+		}),
+		groups.EXPECT().CreateGroup(gomock.Any(), gomock.Any()).Return(&resources.Group{Name: "foo"}, nil),
+	)
+
+	sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+		Groups:           groups,
+		IdempotencyStore: NewMemoryIdempotencyStore(MemoryIdempotencyStoreParams{}),
+	})
+	handler := sut.Handler("")
+
+	post := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/groups", strings.NewReader(`{"name":"foo"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", "key-1")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	c.Assert(func() { post() }, qt.PanicMatches, "test-panic")
+
+	// The retry is handled, rather than rejected as in progress.
+	c.Assert(post().Code, qt.Equals, http.StatusCreated)
+}
@@ -73,6 +73,10 @@ func (h handler) PostIdentities(w http.ResponseWriter, req *http.Request) {
 func (h handler) DeleteIdentitiesItem(w http.ResponseWriter, req *http.Request, id string) {
 	ctx := req.Context()
 
+	if !h.identitiesTagger().checkPreconditions(w, req, id) {
+		return
+	}
+
 	_, err := h.Identities.DeleteIdentity(ctx, id)
 	if err != nil {
 		writeServiceErrorResponse(w, h.IdentitiesErrorMapper, err)
@@ -93,6 +97,7 @@ func (h handler) GetIdentitiesItem(w http.ResponseWriter, req *http.Request, id
 		return
 	}
 
+	h.identitiesTagger().setTag(w, req, id, identity)
 	writeResponse(w, http.StatusOK, identity)
 }
 
@@ -113,12 +118,17 @@ func (h handler) PutIdentitiesItem(w http.ResponseWriter, req *http.Request, id
 		return
 	}
 
+	if !h.identitiesTagger().checkPreconditions(w, req, id) {
+		return
+	}
+
 	result, err := h.Identities.UpdateIdentity(ctx, identity)
 	if err != nil {
 		writeServiceErrorResponse(w, h.IdentitiesErrorMapper, err)
 		return
 	}
 
+	h.identitiesTagger().setTag(w, req, id, result)
 	writeResponse(w, http.StatusOK, result)
 }
 
@@ -160,6 +170,16 @@ func (h handler) PatchIdentitiesItemEntitlements(w http.ResponseWriter, req *htt
 		return
 	}
 
+	if h.IdentitiesPatchReporter != nil {
+		results, err := h.IdentitiesPatchReporter.PatchIdentityEntitlementsWithResults(ctx, id, identityEntitlements.Patches)
+		if err != nil {
+			writeServiceErrorResponse(w, h.IdentitiesErrorMapper, err)
+			return
+		}
+		writePatchResults(w, h.IdentitiesErrorMapper, len(identityEntitlements.Patches), results)
+		return
+	}
+
 	_, err = h.Identities.PatchIdentityEntitlements(ctx, id, identityEntitlements.Patches)
 	if err != nil {
 		writeServiceErrorResponse(w, h.IdentitiesErrorMapper, err)
@@ -207,6 +227,16 @@ func (h handler) PatchIdentitiesItemGroups(w http.ResponseWriter, req *http.Requ
 		return
 	}
 
+	if h.IdentitiesPatchReporter != nil {
+		results, err := h.IdentitiesPatchReporter.PatchIdentityGroupsWithResults(ctx, id, identityGroups.Patches)
+		if err != nil {
+			writeServiceErrorResponse(w, h.IdentitiesErrorMapper, err)
+			return
+		}
+		writePatchResults(w, h.IdentitiesErrorMapper, len(identityGroups.Patches), results)
+		return
+	}
+
 	_, err = h.Identities.PatchIdentityGroups(ctx, id, identityGroups.Patches)
 	if err != nil {
 		writeServiceErrorResponse(w, h.IdentitiesErrorMapper, err)
@@ -254,6 +284,16 @@ func (h handler) PatchIdentitiesItemRoles(w http.ResponseWriter, req *http.Reque
 		return
 	}
 
+	if h.IdentitiesPatchReporter != nil {
+		results, err := h.IdentitiesPatchReporter.PatchIdentityRolesWithResults(ctx, id, identityRoles.Patches)
+		if err != nil {
+			writeServiceErrorResponse(w, h.IdentitiesErrorMapper, err)
+			return
+		}
+		writePatchResults(w, h.IdentitiesErrorMapper, len(identityRoles.Patches), results)
+		return
+	}
+
 	_, err = h.Identities.PatchIdentityRoles(ctx, id, identityRoles.Patches)
 	if err != nil {
 		writeServiceErrorResponse(w, h.IdentitiesErrorMapper, err)
@@ -21,6 +21,17 @@ import (
 	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
 )
 
+// GetIdentities validates the pagination parameters, filter and sort order of the GetIdentities method and delegates to the underlying handler.
+func (v handlerWithValidation) GetIdentities(w http.ResponseWriter, r *http.Request, params resources.GetIdentitiesParams) {
+	v.validatePagination(&params.Size, params.Page, params.NextToken, params.NextPageToken, w, r, func(w http.ResponseWriter, r *http.Request) {
+		v.validateFilter(params.Filter, resources.Identity{}, w, r, func(w http.ResponseWriter, r *http.Request) {
+			v.validateSort(identitiesSortableFields, w, r, func(w http.ResponseWriter, r *http.Request) {
+				v.ServerInterface.GetIdentities(w, r, params)
+			})
+		})
+	})
+}
+
 // PostIdentities validates request body for the PostIdentities method and delegates to the underlying handler.
 func (v handlerWithValidation) PostIdentities(w http.ResponseWriter, r *http.Request) {
 	body := &resources.Identity{}
@@ -41,11 +52,33 @@ func (v handlerWithValidation) PutIdentitiesItem(w http.ResponseWriter, r *http.
 	})
 }
 
+// GetIdentitiesItemEntitlements validates the pagination parameters of the GetIdentitiesItemEntitlements method and delegates to the underlying handler.
+func (v handlerWithValidation) GetIdentitiesItemEntitlements(w http.ResponseWriter, r *http.Request, id string, params resources.GetIdentitiesItemEntitlementsParams) {
+	v.validatePagination(&params.Size, params.Page, params.NextToken, params.NextPageToken, w, r, func(w http.ResponseWriter, r *http.Request) {
+		v.ServerInterface.GetIdentitiesItemEntitlements(w, r, id, params)
+	})
+}
+
 // PatchIdentitiesItemEntitlements validates request body for the PatchIdentitiesItemEntitlements method and delegates to the underlying handler.
 func (v handlerWithValidation) PatchIdentitiesItemEntitlements(w http.ResponseWriter, r *http.Request, id string) {
 	body := &resources.IdentityEntitlementsPatchRequestBody{}
 	v.validateRequestBody(body, w, r, func(w http.ResponseWriter, r *http.Request) {
-		v.ServerInterface.PatchIdentitiesItemEntitlements(w, r, id)
+		entitlements := make([]addedEntitlement, 0, len(body.Patches))
+		for i, p := range body.Patches {
+			if p.Op == resources.IdentityEntitlementsPatchItemOpAdd {
+				entitlements = append(entitlements, addedEntitlement{index: i, entitlement: p.Entitlement})
+			}
+		}
+		v.validateEntitlementPatches(entitlementReceiverTypeIdentity, entitlements, w, r, func(w http.ResponseWriter, r *http.Request) {
+			v.ServerInterface.PatchIdentitiesItemEntitlements(w, r, id)
+		})
+	})
+}
+
+// GetIdentitiesItemGroups validates the pagination parameters of the GetIdentitiesItemGroups method and delegates to the underlying handler.
+func (v handlerWithValidation) GetIdentitiesItemGroups(w http.ResponseWriter, r *http.Request, id string, params resources.GetIdentitiesItemGroupsParams) {
+	v.validatePagination(&params.Size, params.Page, params.NextToken, params.NextPageToken, w, r, func(w http.ResponseWriter, r *http.Request) {
+		v.ServerInterface.GetIdentitiesItemGroups(w, r, id, params)
 	})
 }
 
@@ -53,7 +86,22 @@ func (v handlerWithValidation) PatchIdentitiesItemEntitlements(w http.ResponseWr
 func (v handlerWithValidation) PatchIdentitiesItemGroups(w http.ResponseWriter, r *http.Request, id string) {
 	body := &resources.IdentityGroupsPatchRequestBody{}
 	v.validateRequestBody(body, w, r, func(w http.ResponseWriter, r *http.Request) {
-		v.ServerInterface.PatchIdentitiesItemGroups(w, r, id)
+		added := make([]string, 0, len(body.Patches))
+		for _, p := range body.Patches {
+			if p.Op == resources.IdentityGroupsPatchItemOpAdd {
+				added = append(added, p.Group)
+			}
+		}
+		v.validateReferences(v.references.identityRefs(id), v.references.groupRefs(added...), w, r, func(w http.ResponseWriter, r *http.Request) {
+			v.ServerInterface.PatchIdentitiesItemGroups(w, r, id)
+		})
+	})
+}
+
+// GetIdentitiesItemRoles validates the pagination parameters of the GetIdentitiesItemRoles method and delegates to the underlying handler.
+func (v handlerWithValidation) GetIdentitiesItemRoles(w http.ResponseWriter, r *http.Request, id string, params resources.GetIdentitiesItemRolesParams) {
+	v.validatePagination(&params.Size, params.Page, params.NextToken, params.NextPageToken, w, r, func(w http.ResponseWriter, r *http.Request) {
+		v.ServerInterface.GetIdentitiesItemRoles(w, r, id, params)
 	})
 }
 
@@ -61,6 +109,19 @@ func (v handlerWithValidation) PatchIdentitiesItemGroups(w http.ResponseWriter,
 func (v handlerWithValidation) PatchIdentitiesItemRoles(w http.ResponseWriter, r *http.Request, id string) {
 	body := &resources.IdentityRolesPatchRequestBody{}
 	v.validateRequestBody(body, w, r, func(w http.ResponseWriter, r *http.Request) {
-		v.ServerInterface.PatchIdentitiesItemRoles(w, r, id)
+		added := make([]string, 0, len(body.Patches))
+		for _, p := range body.Patches {
+			if p.Op == resources.IdentityRolesPatchItemOpAdd {
+				added = append(added, p.Role)
+			}
+		}
+		v.validateReferences(v.references.identityRefs(id), v.references.roleRefs(added...), w, r, func(w http.ResponseWriter, r *http.Request) {
+			v.ServerInterface.PatchIdentitiesItemRoles(w, r, id)
+		})
 	})
 }
+
+// GetIdentitiesItemEffectiveEntitlements delegates to the underlying handler, as there is no request body to validate.
+func (v handlerWithValidation) GetIdentitiesItemEffectiveEntitlements(w http.ResponseWriter, r *http.Request, id string) {
+	extensionsOf(v.ServerInterface).GetIdentitiesItemEffectiveEntitlements(w, r, id)
+}
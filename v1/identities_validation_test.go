@@ -374,7 +374,7 @@ func TestHandlerWithValidation_Identities(t *testing.T) {
 				tt.setupHandlerMock(mockHandler)
 			}
 
-			sut := newHandlerWithValidation(mockHandler)
+			sut := newHandlerWithValidation(mockHandler, RequestDecodingPolicy{}, PaginationPolicy{})
 
 			var req *http.Request
 			if tt.requestBody != nil {
@@ -96,6 +96,10 @@ func (h handler) PostIdentityProviders(w http.ResponseWriter, req *http.Request)
 func (h handler) DeleteIdentityProvidersItem(w http.ResponseWriter, req *http.Request, id string) {
 	ctx := req.Context()
 
+	if !h.identityProvidersTagger().checkPreconditions(w, req, id) {
+		return
+	}
+
 	_, err := h.IdentityProviders.DeleteConfiguration(ctx, id)
 	if err != nil {
 		writeServiceErrorResponse(w, h.IdentityProvidersErrorMapper, err)
@@ -116,6 +120,7 @@ func (h handler) GetIdentityProvidersItem(w http.ResponseWriter, req *http.Reque
 		return
 	}
 
+	h.identityProvidersTagger().setTag(w, req, id, identityProvider)
 	writeResponse(w, http.StatusOK, identityProvider)
 }
 
@@ -136,11 +141,16 @@ func (h handler) PutIdentityProvidersItem(w http.ResponseWriter, req *http.Reque
 		return
 	}
 
+	if !h.identityProvidersTagger().checkPreconditions(w, req, id) {
+		return
+	}
+
 	result, err := h.IdentityProviders.UpdateConfiguration(ctx, identityProvider)
 	if err != nil {
 		writeServiceErrorResponse(w, h.IdentityProvidersErrorMapper, err)
 		return
 	}
 
+	h.identityProvidersTagger().setTag(w, req, id, result)
 	writeResponse(w, http.StatusOK, result)
 }
@@ -21,6 +21,22 @@ import (
 	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
 )
 
+// GetAvailableIdentityProviders validates the pagination parameters of the GetAvailableIdentityProviders method and delegates to the underlying handler.
+func (v handlerWithValidation) GetAvailableIdentityProviders(w http.ResponseWriter, r *http.Request, params resources.GetAvailableIdentityProvidersParams) {
+	v.validatePagination(&params.Size, params.Page, params.NextToken, params.NextPageToken, w, r, func(w http.ResponseWriter, r *http.Request) {
+		v.ServerInterface.GetAvailableIdentityProviders(w, r, params)
+	})
+}
+
+// GetIdentityProviders validates the pagination parameters and sort order of the GetIdentityProviders method and delegates to the underlying handler.
+func (v handlerWithValidation) GetIdentityProviders(w http.ResponseWriter, r *http.Request, params resources.GetIdentityProvidersParams) {
+	v.validatePagination(&params.Size, params.Page, params.NextToken, params.NextPageToken, w, r, func(w http.ResponseWriter, r *http.Request) {
+		v.validateSort(identityProvidersSortableFields, w, r, func(w http.ResponseWriter, r *http.Request) {
+			v.ServerInterface.GetIdentityProviders(w, r, params)
+		})
+	})
+}
+
 // PostIdentityProviders validates request body for the PostIdentityProviders method and delegates to the underlying handler.
 func (v handlerWithValidation) PostIdentityProviders(w http.ResponseWriter, r *http.Request) {
 	body := &resources.IdentityProvider{}
@@ -128,7 +128,7 @@ func TestHandlerWithValidation_Authentication(t *testing.T) {
 				tt.setupHandlerMock(mockHandler)
 			}
 
-			sut := newHandlerWithValidation(mockHandler)
+			sut := newHandlerWithValidation(mockHandler, RequestDecodingPolicy{}, PaginationPolicy{})
 
 			var req *http.Request
 			if tt.requestBody != nil {
@@ -0,0 +1,36 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package interfaces
+
+import (
+	"context"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// AccessCheckService defines an abstract backend to check the access of
+// identities (e.g., via OpenFGA Check and Expand calls).
+//
+// This is optional. If not implemented, the `POST /check` endpoint responds
+// with a `501 Not Implemented` status code.
+type AccessCheckService interface {
+	// CheckAccess checks whether the identity identified by `identityId` has
+	// the given entitlement, either directly, or via its groups and roles. If
+	// `explain` is true, the paths that grant the entitlement should also be
+	// returned. If the identity does not exist, a not-found error should be
+	// returned.
+	CheckAccess(ctx context.Context, identityId string, entitlement resources.EntityEntitlement, explain bool) (*resources.AccessCheck, error)
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package interfaces
+
+import (
+	"context"
+	"time"
+)
+
+// AuditEvent represents a mutating operation (i.e., `Post*`, `Put*`,
+// `Delete*` and `Patch*` operations) requested via the API.
+type AuditEvent struct {
+	// Identity is the caller identity, as returned by the `Authenticator` (or
+	// associated with the request context via `v1.ContextWithIdentity`). It's
+	// nil if the caller identity is not known.
+	Identity any
+
+	// OperationId is the OpenAPI operation ID (e.g., `PatchGroupsItemIdentities`).
+	OperationId string
+
+	// TargetId is the ID of the target entity (e.g., the group ID), or empty
+	// for operations with no target entity (e.g., `PostGroups`).
+	TargetId string
+
+	// RequestBody is the parsed request body (e.g.,
+	// `*resources.GroupIdentitiesPatchRequestBody`). It's nil if the operation
+	// does not accept a request body, or if the request was rejected before
+	// the body was parsed and validated.
+	RequestBody any
+
+	// Status is the HTTP status code of the response. Failed operations can be
+	// distinguished by non-2xx status codes.
+	Status int
+
+	// Replayed reports whether the response was replayed from a previous
+	// request with the same `Idempotency-Key` header, in which case the
+	// operation was not performed again.
+	Replayed bool
+
+	// BulkIndex is the index of the operation in the `PostBulk` request it
+	// belongs to, or nil if the event does not represent an operation of a
+	// bulk request. Each operation of a bulk request is recorded with the
+	// operation ID, target entity ID and request body of its equivalent
+	// endpoint (e.g., `PatchGroupsItemIdentities`), after the event of the
+	// bulk request itself. Skipped operations are recorded with the `424
+	// Failed Dependency` status code.
+	BulkIndex *int
+
+	// Pending reports whether the event is recorded before the operation is
+	// performed, which only happens if the audit sink is configured to fail
+	// closed. Pending events have no request body or status; the outcome is
+	// recorded via a subsequent event.
+	Pending bool
+
+	// Timestamp is the time the request was received.
+	Timestamp time.Time
+}
+
+// AuditSink defines an abstract backend to record audit events of mutating
+// operations.
+type AuditSink interface {
+	// Record records the given audit event. It's called after the operation
+	// is handled, whether successful or not; and, if configured to fail
+	// closed, also before the operation is performed (see
+	// `AuditEvent.Pending`).
+	//
+	// If recording a pending event fails, the request fails with an internal
+	// server error, and the operation is not performed. Failures to record the
+	// outcome of an operation, which has already been performed, are only
+	// logged.
+	Record(ctx context.Context, event AuditEvent) error
+}
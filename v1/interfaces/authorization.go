@@ -0,0 +1,43 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package interfaces
+
+import "context"
+
+// Authorizer defines an abstract backend to perform authorization on requests,
+// before they are delegated to the `*Service` backends.
+type Authorizer interface {
+	// Authorize checks whether the caller is allowed to perform the given
+	// operation on the given target entities.
+	//
+	// The `identity` is the caller identity returned by the `Authenticator` (or
+	// associated with the request context via `ContextWithIdentity`). If there
+	// is no identity associated with the request, it will be nil.
+	//
+	// The `operationId` is the OpenAPI operation ID of the requested endpoint,
+	// which is the same as the corresponding method name on the
+	// `resources.ServerInterface` (e.g., `GetGroups` or `PatchIdentitiesItemRoles`).
+	//
+	// The `targetIds` contain the ID path parameter(s) of the requested
+	// endpoint (e.g., the group ID for `/groups/{id}`). For endpoints that do not
+	// have such parameters (e.g., `/groups`) it will be empty.
+	//
+	// If the caller is not allowed to perform the operation, the implementations
+	// should return (false, nil), which results in a `403 Forbidden` response.
+	// Returned errors are mapped to the HTTP response in the same way as the
+	// errors returned by the `*Service` backends.
+	Authorize(ctx context.Context, identity any, operationId string, targetIds []string) (bool, error)
+}
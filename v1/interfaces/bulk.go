@@ -0,0 +1,39 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package interfaces
+
+import (
+	"context"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// BulkService defines an abstract backend to execute a list of operations
+// atomically (e.g., in a single database transaction).
+//
+// This is optional. If not implemented, the `POST /bulk` endpoint is not
+// available, unless `ReBACAdminBackendParams.SequentialBulk` is set; in which
+// case, the operations are executed one by one via the other `*Service`
+// backends, and a failure halfway leaves the effects of the previous
+// operations in place.
+type BulkService interface {
+	// ExecuteBulk executes the given operations, in order, atomically. That is,
+	// either all operations take effect, or none do. On success, it returns the
+	// outcome of each operation, in the same order. If any operation fails, an
+	// error should be returned (e.g., a not-found error), which is used as the
+	// response of the whole request.
+	ExecuteBulk(ctx context.Context, operations []resources.BulkOperation) ([]resources.BulkOperationResult, error)
+}
@@ -0,0 +1,39 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package interfaces
+
+import (
+	"context"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// EffectiveEntitlementsResolver is an optional interface that can be
+// implemented by the `IdentitiesService` implementation to compute the
+// effective entitlements of identities natively (e.g., with a single query).
+//
+// If not implemented, the effective entitlements are composed from the
+// direct entitlements and roles of the identity, its groups, and the
+// entitlements and roles of its groups, which takes a few service calls per
+// group and role.
+type EffectiveEntitlementsResolver interface {
+	// GetIdentityEffectiveEntitlements returns the entitlements of identity
+	// `identityId`, including the ones inherited via its groups and roles.
+	// Each entitlement should appear once, along with all the paths that grant
+	// it. If the identity does not exist, the method should return a
+	// not-found error.
+	GetIdentityEffectiveEntitlements(ctx context.Context, identityId string) ([]resources.EffectiveEntitlement, error)
+}
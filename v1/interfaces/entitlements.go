@@ -24,6 +24,13 @@ import (
 // EntitlementsService defines an abstract backend to handle entitlement schema related operations.
 type EntitlementsService interface {
 	// ListEntitlements returns the list of entitlements in JSON format.
+	//
+	// The `receiver_type` field of each entry is the type of the entities the
+	// entitlement can be granted to. If entitlement schema validation is enabled
+	// (see `ReBACAdminBackendParams.EntitlementSchemaValidation`), it must be
+	// one of `identity`, `group` or `role`, as the entitlement patches of these
+	// entities only accept the entries of their own type; entries of other
+	// receiver types are ignored.
 	ListEntitlements(ctx context.Context, params *resources.GetEntitlementsParams) ([]resources.EntitlementSchema, error)
 
 	// RawEntitlements returns the list of entitlements as raw text.
@@ -25,6 +25,8 @@ import (
 type GroupsService interface {
 
 	// ListGroups returns a page of Group objects of at least `size` elements if available.
+	// The page is expected to be sorted by `sorting.FromContext(ctx)` (see the
+	// `sorting` package); sortable fields are `id` and `name`.
 	ListGroups(ctx context.Context, params *resources.GetGroupsParams) (*resources.PaginatedResponse[resources.Group], error)
 	// CreateGroup creates a single Group.
 	CreateGroup(ctx context.Context, group *resources.Group) (*resources.Group, error)
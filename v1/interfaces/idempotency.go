@@ -0,0 +1,45 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package interfaces
+
+import (
+	"context"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// IdempotencyStore is the interface to store the responses of creation requests
+// (i.e., `POST /groups`, `POST /roles`, `POST /identities` and
+// `POST /authentication`) made with an `Idempotency-Key` header, so that their
+// retries are answered with the recorded response, instead of being handled
+// again.
+//
+// Implementations should be safe for concurrent use, and are free to expire
+// records after a while (e.g., a day). In case of multiple replicas of the
+// service, the store should be shared among them.
+type IdempotencyStore interface {
+	// Reserve atomically reserves `key` for a request with the given body hash,
+	// and returns nil, if the key is not in use. Otherwise, it returns the
+	// existing record, which may not be completed yet.
+	Reserve(ctx context.Context, key string, requestHash string) (*resources.IdempotencyRecord, error)
+
+	// Complete stores the response of the request that reserved `key`.
+	Complete(ctx context.Context, key string, record resources.IdempotencyRecord) error
+
+	// Release removes the reservation of `key`, so that the request can be
+	// retried (e.g., after a server error).
+	Release(ctx context.Context, key string) error
+}
@@ -25,6 +25,9 @@ import (
 type IdentitiesService interface {
 
 	// ListIdentities returns a page of Identity objects of at least `size` elements if available
+	// The page is expected to be sorted by `sorting.FromContext(ctx)` (see the
+	// `sorting` package); sortable fields are `id`, `email`, `firstName`,
+	// `lastName`, `addedBy`, `source`, `joined` and `lastLogin`.
 	ListIdentities(ctx context.Context, params *resources.GetIdentitiesParams) (*resources.PaginatedResponse[resources.Identity], error)
 	// CreateIdentity creates a single Identity.
 	CreateIdentity(ctx context.Context, identity *resources.Identity) (*resources.Identity, error)
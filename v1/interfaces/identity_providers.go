@@ -28,6 +28,9 @@ type IdentityProvidersService interface {
 	ListAvailableIdentityProviders(ctx context.Context, params *resources.GetAvailableIdentityProvidersParams) (*resources.PaginatedResponse[resources.AvailableIdentityProvider], error)
 
 	// ListIdentityProviders returns a list of registered identity providers configurations.
+	// The page is expected to be sorted by `sorting.FromContext(ctx)` (see the
+	// `sorting` package); sortable fields are `id`, `name`, `enabled`
+	// and `identityCount`.
 	ListIdentityProviders(ctx context.Context, params *resources.GetIdentityProvidersParams) (*resources.PaginatedResponse[resources.IdentityProvider], error)
 
 	// RegisterConfiguration register a new authentication provider configuration.
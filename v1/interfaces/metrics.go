@@ -0,0 +1,43 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package interfaces
+
+import (
+	"context"
+	"time"
+)
+
+// MetricsRecorder defines an abstract backend to record metrics about the
+// served API operations and the underlying backend calls. It's meant to be
+// implemented by adapters to metrics systems (e.g., Prometheus counters and
+// histograms).
+//
+// Implementations should be safe for concurrent use and return quickly, since
+// they are called synchronously while serving requests.
+type MetricsRecorder interface {
+	// ObserveRequest records a served request for the given OpenAPI operation
+	// (e.g., `GetGroups` or `PatchIdentitiesItemRoles`), along with the
+	// response status code, its class (e.g., `2xx` or `5xx`) and the time it
+	// took to serve the request.
+	//
+	// Requests that do not match any of the API routes are not recorded.
+	ObserveRequest(ctx context.Context, operationId string, status int, statusClass string, duration time.Duration)
+
+	// ObserveServiceCall records a call to a backend method, identified as
+	// `<Interface>.<Method>` (e.g., `GroupsService.ListGroups`), along with
+	// the time it took and the returned error (nil for successful calls).
+	ObserveServiceCall(ctx context.Context, method string, duration time.Duration, err error)
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package interfaces
+
+import (
+	"context"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// GroupsPatchReporter is an optional interface that can be implemented by the
+// `GroupsService` implementation to report the outcome of each item of
+// relationship patches (e.g., whether an identity to add was already a member
+// of the group).
+//
+// If implemented, its methods are called instead of the `Patch*` methods of
+// `GroupsService`, and the outcomes are returned in the response body. The
+// returned slice should have one result per patch item, in the same order. A
+// failure that affects the whole request (e.g., a non-existent group) should
+// be returned as an error, instead.
+type GroupsPatchReporter interface {
+	// PatchGroupIdentitiesWithResults performs addition or removal of identities to/from a Group identified by `groupId`.
+	PatchGroupIdentitiesWithResults(ctx context.Context, groupId string, identityPatches []resources.GroupIdentitiesPatchItem) ([]resources.PatchItemResult, error)
+
+	// PatchGroupRolesWithResults performs addition or removal of a Role to/from a Group identified by `groupId`.
+	PatchGroupRolesWithResults(ctx context.Context, groupId string, rolePatches []resources.GroupRolesPatchItem) ([]resources.PatchItemResult, error)
+
+	// PatchGroupEntitlementsWithResults performs addition or removal of an Entitlement to/from a Group identified by `groupId`.
+	PatchGroupEntitlementsWithResults(ctx context.Context, groupId string, entitlementPatches []resources.GroupEntitlementsPatchItem) ([]resources.PatchItemResult, error)
+}
+
+// IdentitiesPatchReporter is an optional interface that can be implemented by
+// the `IdentitiesService` implementation to report the outcome of each item of
+// relationship patches. See `GroupsPatchReporter` for details.
+type IdentitiesPatchReporter interface {
+	// PatchIdentityGroupsWithResults performs addition or removal of a Group to/from an Identity.
+	PatchIdentityGroupsWithResults(ctx context.Context, identityId string, groupPatches []resources.IdentityGroupsPatchItem) ([]resources.PatchItemResult, error)
+
+	// PatchIdentityRolesWithResults performs addition or removal of a Role to/from an Identity.
+	PatchIdentityRolesWithResults(ctx context.Context, identityId string, rolePatches []resources.IdentityRolesPatchItem) ([]resources.PatchItemResult, error)
+
+	// PatchIdentityEntitlementsWithResults performs addition or removal of an Entitlement to/from an Identity.
+	PatchIdentityEntitlementsWithResults(ctx context.Context, identityId string, entitlementPatches []resources.IdentityEntitlementsPatchItem) ([]resources.PatchItemResult, error)
+}
+
+// RolesPatchReporter is an optional interface that can be implemented by the
+// `RolesService` implementation to report the outcome of each item of
+// relationship patches. See `GroupsPatchReporter` for details.
+type RolesPatchReporter interface {
+	// PatchRoleEntitlementsWithResults performs addition or removal of an Entitlement to/from a Role.
+	PatchRoleEntitlementsWithResults(ctx context.Context, roleId string, entitlementPatches []resources.RoleEntitlementsPatchItem) ([]resources.PatchItemResult, error)
+}
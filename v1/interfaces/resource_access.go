@@ -0,0 +1,35 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package interfaces
+
+import (
+	"context"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// ResourceAccessLister is an optional interface that can be implemented by the
+// `ResourcesService` implementation to list who has access to a resource.
+// If not implemented, the `GET /resources/{type}/{id}/access` endpoint is not
+// served.
+type ResourceAccessLister interface {
+	// ListResourceAccess returns a page of the entitlements held on the
+	// resource identified by `entityType` and `entityId`, by identities, groups
+	// and roles. Besides the direct assignments, the entries should include
+	// the inherited ones (e.g., an identity that holds an entitlement via one
+	// of its groups).
+	ListResourceAccess(ctx context.Context, entityType string, entityId string, params *resources.GetResourcesItemAccessParams) (*resources.PaginatedResponse[resources.ResourceAccess], error)
+}
@@ -24,5 +24,8 @@ import (
 // ResourcesService defines an abstract backend to handle Resources related operations.
 type ResourcesService interface {
 	// ListResources returns a page of Resource objects of at least `size` elements if available.
+	// The page is expected to be sorted by `sorting.FromContext(ctx)` (see the
+	// `sorting` package); sortable fields are `entity.id`, `entity.name`,
+	// `entity.type`, `parent.name` and `parent.type`.
 	ListResources(ctx context.Context, params *resources.GetResourcesParams) (*resources.PaginatedResponse[resources.Resource], error)
 }
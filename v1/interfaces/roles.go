@@ -24,6 +24,8 @@ import (
 // RolesService defines an abstract backend to handle Roles related operations.
 type RolesService interface {
 	// ListRoles returns a page of Role objects of at least `size` elements if available.
+	// The page is expected to be sorted by `sorting.FromContext(ctx)` (see the
+	// `sorting` package); sortable fields are `id` and `name`.
 	ListRoles(ctx context.Context, params *resources.GetRolesParams) (*resources.PaginatedResponse[resources.Role], error)
 	// CreateRole creates a single Role.
 	CreateRole(ctx context.Context, role *resources.Role) (*resources.Role, error)
@@ -0,0 +1,36 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package interfaces
+
+import (
+	"context"
+)
+
+// EntityVersioner is an optional interface that can be implemented by the
+// `GroupsService`, `RolesService`, `IdentitiesService` and
+// `IdentityProvidersService` implementations to provide the current version of
+// their entities (e.g., a revision number or a last modification timestamp).
+//
+// Versions are used (base64url-encoded) as `ETag`s of the entities, and to
+// check the `If-Match` preconditions of update/delete requests. If not
+// implemented, the `ETag`s are derived from the content of the entities, which
+// requires fetching the entity to check preconditions.
+type EntityVersioner interface {
+	// EntityVersion returns the current version of the entity identified by
+	// `id`. The version should change whenever the entity changes. If the
+	// entity does not exist, the method should return a not-found error.
+	EntityVersion(ctx context.Context, id string) (string, error)
+}
@@ -0,0 +1,155 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// loggingResponseWriter is a wrapper around http.ResponseWriter that carries
+// the logger and records the information needed for access logs.
+type loggingResponseWriter struct {
+	http.ResponseWriter
+	logger *slog.Logger
+
+	// status is the HTTP status code written to the response. Zero means
+	// nothing has been written yet.
+	status int
+
+	// identity is the authenticated caller identity, if any.
+	identity any
+}
+
+// WriteHeader implements the http.ResponseWriter interface.
+func (w *loggingResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write implements the http.ResponseWriter interface.
+func (w *loggingResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the underlying http.ResponseWriter. This is used by
+// http.ResponseController to access optional interfaces (e.g., http.Flusher).
+func (w *loggingResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// findLoggingResponseWriter returns the loggingResponseWriter instance wrapped
+// (possibly in multiple layers) by the given writer, or nil if there's none.
+func findLoggingResponseWriter(w http.ResponseWriter) *loggingResponseWriter {
+	for {
+		switch v := w.(type) {
+		case *loggingResponseWriter:
+			return v
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = v.Unwrap()
+		default:
+			return nil
+		}
+	}
+}
+
+// getLogger returns the logger associated with the given response writer. If
+// there's none (e.g., when handlers are directly called in tests), the default
+// slog logger is returned.
+func getLogger(w http.ResponseWriter) *slog.Logger {
+	if lw := findLoggingResponseWriter(w); lw != nil {
+		return lw.logger
+	}
+	return slog.Default()
+}
+
+// logger returns the configured logger, or the default slog logger if none is
+// provided.
+func (b *ReBACAdminBackend) logger() *slog.Logger {
+	if b.params.Logger != nil {
+		return b.params.Logger
+	}
+	return slog.Default()
+}
+
+// loggingHandler wraps the given handler so that the configured logger is
+// available to the inner handlers. If access logs are enabled, it also logs a
+// line per request.
+func (b *ReBACAdminBackend) loggingHandler(next http.Handler) http.Handler {
+	logger := b.logger()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		r, rctx := withRouteContext(r)
+		lw := &loggingResponseWriter{ResponseWriter: w, logger: logger}
+		next.ServeHTTP(lw, r)
+
+		if !b.params.AccessLog {
+			return
+		}
+
+		status := lw.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		logger.LogAttrs(r.Context(), slog.LevelInfo, "request served",
+			slog.String("method", r.Method),
+			slog.String("route", rctx.RoutePattern()),
+			slog.Int("status", status),
+			slog.Duration("latency", time.Since(start)),
+			slog.Any("identity", lw.identity),
+		)
+	})
+}
+
+// withRouteContext makes sure the given request has a chi routing context, so
+// that the matched route can be read after the request is served. Note that chi
+// routers reuse the existing routing context, if any.
+func withRouteContext(r *http.Request) (*http.Request, *chi.Context) {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil {
+		rctx = chi.NewRouteContext()
+		r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+	}
+	return r, rctx
+}
+
+// identityLoggingMiddleware returns a middleware that records the caller
+// identity (if any) for the access log. It must run after the authentication
+// middleware has populated the request context.
+func identityLoggingMiddleware() resources.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if lw := findLoggingResponseWriter(w); lw != nil {
+				if identity, err := GetIdentityFromContext(r.Context()); err == nil {
+					lw.identity = identity
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
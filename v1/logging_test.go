@@ -0,0 +1,148 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+)
+
+// decodeLogLines decodes the JSON log lines written to the given buffer.
+func decodeLogLines(c *qt.C, buf *bytes.Buffer) []map[string]any {
+	lines := []map[string]any{}
+	decoder := json.NewDecoder(buf)
+	for decoder.More() {
+		line := map[string]any{}
+		c.Assert(decoder.Decode(&line), qt.IsNil)
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestLogging_ServerErrorAndAccessLog(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	groups := interfaces.NewMockGroupsService(ctrl)
+	groups.EXPECT().GetGroup(gomock.Any(), "some-group").Return(nil, errors.New("database is down"))
+
+	authenticator := interfaces.NewMockAuthenticator(ctrl)
+	authenticator.EXPECT().Authenticate(gomock.Any()).Return("some-identity", nil)
+
+	buf := &bytes.Buffer{}
+	sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+		Authenticator: authenticator,
+		Groups:        groups,
+		Logger:        slog.New(slog.NewJSONHandler(buf, nil)),
+		AccessLog:     true,
+	})
+
+	server := httptest.NewServer(sut.Handler("/rebac"))
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "/rebac/v1/groups/some-group")
+	c.Assert(err, qt.IsNil)
+	defer res.Body.Close()
+	c.Assert(res.StatusCode, qt.Equals, http.StatusInternalServerError)
+
+	lines := decodeLogLines(c, buf)
+	c.Assert(lines, qt.HasLen, 2)
+
+	c.Assert(lines[0]["level"], qt.Equals, "ERROR")
+	c.Assert(lines[0]["msg"], qt.Equals, "request failed with server error")
+	c.Assert(lines[0]["error"], qt.Equals, "database is down")
+	c.Assert(lines[0]["status"], qt.Equals, float64(http.StatusInternalServerError))
+
+	c.Assert(lines[1]["level"], qt.Equals, "INFO")
+	c.Assert(lines[1]["msg"], qt.Equals, "request served")
+	c.Assert(lines[1]["method"], qt.Equals, http.MethodGet)
+	c.Assert(lines[1]["route"], qt.Equals, "/rebac/v1/groups/{id}")
+	c.Assert(lines[1]["status"], qt.Equals, float64(http.StatusInternalServerError))
+	c.Assert(lines[1]["identity"], qt.Equals, "some-identity")
+	c.Assert(lines[1]["latency"], qt.Not(qt.IsNil))
+}
+
+func TestLogging_AccessLogDisabled(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	groups := interfaces.NewMockGroupsService(ctrl)
+	groups.EXPECT().DeleteGroup(gomock.Any(), "some-group").Return(true, nil)
+
+	buf := &bytes.Buffer{}
+	sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+		Groups: groups,
+		Logger: slog.New(slog.NewJSONHandler(buf, nil)),
+	})
+
+	req := httptest.NewRequest(http.MethodDelete, "/v1/groups/some-group", nil)
+	w := httptest.NewRecorder()
+	sut.Handler("").ServeHTTP(w, req)
+
+	c.Assert(w.Code, qt.Equals, http.StatusOK)
+	c.Assert(buf.Len(), qt.Equals, 0)
+}
+
+func TestLogging_MarshalErrorIsLoggedOnce(t *testing.T) {
+	c := qt.New(t)
+
+	buf := &bytes.Buffer{}
+	w := httptest.NewRecorder()
+	lw := &loggingResponseWriter{ResponseWriter: w, logger: slog.New(slog.NewJSONHandler(buf, nil))}
+
+	writeResponse(lw, http.StatusOK, map[string]any{"foo": make(chan int)})
+	c.Assert(w.Code, qt.Equals, http.StatusInternalServerError)
+
+	lines := decodeLogLines(c, buf)
+	c.Assert(lines, qt.HasLen, 1)
+	c.Assert(lines[0]["msg"], qt.Equals, "request failed with server error")
+	c.Assert(lines[0]["error"], qt.Equals, "json: unsupported type: chan int")
+}
+
+func TestGetLogger(t *testing.T) {
+	c := qt.New(t)
+
+	logger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+
+	c.Assert(getLogger(httptest.NewRecorder()), qt.Equals, slog.Default())
+
+	lw := &loggingResponseWriter{ResponseWriter: httptest.NewRecorder(), logger: logger}
+	c.Assert(getLogger(lw), qt.Equals, logger)
+
+	// Wrapped writers should be unwrapped to find the logger.
+	wrapped := &wrappingResponseWriter{lw}
+	c.Assert(getLogger(wrapped), qt.Equals, logger)
+}
+
+type wrappingResponseWriter struct {
+	http.ResponseWriter
+}
+
+func (w *wrappingResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
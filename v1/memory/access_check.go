@@ -0,0 +1,89 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// AccessCheckService implements the `AccessCheckService` interface on top of
+// a Database.
+type AccessCheckService struct {
+	db *Database
+}
+
+// For doc/test sake, to hint that the struct needs to implement a specific interface.
+var _ interfaces.AccessCheckService = &AccessCheckService{}
+
+// NewAccessCheckService returns a new AccessCheckService instance.
+func NewAccessCheckService(db *Database) *AccessCheckService {
+	return &AccessCheckService{db: db}
+}
+
+// CheckAccess checks whether the identity has the given entitlement, either
+// directly, via its groups, via its roles, or via the roles of its groups.
+// Since the paths are readily available, they are always computed, regardless
+// of `explain`.
+func (s *AccessCheckService) CheckAccess(ctx context.Context, identityId string, entitlement resources.EntityEntitlement, explain bool) (*resources.AccessCheck, error) {
+	target := entitlementToString(entitlement)
+	paths := []resources.AccessPath{}
+	found := false
+	s.db.view(func(state *State) {
+		if _, found = state.Identities[identityId]; !found {
+			return
+		}
+
+		if slices.Contains(state.Identity2Entitlement.rights(identityId), target) {
+			paths = append(paths, resources.AccessPath{Via: []resources.AccessPathStep{}})
+		}
+
+		roleGrants := func(via []resources.AccessPathStep, roleIds []string) {
+			for _, roleId := range roleIds {
+				if slices.Contains(state.Role2Entitlement.rights(roleId), target) {
+					step := resources.AccessPathStep{Type: resources.AccessPathStepTypeRole, Id: roleId}
+					paths = append(paths, resources.AccessPath{Via: append(slices.Clone(via), step)})
+				}
+			}
+		}
+
+		for _, groupId := range sorted(state.Group2Identity.lefts(identityId)) {
+			via := []resources.AccessPathStep{{Type: resources.AccessPathStepTypeGroup, Id: groupId}}
+			if slices.Contains(state.Group2Entitlement.rights(groupId), target) {
+				paths = append(paths, resources.AccessPath{Via: via})
+			}
+			roleGrants(via, sorted(state.Group2Role.rights(groupId)))
+		}
+		roleGrants(nil, sorted(state.Identity2Role.rights(identityId)))
+	})
+	if !found {
+		return nil, identityNotFound(identityId)
+	}
+
+	return &resources.AccessCheck{
+		Allowed: len(paths) > 0,
+		Paths:   paths,
+	}, nil
+}
+
+// sorted returns the given slice, sorted in place.
+func sorted(s []string) []string {
+	slices.Sort(s)
+	return s
+}
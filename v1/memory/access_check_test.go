@@ -0,0 +1,78 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package memory
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+func TestAccessCheckService(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	entitlement := resources.EntityEntitlement{Entitlement: "can_view", EntityType: "model", EntityId: "foo"}
+	grant := entitlementToString(entitlement)
+
+	s := NewAccessCheckService(newTestDatabase(c, &State{
+		Groups: map[string]resources.Group{
+			"admins": {Id: stringPtr("admins"), Name: "admins"},
+		},
+		Identities: map[string]resources.Identity{
+			"joe@example.com":  {Id: stringPtr("joe@example.com"), Email: "joe@example.com"},
+			"jane@example.com": {Id: stringPtr("jane@example.com"), Email: "jane@example.com"},
+		},
+		Roles: map[string]resources.Role{
+			"viewer": {Id: stringPtr("viewer"), Name: "viewer"},
+		},
+		Group2Identity:       Relationship{Tuples: []RelationshipTuple{{Left: "admins", Right: "joe@example.com"}}},
+		Group2Role:           Relationship{Tuples: []RelationshipTuple{{Left: "admins", Right: "viewer"}}},
+		Group2Entitlement:    Relationship{Tuples: []RelationshipTuple{{Left: "admins", Right: grant}}},
+		Identity2Role:        Relationship{Tuples: []RelationshipTuple{{Left: "joe@example.com", Right: "viewer"}}},
+		Identity2Entitlement: Relationship{Tuples: []RelationshipTuple{{Left: "joe@example.com", Right: grant}}},
+		Role2Entitlement:     Relationship{Tuples: []RelationshipTuple{{Left: "viewer", Right: grant}}},
+	}))
+
+	result, err := s.CheckAccess(ctx, "joe@example.com", entitlement, true)
+	c.Assert(err, qt.IsNil)
+	c.Assert(result, qt.DeepEquals, &resources.AccessCheck{
+		Allowed: true,
+		Paths: []resources.AccessPath{{
+			Via: []resources.AccessPathStep{},
+		}, {
+			Via: []resources.AccessPathStep{{Type: resources.AccessPathStepTypeGroup, Id: "admins"}},
+		}, {
+			Via: []resources.AccessPathStep{
+				{Type: resources.AccessPathStepTypeGroup, Id: "admins"},
+				{Type: resources.AccessPathStepTypeRole, Id: "viewer"},
+			},
+		}, {
+			Via: []resources.AccessPathStep{{Type: resources.AccessPathStepTypeRole, Id: "viewer"}},
+		}},
+	})
+
+	result, err = s.CheckAccess(ctx, "jane@example.com", entitlement, true)
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Allowed, qt.IsFalse)
+	c.Assert(result.Paths, qt.HasLen, 0)
+
+	_, err = s.CheckAccess(ctx, "bob@example.com", entitlement, false)
+	c.Assert(err, qt.ErrorMatches, `Not Found: .*`)
+}
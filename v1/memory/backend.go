@@ -0,0 +1,36 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package memory
+
+import (
+	v1 "github.com/canonical/rebac-admin-ui-handlers/v1"
+)
+
+// NewBackendParams returns backend parameters with all the services backed by
+// the given database. The capabilities service is not set, so that the
+// handlers infer the capabilities; use `NewCapabilitiesService` to serve the
+// capabilities stored in the database instead.
+func NewBackendParams(db *Database) v1.ReBACAdminBackendParams {
+	return v1.ReBACAdminBackendParams{
+		Groups:            NewGroupsService(db),
+		Identities:        NewIdentitiesService(db),
+		Roles:             NewRolesService(db),
+		IdentityProviders: NewIdentityProvidersService(db),
+		Entitlements:      NewEntitlementsService(db),
+		Resources:         NewResourcesService(db),
+		AccessCheck:       NewAccessCheckService(db),
+	}
+}
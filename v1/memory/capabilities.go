@@ -13,28 +13,39 @@
 // You should have received a copy of the GNU Affero General Public License
 // along with this program.  If not, see <http://www.gnu.org/licenses/>.
 
-package service
+package memory
 
 import (
 	"context"
-	"example/cmd/database"
 
 	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
 	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
 )
 
-// CapabilitiesService implements the `CapabilitiesService` interface.
+// CapabilitiesService implements the `CapabilitiesService` interface on top of
+// a Database.
+//
+// Normally, capabilities are known at the service level. But, to be able to
+// exercise the UI with different responses, they are read from the database
+// (i.e., `State.Capabilities`) here. Note that if this service is not
+// provided, the handlers infer the capabilities from the provided services.
 type CapabilitiesService struct {
-	Database *database.Database
+	db *Database
 }
 
 // For doc/test sake, to hint that the struct needs to implement a specific interface.
 var _ interfaces.CapabilitiesService = &CapabilitiesService{}
 
-// ListCapabilities returns a list of capabilities supported by this service.
+// NewCapabilitiesService returns a new CapabilitiesService instance.
+func NewCapabilitiesService(db *Database) *CapabilitiesService {
+	return &CapabilitiesService{db: db}
+}
+
+// ListCapabilities returns the list of capabilities.
 func (s *CapabilitiesService) ListCapabilities(ctx context.Context) ([]resources.Capability, error) {
-	// Note that, normally, capabilities are known (as static information). But,
-	// here, to avoid hardcoding things and be able to modify the API with
-	// different responses, we need to fetch the data from database.
-	return s.Database.ListCapabilities(), nil
+	result := []resources.Capability{}
+	s.db.view(func(state *State) {
+		result = append(result, state.Capabilities...)
+	})
+	return result, nil
 }
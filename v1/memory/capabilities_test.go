@@ -0,0 +1,44 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package memory
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+func TestCapabilitiesService(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	capabilities := []resources.Capability{
+		{Endpoint: "/groups", Methods: []resources.CapabilityMethods{"GET", "POST"}},
+	}
+	s := NewCapabilitiesService(newTestDatabase(c, &State{Capabilities: capabilities}))
+
+	result, err := s.ListCapabilities(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(result, qt.DeepEquals, capabilities)
+
+	s = NewCapabilitiesService(newTestDatabase(c, nil))
+	result, err = s.ListCapabilities(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(result, qt.HasLen, 0)
+}
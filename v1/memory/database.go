@@ -0,0 +1,289 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package memory
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// State represents the content of a Database. It's used as the JSON
+// representation of database snapshots and seeds.
+type State struct {
+	Groups     map[string]resources.Group
+	Identities map[string]resources.Identity
+	Roles      map[string]resources.Role
+	Idps       map[string]resources.IdentityProvider
+
+	Group2Identity       Relationship
+	Group2Role           Relationship
+	Group2Entitlement    Relationship
+	Identity2Role        Relationship
+	Identity2Entitlement Relationship
+	Role2Entitlement     Relationship
+
+	// Constant data
+	Entitlements               []resources.EntitlementSchema
+	AvailableIdentityProviders []resources.AvailableIdentityProvider
+	Resources                  []resources.Resource
+	AuthModel                  string
+	Capabilities               []resources.Capability
+}
+
+// LoadState reads a State from the given JSON file.
+func LoadState(filename string) (*State, error) {
+	raw, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file %s: %w", filename, err)
+	}
+	state := &State{}
+	if err := json.Unmarshal(raw, state); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal state file %s: %w", filename, err)
+	}
+	return state, nil
+}
+
+// clone returns a copy of the state, with all nil maps initialized. The maps,
+// relationships and slices are copied, but entities are copied by value, so
+// they share the values their pointer fields refer to. That's fine, since
+// entities are always replaced, rather than modified in place.
+func (s *State) clone() *State {
+	result := &State{}
+	if s != nil {
+		result = &State{
+			Groups:     maps.Clone(s.Groups),
+			Identities: maps.Clone(s.Identities),
+			Roles:      maps.Clone(s.Roles),
+			Idps:       maps.Clone(s.Idps),
+
+			Group2Identity:       s.Group2Identity.clone(),
+			Group2Role:           s.Group2Role.clone(),
+			Group2Entitlement:    s.Group2Entitlement.clone(),
+			Identity2Role:        s.Identity2Role.clone(),
+			Identity2Entitlement: s.Identity2Entitlement.clone(),
+			Role2Entitlement:     s.Role2Entitlement.clone(),
+
+			Entitlements:               slices.Clone(s.Entitlements),
+			AvailableIdentityProviders: slices.Clone(s.AvailableIdentityProviders),
+			Resources:                  slices.Clone(s.Resources),
+			AuthModel:                  s.AuthModel,
+			Capabilities:               slices.Clone(s.Capabilities),
+		}
+	}
+
+	if result.Groups == nil {
+		result.Groups = map[string]resources.Group{}
+	}
+	if result.Identities == nil {
+		result.Identities = map[string]resources.Identity{}
+	}
+	if result.Roles == nil {
+		result.Roles = map[string]resources.Role{}
+	}
+	if result.Idps == nil {
+		result.Idps = map[string]resources.IdentityProvider{}
+	}
+	return result
+}
+
+// DatabaseParams contains the configuration of a Database.
+type DatabaseParams struct {
+	// Seed is the initial state of the database, which is also restored by
+	// `Database.Reset`. If nil, the database starts empty.
+	Seed *State
+
+	// SnapshotFilename is the path to a JSON file that the state is written to
+	// after every change. If the file exists when the database is created, the
+	// state is loaded from it instead of the seed. If empty, the state is not
+	// persisted.
+	SnapshotFilename string
+
+	// PageTokenKeys are the secret keys used to sign next page tokens (see
+	// `resources.PageTokenCodecParams`). If empty, a random key is generated,
+	// so tokens do not remain valid across restarts.
+	PageTokenKeys [][]byte
+}
+
+// Database is a thread-safe in-memory storage of entities and the
+// relationships between them.
+type Database struct {
+	params DatabaseParams
+
+	mutex sync.RWMutex
+	state *State
+
+	paginator *resources.Paginator
+}
+
+// NewDatabase returns a new Database instance, populated with either the
+// snapshot file (if it exists) or the seed state.
+func NewDatabase(params DatabaseParams) (*Database, error) {
+	keys := params.PageTokenKeys
+	if len(keys) == 0 {
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("failed to generate page token key: %w", err)
+		}
+		keys = [][]byte{key}
+	}
+	pageTokens, err := resources.NewPageTokenCodec(resources.PageTokenCodecParams{Keys: keys})
+	if err != nil {
+		return nil, err
+	}
+
+	db := &Database{
+		params:    params,
+		state:     params.Seed.clone(),
+		paginator: resources.NewPaginator(resources.PaginatorParams{Tokens: pageTokens}),
+	}
+
+	if params.SnapshotFilename == "" {
+		return db, nil
+	}
+	if _, err := os.Stat(params.SnapshotFilename); err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("failed to stat snapshot file %s: %w", params.SnapshotFilename, err)
+		}
+		return db, nil
+	}
+	state, err := LoadState(params.SnapshotFilename)
+	if err != nil {
+		return nil, err
+	}
+	db.state = state.clone()
+	return db, nil
+}
+
+// Snapshot returns a copy of the current state.
+func (db *Database) Snapshot() *State {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	return db.state.clone()
+}
+
+// Reset restores the seed state, and persists it if a snapshot file is
+// configured.
+func (db *Database) Reset() error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	state := db.params.Seed.clone()
+	if err := db.persist(state); err != nil {
+		return err
+	}
+	db.state = state
+	return nil
+}
+
+// view calls the given function with the state, while holding the read lock.
+func (db *Database) view(f func(s *State)) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	f(db.state)
+}
+
+// update calls the given function with a copy of the state, while holding the
+// write lock. If the function reports a change, the copy is persisted, and
+// then replaces the state. So, if either the function or the persistence
+// fails, the state is left intact.
+func (db *Database) update(f func(s *State) (bool, error)) (bool, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	state := db.state.clone()
+	changed, err := f(state)
+	if err != nil || !changed {
+		return false, err
+	}
+	if err := db.persist(state); err != nil {
+		return false, err
+	}
+	db.state = state
+	return true, nil
+}
+
+// persist writes the given state to the snapshot file, if configured. The file
+// is replaced atomically, so readers never observe a partially written state.
+func (db *Database) persist(state *State) error {
+	if db.params.SnapshotFilename == "" {
+		return nil
+	}
+
+	raw, err := json.MarshalIndent(state, "", "    ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	dir, base := filepath.Split(db.params.SnapshotFilename)
+	f, err := os.CreateTemp(dir, base+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to persist state: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(raw); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to persist state: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to persist state: %w", err)
+	}
+	if err := os.Rename(f.Name(), db.params.SnapshotFilename); err != nil {
+		return fmt.Errorf("failed to persist state: %w", err)
+	}
+	return nil
+}
+
+// sortedValues returns the values of the given map, sorted by their keys.
+func sortedValues[V any](m map[string]V) []V {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	result := make([]V, 0, len(keys))
+	for _, k := range keys {
+		result = append(result, m[k])
+	}
+	return result
+}
+
+// lookup returns the values of the given map for the given keys, sorted by the
+// keys. Missing keys are skipped.
+func lookup[V any](m map[string]V, keys []string) []V {
+	keys = slices.Clone(keys)
+	slices.Sort(keys)
+
+	result := make([]V, 0, len(keys))
+	for _, k := range keys {
+		if v, ok := m[k]; ok {
+			result = append(result, v)
+		}
+	}
+	return result
+}
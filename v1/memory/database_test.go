@@ -0,0 +1,224 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package memory
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// newTestDatabase returns a new Database instance, populated with the given
+// seed and no snapshot file.
+func newTestDatabase(c *qt.C, seed *State) *Database {
+	db, err := NewDatabase(DatabaseParams{Seed: seed})
+	c.Assert(err, qt.IsNil)
+	return db
+}
+
+func TestNewDatabase_Seed(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	seed := &State{
+		Groups: map[string]resources.Group{
+			"admins": {Id: stringPtr("admins"), Name: "admins"},
+		},
+		AuthModel: "some-model",
+	}
+	db := newTestDatabase(c, seed)
+
+	// Changes to the seed should not affect the database, and vice versa.
+	seed.Groups["viewers"] = resources.Group{Id: stringPtr("viewers"), Name: "viewers"}
+	_, err := NewGroupsService(db).CreateGroup(ctx, &resources.Group{Name: "editors"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(seed.Groups, qt.HasLen, 2)
+
+	snapshot := db.Snapshot()
+	c.Assert(snapshot.Groups, qt.HasLen, 2)
+	c.Assert(snapshot.Groups["admins"].Name, qt.Equals, "admins")
+	c.Assert(snapshot.Groups["editors"].Name, qt.Equals, "editors")
+	c.Assert(snapshot.AuthModel, qt.Equals, "some-model")
+
+	// The snapshot is a copy.
+	delete(snapshot.Groups, "admins")
+	_, err = NewGroupsService(db).GetGroup(ctx, "admins")
+	c.Assert(err, qt.IsNil)
+
+	err = db.Reset()
+	c.Assert(err, qt.IsNil)
+	snapshot = db.Snapshot()
+	c.Assert(snapshot.Groups, qt.HasLen, 2)
+	c.Assert(snapshot.Groups["admins"].Name, qt.Equals, "admins")
+	c.Assert(snapshot.Groups["viewers"].Name, qt.Equals, "viewers")
+}
+
+func TestNewDatabase_Empty(t *testing.T) {
+	c := qt.New(t)
+
+	db := newTestDatabase(c, nil)
+	snapshot := db.Snapshot()
+	c.Assert(snapshot.Groups, qt.HasLen, 0)
+	c.Assert(snapshot.Identities, qt.HasLen, 0)
+	c.Assert(snapshot.Roles, qt.HasLen, 0)
+	c.Assert(snapshot.Idps, qt.HasLen, 0)
+}
+
+func TestNewDatabase_Snapshot(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	filename := filepath.Join(c.TempDir(), "state.json")
+	params := DatabaseParams{
+		Seed: &State{
+			Roles: map[string]resources.Role{
+				"viewer": {Id: stringPtr("viewer"), Name: "viewer"},
+			},
+		},
+		SnapshotFilename: filename,
+	}
+
+	db, err := NewDatabase(params)
+	c.Assert(err, qt.IsNil)
+
+	// Nothing is persisted until the state changes.
+	_, err = os.Stat(filename)
+	c.Assert(err, qt.ErrorIs, os.ErrNotExist)
+
+	_, err = NewGroupsService(db).CreateGroup(ctx, &resources.Group{Name: "admins"})
+	c.Assert(err, qt.IsNil)
+
+	state, err := LoadState(filename)
+	c.Assert(err, qt.IsNil)
+	c.Assert(state.Groups, qt.HasLen, 1)
+	c.Assert(state.Roles, qt.HasLen, 1)
+
+	// A new database should be loaded from the snapshot, rather than the seed.
+	db, err = NewDatabase(params)
+	c.Assert(err, qt.IsNil)
+	_, err = NewGroupsService(db).GetGroup(ctx, "admins")
+	c.Assert(err, qt.IsNil)
+
+	// Failed operations should not be persisted.
+	_, err = NewGroupsService(db).CreateGroup(ctx, &resources.Group{Name: "admins"})
+	c.Assert(err, qt.IsNotNil)
+
+	err = db.Reset()
+	c.Assert(err, qt.IsNil)
+	state, err = LoadState(filename)
+	c.Assert(err, qt.IsNil)
+	c.Assert(state.Groups, qt.HasLen, 0)
+	c.Assert(state.Roles, qt.HasLen, 1)
+
+	// No temporary files should be left behind.
+	entries, err := os.ReadDir(filepath.Dir(filename))
+	c.Assert(err, qt.IsNil)
+	c.Assert(entries, qt.HasLen, 1)
+}
+
+func TestNewDatabase_PersistError(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	// The snapshot directory does not exist, so persisting fails.
+	db, err := NewDatabase(DatabaseParams{
+		SnapshotFilename: filepath.Join(c.TempDir(), "missing", "state.json"),
+	})
+	c.Assert(err, qt.IsNil)
+
+	_, err = NewGroupsService(db).CreateGroup(ctx, &resources.Group{Name: "admins"})
+	c.Assert(err, qt.ErrorMatches, "failed to persist state: .*")
+
+	// The change should be rolled back.
+	c.Assert(db.Snapshot().Groups, qt.HasLen, 0)
+}
+
+func TestNewDatabase_InvalidSnapshot(t *testing.T) {
+	c := qt.New(t)
+
+	filename := filepath.Join(c.TempDir(), "state.json")
+	err := os.WriteFile(filename, []byte("not json"), 0o644)
+	c.Assert(err, qt.IsNil)
+
+	_, err = NewDatabase(DatabaseParams{SnapshotFilename: filename})
+	c.Assert(err, qt.ErrorMatches, "failed to unmarshal state file .*")
+}
+
+func TestStateClone(t *testing.T) {
+	c := qt.New(t)
+
+	tuples := Relationship{Tuples: []RelationshipTuple{{Left: "a", Right: "b"}}}
+	state := &State{
+		Groups:                     map[string]resources.Group{"g": {Name: "g"}},
+		Identities:                 map[string]resources.Identity{"i": {Email: "i"}},
+		Roles:                      map[string]resources.Role{"r": {Name: "r"}},
+		Idps:                       map[string]resources.IdentityProvider{"p": {Name: stringPtr("p")}},
+		Group2Identity:             tuples,
+		Group2Role:                 tuples,
+		Group2Entitlement:          tuples,
+		Identity2Role:              tuples,
+		Identity2Entitlement:       tuples,
+		Role2Entitlement:           tuples,
+		Entitlements:               []resources.EntitlementSchema{{Entitlement: "e"}},
+		AvailableIdentityProviders: []resources.AvailableIdentityProvider{{Id: "p"}},
+		Resources:                  []resources.Resource{{Entity: resources.Entity{Id: "r"}}},
+		AuthModel:                  "model",
+		Capabilities:               []resources.Capability{{Endpoint: "/groups"}},
+	}
+
+	// All fields should be set, so that the test catches the ones that are
+	// not copied.
+	v := reflect.ValueOf(state).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		c.Assert(v.Field(i).IsZero(), qt.IsFalse, qt.Commentf("field %s", v.Type().Field(i).Name))
+	}
+
+	clone := state.clone()
+	c.Assert(clone, qt.DeepEquals, state)
+
+	// Changes to the clone should not affect the original state.
+	clone.Groups["h"] = resources.Group{Name: "h"}
+	clone.Group2Identity.add(RelationshipTuple{Left: "c", Right: "d"})
+	clone.Group2Role.remove(RelationshipTuple{Left: "a", Right: "b"})
+	clone.Entitlements[0].Entitlement = "f"
+	c.Assert(state.Groups, qt.HasLen, 1)
+	c.Assert(state.Group2Identity.Tuples, qt.HasLen, 1)
+	c.Assert(state.Group2Role.Tuples, qt.HasLen, 1)
+	c.Assert(state.Entitlements[0].Entitlement, qt.Equals, "e")
+}
+
+func TestLoadState(t *testing.T) {
+	c := qt.New(t)
+
+	_, err := LoadState(filepath.Join(c.TempDir(), "missing.json"))
+	c.Assert(err, qt.ErrorMatches, "failed to read state file .*")
+
+	// The zero state of the example application should be loadable.
+	state, err := LoadState("../../_example/state.zero.json")
+	c.Assert(err, qt.IsNil)
+	c.Assert(state.Entitlements, qt.Not(qt.HasLen), 0)
+	c.Assert(state.Resources, qt.Not(qt.HasLen), 0)
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,39 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package memory provides an in-memory implementation of all the service
+// interfaces (i.e., `GroupsService`, `IdentitiesService`, `RolesService`,
+// `IdentityProvidersService`, `EntitlementsService`, `ResourcesService` and
+// `CapabilitiesService`), backed by a thread-safe `Database`.
+//
+// It's meant to be used in tests and for local development (e.g., to run the
+// UI against a real backend), not in production:
+//
+//	db, err := memory.NewDatabase(memory.DatabaseParams{})
+//	if err != nil {
+//		// handle error
+//	}
+//	backend, err := v1.NewReBACAdminBackend(memory.NewBackendParams(db))
+//
+// The database can be populated with an initial state (see
+// `DatabaseParams.Seed` and `LoadState`), and optionally persist its state
+// to a JSON snapshot file on every change (see
+// `DatabaseParams.SnapshotFilename`).
+//
+// The ID of each entity is derived from its name (or email, in case of
+// identities) when it's created, and does not change afterwards. Lists are
+// sorted by ID, and paginated with either the page/size or the next page
+// token mechanism, depending on the request parameters.
+package memory
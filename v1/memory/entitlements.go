@@ -0,0 +1,56 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package memory
+
+import (
+	"context"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// EntitlementsService implements the `EntitlementsService` interface on top of
+// a Database.
+type EntitlementsService struct {
+	db *Database
+}
+
+// For doc/test sake, to hint that the struct needs to implement a specific interface.
+var _ interfaces.EntitlementsService = &EntitlementsService{}
+
+// NewEntitlementsService returns a new EntitlementsService instance.
+func NewEntitlementsService(db *Database) *EntitlementsService {
+	return &EntitlementsService{db: db}
+}
+
+// ListEntitlements returns the list of entitlements (i.e.,
+// `State.Entitlements`), filtered by the given filter expression.
+func (s *EntitlementsService) ListEntitlements(ctx context.Context, params *resources.GetEntitlementsParams) ([]resources.EntitlementSchema, error) {
+	result := []resources.EntitlementSchema{}
+	s.db.view(func(state *State) {
+		result = append(result, state.Entitlements...)
+	})
+	return filterEntities(params.Filter, result)
+}
+
+// RawEntitlements returns the raw authorization model (i.e., `State.AuthModel`).
+func (s *EntitlementsService) RawEntitlements(ctx context.Context) (string, error) {
+	var result string
+	s.db.view(func(state *State) {
+		result = state.AuthModel
+	})
+	return result, nil
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package memory
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+func TestEntitlementsService(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	entitlements := []resources.EntitlementSchema{
+		{Entitlement: "administrator", EntityType: "controller", ReceiverType: "identity"},
+		{Entitlement: "reader", EntityType: "model", ReceiverType: "group"},
+	}
+	s := NewEntitlementsService(newTestDatabase(c, &State{
+		Entitlements: entitlements,
+		AuthModel:    "some-model",
+	}))
+
+	result, err := s.ListEntitlements(ctx, &resources.GetEntitlementsParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result, qt.DeepEquals, entitlements)
+
+	filter := "model"
+	result, err = s.ListEntitlements(ctx, &resources.GetEntitlementsParams{Filter: &filter})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result, qt.DeepEquals, entitlements[1:])
+
+	raw, err := s.RawEntitlements(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(raw, qt.Equals, "some-model")
+}
+
+func TestEntitlementString(t *testing.T) {
+	c := qt.New(t)
+
+	e := resources.EntityEntitlement{Entitlement: "can_read", EntityType: "controller", EntityId: "foo:bar"}
+	s := entitlementToString(e)
+	c.Assert(s, qt.Equals, "can_read::controller:foo:bar")
+	c.Assert(entitlementFromString(s), qt.DeepEquals, e)
+}
@@ -0,0 +1,41 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package memory
+
+import (
+	"fmt"
+
+	v1 "github.com/canonical/rebac-admin-ui-handlers/v1"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/filter"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// filterEntities returns the given entities that match the given `filter`
+// query parameter (see the `filter` package), if any.
+func filterEntities[T any](param *resources.FilterParam, entities []T) ([]T, error) {
+	if param == nil {
+		return entities, nil
+	}
+	expr, err := filter.Parse(*param)
+	if err != nil {
+		return nil, v1.NewValidationError(fmt.Sprintf("invalid filter: %s", err))
+	}
+	result, err := filter.Apply(expr, entities)
+	if err != nil {
+		return nil, v1.NewValidationError(fmt.Sprintf("invalid filter: %s", err))
+	}
+	return result, nil
+}
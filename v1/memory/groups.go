@@ -0,0 +1,276 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	v1 "github.com/canonical/rebac-admin-ui-handlers/v1"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/sorting"
+)
+
+// GroupsService implements the `GroupsService` interface on top of a Database.
+type GroupsService struct {
+	db *Database
+}
+
+// For doc/test sake, to hint that the struct needs to implement a specific interface.
+var _ interfaces.GroupsService = &GroupsService{}
+var _ interfaces.GroupsPatchReporter = &GroupsService{}
+
+// NewGroupsService returns a new GroupsService instance.
+func NewGroupsService(db *Database) *GroupsService {
+	return &GroupsService{db: db}
+}
+
+// ListGroups returns a page of Group objects of at least `size` elements if available.
+func (s *GroupsService) ListGroups(ctx context.Context, params *resources.GetGroupsParams) (*resources.PaginatedResponse[resources.Group], error) {
+	var groups []resources.Group
+	s.db.view(func(state *State) {
+		groups = sortedValues(state.Groups)
+	})
+	groups, err := filterEntities(params.Filter, groups)
+	if err != nil {
+		return nil, err
+	}
+	if err := sorting.Sort(groups, sorting.FromContext(ctx)); err != nil {
+		return nil, err
+	}
+	return paginate(s.db.paginator, groups, params.Size, params.Page, params.NextToken, params.NextPageToken, false, listScope(ctx, params.Filter)...)
+}
+
+// CreateGroup creates a single Group. The group name is used as its ID.
+func (s *GroupsService) CreateGroup(ctx context.Context, group *resources.Group) (*resources.Group, error) {
+	if group.Name == "" {
+		return nil, v1.NewValidationError("empty group name")
+	}
+
+	id := group.Name
+	entry := *group
+	entry.Id = &id
+	_, err := s.db.update(func(state *State) (bool, error) {
+		if _, ok := state.Groups[id]; ok {
+			return false, v1.NewInvalidRequestError(fmt.Sprintf("group %q already exists", id))
+		}
+		state.Groups[id] = entry
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// GetGroup returns a single Group identified by `groupId`.
+func (s *GroupsService) GetGroup(ctx context.Context, groupId string) (*resources.Group, error) {
+	var group *resources.Group
+	s.db.view(func(state *State) {
+		if g, ok := state.Groups[groupId]; ok {
+			group = &g
+		}
+	})
+	if group == nil {
+		return nil, groupNotFound(groupId)
+	}
+	return group, nil
+}
+
+// UpdateGroup updates a Group. Note that the group ID does not change, even if
+// the group is renamed.
+func (s *GroupsService) UpdateGroup(ctx context.Context, group *resources.Group) (*resources.Group, error) {
+	if group.Id == nil {
+		return nil, v1.NewValidationError("missing group ID")
+	}
+
+	entry := *group
+	_, err := s.db.update(func(state *State) (bool, error) {
+		if _, ok := state.Groups[*entry.Id]; !ok {
+			return false, groupNotFound(*entry.Id)
+		}
+		state.Groups[*entry.Id] = entry
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// DeleteGroup deletes a Group identified by `groupId`, along with all its
+// relations. It returns (false, nil) if the group does not exist.
+func (s *GroupsService) DeleteGroup(ctx context.Context, groupId string) (bool, error) {
+	return s.db.update(func(state *State) (bool, error) {
+		if _, ok := state.Groups[groupId]; !ok {
+			return false, nil
+		}
+		delete(state.Groups, groupId)
+		state.Group2Identity.removeLeft(groupId)
+		state.Group2Role.removeLeft(groupId)
+		state.Group2Entitlement.removeLeft(groupId)
+		return true, nil
+	})
+}
+
+// GetGroupIdentities returns a page of identities in a Group identified by `groupId`.
+func (s *GroupsService) GetGroupIdentities(ctx context.Context, groupId string, params *resources.GetGroupsItemIdentitiesParams) (*resources.PaginatedResponse[resources.Identity], error) {
+	var identities []resources.Identity
+	found := false
+	s.db.view(func(state *State) {
+		if _, found = state.Groups[groupId]; found {
+			identities = lookup(state.Identities, state.Group2Identity.rights(groupId))
+		}
+	})
+	if !found {
+		return nil, groupNotFound(groupId)
+	}
+	return paginate(s.db.paginator, identities, params.Size, params.Page, params.NextToken, params.NextPageToken, false, listScope(ctx, &groupId)...)
+}
+
+// PatchGroupIdentities performs addition or removal of identities to/from a Group identified by `groupId`.
+func (s *GroupsService) PatchGroupIdentities(ctx context.Context, groupId string, identityPatches []resources.GroupIdentitiesPatchItem) (bool, error) {
+	additions, removals := splitPatches(identityPatches, func(p resources.GroupIdentitiesPatchItem) (string, string) {
+		return p.Identity, string(p.Op)
+	})
+	return s.db.update(func(state *State) (bool, error) {
+		if _, ok := state.Groups[groupId]; !ok {
+			return false, groupNotFound(groupId)
+		}
+		return state.Group2Identity.patchLeft(groupId, additions, removals), nil
+	})
+}
+
+// PatchGroupIdentitiesWithResults works the same as PatchGroupIdentities, but it applies the patch items
+// in order, and reports the outcome of each one.
+func (s *GroupsService) PatchGroupIdentitiesWithResults(ctx context.Context, groupId string, identityPatches []resources.GroupIdentitiesPatchItem) ([]resources.PatchItemResult, error) {
+	var results []resources.PatchItemResult
+	_, err := s.db.update(func(state *State) (bool, error) {
+		if _, ok := state.Groups[groupId]; !ok {
+			return false, groupNotFound(groupId)
+		}
+		var changed bool
+		results, changed = applyPatches(&state.Group2Identity, identityPatches, func(p resources.GroupIdentitiesPatchItem) (RelationshipTuple, string) {
+			return RelationshipTuple{Left: groupId, Right: p.Identity}, string(p.Op)
+		})
+		return changed, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// GetGroupRoles returns a page of Roles for Group `groupId`.
+func (s *GroupsService) GetGroupRoles(ctx context.Context, groupId string, params *resources.GetGroupsItemRolesParams) (*resources.PaginatedResponse[resources.Role], error) {
+	var roles []resources.Role
+	found := false
+	s.db.view(func(state *State) {
+		if _, found = state.Groups[groupId]; found {
+			roles = lookup(state.Roles, state.Group2Role.rights(groupId))
+		}
+	})
+	if !found {
+		return nil, groupNotFound(groupId)
+	}
+	return paginate(s.db.paginator, roles, params.Size, params.Page, params.NextToken, params.NextPageToken, false, listScope(ctx, &groupId)...)
+}
+
+// PatchGroupRoles performs addition or removal of a Role to/from a Group identified by `groupId`.
+func (s *GroupsService) PatchGroupRoles(ctx context.Context, groupId string, rolePatches []resources.GroupRolesPatchItem) (bool, error) {
+	additions, removals := splitPatches(rolePatches, func(p resources.GroupRolesPatchItem) (string, string) {
+		return p.Role, string(p.Op)
+	})
+	return s.db.update(func(state *State) (bool, error) {
+		if _, ok := state.Groups[groupId]; !ok {
+			return false, groupNotFound(groupId)
+		}
+		return state.Group2Role.patchLeft(groupId, additions, removals), nil
+	})
+}
+
+// PatchGroupRolesWithResults works the same as PatchGroupRoles, but it applies the patch items
+// in order, and reports the outcome of each one.
+func (s *GroupsService) PatchGroupRolesWithResults(ctx context.Context, groupId string, rolePatches []resources.GroupRolesPatchItem) ([]resources.PatchItemResult, error) {
+	var results []resources.PatchItemResult
+	_, err := s.db.update(func(state *State) (bool, error) {
+		if _, ok := state.Groups[groupId]; !ok {
+			return false, groupNotFound(groupId)
+		}
+		var changed bool
+		results, changed = applyPatches(&state.Group2Role, rolePatches, func(p resources.GroupRolesPatchItem) (RelationshipTuple, string) {
+			return RelationshipTuple{Left: groupId, Right: p.Role}, string(p.Op)
+		})
+		return changed, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// GetGroupEntitlements returns a page of Entitlements for Group `groupId`.
+func (s *GroupsService) GetGroupEntitlements(ctx context.Context, groupId string, params *resources.GetGroupsItemEntitlementsParams) (*resources.PaginatedResponse[resources.EntityEntitlement], error) {
+	var entitlements []resources.EntityEntitlement
+	found := false
+	s.db.view(func(state *State) {
+		if _, found = state.Groups[groupId]; found {
+			entitlements = entitlementsFromStrings(state.Group2Entitlement.rights(groupId))
+		}
+	})
+	if !found {
+		return nil, groupNotFound(groupId)
+	}
+	return paginate(s.db.paginator, entitlements, params.Size, params.Page, params.NextToken, params.NextPageToken, false, listScope(ctx, &groupId)...)
+}
+
+// PatchGroupEntitlements performs addition or removal of an Entitlement to/from a Group identified by `groupId`.
+func (s *GroupsService) PatchGroupEntitlements(ctx context.Context, groupId string, entitlementPatches []resources.GroupEntitlementsPatchItem) (bool, error) {
+	additions, removals := splitPatches(entitlementPatches, func(p resources.GroupEntitlementsPatchItem) (string, string) {
+		return entitlementToString(p.Entitlement), string(p.Op)
+	})
+	return s.db.update(func(state *State) (bool, error) {
+		if _, ok := state.Groups[groupId]; !ok {
+			return false, groupNotFound(groupId)
+		}
+		return state.Group2Entitlement.patchLeft(groupId, additions, removals), nil
+	})
+}
+
+// PatchGroupEntitlementsWithResults works the same as PatchGroupEntitlements, but it applies the patch items
+// in order, and reports the outcome of each one.
+func (s *GroupsService) PatchGroupEntitlementsWithResults(ctx context.Context, groupId string, entitlementPatches []resources.GroupEntitlementsPatchItem) ([]resources.PatchItemResult, error) {
+	var results []resources.PatchItemResult
+	_, err := s.db.update(func(state *State) (bool, error) {
+		if _, ok := state.Groups[groupId]; !ok {
+			return false, groupNotFound(groupId)
+		}
+		var changed bool
+		results, changed = applyPatches(&state.Group2Entitlement, entitlementPatches, func(p resources.GroupEntitlementsPatchItem) (RelationshipTuple, string) {
+			return RelationshipTuple{Left: groupId, Right: entitlementToString(p.Entitlement)}, string(p.Op)
+		})
+		return changed, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func groupNotFound(groupId string) error {
+	return v1.NewNotFoundError(fmt.Sprintf("group %q not found", groupId))
+}
@@ -0,0 +1,263 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package memory
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+func TestGroupsService_CRUD(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	s := NewGroupsService(newTestDatabase(c, nil))
+
+	group, err := s.CreateGroup(ctx, &resources.Group{Name: "admins"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(*group.Id, qt.Equals, "admins")
+	c.Assert(group.Name, qt.Equals, "admins")
+
+	_, err = s.CreateGroup(ctx, &resources.Group{Name: "admins"})
+	c.Assert(err, qt.ErrorMatches, `Bad Request: invalid request: group "admins" already exists`)
+
+	_, err = s.CreateGroup(ctx, &resources.Group{})
+	c.Assert(err, qt.ErrorMatches, "Bad Request: empty group name")
+
+	_, err = s.CreateGroup(ctx, &resources.Group{Name: "viewers"})
+	c.Assert(err, qt.IsNil)
+
+	group, err = s.GetGroup(ctx, "admins")
+	c.Assert(err, qt.IsNil)
+	c.Assert(group.Name, qt.Equals, "admins")
+
+	_, err = s.GetGroup(ctx, "missing")
+	c.Assert(err, qt.ErrorMatches, `Not Found: group "missing" not found`)
+
+	// Renaming a group keeps its ID.
+	group, err = s.UpdateGroup(ctx, &resources.Group{Id: stringPtr("admins"), Name: "administrators"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(*group.Id, qt.Equals, "admins")
+	group, err = s.GetGroup(ctx, "admins")
+	c.Assert(err, qt.IsNil)
+	c.Assert(group.Name, qt.Equals, "administrators")
+
+	_, err = s.UpdateGroup(ctx, &resources.Group{Name: "missing"})
+	c.Assert(err, qt.ErrorMatches, "Bad Request: missing group ID")
+
+	_, err = s.UpdateGroup(ctx, &resources.Group{Id: stringPtr("missing"), Name: "missing"})
+	c.Assert(err, qt.ErrorMatches, `Not Found: group "missing" not found`)
+
+	groups, err := s.ListGroups(ctx, &resources.GetGroupsParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(groups.Data, qt.HasLen, 2)
+	c.Assert(groups.Data[0].Name, qt.Equals, "administrators")
+	c.Assert(groups.Data[1].Name, qt.Equals, "viewers")
+
+	filter := "view"
+	groups, err = s.ListGroups(ctx, &resources.GetGroupsParams{Filter: &filter})
+	c.Assert(err, qt.IsNil)
+	c.Assert(groups.Data, qt.HasLen, 1)
+	c.Assert(groups.Data[0].Name, qt.Equals, "viewers")
+
+	filter = "name startsWith admin or name eq viewers"
+	groups, err = s.ListGroups(ctx, &resources.GetGroupsParams{Filter: &filter})
+	c.Assert(err, qt.IsNil)
+	c.Assert(groups.Data, qt.HasLen, 2)
+
+	filter = "name eq"
+	_, err = s.ListGroups(ctx, &resources.GetGroupsParams{Filter: &filter})
+	c.Assert(err, qt.ErrorMatches, `Bad Request: invalid filter: .*`)
+
+	deleted, err := s.DeleteGroup(ctx, "admins")
+	c.Assert(err, qt.IsNil)
+	c.Assert(deleted, qt.IsTrue)
+
+	deleted, err = s.DeleteGroup(ctx, "admins")
+	c.Assert(err, qt.IsNil)
+	c.Assert(deleted, qt.IsFalse)
+}
+
+func TestGroupsService_Relations(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	db := newTestDatabase(c, &State{
+		Groups: map[string]resources.Group{
+			"admins": {Id: stringPtr("admins"), Name: "admins"},
+		},
+		Identities: map[string]resources.Identity{
+			"joe@example.com": {Id: stringPtr("joe@example.com"), Email: "joe@example.com"},
+		},
+		Roles: map[string]resources.Role{
+			"viewer": {Id: stringPtr("viewer"), Name: "viewer"},
+		},
+	})
+	s := NewGroupsService(db)
+
+	changed, err := s.PatchGroupIdentities(ctx, "admins", []resources.GroupIdentitiesPatchItem{
+		{Identity: "joe@example.com", Op: resources.GroupIdentitiesPatchItemOpAdd},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(changed, qt.IsTrue)
+
+	// Adding the same identity again is a no-op.
+	changed, err = s.PatchGroupIdentities(ctx, "admins", []resources.GroupIdentitiesPatchItem{
+		{Identity: "joe@example.com", Op: resources.GroupIdentitiesPatchItemOpAdd},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(changed, qt.IsFalse)
+
+	identities, err := s.GetGroupIdentities(ctx, "admins", &resources.GetGroupsItemIdentitiesParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(identities.Data, qt.HasLen, 1)
+	c.Assert(identities.Data[0].Email, qt.Equals, "joe@example.com")
+
+	// The relation should be visible from the identity side as well.
+	groups, err := NewIdentitiesService(db).GetIdentityGroups(ctx, "joe@example.com", &resources.GetIdentitiesItemGroupsParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(groups.Data, qt.HasLen, 1)
+	c.Assert(groups.Data[0].Name, qt.Equals, "admins")
+
+	changed, err = s.PatchGroupRoles(ctx, "admins", []resources.GroupRolesPatchItem{
+		{Role: "viewer", Op: resources.GroupRolesPatchItemOpAdd},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(changed, qt.IsTrue)
+
+	roles, err := s.GetGroupRoles(ctx, "admins", &resources.GetGroupsItemRolesParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(roles.Data, qt.HasLen, 1)
+	c.Assert(roles.Data[0].Name, qt.Equals, "viewer")
+
+	entitlement := resources.EntityEntitlement{Entitlement: "administrator", EntityType: "controller", EntityId: "ctrl:1"}
+	changed, err = s.PatchGroupEntitlements(ctx, "admins", []resources.GroupEntitlementsPatchItem{
+		{Entitlement: entitlement, Op: resources.GroupEntitlementsPatchItemOpAdd},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(changed, qt.IsTrue)
+
+	entitlements, err := s.GetGroupEntitlements(ctx, "admins", &resources.GetGroupsItemEntitlementsParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(entitlements.Data, qt.DeepEquals, []resources.EntityEntitlement{entitlement})
+
+	changed, err = s.PatchGroupIdentities(ctx, "admins", []resources.GroupIdentitiesPatchItem{
+		{Identity: "joe@example.com", Op: resources.GroupIdentitiesPatchItemOpRemove},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(changed, qt.IsTrue)
+
+	identities, err = s.GetGroupIdentities(ctx, "admins", &resources.GetGroupsItemIdentitiesParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(identities.Data, qt.HasLen, 0)
+
+	// Deleting the group removes its relations.
+	_, err = s.DeleteGroup(ctx, "admins")
+	c.Assert(err, qt.IsNil)
+	snapshot := db.Snapshot()
+	c.Assert(snapshot.Group2Role.Tuples, qt.HasLen, 0)
+	c.Assert(snapshot.Group2Entitlement.Tuples, qt.HasLen, 0)
+
+	_, err = s.GetGroupIdentities(ctx, "admins", &resources.GetGroupsItemIdentitiesParams{})
+	c.Assert(err, qt.ErrorMatches, `Not Found: group "admins" not found`)
+	_, err = s.GetGroupRoles(ctx, "admins", &resources.GetGroupsItemRolesParams{})
+	c.Assert(err, qt.ErrorMatches, `Not Found: group "admins" not found`)
+	_, err = s.GetGroupEntitlements(ctx, "admins", &resources.GetGroupsItemEntitlementsParams{})
+	c.Assert(err, qt.ErrorMatches, `Not Found: group "admins" not found`)
+	_, err = s.PatchGroupIdentities(ctx, "admins", nil)
+	c.Assert(err, qt.ErrorMatches, `Not Found: group "admins" not found`)
+	_, err = s.PatchGroupRoles(ctx, "admins", nil)
+	c.Assert(err, qt.ErrorMatches, `Not Found: group "admins" not found`)
+	_, err = s.PatchGroupEntitlements(ctx, "admins", nil)
+	c.Assert(err, qt.ErrorMatches, `Not Found: group "admins" not found`)
+}
+
+func TestGroupsService_RelationsPageTokenScope(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	db := newTestDatabase(c, &State{
+		Groups: map[string]resources.Group{
+			"admins":  {Id: stringPtr("admins"), Name: "admins"},
+			"viewers": {Id: stringPtr("viewers"), Name: "viewers"},
+		},
+	})
+	s := NewGroupsService(db)
+
+	// A token issued for the identities of one group.
+	size := 1
+	first, err := resources.Paginate(db.paginator, []int{0, 1}, resources.PageRequest{
+		Size:                &size,
+		PreferNextPageToken: true,
+		Scope:               listScope(ctx, stringPtr("admins")),
+	})
+	c.Assert(err, qt.IsNil)
+
+	_, err = s.GetGroupIdentities(ctx, "admins", &resources.GetGroupsItemIdentitiesParams{NextToken: first.Next.PageToken})
+	c.Assert(err, qt.IsNil)
+
+	_, err = s.GetGroupIdentities(ctx, "viewers", &resources.GetGroupsItemIdentitiesParams{NextToken: first.Next.PageToken})
+	c.Assert(err, qt.ErrorMatches, "invalid page token: token was issued for different query parameters")
+}
+
+func TestGroupsService_PatchResults(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	db := newTestDatabase(c, &State{
+		Groups: map[string]resources.Group{
+			"admins": {Id: stringPtr("admins"), Name: "admins"},
+		},
+		Identities: map[string]resources.Identity{
+			"joe@example.com": {Id: stringPtr("joe@example.com"), Email: "joe@example.com"},
+		},
+	})
+	s := NewGroupsService(db)
+
+	results, err := s.PatchGroupIdentitiesWithResults(ctx, "admins", []resources.GroupIdentitiesPatchItem{
+		{Identity: "joe@example.com", Op: resources.GroupIdentitiesPatchItemOpAdd},
+		{Identity: "joe@example.com", Op: resources.GroupIdentitiesPatchItemOpAdd},
+		{Identity: "jane@example.com", Op: resources.GroupIdentitiesPatchItemOpRemove},
+		{Identity: "joe@example.com", Op: resources.GroupIdentitiesPatchItemOpRemove},
+		{Identity: "joe@example.com", Op: "replace"},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(results, qt.HasLen, 5)
+	c.Assert(results[0], qt.DeepEquals, resources.PatchItemResult{Outcome: resources.PatchItemOutcomeApplied})
+	c.Assert(results[1], qt.DeepEquals, resources.PatchItemResult{Outcome: resources.PatchItemOutcomeAlreadyPresent})
+	c.Assert(results[2], qt.DeepEquals, resources.PatchItemResult{Outcome: resources.PatchItemOutcomeNotPresent})
+	c.Assert(results[3], qt.DeepEquals, resources.PatchItemResult{Outcome: resources.PatchItemOutcomeApplied})
+	c.Assert(results[4].Outcome, qt.Equals, resources.PatchItemOutcomeFailed)
+	c.Assert(results[4].Error, qt.ErrorMatches, `Bad Request: invalid patch operation "replace"`)
+
+	// The patch items are applied in order.
+	identities, err := s.GetGroupIdentities(ctx, "admins", &resources.GetGroupsItemIdentitiesParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(identities.Data, qt.HasLen, 0)
+
+	results, err = s.PatchGroupRolesWithResults(ctx, "admins", []resources.GroupRolesPatchItem{
+		{Role: "viewer", Op: resources.GroupRolesPatchItemOpRemove},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(results, qt.DeepEquals, []resources.PatchItemResult{{Outcome: resources.PatchItemOutcomeNotPresent}})
+
+	_, err = s.PatchGroupEntitlementsWithResults(ctx, "missing", []resources.GroupEntitlementsPatchItem{})
+	c.Assert(err, qt.ErrorMatches, `Not Found: group "missing" not found`)
+}
@@ -0,0 +1,279 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	v1 "github.com/canonical/rebac-admin-ui-handlers/v1"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/sorting"
+)
+
+// IdentitiesService implements the `IdentitiesService` interface on top of a
+// Database.
+type IdentitiesService struct {
+	db *Database
+}
+
+// For doc/test sake, to hint that the struct needs to implement a specific interface.
+var _ interfaces.IdentitiesService = &IdentitiesService{}
+var _ interfaces.IdentitiesPatchReporter = &IdentitiesService{}
+
+// NewIdentitiesService returns a new IdentitiesService instance.
+func NewIdentitiesService(db *Database) *IdentitiesService {
+	return &IdentitiesService{db: db}
+}
+
+// ListIdentities returns a page of Identity objects of at least `size` elements
+// if available. To demonstrate both pagination mechanisms, identities are
+// always paginated with next page tokens.
+func (s *IdentitiesService) ListIdentities(ctx context.Context, params *resources.GetIdentitiesParams) (*resources.PaginatedResponse[resources.Identity], error) {
+	var identities []resources.Identity
+	s.db.view(func(state *State) {
+		identities = sortedValues(state.Identities)
+	})
+	identities, err := filterEntities(params.Filter, identities)
+	if err != nil {
+		return nil, err
+	}
+	if err := sorting.Sort(identities, sorting.FromContext(ctx)); err != nil {
+		return nil, err
+	}
+	return paginate(s.db.paginator, identities, params.Size, params.Page, params.NextToken, params.NextPageToken, true, listScope(ctx, params.Filter)...)
+}
+
+// CreateIdentity creates a single Identity. The identity email is used as its ID.
+func (s *IdentitiesService) CreateIdentity(ctx context.Context, identity *resources.Identity) (*resources.Identity, error) {
+	if identity.Email == "" {
+		return nil, v1.NewValidationError("empty identity email")
+	}
+
+	id := identity.Email
+	entry := *identity
+	entry.Id = &id
+	_, err := s.db.update(func(state *State) (bool, error) {
+		if _, ok := state.Identities[id]; ok {
+			return false, v1.NewInvalidRequestError(fmt.Sprintf("identity %q already exists", id))
+		}
+		state.Identities[id] = entry
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// GetIdentity returns a single Identity identified by `identityId`.
+func (s *IdentitiesService) GetIdentity(ctx context.Context, identityId string) (*resources.Identity, error) {
+	var identity *resources.Identity
+	s.db.view(func(state *State) {
+		if i, ok := state.Identities[identityId]; ok {
+			identity = &i
+		}
+	})
+	if identity == nil {
+		return nil, identityNotFound(identityId)
+	}
+	return identity, nil
+}
+
+// UpdateIdentity updates an Identity. Note that the identity ID does not
+// change, even if the email is changed.
+func (s *IdentitiesService) UpdateIdentity(ctx context.Context, identity *resources.Identity) (*resources.Identity, error) {
+	if identity.Id == nil {
+		return nil, v1.NewValidationError("missing identity ID")
+	}
+
+	entry := *identity
+	_, err := s.db.update(func(state *State) (bool, error) {
+		if _, ok := state.Identities[*entry.Id]; !ok {
+			return false, identityNotFound(*entry.Id)
+		}
+		state.Identities[*entry.Id] = entry
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// DeleteIdentity deletes an Identity identified by `identityId`, along with
+// all its relations. It returns (false, nil) if the identity does not exist.
+func (s *IdentitiesService) DeleteIdentity(ctx context.Context, identityId string) (bool, error) {
+	return s.db.update(func(state *State) (bool, error) {
+		if _, ok := state.Identities[identityId]; !ok {
+			return false, nil
+		}
+		delete(state.Identities, identityId)
+		state.Group2Identity.removeRight(identityId)
+		state.Identity2Role.removeLeft(identityId)
+		state.Identity2Entitlement.removeLeft(identityId)
+		return true, nil
+	})
+}
+
+// GetIdentityGroups returns a page of Groups for identity `identityId`.
+func (s *IdentitiesService) GetIdentityGroups(ctx context.Context, identityId string, params *resources.GetIdentitiesItemGroupsParams) (*resources.PaginatedResponse[resources.Group], error) {
+	var groups []resources.Group
+	found := false
+	s.db.view(func(state *State) {
+		if _, found = state.Identities[identityId]; found {
+			groups = lookup(state.Groups, state.Group2Identity.lefts(identityId))
+		}
+	})
+	if !found {
+		return nil, identityNotFound(identityId)
+	}
+	return paginate(s.db.paginator, groups, params.Size, params.Page, params.NextToken, params.NextPageToken, false, listScope(ctx, &identityId)...)
+}
+
+// PatchIdentityGroups performs addition or removal of a Group to/from an Identity.
+func (s *IdentitiesService) PatchIdentityGroups(ctx context.Context, identityId string, groupPatches []resources.IdentityGroupsPatchItem) (bool, error) {
+	additions, removals := splitPatches(groupPatches, func(p resources.IdentityGroupsPatchItem) (string, string) {
+		return p.Group, string(p.Op)
+	})
+	return s.db.update(func(state *State) (bool, error) {
+		if _, ok := state.Identities[identityId]; !ok {
+			return false, identityNotFound(identityId)
+		}
+		return state.Group2Identity.patchRight(identityId, additions, removals), nil
+	})
+}
+
+// PatchIdentityGroupsWithResults works the same as PatchIdentityGroups, but it applies the patch items
+// in order, and reports the outcome of each one.
+func (s *IdentitiesService) PatchIdentityGroupsWithResults(ctx context.Context, identityId string, groupPatches []resources.IdentityGroupsPatchItem) ([]resources.PatchItemResult, error) {
+	var results []resources.PatchItemResult
+	_, err := s.db.update(func(state *State) (bool, error) {
+		if _, ok := state.Identities[identityId]; !ok {
+			return false, identityNotFound(identityId)
+		}
+		var changed bool
+		results, changed = applyPatches(&state.Group2Identity, groupPatches, func(p resources.IdentityGroupsPatchItem) (RelationshipTuple, string) {
+			return RelationshipTuple{Left: p.Group, Right: identityId}, string(p.Op)
+		})
+		return changed, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// GetIdentityRoles returns a page of Roles for identity `identityId`.
+func (s *IdentitiesService) GetIdentityRoles(ctx context.Context, identityId string, params *resources.GetIdentitiesItemRolesParams) (*resources.PaginatedResponse[resources.Role], error) {
+	var roles []resources.Role
+	found := false
+	s.db.view(func(state *State) {
+		if _, found = state.Identities[identityId]; found {
+			roles = lookup(state.Roles, state.Identity2Role.rights(identityId))
+		}
+	})
+	if !found {
+		return nil, identityNotFound(identityId)
+	}
+	return paginate(s.db.paginator, roles, params.Size, params.Page, params.NextToken, params.NextPageToken, false, listScope(ctx, &identityId)...)
+}
+
+// PatchIdentityRoles performs addition or removal of a Role to/from an Identity.
+func (s *IdentitiesService) PatchIdentityRoles(ctx context.Context, identityId string, rolePatches []resources.IdentityRolesPatchItem) (bool, error) {
+	additions, removals := splitPatches(rolePatches, func(p resources.IdentityRolesPatchItem) (string, string) {
+		return p.Role, string(p.Op)
+	})
+	return s.db.update(func(state *State) (bool, error) {
+		if _, ok := state.Identities[identityId]; !ok {
+			return false, identityNotFound(identityId)
+		}
+		return state.Identity2Role.patchLeft(identityId, additions, removals), nil
+	})
+}
+
+// PatchIdentityRolesWithResults works the same as PatchIdentityRoles, but it applies the patch items
+// in order, and reports the outcome of each one.
+func (s *IdentitiesService) PatchIdentityRolesWithResults(ctx context.Context, identityId string, rolePatches []resources.IdentityRolesPatchItem) ([]resources.PatchItemResult, error) {
+	var results []resources.PatchItemResult
+	_, err := s.db.update(func(state *State) (bool, error) {
+		if _, ok := state.Identities[identityId]; !ok {
+			return false, identityNotFound(identityId)
+		}
+		var changed bool
+		results, changed = applyPatches(&state.Identity2Role, rolePatches, func(p resources.IdentityRolesPatchItem) (RelationshipTuple, string) {
+			return RelationshipTuple{Left: identityId, Right: p.Role}, string(p.Op)
+		})
+		return changed, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// GetIdentityEntitlements returns a page of Entitlements for identity `identityId`.
+func (s *IdentitiesService) GetIdentityEntitlements(ctx context.Context, identityId string, params *resources.GetIdentitiesItemEntitlementsParams) (*resources.PaginatedResponse[resources.EntityEntitlement], error) {
+	var entitlements []resources.EntityEntitlement
+	found := false
+	s.db.view(func(state *State) {
+		if _, found = state.Identities[identityId]; found {
+			entitlements = entitlementsFromStrings(state.Identity2Entitlement.rights(identityId))
+		}
+	})
+	if !found {
+		return nil, identityNotFound(identityId)
+	}
+	return paginate(s.db.paginator, entitlements, params.Size, params.Page, params.NextToken, params.NextPageToken, false, listScope(ctx, &identityId)...)
+}
+
+// PatchIdentityEntitlements performs addition or removal of an Entitlement to/from an Identity.
+func (s *IdentitiesService) PatchIdentityEntitlements(ctx context.Context, identityId string, entitlementPatches []resources.IdentityEntitlementsPatchItem) (bool, error) {
+	additions, removals := splitPatches(entitlementPatches, func(p resources.IdentityEntitlementsPatchItem) (string, string) {
+		return entitlementToString(p.Entitlement), string(p.Op)
+	})
+	return s.db.update(func(state *State) (bool, error) {
+		if _, ok := state.Identities[identityId]; !ok {
+			return false, identityNotFound(identityId)
+		}
+		return state.Identity2Entitlement.patchLeft(identityId, additions, removals), nil
+	})
+}
+
+// PatchIdentityEntitlementsWithResults works the same as PatchIdentityEntitlements, but it applies the patch items
+// in order, and reports the outcome of each one.
+func (s *IdentitiesService) PatchIdentityEntitlementsWithResults(ctx context.Context, identityId string, entitlementPatches []resources.IdentityEntitlementsPatchItem) ([]resources.PatchItemResult, error) {
+	var results []resources.PatchItemResult
+	_, err := s.db.update(func(state *State) (bool, error) {
+		if _, ok := state.Identities[identityId]; !ok {
+			return false, identityNotFound(identityId)
+		}
+		var changed bool
+		results, changed = applyPatches(&state.Identity2Entitlement, entitlementPatches, func(p resources.IdentityEntitlementsPatchItem) (RelationshipTuple, string) {
+			return RelationshipTuple{Left: identityId, Right: entitlementToString(p.Entitlement)}, string(p.Op)
+		})
+		return changed, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func identityNotFound(identityId string) error {
+	return v1.NewNotFoundError(fmt.Sprintf("identity %q not found", identityId))
+}
@@ -0,0 +1,164 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package memory
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+func TestIdentitiesService_CRUD(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	s := NewIdentitiesService(newTestDatabase(c, nil))
+
+	identity, err := s.CreateIdentity(ctx, &resources.Identity{Email: "joe@example.com", AddedBy: "admin", Source: "local"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(*identity.Id, qt.Equals, "joe@example.com")
+	c.Assert(identity.AddedBy, qt.Equals, "admin")
+
+	_, err = s.CreateIdentity(ctx, &resources.Identity{Email: "joe@example.com"})
+	c.Assert(err, qt.ErrorMatches, `Bad Request: invalid request: identity "joe@example.com" already exists`)
+
+	_, err = s.CreateIdentity(ctx, &resources.Identity{})
+	c.Assert(err, qt.ErrorMatches, "Bad Request: empty identity email")
+
+	_, err = s.GetIdentity(ctx, "missing")
+	c.Assert(err, qt.ErrorMatches, `Not Found: identity "missing" not found`)
+
+	identity, err = s.UpdateIdentity(ctx, &resources.Identity{Id: stringPtr("joe@example.com"), Email: "joe@example.com", FirstName: stringPtr("Joe")})
+	c.Assert(err, qt.IsNil)
+	c.Assert(*identity.FirstName, qt.Equals, "Joe")
+
+	identity, err = s.GetIdentity(ctx, "joe@example.com")
+	c.Assert(err, qt.IsNil)
+	c.Assert(*identity.FirstName, qt.Equals, "Joe")
+
+	_, err = s.UpdateIdentity(ctx, &resources.Identity{Email: "joe@example.com"})
+	c.Assert(err, qt.ErrorMatches, "Bad Request: missing identity ID")
+
+	_, err = s.UpdateIdentity(ctx, &resources.Identity{Id: stringPtr("missing")})
+	c.Assert(err, qt.ErrorMatches, `Not Found: identity "missing" not found`)
+
+	deleted, err := s.DeleteIdentity(ctx, "joe@example.com")
+	c.Assert(err, qt.IsNil)
+	c.Assert(deleted, qt.IsTrue)
+
+	deleted, err = s.DeleteIdentity(ctx, "joe@example.com")
+	c.Assert(err, qt.IsNil)
+	c.Assert(deleted, qt.IsFalse)
+}
+
+func TestIdentitiesService_ListIdentities(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	s := NewIdentitiesService(newTestDatabase(c, nil))
+	for _, email := range []string{"c@example.com", "a@example.com", "b@example.org"} {
+		_, err := s.CreateIdentity(ctx, &resources.Identity{Email: email})
+		c.Assert(err, qt.IsNil)
+	}
+
+	// Identities are paginated with next page tokens.
+	size := 2
+	page, err := s.ListIdentities(ctx, &resources.GetIdentitiesParams{Size: &size})
+	c.Assert(err, qt.IsNil)
+	c.Assert(page.Data, qt.HasLen, 2)
+	c.Assert(page.Data[0].Email, qt.Equals, "a@example.com")
+	c.Assert(page.Data[1].Email, qt.Equals, "b@example.org")
+	c.Assert(page.Next.PageToken, qt.IsNotNil)
+
+	page, err = s.ListIdentities(ctx, &resources.GetIdentitiesParams{NextPageToken: page.Next.PageToken})
+	c.Assert(err, qt.IsNil)
+	c.Assert(page.Data, qt.HasLen, 1)
+	c.Assert(page.Data[0].Email, qt.Equals, "c@example.com")
+	c.Assert(page.Next.PageToken, qt.IsNil)
+
+	filter := "example.com"
+	page, err = s.ListIdentities(ctx, &resources.GetIdentitiesParams{Filter: &filter})
+	c.Assert(err, qt.IsNil)
+	c.Assert(page.Data, qt.HasLen, 2)
+}
+
+func TestIdentitiesService_Relations(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	db := newTestDatabase(c, &State{
+		Groups: map[string]resources.Group{
+			"admins": {Id: stringPtr("admins"), Name: "admins"},
+		},
+		Identities: map[string]resources.Identity{
+			"joe@example.com": {Id: stringPtr("joe@example.com"), Email: "joe@example.com"},
+		},
+		Roles: map[string]resources.Role{
+			"viewer": {Id: stringPtr("viewer"), Name: "viewer"},
+		},
+	})
+	s := NewIdentitiesService(db)
+
+	changed, err := s.PatchIdentityGroups(ctx, "joe@example.com", []resources.IdentityGroupsPatchItem{
+		{Group: "admins", Op: resources.IdentityGroupsPatchItemOpAdd},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(changed, qt.IsTrue)
+
+	// The relation should be visible from the group side as well.
+	identities, err := NewGroupsService(db).GetGroupIdentities(ctx, "admins", &resources.GetGroupsItemIdentitiesParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(identities.Data, qt.HasLen, 1)
+	c.Assert(identities.Data[0].Email, qt.Equals, "joe@example.com")
+
+	changed, err = s.PatchIdentityRoles(ctx, "joe@example.com", []resources.IdentityRolesPatchItem{
+		{Role: "viewer", Op: resources.IdentityRolesPatchItemOpAdd},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(changed, qt.IsTrue)
+
+	roles, err := s.GetIdentityRoles(ctx, "joe@example.com", &resources.GetIdentitiesItemRolesParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(roles.Data, qt.HasLen, 1)
+	c.Assert(roles.Data[0].Name, qt.Equals, "viewer")
+
+	entitlement := resources.EntityEntitlement{Entitlement: "reader", EntityType: "model", EntityId: "m1"}
+	changed, err = s.PatchIdentityEntitlements(ctx, "joe@example.com", []resources.IdentityEntitlementsPatchItem{
+		{Entitlement: entitlement, Op: resources.IdentityEntitlementsPatchItemOpAdd},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(changed, qt.IsTrue)
+
+	entitlements, err := s.GetIdentityEntitlements(ctx, "joe@example.com", &resources.GetIdentitiesItemEntitlementsParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(entitlements.Data, qt.DeepEquals, []resources.EntityEntitlement{entitlement})
+
+	// Deleting the identity removes its relations.
+	_, err = s.DeleteIdentity(ctx, "joe@example.com")
+	c.Assert(err, qt.IsNil)
+	snapshot := db.Snapshot()
+	c.Assert(snapshot.Group2Identity.Tuples, qt.HasLen, 0)
+	c.Assert(snapshot.Identity2Role.Tuples, qt.HasLen, 0)
+	c.Assert(snapshot.Identity2Entitlement.Tuples, qt.HasLen, 0)
+
+	_, err = s.GetIdentityGroups(ctx, "joe@example.com", &resources.GetIdentitiesItemGroupsParams{})
+	c.Assert(err, qt.ErrorMatches, `Not Found: identity "joe@example.com" not found`)
+	_, err = s.PatchIdentityRoles(ctx, "joe@example.com", nil)
+	c.Assert(err, qt.ErrorMatches, `Not Found: identity "joe@example.com" not found`)
+}
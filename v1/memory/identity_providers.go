@@ -0,0 +1,136 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	v1 "github.com/canonical/rebac-admin-ui-handlers/v1"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/sorting"
+)
+
+// IdentityProvidersService implements the `IdentityProvidersService` interface
+// on top of a Database.
+type IdentityProvidersService struct {
+	db *Database
+}
+
+// For doc/test sake, to hint that the struct needs to implement a specific interface.
+var _ interfaces.IdentityProvidersService = &IdentityProvidersService{}
+
+// NewIdentityProvidersService returns a new IdentityProvidersService instance.
+func NewIdentityProvidersService(db *Database) *IdentityProvidersService {
+	return &IdentityProvidersService{db: db}
+}
+
+// ListAvailableIdentityProviders returns the static list of supported identity
+// providers (i.e., `State.AvailableIdentityProviders`).
+func (s *IdentityProvidersService) ListAvailableIdentityProviders(ctx context.Context, params *resources.GetAvailableIdentityProvidersParams) (*resources.PaginatedResponse[resources.AvailableIdentityProvider], error) {
+	var idps []resources.AvailableIdentityProvider
+	s.db.view(func(state *State) {
+		idps = state.AvailableIdentityProviders
+	})
+	return paginate(s.db.paginator, idps, params.Size, params.Page, params.NextToken, params.NextPageToken, false, listScope(ctx)...)
+}
+
+// ListIdentityProviders returns a list of registered identity providers configurations.
+func (s *IdentityProvidersService) ListIdentityProviders(ctx context.Context, params *resources.GetIdentityProvidersParams) (*resources.PaginatedResponse[resources.IdentityProvider], error) {
+	var idps []resources.IdentityProvider
+	s.db.view(func(state *State) {
+		idps = sortedValues(state.Idps)
+	})
+	if err := sorting.Sort(idps, sorting.FromContext(ctx)); err != nil {
+		return nil, err
+	}
+	return paginate(s.db.paginator, idps, params.Size, params.Page, params.NextToken, params.NextPageToken, false, listScope(ctx)...)
+}
+
+// RegisterConfiguration registers a new identity provider configuration. The
+// provider name, which is required here, is used as its ID.
+func (s *IdentityProvidersService) RegisterConfiguration(ctx context.Context, provider *resources.IdentityProvider) (*resources.IdentityProvider, error) {
+	if provider.Name == nil || *provider.Name == "" {
+		return nil, v1.NewValidationError("missing identity provider name")
+	}
+
+	id := *provider.Name
+	entry := *provider
+	entry.Id = &id
+	_, err := s.db.update(func(state *State) (bool, error) {
+		if _, ok := state.Idps[id]; ok {
+			return false, v1.NewInvalidRequestError(fmt.Sprintf("identity provider %q already exists", id))
+		}
+		state.Idps[id] = entry
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// DeleteConfiguration removes an identity provider configuration identified by
+// `id`. It returns (false, nil) if the identity provider does not exist.
+func (s *IdentityProvidersService) DeleteConfiguration(ctx context.Context, id string) (bool, error) {
+	return s.db.update(func(state *State) (bool, error) {
+		if _, ok := state.Idps[id]; !ok {
+			return false, nil
+		}
+		delete(state.Idps, id)
+		return true, nil
+	})
+}
+
+// GetConfiguration returns the identity provider configuration identified by `id`.
+func (s *IdentityProvidersService) GetConfiguration(ctx context.Context, id string) (*resources.IdentityProvider, error) {
+	var idp *resources.IdentityProvider
+	s.db.view(func(state *State) {
+		if i, ok := state.Idps[id]; ok {
+			idp = &i
+		}
+	})
+	if idp == nil {
+		return nil, identityProviderNotFound(id)
+	}
+	return idp, nil
+}
+
+// UpdateConfiguration updates the identity provider configuration identified by
+// `provider.Id`.
+func (s *IdentityProvidersService) UpdateConfiguration(ctx context.Context, provider *resources.IdentityProvider) (*resources.IdentityProvider, error) {
+	if provider.Id == nil {
+		return nil, v1.NewValidationError("missing identity provider ID")
+	}
+
+	entry := *provider
+	_, err := s.db.update(func(state *State) (bool, error) {
+		if _, ok := state.Idps[*entry.Id]; !ok {
+			return false, identityProviderNotFound(*entry.Id)
+		}
+		state.Idps[*entry.Id] = entry
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func identityProviderNotFound(id string) error {
+	return v1.NewNotFoundError(fmt.Sprintf("identity provider %q not found", id))
+}
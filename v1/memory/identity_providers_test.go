@@ -0,0 +1,81 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package memory
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+func TestIdentityProvidersService(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	s := NewIdentityProvidersService(newTestDatabase(c, &State{
+		AvailableIdentityProviders: []resources.AvailableIdentityProvider{
+			{Id: "github", Name: stringPtr("GitHub")},
+		},
+	}))
+
+	available, err := s.ListAvailableIdentityProviders(ctx, &resources.GetAvailableIdentityProvidersParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(available.Data, qt.HasLen, 1)
+	c.Assert(available.Data[0].Id, qt.Equals, "github")
+
+	idp, err := s.RegisterConfiguration(ctx, &resources.IdentityProvider{Name: stringPtr("github")})
+	c.Assert(err, qt.IsNil)
+	c.Assert(*idp.Id, qt.Equals, "github")
+
+	_, err = s.RegisterConfiguration(ctx, &resources.IdentityProvider{Name: stringPtr("github")})
+	c.Assert(err, qt.ErrorMatches, `Bad Request: invalid request: identity provider "github" already exists`)
+
+	_, err = s.RegisterConfiguration(ctx, &resources.IdentityProvider{})
+	c.Assert(err, qt.ErrorMatches, "Bad Request: missing identity provider name")
+
+	enabled := true
+	idp, err = s.UpdateConfiguration(ctx, &resources.IdentityProvider{Id: stringPtr("github"), Name: stringPtr("github"), Enabled: &enabled})
+	c.Assert(err, qt.IsNil)
+	c.Assert(*idp.Enabled, qt.IsTrue)
+
+	idp, err = s.GetConfiguration(ctx, "github")
+	c.Assert(err, qt.IsNil)
+	c.Assert(*idp.Enabled, qt.IsTrue)
+
+	_, err = s.UpdateConfiguration(ctx, &resources.IdentityProvider{})
+	c.Assert(err, qt.ErrorMatches, "Bad Request: missing identity provider ID")
+
+	_, err = s.UpdateConfiguration(ctx, &resources.IdentityProvider{Id: stringPtr("missing")})
+	c.Assert(err, qt.ErrorMatches, `Not Found: identity provider "missing" not found`)
+
+	_, err = s.GetConfiguration(ctx, "missing")
+	c.Assert(err, qt.ErrorMatches, `Not Found: identity provider "missing" not found`)
+
+	idps, err := s.ListIdentityProviders(ctx, &resources.GetIdentityProvidersParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(idps.Data, qt.HasLen, 1)
+
+	deleted, err := s.DeleteConfiguration(ctx, "github")
+	c.Assert(err, qt.IsNil)
+	c.Assert(deleted, qt.IsTrue)
+
+	deleted, err = s.DeleteConfiguration(ctx, "github")
+	c.Assert(err, qt.IsNil)
+	c.Assert(deleted, qt.IsFalse)
+}
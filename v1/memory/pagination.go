@@ -0,0 +1,65 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package memory
+
+import (
+	"context"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/sorting"
+)
+
+// listScope returns the scope of next page tokens of list operations, which
+// consists of the given parameters (e.g., the filter, or the ID of the entity
+// whose relations are listed) and the sort order.
+func listScope(ctx context.Context, params ...*string) []string {
+	scope := make([]string, 0, len(params)+1)
+	for _, p := range params {
+		if p == nil {
+			scope = append(scope, "")
+		} else {
+			scope = append(scope, *p)
+		}
+	}
+	return append(scope, sorting.FromContext(ctx).String())
+}
+
+// paginate returns a page of the given data, via `resources.Paginate`. If
+// `preferNextPageToken` is true, or a next page token is given (either via the
+// query parameter or the header), the next page token pagination mechanism is
+// used; otherwise, the page/size mechanism is used.
+//
+// Next page tokens are bound to the given scope (i.e., the query parameters
+// that affect the data, like the filter).
+func paginate[T any](
+	paginator *resources.Paginator,
+	data []T,
+	size *resources.PaginationSize,
+	page *resources.PaginationPage,
+	nextToken *resources.PaginationNextToken,
+	nextPageToken *resources.PaginationNextTokenHeader,
+	preferNextPageToken bool,
+	scope ...string,
+) (*resources.PaginatedResponse[T], error) {
+	return resources.Paginate(paginator, data, resources.PageRequest{
+		Size:                size,
+		Page:                page,
+		NextToken:           nextToken,
+		NextPageToken:       nextPageToken,
+		PreferNextPageToken: preferNextPageToken,
+		Scope:               scope,
+	})
+}
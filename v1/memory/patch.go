@@ -0,0 +1,110 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package memory
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	v1 "github.com/canonical/rebac-admin-ui-handlers/v1"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// Patch operations, as used in the `Op` field of patch items.
+const (
+	patchOpAdd    = "add"
+	patchOpRemove = "remove"
+)
+
+// splitPatches splits the given patch items into additions and removals. The
+// given function should return the value of each item, along with its
+// operation.
+func splitPatches[T any](patches []T, f func(T) (string, string)) ([]string, []string) {
+	additions := []string{}
+	removals := []string{}
+	for _, p := range patches {
+		value, op := f(p)
+		switch op {
+		case patchOpAdd:
+			additions = append(additions, value)
+		case patchOpRemove:
+			removals = append(removals, value)
+		}
+	}
+	return additions, removals
+}
+
+// applyPatches adds/removes the tuples of the given patch items to/from the
+// given relationship, in order, and returns the outcome of each item, along
+// with whether anything changed. The given function should return the tuple
+// of each item, along with its operation.
+func applyPatches[T any](r *Relationship, patches []T, f func(T) (RelationshipTuple, string)) ([]resources.PatchItemResult, bool) {
+	results := make([]resources.PatchItemResult, 0, len(patches))
+	changed := false
+	for _, p := range patches {
+		t, op := f(p)
+		var result resources.PatchItemResult
+		switch op {
+		case patchOpAdd:
+			result.Outcome = resources.PatchItemOutcomeAlreadyPresent
+			if r.add(t) {
+				result.Outcome = resources.PatchItemOutcomeApplied
+			}
+		case patchOpRemove:
+			result.Outcome = resources.PatchItemOutcomeNotPresent
+			if r.remove(t) {
+				result.Outcome = resources.PatchItemOutcomeApplied
+			}
+		default:
+			result.Outcome = resources.PatchItemOutcomeFailed
+			result.Error = v1.NewValidationError(fmt.Sprintf("invalid patch operation %q", op))
+		}
+		changed = changed || result.Outcome == resources.PatchItemOutcomeApplied
+		results = append(results, result)
+	}
+	return results, changed
+}
+
+// entitlementToString marshals the given entitlement as a string (e.g.,
+// `can_read::controller:foo`), to be stored in a Relationship.
+func entitlementToString(e resources.EntityEntitlement) string {
+	return fmt.Sprintf("%s::%s:%s", e.Entitlement, e.EntityType, e.EntityId)
+}
+
+// entitlementFromString unmarshals an entitlement from the given string.
+func entitlementFromString(s string) resources.EntityEntitlement {
+	entitlement, entity, _ := strings.Cut(s, "::")
+	entityType, entityId, _ := strings.Cut(entity, ":")
+	return resources.EntityEntitlement{
+		Entitlement: entitlement,
+		EntityType:  entityType,
+		EntityId:    entityId,
+	}
+}
+
+// entitlementsFromStrings unmarshals the given entitlements, sorted by their
+// string representation.
+func entitlementsFromStrings(s []string) []resources.EntityEntitlement {
+	s = slices.Clone(s)
+	slices.Sort(s)
+
+	result := make([]resources.EntityEntitlement, 0, len(s))
+	for _, e := range s {
+		result = append(result, entitlementFromString(e))
+	}
+	return result
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package memory
+
+import (
+	"slices"
+)
+
+// Relationship maintains a unique set of (left, right) tuples to track the
+// relation between entities of (one or two) specific types. This can be viewed
+// as an in-memory implementation of foreign-key relationships and JOIN
+// operations in relational databases.
+//
+// Relationship is not thread-safe on its own; it's guarded by the lock of the
+// owning Database.
+type Relationship struct {
+	Tuples []RelationshipTuple
+}
+
+// RelationshipTuple represents a (left, right) tuple.
+type RelationshipTuple struct {
+	Left  string
+	Right string
+}
+
+// clone returns a copy of the relationship.
+func (r *Relationship) clone() Relationship {
+	return Relationship{Tuples: slices.Clone(r.Tuples)}
+}
+
+// lefts returns all "left" components of tuples that have the given "right"
+// component.
+func (r *Relationship) lefts(right string) []string {
+	result := []string{}
+	for _, t := range r.Tuples {
+		if t.Right == right {
+			result = append(result, t.Left)
+		}
+	}
+	return result
+}
+
+// rights returns all "right" components of tuples that have the given "left"
+// component.
+func (r *Relationship) rights(left string) []string {
+	result := []string{}
+	for _, t := range r.Tuples {
+		if t.Left == left {
+			result = append(result, t.Right)
+		}
+	}
+	return result
+}
+
+// add adds the given tuple, if it does not exist. It returns true if the tuple
+// was added.
+func (r *Relationship) add(t RelationshipTuple) bool {
+	if slices.Contains(r.Tuples, t) {
+		return false
+	}
+	r.Tuples = append(r.Tuples, t)
+	return true
+}
+
+// remove removes the given tuple, if it exists. It returns true if the tuple
+// was removed.
+func (r *Relationship) remove(t RelationshipTuple) bool {
+	n := len(r.Tuples)
+	r.Tuples = slices.DeleteFunc(r.Tuples, func(x RelationshipTuple) bool {
+		return x == t
+	})
+	return len(r.Tuples) != n
+}
+
+// removeLeft removes all tuples with the given "left" component.
+func (r *Relationship) removeLeft(left string) {
+	r.Tuples = slices.DeleteFunc(r.Tuples, func(t RelationshipTuple) bool {
+		return t.Left == left
+	})
+}
+
+// removeRight removes all tuples with the given "right" component.
+func (r *Relationship) removeRight(right string) {
+	r.Tuples = slices.DeleteFunc(r.Tuples, func(t RelationshipTuple) bool {
+		return t.Right == right
+	})
+}
+
+// patchLeft adds/removes tuples with the given "left" component and the given
+// "right" components. It returns true if anything changed.
+func (r *Relationship) patchLeft(left string, additions, removals []string) bool {
+	changed := false
+	for _, right := range additions {
+		changed = r.add(RelationshipTuple{Left: left, Right: right}) || changed
+	}
+	for _, right := range removals {
+		changed = r.remove(RelationshipTuple{Left: left, Right: right}) || changed
+	}
+	return changed
+}
+
+// patchRight adds/removes tuples with the given "right" component and the given
+// "left" components. It returns true if anything changed.
+func (r *Relationship) patchRight(right string, additions, removals []string) bool {
+	changed := false
+	for _, left := range additions {
+		changed = r.add(RelationshipTuple{Left: left, Right: right}) || changed
+	}
+	for _, left := range removals {
+		changed = r.remove(RelationshipTuple{Left: left, Right: right}) || changed
+	}
+	return changed
+}
@@ -0,0 +1,55 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package memory
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestRelationship(t *testing.T) {
+	c := qt.New(t)
+
+	r := Relationship{}
+
+	changed := r.patchLeft("a", []string{"x", "y"}, nil)
+	c.Assert(changed, qt.IsTrue)
+	changed = r.patchRight("z", []string{"a", "b"}, nil)
+	c.Assert(changed, qt.IsTrue)
+
+	// Adding existing tuples, or removing missing ones, is a no-op.
+	changed = r.patchLeft("a", []string{"x"}, []string{"missing"})
+	c.Assert(changed, qt.IsFalse)
+
+	c.Assert(r.rights("a"), qt.DeepEquals, []string{"x", "y", "z"})
+	c.Assert(r.lefts("z"), qt.DeepEquals, []string{"a", "b"})
+	c.Assert(r.rights("missing"), qt.DeepEquals, []string{})
+
+	changed = r.patchLeft("a", nil, []string{"y"})
+	c.Assert(changed, qt.IsTrue)
+	c.Assert(r.rights("a"), qt.DeepEquals, []string{"x", "z"})
+
+	changed = r.patchRight("z", nil, []string{"b"})
+	c.Assert(changed, qt.IsTrue)
+	c.Assert(r.lefts("z"), qt.DeepEquals, []string{"a"})
+
+	r.removeRight("z")
+	c.Assert(r.Tuples, qt.DeepEquals, []RelationshipTuple{{Left: "a", Right: "x"}})
+
+	r.removeLeft("a")
+	c.Assert(r.Tuples, qt.HasLen, 0)
+}
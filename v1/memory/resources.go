@@ -0,0 +1,135 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/sorting"
+)
+
+// ResourcesService implements the `ResourcesService` interface on top of a
+// Database.
+type ResourcesService struct {
+	db *Database
+}
+
+// For doc/test sake, to hint that the struct needs to implement a specific interface.
+var _ interfaces.ResourcesService = &ResourcesService{}
+var _ interfaces.ResourceAccessLister = &ResourcesService{}
+
+// NewResourcesService returns a new ResourcesService instance.
+func NewResourcesService(db *Database) *ResourcesService {
+	return &ResourcesService{db: db}
+}
+
+// ListResources returns a page of resources (i.e., `State.Resources`). If
+// given, the entity type filter is an exact match, and the entity name filter
+// is a prefix match. Resources are returned in their stored order, unless the
+// request specifies a sort order.
+func (s *ResourcesService) ListResources(ctx context.Context, params *resources.GetResourcesParams) (*resources.PaginatedResponse[resources.Resource], error) {
+	result := []resources.Resource{}
+	s.db.view(func(state *State) {
+		for _, r := range state.Resources {
+			if params.EntityType != nil && r.Entity.Type != *params.EntityType {
+				continue
+			}
+			if params.EntityName != nil && !strings.HasPrefix(r.Entity.Name, *params.EntityName) {
+				continue
+			}
+			result = append(result, r)
+		}
+	})
+	if err := sorting.Sort(result, sorting.FromContext(ctx)); err != nil {
+		return nil, err
+	}
+	return paginate(s.db.paginator, result, params.Size, params.Page, params.NextToken, params.NextPageToken, false, listScope(ctx, params.EntityType, params.EntityName)...)
+}
+
+// ListResourceAccess returns a page of the entitlements held on the given
+// entity, by identities, groups and roles, either directly or inherited via
+// groups and roles. Entries are sorted by receiver type, receiver ID and
+// entitlement.
+func (s *ResourcesService) ListResourceAccess(ctx context.Context, entityType string, entityId string, params *resources.GetResourcesItemAccessParams) (*resources.PaginatedResponse[resources.ResourceAccess], error) {
+	result := []resources.ResourceAccess{}
+	s.db.view(func(state *State) {
+		add := func(receiverType resources.ResourceAccessReceiverType, receiverId, entitlement string, via ...resources.AccessPathStep) {
+			result = append(result, resources.ResourceAccess{
+				ReceiverType: receiverType,
+				ReceiverId:   receiverId,
+				Entitlement:  entitlement,
+				Via:          append([]resources.AccessPathStep{}, via...),
+			})
+		}
+
+		for _, t := range entityGrants(state.Role2Entitlement, entityType, entityId) {
+			role := resources.AccessPathStep{Type: resources.AccessPathStepTypeRole, Id: t.Left}
+			add(resources.ResourceAccessReceiverTypeRole, t.Left, t.Right)
+			for _, groupId := range state.Group2Role.lefts(t.Left) {
+				group := resources.AccessPathStep{Type: resources.AccessPathStepTypeGroup, Id: groupId}
+				add(resources.ResourceAccessReceiverTypeGroup, groupId, t.Right, role)
+				for _, identityId := range state.Group2Identity.rights(groupId) {
+					add(resources.ResourceAccessReceiverTypeIdentity, identityId, t.Right, group, role)
+				}
+			}
+			for _, identityId := range state.Identity2Role.lefts(t.Left) {
+				add(resources.ResourceAccessReceiverTypeIdentity, identityId, t.Right, role)
+			}
+		}
+
+		for _, t := range entityGrants(state.Group2Entitlement, entityType, entityId) {
+			group := resources.AccessPathStep{Type: resources.AccessPathStepTypeGroup, Id: t.Left}
+			add(resources.ResourceAccessReceiverTypeGroup, t.Left, t.Right)
+			for _, identityId := range state.Group2Identity.rights(t.Left) {
+				add(resources.ResourceAccessReceiverTypeIdentity, identityId, t.Right, group)
+			}
+		}
+
+		for _, t := range entityGrants(state.Identity2Entitlement, entityType, entityId) {
+			add(resources.ResourceAccessReceiverTypeIdentity, t.Left, t.Right)
+		}
+	})
+
+	slices.SortStableFunc(result, func(a, b resources.ResourceAccess) int {
+		if c := cmp.Compare(a.ReceiverType, b.ReceiverType); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.ReceiverId, b.ReceiverId); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Entitlement, b.Entitlement)
+	})
+	return paginate(s.db.paginator, result, params.Size, params.Page, params.NextToken, params.NextPageToken, false, listScope(ctx, &entityType, &entityId)...)
+}
+
+// entityGrants returns the tuples of the given relationship, whose right
+// component is an entitlement on the given entity. The right components of the
+// returned tuples are replaced with the entitlement names.
+func entityGrants(r Relationship, entityType, entityId string) []RelationshipTuple {
+	result := []RelationshipTuple{}
+	for _, t := range r.Tuples {
+		e := entitlementFromString(t.Right)
+		if e.EntityType == entityType && e.EntityId == entityId {
+			result = append(result, RelationshipTuple{Left: t.Left, Right: e.Entitlement})
+		}
+	}
+	return result
+}
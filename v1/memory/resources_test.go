@@ -0,0 +1,97 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package memory
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/sorting"
+)
+
+func TestResourcesService(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	data := []resources.Resource{
+		{Entity: resources.Entity{Id: "1", Name: "controller-foo", Type: "controller"}},
+		{Entity: resources.Entity{Id: "2", Name: "model-foo", Type: "model"}},
+		{Entity: resources.Entity{Id: "3", Name: "model-bar", Type: "model"}},
+	}
+	s := NewResourcesService(newTestDatabase(c, &State{Resources: data}))
+
+	result, err := s.ListResources(ctx, &resources.GetResourcesParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Data, qt.DeepEquals, data)
+
+	entityType := "model"
+	result, err = s.ListResources(ctx, &resources.GetResourcesParams{EntityType: &entityType})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Data, qt.DeepEquals, data[1:])
+
+	entityName := "model-b"
+	result, err = s.ListResources(ctx, &resources.GetResourcesParams{EntityType: &entityType, EntityName: &entityName})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Data, qt.DeepEquals, data[2:])
+
+	result, err = s.ListResources(sorting.NewContext(ctx, sorting.Order{{Name: "entity.type", Descending: true}, {Name: "entity.name"}}), &resources.GetResourcesParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Data, qt.DeepEquals, []resources.Resource{data[2], data[1], data[0]})
+}
+
+func TestResourcesService_ListResourceAccess(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	onFoo := entitlementToString(resources.EntityEntitlement{Entitlement: "can_view", EntityType: "model", EntityId: "foo"})
+	onBar := entitlementToString(resources.EntityEntitlement{Entitlement: "can_view", EntityType: "model", EntityId: "bar"})
+
+	s := NewResourcesService(newTestDatabase(c, &State{
+		Group2Identity:       Relationship{Tuples: []RelationshipTuple{{Left: "admins", Right: "joe"}}},
+		Group2Role:           Relationship{Tuples: []RelationshipTuple{{Left: "admins", Right: "viewer"}}},
+		Group2Entitlement:    Relationship{Tuples: []RelationshipTuple{{Left: "admins", Right: onBar}}},
+		Identity2Role:        Relationship{Tuples: []RelationshipTuple{{Left: "jane", Right: "viewer"}}},
+		Identity2Entitlement: Relationship{Tuples: []RelationshipTuple{{Left: "joe", Right: onFoo}}},
+		Role2Entitlement:     Relationship{Tuples: []RelationshipTuple{{Left: "viewer", Right: onFoo}}},
+	}))
+
+	group := resources.AccessPathStep{Type: resources.AccessPathStepTypeGroup, Id: "admins"}
+	role := resources.AccessPathStep{Type: resources.AccessPathStepTypeRole, Id: "viewer"}
+
+	result, err := s.ListResourceAccess(ctx, "model", "foo", &resources.GetResourcesItemAccessParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Data, qt.DeepEquals, []resources.ResourceAccess{
+		{ReceiverType: "group", ReceiverId: "admins", Entitlement: "can_view", Via: []resources.AccessPathStep{role}},
+		{ReceiverType: "identity", ReceiverId: "jane", Entitlement: "can_view", Via: []resources.AccessPathStep{role}},
+		{ReceiverType: "identity", ReceiverId: "joe", Entitlement: "can_view", Via: []resources.AccessPathStep{group, role}},
+		{ReceiverType: "identity", ReceiverId: "joe", Entitlement: "can_view", Via: []resources.AccessPathStep{}},
+		{ReceiverType: "role", ReceiverId: "viewer", Entitlement: "can_view", Via: []resources.AccessPathStep{}},
+	})
+
+	result, err = s.ListResourceAccess(ctx, "model", "bar", &resources.GetResourcesItemAccessParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Data, qt.DeepEquals, []resources.ResourceAccess{
+		{ReceiverType: "group", ReceiverId: "admins", Entitlement: "can_view", Via: []resources.AccessPathStep{}},
+		{ReceiverType: "identity", ReceiverId: "joe", Entitlement: "can_view", Via: []resources.AccessPathStep{group}},
+	})
+
+	result, err = s.ListResourceAccess(ctx, "model", "baz", &resources.GetResourcesItemAccessParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Data, qt.HasLen, 0)
+}
@@ -0,0 +1,180 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	v1 "github.com/canonical/rebac-admin-ui-handlers/v1"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/sorting"
+)
+
+// RolesService implements the `RolesService` interface on top of a Database.
+type RolesService struct {
+	db *Database
+}
+
+// For doc/test sake, to hint that the struct needs to implement a specific interface.
+var _ interfaces.RolesService = &RolesService{}
+var _ interfaces.RolesPatchReporter = &RolesService{}
+
+// NewRolesService returns a new RolesService instance.
+func NewRolesService(db *Database) *RolesService {
+	return &RolesService{db: db}
+}
+
+// ListRoles returns a page of Role objects of at least `size` elements if available.
+func (s *RolesService) ListRoles(ctx context.Context, params *resources.GetRolesParams) (*resources.PaginatedResponse[resources.Role], error) {
+	var roles []resources.Role
+	s.db.view(func(state *State) {
+		roles = sortedValues(state.Roles)
+	})
+	roles, err := filterEntities(params.Filter, roles)
+	if err != nil {
+		return nil, err
+	}
+	if err := sorting.Sort(roles, sorting.FromContext(ctx)); err != nil {
+		return nil, err
+	}
+	return paginate(s.db.paginator, roles, params.Size, params.Page, params.NextToken, params.NextPageToken, false, listScope(ctx, params.Filter)...)
+}
+
+// CreateRole creates a single Role. The role name is used as its ID.
+func (s *RolesService) CreateRole(ctx context.Context, role *resources.Role) (*resources.Role, error) {
+	if role.Name == "" {
+		return nil, v1.NewValidationError("empty role name")
+	}
+
+	id := role.Name
+	entry := *role
+	entry.Id = &id
+	_, err := s.db.update(func(state *State) (bool, error) {
+		if _, ok := state.Roles[id]; ok {
+			return false, v1.NewInvalidRequestError(fmt.Sprintf("role %q already exists", id))
+		}
+		state.Roles[id] = entry
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// GetRole returns a single Role identified by `roleId`.
+func (s *RolesService) GetRole(ctx context.Context, roleId string) (*resources.Role, error) {
+	var role *resources.Role
+	s.db.view(func(state *State) {
+		if r, ok := state.Roles[roleId]; ok {
+			role = &r
+		}
+	})
+	if role == nil {
+		return nil, roleNotFound(roleId)
+	}
+	return role, nil
+}
+
+// UpdateRole updates a Role. Note that the role ID does not change, even if the
+// role is renamed.
+func (s *RolesService) UpdateRole(ctx context.Context, role *resources.Role) (*resources.Role, error) {
+	if role.Id == nil {
+		return nil, v1.NewValidationError("missing role ID")
+	}
+
+	entry := *role
+	_, err := s.db.update(func(state *State) (bool, error) {
+		if _, ok := state.Roles[*entry.Id]; !ok {
+			return false, roleNotFound(*entry.Id)
+		}
+		state.Roles[*entry.Id] = entry
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// DeleteRole deletes a Role identified by `roleId`, along with all its
+// relations. It returns (false, nil) if the role does not exist.
+func (s *RolesService) DeleteRole(ctx context.Context, roleId string) (bool, error) {
+	return s.db.update(func(state *State) (bool, error) {
+		if _, ok := state.Roles[roleId]; !ok {
+			return false, nil
+		}
+		delete(state.Roles, roleId)
+		state.Group2Role.removeRight(roleId)
+		state.Identity2Role.removeRight(roleId)
+		state.Role2Entitlement.removeLeft(roleId)
+		return true, nil
+	})
+}
+
+// GetRoleEntitlements returns a page of Entitlements for Role `roleId`.
+func (s *RolesService) GetRoleEntitlements(ctx context.Context, roleId string, params *resources.GetRolesItemEntitlementsParams) (*resources.PaginatedResponse[resources.EntityEntitlement], error) {
+	var entitlements []resources.EntityEntitlement
+	found := false
+	s.db.view(func(state *State) {
+		if _, found = state.Roles[roleId]; found {
+			entitlements = entitlementsFromStrings(state.Role2Entitlement.rights(roleId))
+		}
+	})
+	if !found {
+		return nil, roleNotFound(roleId)
+	}
+	return paginate(s.db.paginator, entitlements, params.Size, params.Page, params.NextToken, params.NextPageToken, false, listScope(ctx, &roleId)...)
+}
+
+// PatchRoleEntitlements performs addition or removal of an Entitlement to/from a Role.
+func (s *RolesService) PatchRoleEntitlements(ctx context.Context, roleId string, entitlementPatches []resources.RoleEntitlementsPatchItem) (bool, error) {
+	additions, removals := splitPatches(entitlementPatches, func(p resources.RoleEntitlementsPatchItem) (string, string) {
+		return entitlementToString(p.Entitlement), string(p.Op)
+	})
+	return s.db.update(func(state *State) (bool, error) {
+		if _, ok := state.Roles[roleId]; !ok {
+			return false, roleNotFound(roleId)
+		}
+		return state.Role2Entitlement.patchLeft(roleId, additions, removals), nil
+	})
+}
+
+// PatchRoleEntitlementsWithResults works the same as PatchRoleEntitlements, but it applies the patch items
+// in order, and reports the outcome of each one.
+func (s *RolesService) PatchRoleEntitlementsWithResults(ctx context.Context, roleId string, entitlementPatches []resources.RoleEntitlementsPatchItem) ([]resources.PatchItemResult, error) {
+	var results []resources.PatchItemResult
+	_, err := s.db.update(func(state *State) (bool, error) {
+		if _, ok := state.Roles[roleId]; !ok {
+			return false, roleNotFound(roleId)
+		}
+		var changed bool
+		results, changed = applyPatches(&state.Role2Entitlement, entitlementPatches, func(p resources.RoleEntitlementsPatchItem) (RelationshipTuple, string) {
+			return RelationshipTuple{Left: roleId, Right: entitlementToString(p.Entitlement)}, string(p.Op)
+		})
+		return changed, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func roleNotFound(roleId string) error {
+	return v1.NewNotFoundError(fmt.Sprintf("role %q not found", roleId))
+}
@@ -0,0 +1,123 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package memory
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+func TestRolesService_CRUD(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	s := NewRolesService(newTestDatabase(c, nil))
+
+	role, err := s.CreateRole(ctx, &resources.Role{Name: "viewer"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(*role.Id, qt.Equals, "viewer")
+
+	_, err = s.CreateRole(ctx, &resources.Role{Name: "viewer"})
+	c.Assert(err, qt.ErrorMatches, `Bad Request: invalid request: role "viewer" already exists`)
+
+	_, err = s.CreateRole(ctx, &resources.Role{})
+	c.Assert(err, qt.ErrorMatches, "Bad Request: empty role name")
+
+	_, err = s.GetRole(ctx, "missing")
+	c.Assert(err, qt.ErrorMatches, `Not Found: role "missing" not found`)
+
+	role, err = s.UpdateRole(ctx, &resources.Role{Id: stringPtr("viewer"), Name: "readers"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(role.Name, qt.Equals, "readers")
+
+	_, err = s.UpdateRole(ctx, &resources.Role{Name: "readers"})
+	c.Assert(err, qt.ErrorMatches, "Bad Request: missing role ID")
+
+	_, err = s.UpdateRole(ctx, &resources.Role{Id: stringPtr("missing")})
+	c.Assert(err, qt.ErrorMatches, `Not Found: role "missing" not found`)
+
+	filter := "read"
+	roles, err := s.ListRoles(ctx, &resources.GetRolesParams{Filter: &filter})
+	c.Assert(err, qt.IsNil)
+	c.Assert(roles.Data, qt.HasLen, 1)
+	c.Assert(*roles.Data[0].Id, qt.Equals, "viewer")
+
+	deleted, err := s.DeleteRole(ctx, "viewer")
+	c.Assert(err, qt.IsNil)
+	c.Assert(deleted, qt.IsTrue)
+
+	deleted, err = s.DeleteRole(ctx, "viewer")
+	c.Assert(err, qt.IsNil)
+	c.Assert(deleted, qt.IsFalse)
+}
+
+func TestRolesService_Relations(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	db := newTestDatabase(c, &State{
+		Groups: map[string]resources.Group{
+			"admins": {Id: stringPtr("admins"), Name: "admins"},
+		},
+		Identities: map[string]resources.Identity{
+			"joe@example.com": {Id: stringPtr("joe@example.com"), Email: "joe@example.com"},
+		},
+		Roles: map[string]resources.Role{
+			"viewer": {Id: stringPtr("viewer"), Name: "viewer"},
+		},
+		Group2Role:    Relationship{Tuples: []RelationshipTuple{{Left: "admins", Right: "viewer"}}},
+		Identity2Role: Relationship{Tuples: []RelationshipTuple{{Left: "joe@example.com", Right: "viewer"}}},
+	})
+	s := NewRolesService(db)
+
+	entitlements := []resources.EntityEntitlement{
+		{Entitlement: "reader", EntityType: "model", EntityId: "m2"},
+		{Entitlement: "reader", EntityType: "model", EntityId: "m1"},
+	}
+	changed, err := s.PatchRoleEntitlements(ctx, "viewer", []resources.RoleEntitlementsPatchItem{
+		{Entitlement: entitlements[0], Op: resources.Add},
+		{Entitlement: entitlements[1], Op: resources.Add},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(changed, qt.IsTrue)
+
+	result, err := s.GetRoleEntitlements(ctx, "viewer", &resources.GetRolesItemEntitlementsParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Data, qt.DeepEquals, []resources.EntityEntitlement{entitlements[1], entitlements[0]})
+
+	changed, err = s.PatchRoleEntitlements(ctx, "viewer", []resources.RoleEntitlementsPatchItem{
+		{Entitlement: entitlements[0], Op: resources.Remove},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(changed, qt.IsTrue)
+
+	// Deleting the role removes its relations.
+	_, err = s.DeleteRole(ctx, "viewer")
+	c.Assert(err, qt.IsNil)
+	snapshot := db.Snapshot()
+	c.Assert(snapshot.Group2Role.Tuples, qt.HasLen, 0)
+	c.Assert(snapshot.Identity2Role.Tuples, qt.HasLen, 0)
+	c.Assert(snapshot.Role2Entitlement.Tuples, qt.HasLen, 0)
+
+	_, err = s.GetRoleEntitlements(ctx, "viewer", &resources.GetRolesItemEntitlementsParams{})
+	c.Assert(err, qt.ErrorMatches, `Not Found: role "viewer" not found`)
+	_, err = s.PatchRoleEntitlements(ctx, "viewer", nil)
+	c.Assert(err, qt.ErrorMatches, `Not Found: role "viewer" not found`)
+}
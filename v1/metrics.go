@@ -0,0 +1,106 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// statusResponseWriter is a wrapper around http.ResponseWriter that records
+// the written status code.
+type statusResponseWriter struct {
+	http.ResponseWriter
+
+	// status is the HTTP status code written to the response. Zero means
+	// nothing has been written yet.
+	status int
+}
+
+// WriteHeader implements the http.ResponseWriter interface.
+func (w *statusResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write implements the http.ResponseWriter interface.
+func (w *statusResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the underlying http.ResponseWriter.
+func (w *statusResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// responseStatus returns the written status code. If nothing is written yet,
+// it returns 200, which is what the HTTP server would respond with.
+func (w *statusResponseWriter) responseStatus() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+// metricsHandler wraps the given handler to record metrics of the served API
+// operations via the configured metrics recorder.
+func (b *ReBACAdminBackend) metricsHandler(baseURL string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		r, rctx := withRouteContext(r)
+		sw := &statusResponseWriter{ResponseWriter: w}
+		next.ServeHTTP(sw, r)
+
+		operationId := getOperationId(rctx, r.Method, baseURL)
+		if operationId == "" {
+			return
+		}
+
+		status := sw.responseStatus()
+		b.params.Metrics.ObserveRequest(r.Context(), operationId, status, statusClass(status), time.Since(start))
+	})
+}
+
+// getOperationId returns the OpenAPI operation ID of the route matched by the
+// given routing context. If no route is matched, it returns an empty string.
+func getOperationId(rctx *chi.Context, method, baseURL string) string {
+	if len(rctx.RoutePatterns) == 0 {
+		return ""
+	}
+	// The last pattern belongs to the innermost router (i.e., the one created
+	// by this library), which does not include any outer routers' prefixes.
+	pattern := rctx.RoutePatterns[len(rctx.RoutePatterns)-1]
+	relative, ok := strings.CutPrefix(pattern, baseURL)
+	if !ok {
+		return ""
+	}
+	return operations[method+" "+relative]
+}
+
+// statusClass returns the class of the given HTTP status code (e.g., `2xx`).
+func statusClass(status int) string {
+	return fmt.Sprintf("%dxx", status/100)
+}
@@ -0,0 +1,651 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// withServiceMetrics returns a copy of the given parameters, where all provided
+// backends (i.e., non-nil ones) are wrapped to record the latency and errors of
+// their method calls via the given metrics recorder.
+func withServiceMetrics(params ReBACAdminBackendParams, recorder interfaces.MetricsRecorder) ReBACAdminBackendParams {
+	if params.AccessCheck != nil {
+		params.AccessCheck = &accessCheckServiceWithMetrics{params.AccessCheck, recorder}
+	}
+	if params.AuditSink != nil {
+		params.AuditSink = &auditSinkWithMetrics{params.AuditSink, recorder}
+	}
+	if params.Authenticator != nil {
+		params.Authenticator = &authenticatorWithMetrics{params.Authenticator, recorder}
+	}
+	if params.Authorizer != nil {
+		params.Authorizer = &authorizerWithMetrics{params.Authorizer, recorder}
+	}
+	if params.Bulk != nil {
+		params.Bulk = &bulkServiceWithMetrics{params.Bulk, recorder}
+	}
+	if params.Capabilities != nil {
+		params.Capabilities = &capabilitiesServiceWithMetrics{params.Capabilities, recorder}
+	}
+	if params.Entitlements != nil {
+		params.Entitlements = &entitlementsServiceWithMetrics{params.Entitlements, recorder}
+	}
+	if params.Groups != nil {
+		params.Groups = &groupsServiceWithMetrics{params.Groups, recorder}
+	}
+	if params.Identities != nil {
+		params.Identities = &identitiesServiceWithMetrics{params.Identities, recorder}
+	}
+	if params.IdentityProviders != nil {
+		params.IdentityProviders = &identityProvidersServiceWithMetrics{params.IdentityProviders, recorder}
+	}
+	if params.Resources != nil {
+		params.Resources = &resourcesServiceWithMetrics{params.Resources, recorder}
+	}
+	if params.Roles != nil {
+		params.Roles = &rolesServiceWithMetrics{params.Roles, recorder}
+	}
+	return params
+}
+
+// accessCheckServiceWithMetrics wraps an AccessCheckService to record metrics of its method calls.
+type accessCheckServiceWithMetrics struct {
+	interfaces.AccessCheckService
+	recorder interfaces.MetricsRecorder
+}
+
+// CheckAccess implements the AccessCheckService interface.
+func (s *accessCheckServiceWithMetrics) CheckAccess(ctx context.Context, identityId string, entitlement resources.EntityEntitlement, explain bool) (*resources.AccessCheck, error) {
+	start := time.Now()
+	result, err := s.AccessCheckService.CheckAccess(ctx, identityId, entitlement, explain)
+	s.recorder.ObserveServiceCall(ctx, "AccessCheckService.CheckAccess", time.Since(start), err)
+	return result, err
+}
+
+// auditSinkWithMetrics wraps an AuditSink to record metrics of its method calls.
+type auditSinkWithMetrics struct {
+	interfaces.AuditSink
+	recorder interfaces.MetricsRecorder
+}
+
+// Record implements the AuditSink interface.
+func (s *auditSinkWithMetrics) Record(ctx context.Context, event interfaces.AuditEvent) error {
+	start := time.Now()
+	err := s.AuditSink.Record(ctx, event)
+	s.recorder.ObserveServiceCall(ctx, "AuditSink.Record", time.Since(start), err)
+	return err
+}
+
+// authenticatorWithMetrics wraps an Authenticator to record metrics of its method calls.
+type authenticatorWithMetrics struct {
+	interfaces.Authenticator
+	recorder interfaces.MetricsRecorder
+}
+
+// Authenticate implements the Authenticator interface.
+func (s *authenticatorWithMetrics) Authenticate(r *http.Request) (any, error) {
+	start := time.Now()
+	result, err := s.Authenticator.Authenticate(r)
+	s.recorder.ObserveServiceCall(r.Context(), "Authenticator.Authenticate", time.Since(start), err)
+	return result, err
+}
+
+// authorizerWithMetrics wraps an Authorizer to record metrics of its method calls.
+type authorizerWithMetrics struct {
+	interfaces.Authorizer
+	recorder interfaces.MetricsRecorder
+}
+
+// Authorize implements the Authorizer interface.
+func (s *authorizerWithMetrics) Authorize(ctx context.Context, identity any, operationId string, targetIds []string) (bool, error) {
+	start := time.Now()
+	result, err := s.Authorizer.Authorize(ctx, identity, operationId, targetIds)
+	s.recorder.ObserveServiceCall(ctx, "Authorizer.Authorize", time.Since(start), err)
+	return result, err
+}
+
+// bulkServiceWithMetrics wraps a BulkService to record metrics of its method calls.
+type bulkServiceWithMetrics struct {
+	interfaces.BulkService
+	recorder interfaces.MetricsRecorder
+}
+
+// ExecuteBulk implements the BulkService interface.
+func (s *bulkServiceWithMetrics) ExecuteBulk(ctx context.Context, operations []resources.BulkOperation) ([]resources.BulkOperationResult, error) {
+	start := time.Now()
+	result, err := s.BulkService.ExecuteBulk(ctx, operations)
+	s.recorder.ObserveServiceCall(ctx, "BulkService.ExecuteBulk", time.Since(start), err)
+	return result, err
+}
+
+// capabilitiesServiceWithMetrics wraps a CapabilitiesService to record metrics of its method calls.
+type capabilitiesServiceWithMetrics struct {
+	interfaces.CapabilitiesService
+	recorder interfaces.MetricsRecorder
+}
+
+// ListCapabilities implements the CapabilitiesService interface.
+func (s *capabilitiesServiceWithMetrics) ListCapabilities(ctx context.Context) ([]resources.Capability, error) {
+	start := time.Now()
+	result, err := s.CapabilitiesService.ListCapabilities(ctx)
+	s.recorder.ObserveServiceCall(ctx, "CapabilitiesService.ListCapabilities", time.Since(start), err)
+	return result, err
+}
+
+// entitlementsServiceWithMetrics wraps an EntitlementsService to record metrics of its method calls.
+type entitlementsServiceWithMetrics struct {
+	interfaces.EntitlementsService
+	recorder interfaces.MetricsRecorder
+}
+
+// ListEntitlements implements the EntitlementsService interface.
+func (s *entitlementsServiceWithMetrics) ListEntitlements(ctx context.Context, params *resources.GetEntitlementsParams) ([]resources.EntitlementSchema, error) {
+	start := time.Now()
+	result, err := s.EntitlementsService.ListEntitlements(ctx, params)
+	s.recorder.ObserveServiceCall(ctx, "EntitlementsService.ListEntitlements", time.Since(start), err)
+	return result, err
+}
+
+// RawEntitlements implements the EntitlementsService interface.
+func (s *entitlementsServiceWithMetrics) RawEntitlements(ctx context.Context) (string, error) {
+	start := time.Now()
+	result, err := s.EntitlementsService.RawEntitlements(ctx)
+	s.recorder.ObserveServiceCall(ctx, "EntitlementsService.RawEntitlements", time.Since(start), err)
+	return result, err
+}
+
+// groupsServiceWithMetrics wraps a GroupsService to record metrics of its method calls.
+type groupsServiceWithMetrics struct {
+	interfaces.GroupsService
+	recorder interfaces.MetricsRecorder
+}
+
+// ListGroups implements the GroupsService interface.
+func (s *groupsServiceWithMetrics) ListGroups(ctx context.Context, params *resources.GetGroupsParams) (*resources.PaginatedResponse[resources.Group], error) {
+	start := time.Now()
+	result, err := s.GroupsService.ListGroups(ctx, params)
+	s.recorder.ObserveServiceCall(ctx, "GroupsService.ListGroups", time.Since(start), err)
+	return result, err
+}
+
+// CreateGroup implements the GroupsService interface.
+func (s *groupsServiceWithMetrics) CreateGroup(ctx context.Context, group *resources.Group) (*resources.Group, error) {
+	start := time.Now()
+	result, err := s.GroupsService.CreateGroup(ctx, group)
+	s.recorder.ObserveServiceCall(ctx, "GroupsService.CreateGroup", time.Since(start), err)
+	return result, err
+}
+
+// GetGroup implements the GroupsService interface.
+func (s *groupsServiceWithMetrics) GetGroup(ctx context.Context, groupId string) (*resources.Group, error) {
+	start := time.Now()
+	result, err := s.GroupsService.GetGroup(ctx, groupId)
+	s.recorder.ObserveServiceCall(ctx, "GroupsService.GetGroup", time.Since(start), err)
+	return result, err
+}
+
+// UpdateGroup implements the GroupsService interface.
+func (s *groupsServiceWithMetrics) UpdateGroup(ctx context.Context, group *resources.Group) (*resources.Group, error) {
+	start := time.Now()
+	result, err := s.GroupsService.UpdateGroup(ctx, group)
+	s.recorder.ObserveServiceCall(ctx, "GroupsService.UpdateGroup", time.Since(start), err)
+	return result, err
+}
+
+// DeleteGroup implements the GroupsService interface.
+func (s *groupsServiceWithMetrics) DeleteGroup(ctx context.Context, groupId string) (bool, error) {
+	start := time.Now()
+	result, err := s.GroupsService.DeleteGroup(ctx, groupId)
+	s.recorder.ObserveServiceCall(ctx, "GroupsService.DeleteGroup", time.Since(start), err)
+	return result, err
+}
+
+// GetGroupIdentities implements the GroupsService interface.
+func (s *groupsServiceWithMetrics) GetGroupIdentities(ctx context.Context, groupId string, params *resources.GetGroupsItemIdentitiesParams) (*resources.PaginatedResponse[resources.Identity], error) {
+	start := time.Now()
+	result, err := s.GroupsService.GetGroupIdentities(ctx, groupId, params)
+	s.recorder.ObserveServiceCall(ctx, "GroupsService.GetGroupIdentities", time.Since(start), err)
+	return result, err
+}
+
+// PatchGroupIdentities implements the GroupsService interface.
+func (s *groupsServiceWithMetrics) PatchGroupIdentities(ctx context.Context, groupId string, identityPatches []resources.GroupIdentitiesPatchItem) (bool, error) {
+	start := time.Now()
+	result, err := s.GroupsService.PatchGroupIdentities(ctx, groupId, identityPatches)
+	s.recorder.ObserveServiceCall(ctx, "GroupsService.PatchGroupIdentities", time.Since(start), err)
+	return result, err
+}
+
+// GetGroupRoles implements the GroupsService interface.
+func (s *groupsServiceWithMetrics) GetGroupRoles(ctx context.Context, groupId string, params *resources.GetGroupsItemRolesParams) (*resources.PaginatedResponse[resources.Role], error) {
+	start := time.Now()
+	result, err := s.GroupsService.GetGroupRoles(ctx, groupId, params)
+	s.recorder.ObserveServiceCall(ctx, "GroupsService.GetGroupRoles", time.Since(start), err)
+	return result, err
+}
+
+// PatchGroupRoles implements the GroupsService interface.
+func (s *groupsServiceWithMetrics) PatchGroupRoles(ctx context.Context, groupId string, rolePatches []resources.GroupRolesPatchItem) (bool, error) {
+	start := time.Now()
+	result, err := s.GroupsService.PatchGroupRoles(ctx, groupId, rolePatches)
+	s.recorder.ObserveServiceCall(ctx, "GroupsService.PatchGroupRoles", time.Since(start), err)
+	return result, err
+}
+
+// GetGroupEntitlements implements the GroupsService interface.
+func (s *groupsServiceWithMetrics) GetGroupEntitlements(ctx context.Context, groupId string, params *resources.GetGroupsItemEntitlementsParams) (*resources.PaginatedResponse[resources.EntityEntitlement], error) {
+	start := time.Now()
+	result, err := s.GroupsService.GetGroupEntitlements(ctx, groupId, params)
+	s.recorder.ObserveServiceCall(ctx, "GroupsService.GetGroupEntitlements", time.Since(start), err)
+	return result, err
+}
+
+// PatchGroupEntitlements implements the GroupsService interface.
+func (s *groupsServiceWithMetrics) PatchGroupEntitlements(ctx context.Context, groupId string, entitlementPatches []resources.GroupEntitlementsPatchItem) (bool, error) {
+	start := time.Now()
+	result, err := s.GroupsService.PatchGroupEntitlements(ctx, groupId, entitlementPatches)
+	s.recorder.ObserveServiceCall(ctx, "GroupsService.PatchGroupEntitlements", time.Since(start), err)
+	return result, err
+}
+
+// identitiesServiceWithMetrics wraps an IdentitiesService to record metrics of its method calls.
+type identitiesServiceWithMetrics struct {
+	interfaces.IdentitiesService
+	recorder interfaces.MetricsRecorder
+}
+
+// ListIdentities implements the IdentitiesService interface.
+func (s *identitiesServiceWithMetrics) ListIdentities(ctx context.Context, params *resources.GetIdentitiesParams) (*resources.PaginatedResponse[resources.Identity], error) {
+	start := time.Now()
+	result, err := s.IdentitiesService.ListIdentities(ctx, params)
+	s.recorder.ObserveServiceCall(ctx, "IdentitiesService.ListIdentities", time.Since(start), err)
+	return result, err
+}
+
+// CreateIdentity implements the IdentitiesService interface.
+func (s *identitiesServiceWithMetrics) CreateIdentity(ctx context.Context, identity *resources.Identity) (*resources.Identity, error) {
+	start := time.Now()
+	result, err := s.IdentitiesService.CreateIdentity(ctx, identity)
+	s.recorder.ObserveServiceCall(ctx, "IdentitiesService.CreateIdentity", time.Since(start), err)
+	return result, err
+}
+
+// GetIdentity implements the IdentitiesService interface.
+func (s *identitiesServiceWithMetrics) GetIdentity(ctx context.Context, identityId string) (*resources.Identity, error) {
+	start := time.Now()
+	result, err := s.IdentitiesService.GetIdentity(ctx, identityId)
+	s.recorder.ObserveServiceCall(ctx, "IdentitiesService.GetIdentity", time.Since(start), err)
+	return result, err
+}
+
+// UpdateIdentity implements the IdentitiesService interface.
+func (s *identitiesServiceWithMetrics) UpdateIdentity(ctx context.Context, identity *resources.Identity) (*resources.Identity, error) {
+	start := time.Now()
+	result, err := s.IdentitiesService.UpdateIdentity(ctx, identity)
+	s.recorder.ObserveServiceCall(ctx, "IdentitiesService.UpdateIdentity", time.Since(start), err)
+	return result, err
+}
+
+// DeleteIdentity implements the IdentitiesService interface.
+func (s *identitiesServiceWithMetrics) DeleteIdentity(ctx context.Context, identityId string) (bool, error) {
+	start := time.Now()
+	result, err := s.IdentitiesService.DeleteIdentity(ctx, identityId)
+	s.recorder.ObserveServiceCall(ctx, "IdentitiesService.DeleteIdentity", time.Since(start), err)
+	return result, err
+}
+
+// GetIdentityGroups implements the IdentitiesService interface.
+func (s *identitiesServiceWithMetrics) GetIdentityGroups(ctx context.Context, identityId string, params *resources.GetIdentitiesItemGroupsParams) (*resources.PaginatedResponse[resources.Group], error) {
+	start := time.Now()
+	result, err := s.IdentitiesService.GetIdentityGroups(ctx, identityId, params)
+	s.recorder.ObserveServiceCall(ctx, "IdentitiesService.GetIdentityGroups", time.Since(start), err)
+	return result, err
+}
+
+// PatchIdentityGroups implements the IdentitiesService interface.
+func (s *identitiesServiceWithMetrics) PatchIdentityGroups(ctx context.Context, identityId string, groupPatches []resources.IdentityGroupsPatchItem) (bool, error) {
+	start := time.Now()
+	result, err := s.IdentitiesService.PatchIdentityGroups(ctx, identityId, groupPatches)
+	s.recorder.ObserveServiceCall(ctx, "IdentitiesService.PatchIdentityGroups", time.Since(start), err)
+	return result, err
+}
+
+// GetIdentityRoles implements the IdentitiesService interface.
+func (s *identitiesServiceWithMetrics) GetIdentityRoles(ctx context.Context, identityId string, params *resources.GetIdentitiesItemRolesParams) (*resources.PaginatedResponse[resources.Role], error) {
+	start := time.Now()
+	result, err := s.IdentitiesService.GetIdentityRoles(ctx, identityId, params)
+	s.recorder.ObserveServiceCall(ctx, "IdentitiesService.GetIdentityRoles", time.Since(start), err)
+	return result, err
+}
+
+// PatchIdentityRoles implements the IdentitiesService interface.
+func (s *identitiesServiceWithMetrics) PatchIdentityRoles(ctx context.Context, identityId string, rolePatches []resources.IdentityRolesPatchItem) (bool, error) {
+	start := time.Now()
+	result, err := s.IdentitiesService.PatchIdentityRoles(ctx, identityId, rolePatches)
+	s.recorder.ObserveServiceCall(ctx, "IdentitiesService.PatchIdentityRoles", time.Since(start), err)
+	return result, err
+}
+
+// GetIdentityEntitlements implements the IdentitiesService interface.
+func (s *identitiesServiceWithMetrics) GetIdentityEntitlements(ctx context.Context, identityId string, params *resources.GetIdentitiesItemEntitlementsParams) (*resources.PaginatedResponse[resources.EntityEntitlement], error) {
+	start := time.Now()
+	result, err := s.IdentitiesService.GetIdentityEntitlements(ctx, identityId, params)
+	s.recorder.ObserveServiceCall(ctx, "IdentitiesService.GetIdentityEntitlements", time.Since(start), err)
+	return result, err
+}
+
+// PatchIdentityEntitlements implements the IdentitiesService interface.
+func (s *identitiesServiceWithMetrics) PatchIdentityEntitlements(ctx context.Context, identityId string, entitlementPatches []resources.IdentityEntitlementsPatchItem) (bool, error) {
+	start := time.Now()
+	result, err := s.IdentitiesService.PatchIdentityEntitlements(ctx, identityId, entitlementPatches)
+	s.recorder.ObserveServiceCall(ctx, "IdentitiesService.PatchIdentityEntitlements", time.Since(start), err)
+	return result, err
+}
+
+// identityProvidersServiceWithMetrics wraps an IdentityProvidersService to record metrics of its method calls.
+type identityProvidersServiceWithMetrics struct {
+	interfaces.IdentityProvidersService
+	recorder interfaces.MetricsRecorder
+}
+
+// ListAvailableIdentityProviders implements the IdentityProvidersService interface.
+func (s *identityProvidersServiceWithMetrics) ListAvailableIdentityProviders(ctx context.Context, params *resources.GetAvailableIdentityProvidersParams) (*resources.PaginatedResponse[resources.AvailableIdentityProvider], error) {
+	start := time.Now()
+	result, err := s.IdentityProvidersService.ListAvailableIdentityProviders(ctx, params)
+	s.recorder.ObserveServiceCall(ctx, "IdentityProvidersService.ListAvailableIdentityProviders", time.Since(start), err)
+	return result, err
+}
+
+// ListIdentityProviders implements the IdentityProvidersService interface.
+func (s *identityProvidersServiceWithMetrics) ListIdentityProviders(ctx context.Context, params *resources.GetIdentityProvidersParams) (*resources.PaginatedResponse[resources.IdentityProvider], error) {
+	start := time.Now()
+	result, err := s.IdentityProvidersService.ListIdentityProviders(ctx, params)
+	s.recorder.ObserveServiceCall(ctx, "IdentityProvidersService.ListIdentityProviders", time.Since(start), err)
+	return result, err
+}
+
+// RegisterConfiguration implements the IdentityProvidersService interface.
+func (s *identityProvidersServiceWithMetrics) RegisterConfiguration(ctx context.Context, provider *resources.IdentityProvider) (*resources.IdentityProvider, error) {
+	start := time.Now()
+	result, err := s.IdentityProvidersService.RegisterConfiguration(ctx, provider)
+	s.recorder.ObserveServiceCall(ctx, "IdentityProvidersService.RegisterConfiguration", time.Since(start), err)
+	return result, err
+}
+
+// DeleteConfiguration implements the IdentityProvidersService interface.
+func (s *identityProvidersServiceWithMetrics) DeleteConfiguration(ctx context.Context, id string) (bool, error) {
+	start := time.Now()
+	result, err := s.IdentityProvidersService.DeleteConfiguration(ctx, id)
+	s.recorder.ObserveServiceCall(ctx, "IdentityProvidersService.DeleteConfiguration", time.Since(start), err)
+	return result, err
+}
+
+// GetConfiguration implements the IdentityProvidersService interface.
+func (s *identityProvidersServiceWithMetrics) GetConfiguration(ctx context.Context, id string) (*resources.IdentityProvider, error) {
+	start := time.Now()
+	result, err := s.IdentityProvidersService.GetConfiguration(ctx, id)
+	s.recorder.ObserveServiceCall(ctx, "IdentityProvidersService.GetConfiguration", time.Since(start), err)
+	return result, err
+}
+
+// UpdateConfiguration implements the IdentityProvidersService interface.
+func (s *identityProvidersServiceWithMetrics) UpdateConfiguration(ctx context.Context, provider *resources.IdentityProvider) (*resources.IdentityProvider, error) {
+	start := time.Now()
+	result, err := s.IdentityProvidersService.UpdateConfiguration(ctx, provider)
+	s.recorder.ObserveServiceCall(ctx, "IdentityProvidersService.UpdateConfiguration", time.Since(start), err)
+	return result, err
+}
+
+// resourcesServiceWithMetrics wraps a ResourcesService to record metrics of its method calls.
+type resourcesServiceWithMetrics struct {
+	interfaces.ResourcesService
+	recorder interfaces.MetricsRecorder
+}
+
+// ListResources implements the ResourcesService interface.
+func (s *resourcesServiceWithMetrics) ListResources(ctx context.Context, params *resources.GetResourcesParams) (*resources.PaginatedResponse[resources.Resource], error) {
+	start := time.Now()
+	result, err := s.ResourcesService.ListResources(ctx, params)
+	s.recorder.ObserveServiceCall(ctx, "ResourcesService.ListResources", time.Since(start), err)
+	return result, err
+}
+
+// rolesServiceWithMetrics wraps a RolesService to record metrics of its method calls.
+type rolesServiceWithMetrics struct {
+	interfaces.RolesService
+	recorder interfaces.MetricsRecorder
+}
+
+// ListRoles implements the RolesService interface.
+func (s *rolesServiceWithMetrics) ListRoles(ctx context.Context, params *resources.GetRolesParams) (*resources.PaginatedResponse[resources.Role], error) {
+	start := time.Now()
+	result, err := s.RolesService.ListRoles(ctx, params)
+	s.recorder.ObserveServiceCall(ctx, "RolesService.ListRoles", time.Since(start), err)
+	return result, err
+}
+
+// CreateRole implements the RolesService interface.
+func (s *rolesServiceWithMetrics) CreateRole(ctx context.Context, role *resources.Role) (*resources.Role, error) {
+	start := time.Now()
+	result, err := s.RolesService.CreateRole(ctx, role)
+	s.recorder.ObserveServiceCall(ctx, "RolesService.CreateRole", time.Since(start), err)
+	return result, err
+}
+
+// GetRole implements the RolesService interface.
+func (s *rolesServiceWithMetrics) GetRole(ctx context.Context, roleId string) (*resources.Role, error) {
+	start := time.Now()
+	result, err := s.RolesService.GetRole(ctx, roleId)
+	s.recorder.ObserveServiceCall(ctx, "RolesService.GetRole", time.Since(start), err)
+	return result, err
+}
+
+// UpdateRole implements the RolesService interface.
+func (s *rolesServiceWithMetrics) UpdateRole(ctx context.Context, role *resources.Role) (*resources.Role, error) {
+	start := time.Now()
+	result, err := s.RolesService.UpdateRole(ctx, role)
+	s.recorder.ObserveServiceCall(ctx, "RolesService.UpdateRole", time.Since(start), err)
+	return result, err
+}
+
+// DeleteRole implements the RolesService interface.
+func (s *rolesServiceWithMetrics) DeleteRole(ctx context.Context, roleId string) (bool, error) {
+	start := time.Now()
+	result, err := s.RolesService.DeleteRole(ctx, roleId)
+	s.recorder.ObserveServiceCall(ctx, "RolesService.DeleteRole", time.Since(start), err)
+	return result, err
+}
+
+// GetRoleEntitlements implements the RolesService interface.
+func (s *rolesServiceWithMetrics) GetRoleEntitlements(ctx context.Context, roleId string, params *resources.GetRolesItemEntitlementsParams) (*resources.PaginatedResponse[resources.EntityEntitlement], error) {
+	start := time.Now()
+	result, err := s.RolesService.GetRoleEntitlements(ctx, roleId, params)
+	s.recorder.ObserveServiceCall(ctx, "RolesService.GetRoleEntitlements", time.Since(start), err)
+	return result, err
+}
+
+// PatchRoleEntitlements implements the RolesService interface.
+func (s *rolesServiceWithMetrics) PatchRoleEntitlements(ctx context.Context, roleId string, entitlementPatches []resources.RoleEntitlementsPatchItem) (bool, error) {
+	start := time.Now()
+	result, err := s.RolesService.PatchRoleEntitlements(ctx, roleId, entitlementPatches)
+	s.recorder.ObserveServiceCall(ctx, "RolesService.PatchRoleEntitlements", time.Since(start), err)
+	return result, err
+}
+
+// withServiceHooksMetrics returns a copy of the given hooks, where all provided
+// ones (i.e., non-nil ones) are wrapped to record the latency and errors of
+// their method calls via the given metrics recorder.
+func withServiceHooksMetrics(hooks serviceHooks, recorder interfaces.MetricsRecorder) serviceHooks {
+	if hooks.groupsVersioner != nil {
+		hooks.groupsVersioner = &entityVersionerWithMetrics{hooks.groupsVersioner, recorder, "GroupsService"}
+	}
+	if hooks.rolesVersioner != nil {
+		hooks.rolesVersioner = &entityVersionerWithMetrics{hooks.rolesVersioner, recorder, "RolesService"}
+	}
+	if hooks.identitiesVersioner != nil {
+		hooks.identitiesVersioner = &entityVersionerWithMetrics{hooks.identitiesVersioner, recorder, "IdentitiesService"}
+	}
+	if hooks.identityProvidersVersioner != nil {
+		hooks.identityProvidersVersioner = &entityVersionerWithMetrics{hooks.identityProvidersVersioner, recorder, "IdentityProvidersService"}
+	}
+	if hooks.identitiesEffectiveEntitlements != nil {
+		hooks.identitiesEffectiveEntitlements = &effectiveEntitlementsResolverWithMetrics{hooks.identitiesEffectiveEntitlements, recorder}
+	}
+	if hooks.resourcesAccess != nil {
+		hooks.resourcesAccess = &resourceAccessListerWithMetrics{hooks.resourcesAccess, recorder}
+	}
+	if hooks.groupsPatchReporter != nil {
+		hooks.groupsPatchReporter = &groupsPatchReporterWithMetrics{hooks.groupsPatchReporter, recorder}
+	}
+	if hooks.identitiesPatchReporter != nil {
+		hooks.identitiesPatchReporter = &identitiesPatchReporterWithMetrics{hooks.identitiesPatchReporter, recorder}
+	}
+	if hooks.rolesPatchReporter != nil {
+		hooks.rolesPatchReporter = &rolesPatchReporterWithMetrics{hooks.rolesPatchReporter, recorder}
+	}
+	return hooks
+}
+
+// entityVersionerWithMetrics wraps an EntityVersioner to record the latency
+// and errors of its method calls. The calls are recorded under the name of
+// the service that implements the hook.
+type entityVersionerWithMetrics struct {
+	interfaces.EntityVersioner
+	recorder interfaces.MetricsRecorder
+	service  string
+}
+
+// EntityVersion implements the EntityVersioner interface.
+func (s *entityVersionerWithMetrics) EntityVersion(ctx context.Context, id string) (string, error) {
+	start := time.Now()
+	result, err := s.EntityVersioner.EntityVersion(ctx, id)
+	s.recorder.ObserveServiceCall(ctx, s.service+".EntityVersion", time.Since(start), err)
+	return result, err
+}
+
+// effectiveEntitlementsResolverWithMetrics wraps an
+// EffectiveEntitlementsResolver to record the latency and errors of its
+// method calls.
+type effectiveEntitlementsResolverWithMetrics struct {
+	interfaces.EffectiveEntitlementsResolver
+	recorder interfaces.MetricsRecorder
+}
+
+// GetIdentityEffectiveEntitlements implements the EffectiveEntitlementsResolver interface.
+func (s *effectiveEntitlementsResolverWithMetrics) GetIdentityEffectiveEntitlements(ctx context.Context, identityId string) ([]resources.EffectiveEntitlement, error) {
+	start := time.Now()
+	result, err := s.EffectiveEntitlementsResolver.GetIdentityEffectiveEntitlements(ctx, identityId)
+	s.recorder.ObserveServiceCall(ctx, "IdentitiesService.GetIdentityEffectiveEntitlements", time.Since(start), err)
+	return result, err
+}
+
+// resourceAccessListerWithMetrics wraps a ResourceAccessLister to record the
+// latency and errors of its method calls.
+type resourceAccessListerWithMetrics struct {
+	interfaces.ResourceAccessLister
+	recorder interfaces.MetricsRecorder
+}
+
+// ListResourceAccess implements the ResourceAccessLister interface.
+func (s *resourceAccessListerWithMetrics) ListResourceAccess(ctx context.Context, entityType string, entityId string, params *resources.GetResourcesItemAccessParams) (*resources.PaginatedResponse[resources.ResourceAccess], error) {
+	start := time.Now()
+	result, err := s.ResourceAccessLister.ListResourceAccess(ctx, entityType, entityId, params)
+	s.recorder.ObserveServiceCall(ctx, "ResourcesService.ListResourceAccess", time.Since(start), err)
+	return result, err
+}
+
+// groupsPatchReporterWithMetrics wraps a GroupsPatchReporter to record the
+// latency and errors of its method calls.
+type groupsPatchReporterWithMetrics struct {
+	interfaces.GroupsPatchReporter
+	recorder interfaces.MetricsRecorder
+}
+
+// PatchGroupIdentitiesWithResults implements the GroupsPatchReporter interface.
+func (s *groupsPatchReporterWithMetrics) PatchGroupIdentitiesWithResults(ctx context.Context, groupId string, identityPatches []resources.GroupIdentitiesPatchItem) ([]resources.PatchItemResult, error) {
+	start := time.Now()
+	result, err := s.GroupsPatchReporter.PatchGroupIdentitiesWithResults(ctx, groupId, identityPatches)
+	s.recorder.ObserveServiceCall(ctx, "GroupsService.PatchGroupIdentitiesWithResults", time.Since(start), err)
+	return result, err
+}
+
+// PatchGroupRolesWithResults implements the GroupsPatchReporter interface.
+func (s *groupsPatchReporterWithMetrics) PatchGroupRolesWithResults(ctx context.Context, groupId string, rolePatches []resources.GroupRolesPatchItem) ([]resources.PatchItemResult, error) {
+	start := time.Now()
+	result, err := s.GroupsPatchReporter.PatchGroupRolesWithResults(ctx, groupId, rolePatches)
+	s.recorder.ObserveServiceCall(ctx, "GroupsService.PatchGroupRolesWithResults", time.Since(start), err)
+	return result, err
+}
+
+// PatchGroupEntitlementsWithResults implements the GroupsPatchReporter interface.
+func (s *groupsPatchReporterWithMetrics) PatchGroupEntitlementsWithResults(ctx context.Context, groupId string, entitlementPatches []resources.GroupEntitlementsPatchItem) ([]resources.PatchItemResult, error) {
+	start := time.Now()
+	result, err := s.GroupsPatchReporter.PatchGroupEntitlementsWithResults(ctx, groupId, entitlementPatches)
+	s.recorder.ObserveServiceCall(ctx, "GroupsService.PatchGroupEntitlementsWithResults", time.Since(start), err)
+	return result, err
+}
+
+// identitiesPatchReporterWithMetrics wraps an IdentitiesPatchReporter to
+// record the latency and errors of its method calls.
+type identitiesPatchReporterWithMetrics struct {
+	interfaces.IdentitiesPatchReporter
+	recorder interfaces.MetricsRecorder
+}
+
+// PatchIdentityGroupsWithResults implements the IdentitiesPatchReporter interface.
+func (s *identitiesPatchReporterWithMetrics) PatchIdentityGroupsWithResults(ctx context.Context, identityId string, groupPatches []resources.IdentityGroupsPatchItem) ([]resources.PatchItemResult, error) {
+	start := time.Now()
+	result, err := s.IdentitiesPatchReporter.PatchIdentityGroupsWithResults(ctx, identityId, groupPatches)
+	s.recorder.ObserveServiceCall(ctx, "IdentitiesService.PatchIdentityGroupsWithResults", time.Since(start), err)
+	return result, err
+}
+
+// PatchIdentityRolesWithResults implements the IdentitiesPatchReporter interface.
+func (s *identitiesPatchReporterWithMetrics) PatchIdentityRolesWithResults(ctx context.Context, identityId string, rolePatches []resources.IdentityRolesPatchItem) ([]resources.PatchItemResult, error) {
+	start := time.Now()
+	result, err := s.IdentitiesPatchReporter.PatchIdentityRolesWithResults(ctx, identityId, rolePatches)
+	s.recorder.ObserveServiceCall(ctx, "IdentitiesService.PatchIdentityRolesWithResults", time.Since(start), err)
+	return result, err
+}
+
+// PatchIdentityEntitlementsWithResults implements the IdentitiesPatchReporter interface.
+func (s *identitiesPatchReporterWithMetrics) PatchIdentityEntitlementsWithResults(ctx context.Context, identityId string, entitlementPatches []resources.IdentityEntitlementsPatchItem) ([]resources.PatchItemResult, error) {
+	start := time.Now()
+	result, err := s.IdentitiesPatchReporter.PatchIdentityEntitlementsWithResults(ctx, identityId, entitlementPatches)
+	s.recorder.ObserveServiceCall(ctx, "IdentitiesService.PatchIdentityEntitlementsWithResults", time.Since(start), err)
+	return result, err
+}
+
+// rolesPatchReporterWithMetrics wraps a RolesPatchReporter to record the
+// latency and errors of its method calls.
+type rolesPatchReporterWithMetrics struct {
+	interfaces.RolesPatchReporter
+	recorder interfaces.MetricsRecorder
+}
+
+// PatchRoleEntitlementsWithResults implements the RolesPatchReporter interface.
+func (s *rolesPatchReporterWithMetrics) PatchRoleEntitlementsWithResults(ctx context.Context, roleId string, entitlementPatches []resources.RoleEntitlementsPatchItem) ([]resources.PatchItemResult, error) {
+	start := time.Now()
+	result, err := s.RolesPatchReporter.PatchRoleEntitlementsWithResults(ctx, roleId, entitlementPatches)
+	s.recorder.ObserveServiceCall(ctx, "RolesService.PatchRoleEntitlementsWithResults", time.Since(start), err)
+	return result, err
+}
@@ -0,0 +1,158 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+//go:generate mockgen -package interfaces -destination ./interfaces/mock_metrics.go -source=./interfaces/metrics.go
+
+func TestMetrics_RecordsRequestsAndServiceCalls(t *testing.T) {
+	c := qt.New(t)
+
+	groupId := "some-group"
+	serviceError := errors.New("database is down")
+
+	tests := []struct {
+		name                string
+		serviceResult       *resources.Group
+		serviceError        error
+		expectedStatus      int
+		expectedStatusClass string
+	}{{
+		name:                "success",
+		serviceResult:       &resources.Group{Id: &groupId, Name: groupId},
+		expectedStatus:      http.StatusOK,
+		expectedStatusClass: "2xx",
+	}, {
+		name:                "service error",
+		serviceError:        serviceError,
+		expectedStatus:      http.StatusInternalServerError,
+		expectedStatusClass: "5xx",
+	}}
+
+	for _, test := range tests {
+		tt := test
+		c.Run(tt.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+			defer ctrl.Finish()
+
+			groups := interfaces.NewMockGroupsService(ctrl)
+			groups.EXPECT().GetGroup(gomock.Any(), groupId).Return(tt.serviceResult, tt.serviceError)
+
+			recorder := interfaces.NewMockMetricsRecorder(ctrl)
+			recorder.EXPECT().ObserveServiceCall(gomock.Any(), "GroupsService.GetGroup", gomock.Any(), tt.serviceError)
+			recorder.EXPECT().ObserveRequest(gomock.Any(), "GetGroupsItem", tt.expectedStatus, tt.expectedStatusClass, gomock.Any())
+
+			sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+				Groups:  groups,
+				Metrics: recorder,
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/rebac/v1/groups/"+groupId, nil)
+			w := httptest.NewRecorder()
+			sut.Handler("/rebac/").ServeHTTP(w, req)
+			c.Assert(w.Code, qt.Equals, tt.expectedStatus)
+		})
+	}
+}
+
+func TestMetrics_RecordsRejectedRequests(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	authenticator := interfaces.NewMockAuthenticator(ctrl)
+	authenticator.EXPECT().Authenticate(gomock.Any()).Return(nil, NewAuthenticationError("bad token"))
+
+	recorder := interfaces.NewMockMetricsRecorder(ctrl)
+	recorder.EXPECT().ObserveServiceCall(gomock.Any(), "Authenticator.Authenticate", gomock.Any(), gomock.Any())
+	recorder.EXPECT().ObserveRequest(gomock.Any(), "GetRoles", http.StatusUnauthorized, "4xx", gomock.Any())
+
+	sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+		Authenticator: authenticator,
+		Roles:         interfaces.NewMockRolesService(ctrl),
+		Metrics:       recorder,
+	})
+
+	// Mounting the handler on an outer chi router, to make sure outer route
+	// patterns are not taken into account.
+	mux := chi.NewMux()
+	mux.Mount("/rebac", sut.Handler(""))
+
+	req := httptest.NewRequest(http.MethodGet, "/rebac/v1/roles", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	c.Assert(w.Code, qt.Equals, http.StatusUnauthorized)
+}
+
+func TestMetrics_IgnoresUnknownRoutes(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	// No calls are expected on the recorder.
+	recorder := interfaces.NewMockMetricsRecorder(ctrl)
+
+	sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+		Metrics: recorder,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/unknown", nil)
+	w := httptest.NewRecorder()
+	sut.Handler("").ServeHTTP(w, req)
+	c.Assert(w.Code, qt.Equals, http.StatusNotFound)
+}
+
+func TestOperations_CoverServerInterface(t *testing.T) {
+	c := qt.New(t)
+
+	operationIds := map[string]bool{}
+	for _, id := range operations {
+		operationIds[id] = true
+	}
+
+	serverInterface := reflect.TypeOf((*resources.ServerInterface)(nil)).Elem()
+	extensionServerInterface := reflect.TypeOf((*resources.ExtensionServerInterface)(nil)).Elem()
+	c.Assert(operations, qt.HasLen, serverInterface.NumMethod()+extensionServerInterface.NumMethod())
+	for _, t := range []reflect.Type{serverInterface, extensionServerInterface} {
+		for i := 0; i < t.NumMethod(); i++ {
+			name := t.Method(i).Name
+			c.Assert(operationIds[name], qt.IsTrue, qt.Commentf("missing operation %q", name))
+		}
+	}
+}
+
+func TestStatusClass(t *testing.T) {
+	c := qt.New(t)
+
+	c.Assert(statusClass(http.StatusOK), qt.Equals, "2xx")
+	c.Assert(statusClass(http.StatusNoContent), qt.Equals, "2xx")
+	c.Assert(statusClass(http.StatusNotFound), qt.Equals, "4xx")
+	c.Assert(statusClass(http.StatusNotImplemented), qt.Equals, "5xx")
+}
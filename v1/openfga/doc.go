@@ -0,0 +1,67 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package openfga provides reference implementations of the service interfaces
+// (i.e., `GroupsService`, `IdentitiesService`, `RolesService` and
+// `EntitlementsService`) on top of OpenFGA relationship tuples.
+//
+// The services communicate with the underlying authorization provider via the
+// `TupleStore` abstraction. An OpenFGA HTTP API adapter (see `NewHTTPStore`)
+// and an in-memory stand-in, to be used in tests, (see `NewMemoryStore`) are
+// provided by this package.
+//
+// The services assume the following types and relations are defined in the
+// OpenFGA authorization model:
+//
+//	model
+//	  schema 1.1
+//
+//	type user
+//
+//	type registry
+//	  relations
+//	    define identity: [user]
+//	    define group: [group]
+//	    define role: [role]
+//
+//	type group
+//	  relations
+//	    define member: [user, group#member]
+//
+//	type role
+//	  relations
+//	    define assignee: [user, group#member]
+//
+// Since OpenFGA does not store entities on their own, the existence of each
+// identity, group and role is tracked via a tuple on the `registry:default`
+// object (e.g., `group:foo group registry:default`). Also, the ID of each
+// entity is the same as its name (or email, in case of identities).
+//
+// Entitlements are direct relations between receivers (i.e., `user:<id>`,
+// `group:<id>#member` or `role:<id>#assignee`) and application types, which
+// should be declared in the model, like this:
+//
+//	type controller
+//	  relations
+//	    define administrator: [user, group#member, role#assignee]
+//
+// The list of such entitlements should be provided to the services via
+// `Params.Entitlements`.
+//
+// Since OpenFGA only supports continuation tokens, lists of identities, groups
+// and roles are paginated via the next page token mechanism; requests for a
+// page number other than the first are rejected. For the same reason, they
+// cannot be sorted, and requests with the `sort` query parameter are rejected.
+package openfga
@@ -13,44 +13,48 @@
 // You should have received a copy of the GNU Affero General Public License
 // along with this program.  If not, see <http://www.gnu.org/licenses/>.
 
-package service
+package openfga
 
 import (
 	"context"
-	"example/cmd/database"
 
 	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
 	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
 )
 
-// EntitlementsService implements the `EntitlementsService` interface.
+// EntitlementsService implements the `EntitlementsService` interface, based on
+// the entitlements and the authorization model provided via `Params`.
 type EntitlementsService struct {
-	Database *database.Database
+	service
 }
 
 // For doc/test sake, to hint that the struct needs to implement a specific interface.
 var _ interfaces.EntitlementsService = &EntitlementsService{}
 
+// NewEntitlementsService returns a new EntitlementsService instance.
+func NewEntitlementsService(params Params) *EntitlementsService {
+	return &EntitlementsService{service{params: params}}
+}
+
 // ListEntitlements returns the list of entitlements in JSON format.
 func (s *EntitlementsService) ListEntitlements(ctx context.Context, params *resources.GetEntitlementsParams) ([]resources.EntitlementSchema, error) {
-	// For the sake of this example we allow everyone to call this method. If it's not
-	// the case, you can do the following to get the user:
-	//
-	//    raw, _ := v1.GetIdentityFromContext(ctx)
-	//    user, _ := raw.(*User)
-	//
-	// And return this error if the user is not authorized:
-	//
-	//    return nil, v1.NewAuthorizationError("user cannot add group")
-	//
-
-	return s.Database.ListUserEntitlements(), nil
+	expr, err := parseFilter(params.Filter)
+	if err != nil {
+		return nil, err
+	}
+	result := []resources.EntitlementSchema{}
+	for _, e := range s.params.Entitlements {
+		if ok, err := matchFilter(expr, e); err != nil {
+			return nil, err
+		} else if !ok {
+			continue
+		}
+		result = append(result, e)
+	}
+	return result, nil
 }
 
-// RawEntitlements returns the list of entitlements as raw text.
+// RawEntitlements returns the authorization model as raw text.
 func (s *EntitlementsService) RawEntitlements(ctx context.Context) (string, error) {
-	// This should return the raw OpenFGA authorization model, so we just return
-	// a fake one:
-
-	return s.Database.GetAuthModel(), nil
+	return s.params.AuthorizationModel, nil
 }
@@ -0,0 +1,56 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package openfga
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+func TestEntitlementsService(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	params, _ := newTestParams()
+	s := NewEntitlementsService(params)
+
+	entitlements, err := s.ListEntitlements(ctx, &resources.GetEntitlementsParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(entitlements, qt.DeepEquals, testEntitlements)
+
+	filter := "can_edit"
+	entitlements, err = s.ListEntitlements(ctx, &resources.GetEntitlementsParams{Filter: &filter})
+	c.Assert(err, qt.IsNil)
+	c.Assert(entitlements, qt.DeepEquals, []resources.EntitlementSchema{
+		{Entitlement: "can_edit", EntityType: "client", ReceiverType: "role"},
+	})
+
+	filter = `entitlement eq can_view and receiver_type eq group or entitlement startsWith "can_e"`
+	entitlements, err = s.ListEntitlements(ctx, &resources.GetEntitlementsParams{Filter: &filter})
+	c.Assert(err, qt.IsNil)
+	c.Assert(entitlements, qt.DeepEquals, []resources.EntitlementSchema{
+		{Entitlement: "can_view", EntityType: "client", ReceiverType: "group"},
+		{Entitlement: "can_edit", EntityType: "client", ReceiverType: "role"},
+	})
+
+	raw, err := s.RawEntitlements(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(raw, qt.Equals, "model")
+}
@@ -0,0 +1,46 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package openfga
+
+import (
+	"fmt"
+
+	v1 "github.com/canonical/rebac-admin-ui-handlers/v1"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/filter"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// parseFilter parses the given `filter` query parameter (see the `filter`
+// package), if any. A nil expression matches all entities.
+func parseFilter(param *resources.FilterParam) (filter.Expr, error) {
+	if param == nil {
+		return nil, nil
+	}
+	expr, err := filter.Parse(*param)
+	if err != nil {
+		return nil, v1.NewValidationError(fmt.Sprintf("invalid filter: %s", err))
+	}
+	return expr, nil
+}
+
+// matchFilter checks if the given entity matches the given expression.
+func matchFilter(expr filter.Expr, entity any) (bool, error) {
+	ok, err := filter.Match(expr, entity)
+	if err != nil {
+		return false, v1.NewValidationError(fmt.Sprintf("invalid filter: %s", err))
+	}
+	return ok, nil
+}
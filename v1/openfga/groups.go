@@ -0,0 +1,185 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package openfga
+
+import (
+	"context"
+
+	v1 "github.com/canonical/rebac-admin-ui-handlers/v1"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// GroupsService implements the `GroupsService` interface on top of OpenFGA tuples.
+type GroupsService struct {
+	service
+}
+
+// For doc/test sake, to hint that the struct needs to implement a specific interface.
+var _ interfaces.GroupsService = &GroupsService{}
+
+// NewGroupsService returns a new GroupsService instance.
+func NewGroupsService(params Params) *GroupsService {
+	return &GroupsService{service{params: params}}
+}
+
+// newGroup returns a Group object with the given ID.
+func newGroup(id string) resources.Group {
+	return resources.Group{Id: &id, Name: id}
+}
+
+// ListGroups returns a page of Group objects of at least `size` elements if available.
+func (s *GroupsService) ListGroups(ctx context.Context, params *resources.GetGroupsParams) (*resources.PaginatedResponse[resources.Group], error) {
+	filter := Tuple{Relation: groupRegistryRelation, Object: registryObject}
+	expr, err := parseFilter(params.Filter)
+	if err != nil {
+		return nil, err
+	}
+	return listPage(ctx, &s.service, filter, expr, params.Size, params.Page, params.NextToken, params.NextPageToken, func(t Tuple) (resources.Group, bool) {
+		_, id := splitObject(t.User)
+		return newGroup(id), true
+	})
+}
+
+// CreateGroup creates a single Group. The group name is used as its ID.
+func (s *GroupsService) CreateGroup(ctx context.Context, group *resources.Group) (*resources.Group, error) {
+	if err := validateId(group.Name); err != nil {
+		return nil, err
+	}
+	if err := s.register(ctx, groupRegistryRelation, groupObject(group.Name)); err != nil {
+		return nil, err
+	}
+	result := newGroup(group.Name)
+	return &result, nil
+}
+
+// GetGroup returns a single Group identified by `groupId`.
+func (s *GroupsService) GetGroup(ctx context.Context, groupId string) (*resources.Group, error) {
+	if err := s.ensureExists(ctx, groupRegistryRelation, groupObject(groupId)); err != nil {
+		return nil, err
+	}
+	result := newGroup(groupId)
+	return &result, nil
+}
+
+// UpdateGroup updates a Group. Since group names are used as IDs, renaming
+// groups is not supported.
+func (s *GroupsService) UpdateGroup(ctx context.Context, group *resources.Group) (*resources.Group, error) {
+	if group.Id == nil {
+		return nil, v1.NewValidationError("missing group ID")
+	}
+	if err := s.ensureExists(ctx, groupRegistryRelation, groupObject(*group.Id)); err != nil {
+		return nil, err
+	}
+	if group.Name != *group.Id {
+		return nil, v1.NewInvalidRequestError("renaming groups is not supported")
+	}
+	result := newGroup(*group.Id)
+	return &result, nil
+}
+
+// DeleteGroup deletes a Group identified by `groupId`, along with all its
+// relations. It returns (false, nil) if the group does not exist.
+func (s *GroupsService) DeleteGroup(ctx context.Context, groupId string) (bool, error) {
+	filters := []Tuple{
+		{Object: groupObject(groupId)},
+		{User: groupMembers(groupId), Object: groupType + ":"},
+		{User: groupMembers(groupId), Object: roleType + ":"},
+	}
+	filters = append(filters, s.entitlementFilters(groupReceiverType, groupMembers(groupId))...)
+	return s.unregister(ctx, groupRegistryRelation, groupObject(groupId), filters)
+}
+
+// GetGroupIdentities returns a page of identities in a Group identified by `groupId`.
+func (s *GroupsService) GetGroupIdentities(ctx context.Context, groupId string, params *resources.GetGroupsItemIdentitiesParams) (*resources.PaginatedResponse[resources.Identity], error) {
+	if err := s.ensureExists(ctx, groupRegistryRelation, groupObject(groupId)); err != nil {
+		return nil, err
+	}
+	filter := Tuple{Relation: memberRelation, Object: groupObject(groupId)}
+	return listPage(ctx, &s.service, filter, nil, params.Size, params.Page, params.NextToken, params.NextPageToken, func(t Tuple) (resources.Identity, bool) {
+		userType, id, _ := splitUser(t.User)
+		if userType != identityType {
+			return resources.Identity{}, false
+		}
+		return newIdentity(id), true
+	})
+}
+
+// PatchGroupIdentities performs addition or removal of identities to/from a Group identified by `groupId`.
+func (s *GroupsService) PatchGroupIdentities(ctx context.Context, groupId string, identityPatches []resources.GroupIdentitiesPatchItem) (bool, error) {
+	if err := s.ensureExists(ctx, groupRegistryRelation, groupObject(groupId)); err != nil {
+		return false, err
+	}
+	for _, p := range identityPatches {
+		if err := validateId(p.Identity); err != nil {
+			return false, err
+		}
+	}
+	return s.applyPatches(ctx, mapPatches(identityPatches, func(p resources.GroupIdentitiesPatchItem) (Tuple, string) {
+		return memberTuple(p.Identity, groupId), string(p.Op)
+	}))
+}
+
+// GetGroupRoles returns a page of Roles for Group `groupId`.
+func (s *GroupsService) GetGroupRoles(ctx context.Context, groupId string, params *resources.GetGroupsItemRolesParams) (*resources.PaginatedResponse[resources.Role], error) {
+	if err := s.ensureExists(ctx, groupRegistryRelation, groupObject(groupId)); err != nil {
+		return nil, err
+	}
+	filter := Tuple{User: groupMembers(groupId), Relation: assigneeRelation, Object: roleType + ":"}
+	return listPage(ctx, &s.service, filter, nil, params.Size, params.Page, params.NextToken, params.NextPageToken, func(t Tuple) (resources.Role, bool) {
+		_, id := splitObject(t.Object)
+		return newRole(id), true
+	})
+}
+
+// PatchGroupRoles performs addition or removal of a Role to/from a Group identified by `groupId`.
+func (s *GroupsService) PatchGroupRoles(ctx context.Context, groupId string, rolePatches []resources.GroupRolesPatchItem) (bool, error) {
+	if err := s.ensureExists(ctx, groupRegistryRelation, groupObject(groupId)); err != nil {
+		return false, err
+	}
+	for _, p := range rolePatches {
+		if err := validateId(p.Role); err != nil {
+			return false, err
+		}
+	}
+	return s.applyPatches(ctx, mapPatches(rolePatches, func(p resources.GroupRolesPatchItem) (Tuple, string) {
+		return Tuple{User: groupMembers(groupId), Relation: assigneeRelation, Object: roleObject(p.Role)}, string(p.Op)
+	}))
+}
+
+// GetGroupEntitlements returns a page of Entitlements for Group `groupId`.
+func (s *GroupsService) GetGroupEntitlements(ctx context.Context, groupId string, params *resources.GetGroupsItemEntitlementsParams) (*resources.PaginatedResponse[resources.EntityEntitlement], error) {
+	if err := s.ensureExists(ctx, groupRegistryRelation, groupObject(groupId)); err != nil {
+		return nil, err
+	}
+	return s.listEntitlements(ctx, groupReceiverType, groupMembers(groupId), params.Size, params.Page)
+}
+
+// PatchGroupEntitlements performs addition or removal of an Entitlement to/from a Group identified by `groupId`.
+func (s *GroupsService) PatchGroupEntitlements(ctx context.Context, groupId string, entitlementPatches []resources.GroupEntitlementsPatchItem) (bool, error) {
+	if err := s.ensureExists(ctx, groupRegistryRelation, groupObject(groupId)); err != nil {
+		return false, err
+	}
+	return s.patchEntitlements(ctx, groupReceiverType, mapPatches(entitlementPatches, func(p resources.GroupEntitlementsPatchItem) (Tuple, string) {
+		return entitlementTuple(groupMembers(groupId), p.Entitlement), string(p.Op)
+	}))
+}
+
+// memberTuple returns the tuple that represents the membership of the given
+// identity in the given group.
+func memberTuple(identityId, groupId string) Tuple {
+	return Tuple{User: identityObject(identityId), Relation: memberRelation, Object: groupObject(groupId)}
+}
@@ -0,0 +1,219 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package openfga
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/sorting"
+)
+
+func TestGroupsService_CRUD(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	params, _ := newTestParams()
+	s := NewGroupsService(params)
+
+	group, err := s.CreateGroup(ctx, &resources.Group{Name: "admins"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(*group.Id, qt.Equals, "admins")
+	c.Assert(group.Name, qt.Equals, "admins")
+
+	_, err = s.CreateGroup(ctx, &resources.Group{Name: "admins"})
+	c.Assert(err, qt.ErrorMatches, "Bad Request: invalid request: group:admins already exists")
+
+	_, err = s.CreateGroup(ctx, &resources.Group{Name: "bad:name"})
+	c.Assert(err, qt.ErrorMatches, "Bad Request: invalid ID .*")
+
+	_, err = s.CreateGroup(ctx, &resources.Group{Name: "viewers"})
+	c.Assert(err, qt.IsNil)
+
+	group, err = s.GetGroup(ctx, "admins")
+	c.Assert(err, qt.IsNil)
+	c.Assert(group.Name, qt.Equals, "admins")
+
+	_, err = s.GetGroup(ctx, "missing")
+	c.Assert(err, qt.ErrorMatches, "Not Found: group:missing not found")
+
+	id := "admins"
+	_, err = s.UpdateGroup(ctx, &resources.Group{Id: &id, Name: "other"})
+	c.Assert(err, qt.ErrorMatches, "Bad Request: invalid request: renaming groups is not supported")
+
+	filter := "adm"
+	groups, err := s.ListGroups(ctx, &resources.GetGroupsParams{Filter: &filter})
+	c.Assert(err, qt.IsNil)
+	c.Assert(groups.Data, qt.HasLen, 1)
+	c.Assert(groups.Data[0].Name, qt.Equals, "admins")
+
+	deleted, err := s.DeleteGroup(ctx, "admins")
+	c.Assert(err, qt.IsNil)
+	c.Assert(deleted, qt.IsTrue)
+
+	deleted, err = s.DeleteGroup(ctx, "admins")
+	c.Assert(err, qt.IsNil)
+	c.Assert(deleted, qt.IsFalse)
+}
+
+func TestGroupsService_ListGroupsPagination(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	params, _ := newTestParams(
+		registryTuple(groupRegistryRelation, "group:a"),
+		registryTuple(groupRegistryRelation, "group:b"),
+		registryTuple(groupRegistryRelation, "group:c"),
+	)
+	s := NewGroupsService(params)
+
+	size := 2
+	page, err := s.ListGroups(ctx, &resources.GetGroupsParams{Size: &size})
+	c.Assert(err, qt.IsNil)
+	c.Assert(page.Data, qt.DeepEquals, []resources.Group{newGroup("a"), newGroup("b")})
+	c.Assert(page.Next.PageToken, qt.IsNotNil)
+
+	page, err = s.ListGroups(ctx, &resources.GetGroupsParams{Size: &size, NextPageToken: page.Next.PageToken})
+	c.Assert(err, qt.IsNil)
+	c.Assert(page.Data, qt.DeepEquals, []resources.Group{newGroup("c")})
+	c.Assert(page.Next.PageToken, qt.IsNil)
+
+	// Huge page sizes are capped, rather than allocated upfront.
+	size = math.MaxInt
+	page, err = s.ListGroups(ctx, &resources.GetGroupsParams{Size: &size})
+	c.Assert(err, qt.IsNil)
+	c.Assert(page.Data, qt.HasLen, 3)
+
+	// Page numbers are not supported.
+	pageNumber := 1
+	_, err = s.ListGroups(ctx, &resources.GetGroupsParams{Size: &size, Page: &pageNumber})
+	c.Assert(err, qt.ErrorMatches, `Bad Request: invalid query parameter "page": not supported, use nextToken instead`)
+
+	// Sorting is not supported.
+	sortCtx := sorting.NewContext(ctx, sorting.Order{{Name: "name", Descending: true}})
+	_, err = s.ListGroups(sortCtx, &resources.GetGroupsParams{})
+	c.Assert(err, qt.ErrorMatches, `Bad Request: invalid query parameter "sort": not supported by this backend`)
+}
+
+func TestGroupsService_ListGroupsFilter(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	params, _ := newTestParams(
+		registryTuple(groupRegistryRelation, "group:a1"),
+		registryTuple(groupRegistryRelation, "group:b"),
+		registryTuple(groupRegistryRelation, "group:c"),
+		registryTuple(groupRegistryRelation, "group:a2"),
+		registryTuple(groupRegistryRelation, "group:a3"),
+	)
+	s := NewGroupsService(params)
+
+	// The filter is applied before pagination, so pages are not short.
+	size := 2
+	filter := `name startsWith "a" or name eq c`
+	page, err := s.ListGroups(ctx, &resources.GetGroupsParams{Size: &size, Filter: &filter})
+	c.Assert(err, qt.IsNil)
+	c.Assert(page.Data, qt.DeepEquals, []resources.Group{newGroup("a1"), newGroup("c")})
+	c.Assert(page.Next.PageToken, qt.IsNotNil)
+
+	page, err = s.ListGroups(ctx, &resources.GetGroupsParams{Size: &size, Filter: &filter, NextPageToken: page.Next.PageToken})
+	c.Assert(err, qt.IsNil)
+	c.Assert(page.Data, qt.DeepEquals, []resources.Group{newGroup("a2"), newGroup("a3")})
+
+	filter = `name eq`
+	_, err = s.ListGroups(ctx, &resources.GetGroupsParams{Filter: &filter})
+	c.Assert(err, qt.ErrorMatches, "Bad Request: invalid filter: .*")
+}
+
+func TestGroupsService_Relations(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	params, store := newTestParams(
+		registryTuple(groupRegistryRelation, "group:admins"),
+		registryTuple(roleRegistryRelation, "role:viewer"),
+		registryTuple(identityRegistryRelation, "user:joe@example.com"),
+	)
+	s := NewGroupsService(params)
+
+	changed, err := s.PatchGroupIdentities(ctx, "admins", []resources.GroupIdentitiesPatchItem{
+		{Identity: "joe@example.com", Op: resources.GroupIdentitiesPatchItemOpAdd},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(changed, qt.IsTrue)
+
+	// Adding the same identity again is a no-op.
+	changed, err = s.PatchGroupIdentities(ctx, "admins", []resources.GroupIdentitiesPatchItem{
+		{Identity: "joe@example.com", Op: resources.GroupIdentitiesPatchItemOpAdd},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(changed, qt.IsFalse)
+
+	// Repeated items are applied once, and the last one wins.
+	changed, err = s.PatchGroupIdentities(ctx, "admins", []resources.GroupIdentitiesPatchItem{
+		{Identity: "joe@example.com", Op: resources.GroupIdentitiesPatchItemOpRemove},
+		{Identity: "joe@example.com", Op: resources.GroupIdentitiesPatchItemOpRemove},
+		{Identity: "joe@example.com", Op: resources.GroupIdentitiesPatchItemOpAdd},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(changed, qt.IsFalse)
+
+	identities, err := s.GetGroupIdentities(ctx, "admins", &resources.GetGroupsItemIdentitiesParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(identities.Data, qt.DeepEquals, []resources.Identity{newIdentity("joe@example.com")})
+
+	_, err = s.PatchGroupRoles(ctx, "admins", []resources.GroupRolesPatchItem{
+		{Role: "viewer", Op: resources.GroupRolesPatchItemOpAdd},
+		{Role: "viewer", Op: resources.GroupRolesPatchItemOpAdd},
+	})
+	c.Assert(err, qt.IsNil)
+
+	roles, err := s.GetGroupRoles(ctx, "admins", &resources.GetGroupsItemRolesParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(roles.Data, qt.DeepEquals, []resources.Role{newRole("viewer")})
+
+	_, err = s.PatchGroupEntitlements(ctx, "admins", []resources.GroupEntitlementsPatchItem{
+		{Entitlement: resources.EntityEntitlement{Entitlement: "can_view", EntityType: "client", EntityId: "c1"}, Op: resources.GroupEntitlementsPatchItemOpAdd},
+	})
+	c.Assert(err, qt.IsNil)
+
+	_, err = s.PatchGroupEntitlements(ctx, "admins", []resources.GroupEntitlementsPatchItem{
+		{Entitlement: resources.EntityEntitlement{Entitlement: "can_edit", EntityType: "client", EntityId: "c1"}, Op: resources.GroupEntitlementsPatchItemOpAdd},
+	})
+	c.Assert(err, qt.ErrorMatches, "Bad Request: unknown entitlement .*")
+
+	entitlements, err := s.GetGroupEntitlements(ctx, "admins", &resources.GetGroupsItemEntitlementsParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(entitlements.Data, qt.DeepEquals, []resources.EntityEntitlement{
+		{Entitlement: "can_view", EntityType: "client", EntityId: "c1"},
+	})
+
+	_, err = s.GetGroupRoles(ctx, "missing", &resources.GetGroupsItemRolesParams{})
+	c.Assert(err, qt.ErrorMatches, "Not Found: group:missing not found")
+
+	// Deleting the group removes all its relations.
+	deleted, err := s.DeleteGroup(ctx, "admins")
+	c.Assert(err, qt.IsNil)
+	c.Assert(deleted, qt.IsTrue)
+	c.Assert(readAllTuples(c, store), qt.DeepEquals, []Tuple{
+		registryTuple(roleRegistryRelation, "role:viewer"),
+		registryTuple(identityRegistryRelation, "user:joe@example.com"),
+	})
+}
@@ -0,0 +1,322 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package openfga
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// HTTPStoreParams contains the configuration of an OpenFGA HTTP API adapter.
+type HTTPStoreParams struct {
+	// APIURL is the base URL of the OpenFGA HTTP API (e.g., `http://localhost:8080`).
+	APIURL string
+
+	// StoreID is the ID of the OpenFGA store.
+	StoreID string
+
+	// AuthorizationModelID is the ID of the authorization model to use. If
+	// empty, the latest model is used.
+	AuthorizationModelID string
+
+	// Token is the (optional) pre-shared key to authenticate with the OpenFGA
+	// server.
+	Token string
+
+	// Client is the HTTP client used to send requests. If nil, the
+	// `http.DefaultClient` is used.
+	Client *http.Client
+}
+
+// HTTPStore implements the TupleStore interface by calling the OpenFGA HTTP API.
+type HTTPStore struct {
+	params  HTTPStoreParams
+	baseURL string
+}
+
+// For doc/test sake, to hint that the struct needs to implement a specific interface.
+var _ TupleStore = &HTTPStore{}
+
+// NewHTTPStore returns a new HTTPStore instance, configured with the given
+// parameters.
+func NewHTTPStore(params HTTPStoreParams) (*HTTPStore, error) {
+	if params.APIURL == "" {
+		return nil, errors.New("missing OpenFGA API URL")
+	}
+	if _, err := url.Parse(params.APIURL); err != nil {
+		return nil, fmt.Errorf("invalid OpenFGA API URL: %w", err)
+	}
+	if params.StoreID == "" {
+		return nil, errors.New("missing OpenFGA store ID")
+	}
+	if params.Client == nil {
+		params.Client = http.DefaultClient
+	}
+
+	baseURL, _ := strings.CutSuffix(params.APIURL, "/")
+	return &HTTPStore{
+		params:  params,
+		baseURL: fmt.Sprintf("%s/stores/%s", baseURL, url.PathEscape(params.StoreID)),
+	}, nil
+}
+
+// maxReadPageSize is the maximum page size accepted by the OpenFGA `Read`
+// endpoint.
+const maxReadPageSize = 100
+
+type readRequest struct {
+	TupleKey          *Tuple `json:"tuple_key,omitempty"`
+	PageSize          int    `json:"page_size,omitempty"`
+	ContinuationToken string `json:"continuation_token,omitempty"`
+}
+
+type readResponse struct {
+	Tuples []struct {
+		Key Tuple `json:"key"`
+	} `json:"tuples"`
+	ContinuationToken string `json:"continuation_token"`
+}
+
+// ReadTuples returns a page of tuples that match the given filter. Page sizes
+// larger than the maximum accepted by OpenFGA (100) are capped to it.
+func (s *HTTPStore) ReadTuples(ctx context.Context, filter Tuple, pageSize int, continuationToken string) ([]Tuple, string, error) {
+	request := readRequest{
+		PageSize:          min(pageSize, maxReadPageSize),
+		ContinuationToken: continuationToken,
+	}
+	if filter != (Tuple{}) {
+		request.TupleKey = &filter
+	}
+
+	response := readResponse{}
+	if err := s.post(ctx, "/read", request, &response); err != nil {
+		return nil, "", err
+	}
+
+	result := make([]Tuple, 0, len(response.Tuples))
+	for _, t := range response.Tuples {
+		result = append(result, t.Key)
+	}
+	return result, response.ContinuationToken, nil
+}
+
+type tupleKeys struct {
+	TupleKeys []Tuple `json:"tuple_keys"`
+}
+
+type writeRequest struct {
+	Writes               *tupleKeys `json:"writes,omitempty"`
+	Deletes              *tupleKeys `json:"deletes,omitempty"`
+	AuthorizationModelID string     `json:"authorization_model_id,omitempty"`
+}
+
+// WriteTuples writes the given tuples to the store.
+func (s *HTTPStore) WriteTuples(ctx context.Context, tuples ...Tuple) error {
+	return s.Write(ctx, tuples, nil)
+}
+
+// DeleteTuples deletes the given tuples from the store.
+func (s *HTTPStore) DeleteTuples(ctx context.Context, tuples ...Tuple) error {
+	return s.Write(ctx, nil, tuples)
+}
+
+// maxWriteTuples is the maximum number of tuples (writes and deletes
+// combined) accepted by the OpenFGA `Write` endpoint in a single request.
+const maxWriteTuples = 100
+
+// Write writes and deletes the given tuples via `Write` requests, which
+// OpenFGA applies atomically. Since OpenFGA limits the number of tuples per
+// request (to 100), larger changes are split into batches (writes first, then
+// deletes), sent in order. So, atomicity is lost across batches: if a batch
+// fails, the changes of the earlier batches are not rolled back.
+func (s *HTTPStore) Write(ctx context.Context, writes []Tuple, deletes []Tuple) error {
+	for len(writes) > 0 || len(deletes) > 0 {
+		request := writeRequest{
+			AuthorizationModelID: s.params.AuthorizationModelID,
+		}
+		n := min(len(writes), maxWriteTuples)
+		if n > 0 {
+			request.Writes = &tupleKeys{TupleKeys: writes[:n]}
+			writes = writes[n:]
+		}
+		if m := min(len(deletes), maxWriteTuples-n); m > 0 {
+			request.Deletes = &tupleKeys{TupleKeys: deletes[:m]}
+			deletes = deletes[m:]
+		}
+		if err := s.post(ctx, "/write", request, nil); err != nil {
+			var e *apiError
+			if errors.As(err, &e) && e.Code == writeFailedErrorCode && strings.Contains(e.Message, "already exists") {
+				return fmt.Errorf("%w: %w", ErrTupleExists, err)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+type listObjectsRequest struct {
+	AuthorizationModelID string `json:"authorization_model_id,omitempty"`
+	Type                 string `json:"type"`
+	Relation             string `json:"relation"`
+	User                 string `json:"user"`
+}
+
+type listObjectsResponse struct {
+	Objects []string `json:"objects"`
+}
+
+// ListObjects returns the objects of the given type that the given user has
+// the given relation with.
+func (s *HTTPStore) ListObjects(ctx context.Context, user, relation, objectType string) ([]string, error) {
+	response := listObjectsResponse{}
+	if err := s.post(ctx, "/list-objects", listObjectsRequest{
+		AuthorizationModelID: s.params.AuthorizationModelID,
+		Type:                 objectType,
+		Relation:             relation,
+		User:                 user,
+	}, &response); err != nil {
+		return nil, err
+	}
+	return response.Objects, nil
+}
+
+type fgaObject struct {
+	Type string `json:"type"`
+	Id   string `json:"id"`
+}
+
+type fgaUserFilter struct {
+	Type     string `json:"type"`
+	Relation string `json:"relation,omitempty"`
+}
+
+type listUsersRequest struct {
+	AuthorizationModelID string          `json:"authorization_model_id,omitempty"`
+	Object               fgaObject       `json:"object"`
+	Relation             string          `json:"relation"`
+	UserFilters          []fgaUserFilter `json:"user_filters"`
+}
+
+type listUsersResponse struct {
+	Users []struct {
+		Object  *fgaObject `json:"object,omitempty"`
+		Userset *struct {
+			Type     string `json:"type"`
+			Id       string `json:"id"`
+			Relation string `json:"relation"`
+		} `json:"userset,omitempty"`
+		Wildcard *struct {
+			Type string `json:"type"`
+		} `json:"wildcard,omitempty"`
+	} `json:"users"`
+}
+
+// ListUsers returns the users of the given type that have the given relation
+// with the given object.
+func (s *HTTPStore) ListUsers(ctx context.Context, object, relation, userType string) ([]string, error) {
+	objectType, objectId := splitObject(object)
+	filterType, filterRelation, _ := strings.Cut(userType, "#")
+
+	response := listUsersResponse{}
+	if err := s.post(ctx, "/list-users", listUsersRequest{
+		AuthorizationModelID: s.params.AuthorizationModelID,
+		Object:               fgaObject{Type: objectType, Id: objectId},
+		Relation:             relation,
+		UserFilters:          []fgaUserFilter{{Type: filterType, Relation: filterRelation}},
+	}, &response); err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(response.Users))
+	for _, u := range response.Users {
+		switch {
+		case u.Object != nil:
+			result = append(result, fmt.Sprintf("%s:%s", u.Object.Type, u.Object.Id))
+		case u.Userset != nil:
+			result = append(result, fmt.Sprintf("%s:%s#%s", u.Userset.Type, u.Userset.Id, u.Userset.Relation))
+		case u.Wildcard != nil:
+			result = append(result, fmt.Sprintf("%s:*", u.Wildcard.Type))
+		}
+	}
+	return result, nil
+}
+
+// writeFailedErrorCode is the code of the errors returned by the OpenFGA
+// `Write` endpoint for invalid tuples (e.g., writing an existing tuple).
+const writeFailedErrorCode = "write_failed_due_to_invalid_input"
+
+// apiError represents an error response returned by the OpenFGA HTTP API.
+type apiError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error implements the error interface.
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s (%s)", e.Message, e.Code)
+}
+
+// post sends a POST request with the given body to the given endpoint (relative
+// to the store URL), and decodes the response into the given value (if not nil).
+func (s *HTTPStore) post(ctx context.Context, endpoint string, body any, response any) error {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal OpenFGA request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+endpoint, bytes.NewReader(raw))
+	if err != nil {
+		return fmt.Errorf("failed to create OpenFGA request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.params.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.params.Token)
+	}
+
+	res, err := s.params.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send OpenFGA request: %w", err)
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read OpenFGA response: %w", err)
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		e := &apiError{}
+		if err := json.Unmarshal(resBody, e); err != nil || e.Message == "" {
+			return fmt.Errorf("OpenFGA request failed with status %d", res.StatusCode)
+		}
+		return fmt.Errorf("OpenFGA request failed with status %d: %w", res.StatusCode, e)
+	}
+
+	if response == nil {
+		return nil
+	}
+	if err := json.Unmarshal(resBody, response); err != nil {
+		return fmt.Errorf("failed to unmarshal OpenFGA response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package openfga
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+// fakeOpenFGA is a fake OpenFGA HTTP server that records the last request
+// and replies with a fixed response.
+type fakeOpenFGA struct {
+	path          string
+	authorization string
+	body          map[string]any
+	requests      int
+
+	status   int
+	response string
+}
+
+func (f *fakeOpenFGA) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.path = r.URL.Path
+	f.requests++
+	f.authorization = r.Header.Get("Authorization")
+	raw, _ := io.ReadAll(r.Body)
+	f.body = nil
+	_ = json.Unmarshal(raw, &f.body)
+
+	w.WriteHeader(f.status)
+	_, _ = w.Write([]byte(f.response))
+}
+
+func newTestHTTPStore(c *qt.C, fake *fakeOpenFGA) *HTTPStore {
+	server := httptest.NewServer(fake)
+	c.Cleanup(server.Close)
+
+	store, err := NewHTTPStore(HTTPStoreParams{
+		APIURL:               server.URL + "/",
+		StoreID:              "store-id",
+		AuthorizationModelID: "model-id",
+		Token:                "secret",
+	})
+	c.Assert(err, qt.IsNil)
+	return store
+}
+
+func TestNewHTTPStore_Validation(t *testing.T) {
+	c := qt.New(t)
+
+	_, err := NewHTTPStore(HTTPStoreParams{StoreID: "store-id"})
+	c.Assert(err, qt.ErrorMatches, "missing OpenFGA API URL")
+
+	_, err = NewHTTPStore(HTTPStoreParams{APIURL: "http://localhost:8080"})
+	c.Assert(err, qt.ErrorMatches, "missing OpenFGA store ID")
+}
+
+func TestHTTPStore_ReadTuples(t *testing.T) {
+	c := qt.New(t)
+
+	fake := &fakeOpenFGA{
+		status:   http.StatusOK,
+		response: `{"tuples":[{"key":{"user":"user:foo","relation":"member","object":"group:a"}}],"continuation_token":"next"}`,
+	}
+	store := newTestHTTPStore(c, fake)
+
+	tuples, next, err := store.ReadTuples(context.Background(), Tuple{Object: "group:a"}, 10, "token")
+	c.Assert(err, qt.IsNil)
+	c.Assert(tuples, qt.DeepEquals, []Tuple{{User: "user:foo", Relation: "member", Object: "group:a"}})
+	c.Assert(next, qt.Equals, "next")
+
+	c.Assert(fake.path, qt.Equals, "/stores/store-id/read")
+	c.Assert(fake.authorization, qt.Equals, "Bearer secret")
+	c.Assert(fake.body, qt.DeepEquals, map[string]any{
+		"tuple_key":          map[string]any{"user": "", "relation": "", "object": "group:a"},
+		"page_size":          float64(10),
+		"continuation_token": "token",
+	})
+
+	// Page sizes are capped to the maximum accepted by OpenFGA.
+	_, _, err = store.ReadTuples(context.Background(), Tuple{}, 150, "")
+	c.Assert(err, qt.IsNil)
+	c.Assert(fake.body["page_size"], qt.Equals, float64(100))
+}
+
+func TestHTTPStore_WriteAndDeleteTuples(t *testing.T) {
+	c := qt.New(t)
+
+	fake := &fakeOpenFGA{status: http.StatusOK, response: "{}"}
+	store := newTestHTTPStore(c, fake)
+
+	tuple := Tuple{User: "user:foo", Relation: "member", Object: "group:a"}
+	tupleJSON := map[string]any{"user": "user:foo", "relation": "member", "object": "group:a"}
+
+	err := store.WriteTuples(context.Background(), tuple)
+	c.Assert(err, qt.IsNil)
+	c.Assert(fake.path, qt.Equals, "/stores/store-id/write")
+	c.Assert(fake.body, qt.DeepEquals, map[string]any{
+		"writes":                 map[string]any{"tuple_keys": []any{tupleJSON}},
+		"authorization_model_id": "model-id",
+	})
+
+	err = store.DeleteTuples(context.Background(), tuple)
+	c.Assert(err, qt.IsNil)
+	c.Assert(fake.body, qt.DeepEquals, map[string]any{
+		"deletes":                map[string]any{"tuple_keys": []any{tupleJSON}},
+		"authorization_model_id": "model-id",
+	})
+
+	other := Tuple{User: "user:bar", Relation: "member", Object: "group:a"}
+	otherJSON := map[string]any{"user": "user:bar", "relation": "member", "object": "group:a"}
+
+	err = store.Write(context.Background(), []Tuple{tuple}, []Tuple{other})
+	c.Assert(err, qt.IsNil)
+	c.Assert(fake.body, qt.DeepEquals, map[string]any{
+		"writes":                 map[string]any{"tuple_keys": []any{tupleJSON}},
+		"deletes":                map[string]any{"tuple_keys": []any{otherJSON}},
+		"authorization_model_id": "model-id",
+	})
+}
+
+func TestHTTPStore_WriteBatches(t *testing.T) {
+	c := qt.New(t)
+
+	fake := &fakeOpenFGA{status: http.StatusOK, response: "{}"}
+	store := newTestHTTPStore(c, fake)
+
+	tuples := func(n int) []Tuple {
+		result := make([]Tuple, 0, n)
+		for i := 0; i < n; i++ {
+			result = append(result, Tuple{User: fmt.Sprintf("user:%d", i), Relation: "member", Object: "group:a"})
+		}
+		return result
+	}
+
+	err := store.Write(context.Background(), tuples(150), tuples(60))
+	c.Assert(err, qt.IsNil)
+	c.Assert(fake.requests, qt.Equals, 3)
+	c.Assert(fake.body["writes"], qt.IsNil)
+	c.Assert(fake.body["deletes"].(map[string]any)["tuple_keys"], qt.HasLen, 10)
+
+	fake.requests = 0
+	err = store.Write(context.Background(), nil, nil)
+	c.Assert(err, qt.IsNil)
+	c.Assert(fake.requests, qt.Equals, 0)
+}
+
+func TestHTTPStore_ListObjectsAndUsers(t *testing.T) {
+	c := qt.New(t)
+
+	fake := &fakeOpenFGA{status: http.StatusOK, response: `{"objects":["group:a","group:b"]}`}
+	store := newTestHTTPStore(c, fake)
+
+	objects, err := store.ListObjects(context.Background(), "user:foo", "member", "group")
+	c.Assert(err, qt.IsNil)
+	c.Assert(objects, qt.DeepEquals, []string{"group:a", "group:b"})
+	c.Assert(fake.path, qt.Equals, "/stores/store-id/list-objects")
+	c.Assert(fake.body, qt.DeepEquals, map[string]any{
+		"authorization_model_id": "model-id",
+		"type":                   "group",
+		"relation":               "member",
+		"user":                   "user:foo",
+	})
+
+	fake.response = `{"users":[{"object":{"type":"user","id":"foo"}},{"userset":{"type":"group","id":"a","relation":"member"}},{"wildcard":{"type":"user"}}]}`
+	users, err := store.ListUsers(context.Background(), "role:x", "assignee", "group#member")
+	c.Assert(err, qt.IsNil)
+	c.Assert(users, qt.DeepEquals, []string{"user:foo", "group:a#member", "user:*"})
+	c.Assert(fake.path, qt.Equals, "/stores/store-id/list-users")
+	c.Assert(fake.body, qt.DeepEquals, map[string]any{
+		"authorization_model_id": "model-id",
+		"object":                 map[string]any{"type": "role", "id": "x"},
+		"relation":               "assignee",
+		"user_filters":           []any{map[string]any{"type": "group", "relation": "member"}},
+	})
+}
+
+func TestHTTPStore_ErrorResponse(t *testing.T) {
+	c := qt.New(t)
+
+	fake := &fakeOpenFGA{status: http.StatusBadRequest, response: `{"code":"validation_error","message":"invalid tuple"}`}
+	store := newTestHTTPStore(c, fake)
+
+	err := store.WriteTuples(context.Background(), Tuple{User: "user:foo", Relation: "member", Object: "group:a"})
+	c.Assert(err, qt.ErrorMatches, `OpenFGA request failed with status 400: invalid tuple \(validation_error\)`)
+
+	fake.response = `{"code":"write_failed_due_to_invalid_input","message":"cannot write a tuple which already exists: user: 'user:foo', relation: 'member', object: 'group:a'"}`
+	err = store.WriteTuples(context.Background(), Tuple{User: "user:foo", Relation: "member", Object: "group:a"})
+	c.Assert(err, qt.ErrorIs, ErrTupleExists)
+
+	fake.response = "not json"
+	_, _, err = store.ReadTuples(context.Background(), Tuple{}, 0, "")
+	c.Assert(err, qt.ErrorMatches, "OpenFGA request failed with status 400")
+}
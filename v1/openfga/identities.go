@@ -0,0 +1,177 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package openfga
+
+import (
+	"context"
+
+	v1 "github.com/canonical/rebac-admin-ui-handlers/v1"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// IdentitiesService implements the `IdentitiesService` interface on top of OpenFGA tuples.
+type IdentitiesService struct {
+	service
+}
+
+// For doc/test sake, to hint that the struct needs to implement a specific interface.
+var _ interfaces.IdentitiesService = &IdentitiesService{}
+
+// NewIdentitiesService returns a new IdentitiesService instance.
+func NewIdentitiesService(params Params) *IdentitiesService {
+	return &IdentitiesService{service{params: params}}
+}
+
+// newIdentity returns an Identity object with the given ID. Since OpenFGA only
+// keeps the identity ID, which is the same as the email, other fields are left
+// empty.
+func newIdentity(id string) resources.Identity {
+	return resources.Identity{Id: &id, Email: id}
+}
+
+// ListIdentities returns a page of Identity objects of at least `size` elements if available.
+func (s *IdentitiesService) ListIdentities(ctx context.Context, params *resources.GetIdentitiesParams) (*resources.PaginatedResponse[resources.Identity], error) {
+	filter := Tuple{Relation: identityRegistryRelation, Object: registryObject}
+	expr, err := parseFilter(params.Filter)
+	if err != nil {
+		return nil, err
+	}
+	return listPage(ctx, &s.service, filter, expr, params.Size, params.Page, params.NextToken, params.NextPageToken, func(t Tuple) (resources.Identity, bool) {
+		_, id := splitObject(t.User)
+		return newIdentity(id), true
+	})
+}
+
+// CreateIdentity creates a single Identity. The identity email is used as its ID.
+func (s *IdentitiesService) CreateIdentity(ctx context.Context, identity *resources.Identity) (*resources.Identity, error) {
+	if err := validateId(identity.Email); err != nil {
+		return nil, err
+	}
+	if err := s.register(ctx, identityRegistryRelation, identityObject(identity.Email)); err != nil {
+		return nil, err
+	}
+	result := newIdentity(identity.Email)
+	return &result, nil
+}
+
+// GetIdentity returns a single Identity.
+func (s *IdentitiesService) GetIdentity(ctx context.Context, identityId string) (*resources.Identity, error) {
+	if err := s.ensureExists(ctx, identityRegistryRelation, identityObject(identityId)); err != nil {
+		return nil, err
+	}
+	result := newIdentity(identityId)
+	return &result, nil
+}
+
+// UpdateIdentity updates an Identity. Since OpenFGA only keeps the identity ID,
+// changing the email is not supported.
+func (s *IdentitiesService) UpdateIdentity(ctx context.Context, identity *resources.Identity) (*resources.Identity, error) {
+	if identity.Id == nil {
+		return nil, v1.NewValidationError("missing identity ID")
+	}
+	if err := s.ensureExists(ctx, identityRegistryRelation, identityObject(*identity.Id)); err != nil {
+		return nil, err
+	}
+	if identity.Email != *identity.Id {
+		return nil, v1.NewInvalidRequestError("changing identity email is not supported")
+	}
+	result := newIdentity(*identity.Id)
+	return &result, nil
+}
+
+// DeleteIdentity deletes an Identity, along with all its relations. It returns
+// (false, nil) if the identity does not exist.
+func (s *IdentitiesService) DeleteIdentity(ctx context.Context, identityId string) (bool, error) {
+	filters := []Tuple{
+		{User: identityObject(identityId), Object: groupType + ":"},
+		{User: identityObject(identityId), Object: roleType + ":"},
+	}
+	filters = append(filters, s.entitlementFilters(identityReceiverType, identityObject(identityId))...)
+	return s.unregister(ctx, identityRegistryRelation, identityObject(identityId), filters)
+}
+
+// GetIdentityGroups returns a page of Groups for identity `identityId`.
+func (s *IdentitiesService) GetIdentityGroups(ctx context.Context, identityId string, params *resources.GetIdentitiesItemGroupsParams) (*resources.PaginatedResponse[resources.Group], error) {
+	if err := s.ensureExists(ctx, identityRegistryRelation, identityObject(identityId)); err != nil {
+		return nil, err
+	}
+	filter := Tuple{User: identityObject(identityId), Relation: memberRelation, Object: groupType + ":"}
+	return listPage(ctx, &s.service, filter, nil, params.Size, params.Page, params.NextToken, params.NextPageToken, func(t Tuple) (resources.Group, bool) {
+		_, id := splitObject(t.Object)
+		return newGroup(id), true
+	})
+}
+
+// PatchIdentityGroups performs addition or removal of a Group to/from an Identity.
+func (s *IdentitiesService) PatchIdentityGroups(ctx context.Context, identityId string, groupPatches []resources.IdentityGroupsPatchItem) (bool, error) {
+	if err := s.ensureExists(ctx, identityRegistryRelation, identityObject(identityId)); err != nil {
+		return false, err
+	}
+	for _, p := range groupPatches {
+		if err := validateId(p.Group); err != nil {
+			return false, err
+		}
+	}
+	return s.applyPatches(ctx, mapPatches(groupPatches, func(p resources.IdentityGroupsPatchItem) (Tuple, string) {
+		return memberTuple(identityId, p.Group), string(p.Op)
+	}))
+}
+
+// GetIdentityRoles returns a page of Roles for identity `identityId`.
+func (s *IdentitiesService) GetIdentityRoles(ctx context.Context, identityId string, params *resources.GetIdentitiesItemRolesParams) (*resources.PaginatedResponse[resources.Role], error) {
+	if err := s.ensureExists(ctx, identityRegistryRelation, identityObject(identityId)); err != nil {
+		return nil, err
+	}
+	filter := Tuple{User: identityObject(identityId), Relation: assigneeRelation, Object: roleType + ":"}
+	return listPage(ctx, &s.service, filter, nil, params.Size, params.Page, params.NextToken, params.NextPageToken, func(t Tuple) (resources.Role, bool) {
+		_, id := splitObject(t.Object)
+		return newRole(id), true
+	})
+}
+
+// PatchIdentityRoles performs addition or removal of a Role to/from an Identity.
+func (s *IdentitiesService) PatchIdentityRoles(ctx context.Context, identityId string, rolePatches []resources.IdentityRolesPatchItem) (bool, error) {
+	if err := s.ensureExists(ctx, identityRegistryRelation, identityObject(identityId)); err != nil {
+		return false, err
+	}
+	for _, p := range rolePatches {
+		if err := validateId(p.Role); err != nil {
+			return false, err
+		}
+	}
+	return s.applyPatches(ctx, mapPatches(rolePatches, func(p resources.IdentityRolesPatchItem) (Tuple, string) {
+		return Tuple{User: identityObject(identityId), Relation: assigneeRelation, Object: roleObject(p.Role)}, string(p.Op)
+	}))
+}
+
+// GetIdentityEntitlements returns a page of Entitlements for identity `identityId`.
+func (s *IdentitiesService) GetIdentityEntitlements(ctx context.Context, identityId string, params *resources.GetIdentitiesItemEntitlementsParams) (*resources.PaginatedResponse[resources.EntityEntitlement], error) {
+	if err := s.ensureExists(ctx, identityRegistryRelation, identityObject(identityId)); err != nil {
+		return nil, err
+	}
+	return s.listEntitlements(ctx, identityReceiverType, identityObject(identityId), params.Size, params.Page)
+}
+
+// PatchIdentityEntitlements performs addition or removal of an Entitlement to/from an Identity.
+func (s *IdentitiesService) PatchIdentityEntitlements(ctx context.Context, identityId string, entitlementPatches []resources.IdentityEntitlementsPatchItem) (bool, error) {
+	if err := s.ensureExists(ctx, identityRegistryRelation, identityObject(identityId)); err != nil {
+		return false, err
+	}
+	return s.patchEntitlements(ctx, identityReceiverType, mapPatches(entitlementPatches, func(p resources.IdentityEntitlementsPatchItem) (Tuple, string) {
+		return entitlementTuple(identityObject(identityId), p.Entitlement), string(p.Op)
+	}))
+}
@@ -0,0 +1,118 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package openfga
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+func TestIdentitiesService_CRUD(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	params, _ := newTestParams()
+	s := NewIdentitiesService(params)
+
+	identity, err := s.CreateIdentity(ctx, &resources.Identity{Email: "joe@example.com"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(*identity.Id, qt.Equals, "joe@example.com")
+
+	_, err = s.CreateIdentity(ctx, &resources.Identity{Email: "joe@example.com"})
+	c.Assert(err, qt.ErrorMatches, "Bad Request: invalid request: user:joe@example.com already exists")
+
+	identity, err = s.GetIdentity(ctx, "joe@example.com")
+	c.Assert(err, qt.IsNil)
+	c.Assert(identity.Email, qt.Equals, "joe@example.com")
+
+	_, err = s.GetIdentity(ctx, "missing")
+	c.Assert(err, qt.ErrorMatches, "Not Found: user:missing not found")
+
+	id := "joe@example.com"
+	_, err = s.UpdateIdentity(ctx, &resources.Identity{Id: &id, Email: "jane@example.com"})
+	c.Assert(err, qt.ErrorMatches, "Bad Request: invalid request: changing identity email is not supported")
+
+	identities, err := s.ListIdentities(ctx, &resources.GetIdentitiesParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(identities.Data, qt.DeepEquals, []resources.Identity{newIdentity("joe@example.com")})
+
+	deleted, err := s.DeleteIdentity(ctx, "joe@example.com")
+	c.Assert(err, qt.IsNil)
+	c.Assert(deleted, qt.IsTrue)
+
+	deleted, err = s.DeleteIdentity(ctx, "joe@example.com")
+	c.Assert(err, qt.IsNil)
+	c.Assert(deleted, qt.IsFalse)
+}
+
+func TestIdentitiesService_Relations(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	params, store := newTestParams(
+		registryTuple(identityRegistryRelation, "user:joe"),
+		registryTuple(groupRegistryRelation, "group:admins"),
+		registryTuple(roleRegistryRelation, "role:viewer"),
+	)
+	s := NewIdentitiesService(params)
+
+	_, err := s.PatchIdentityGroups(ctx, "joe", []resources.IdentityGroupsPatchItem{
+		{Group: "admins", Op: resources.IdentityGroupsPatchItemOpAdd},
+	})
+	c.Assert(err, qt.IsNil)
+
+	groups, err := s.GetIdentityGroups(ctx, "joe", &resources.GetIdentitiesItemGroupsParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(groups.Data, qt.DeepEquals, []resources.Group{newGroup("admins")})
+
+	_, err = s.PatchIdentityRoles(ctx, "joe", []resources.IdentityRolesPatchItem{
+		{Role: "viewer", Op: resources.IdentityRolesPatchItemOpAdd},
+	})
+	c.Assert(err, qt.IsNil)
+
+	roles, err := s.GetIdentityRoles(ctx, "joe", &resources.GetIdentitiesItemRolesParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(roles.Data, qt.DeepEquals, []resources.Role{newRole("viewer")})
+
+	_, err = s.PatchIdentityEntitlements(ctx, "joe", []resources.IdentityEntitlementsPatchItem{
+		{Entitlement: resources.EntityEntitlement{Entitlement: "can_view", EntityType: "client", EntityId: "c1"}, Op: resources.IdentityEntitlementsPatchItemOpAdd},
+	})
+	c.Assert(err, qt.IsNil)
+
+	entitlements, err := s.GetIdentityEntitlements(ctx, "joe", &resources.GetIdentitiesItemEntitlementsParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(entitlements.Data, qt.DeepEquals, []resources.EntityEntitlement{
+		{Entitlement: "can_view", EntityType: "client", EntityId: "c1"},
+	})
+
+	changed, err := s.PatchIdentityRoles(ctx, "joe", []resources.IdentityRolesPatchItem{
+		{Role: "viewer", Op: resources.IdentityRolesPatchItemOpRemove},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(changed, qt.IsTrue)
+
+	// Deleting the identity removes all its relations.
+	_, err = s.DeleteIdentity(ctx, "joe")
+	c.Assert(err, qt.IsNil)
+	c.Assert(readAllTuples(c, store), qt.DeepEquals, []Tuple{
+		registryTuple(groupRegistryRelation, "group:admins"),
+		registryTuple(roleRegistryRelation, "role:viewer"),
+	})
+}
@@ -0,0 +1,215 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package openfga
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// defaultPageSize is the page size used when reading tuples with no explicit
+// page size (the same as OpenFGA's default).
+const defaultPageSize = 50
+
+// MemoryStore is an in-memory implementation of the TupleStore interface. It's
+// meant to be used as a stand-in for OpenFGA in tests.
+//
+// Note that the store is not aware of the authorization model. So, when
+// listing objects/users, only direct relations and usersets (e.g.,
+// `group:foo#member`) are resolved.
+type MemoryStore struct {
+	mutex  sync.RWMutex
+	tuples []Tuple
+}
+
+// For doc/test sake, to hint that the struct needs to implement a specific interface.
+var _ TupleStore = &MemoryStore{}
+
+// NewMemoryStore returns a new MemoryStore instance, populated with the given
+// tuples.
+func NewMemoryStore(tuples ...Tuple) *MemoryStore {
+	return &MemoryStore{
+		tuples: slices.Clone(tuples),
+	}
+}
+
+// ReadTuples returns a page of tuples that match the given filter.
+func (s *MemoryStore) ReadTuples(ctx context.Context, filter Tuple, pageSize int, continuationToken string) ([]Tuple, string, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if filter.Object != "" && strings.HasSuffix(filter.Object, ":") && filter.User == "" {
+		return nil, "", fmt.Errorf("user is required when reading tuples by object type")
+	}
+
+	offset := 0
+	if continuationToken != "" {
+		var err error
+		offset, err = strconv.Atoi(continuationToken)
+		if err != nil || offset < 0 {
+			return nil, "", fmt.Errorf("invalid continuation token")
+		}
+	}
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	matches := []Tuple{}
+	for _, t := range s.tuples {
+		if matchTuple(filter, t) {
+			matches = append(matches, t)
+		}
+	}
+
+	if offset >= len(matches) {
+		return []Tuple{}, "", nil
+	}
+	end := offset + pageSize
+	if end >= len(matches) {
+		return slices.Clone(matches[offset:]), "", nil
+	}
+	return slices.Clone(matches[offset:end]), strconv.Itoa(end), nil
+}
+
+// WriteTuples writes the given tuples to the store. Like OpenFGA, the operation
+// is atomic and fails if any of the tuples already exists.
+func (s *MemoryStore) WriteTuples(ctx context.Context, tuples ...Tuple) error {
+	return s.Write(ctx, tuples, nil)
+}
+
+// DeleteTuples deletes the given tuples from the store. Like OpenFGA, the
+// operation is atomic and fails if any of the tuples does not exist.
+func (s *MemoryStore) DeleteTuples(ctx context.Context, tuples ...Tuple) error {
+	return s.Write(ctx, nil, tuples)
+}
+
+// Write writes and deletes the given tuples in a single atomic operation. Like
+// OpenFGA, it fails if any of the written tuples already exists, if any of the
+// deleted tuples does not exist, or if a tuple is given more than once.
+func (s *MemoryStore) Write(ctx context.Context, writes []Tuple, deletes []Tuple) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for i, t := range writes {
+		if slices.Contains(s.tuples, t) || slices.Contains(writes[:i], t) {
+			return fmt.Errorf("cannot write tuple %v: %w", t, ErrTupleExists)
+		}
+	}
+	for i, t := range deletes {
+		if !slices.Contains(s.tuples, t) {
+			return fmt.Errorf("cannot delete tuple %v: does not exist", t)
+		}
+		if slices.Contains(deletes[:i], t) || slices.Contains(writes, t) {
+			return fmt.Errorf("cannot delete tuple %v: given more than once", t)
+		}
+	}
+	s.tuples = slices.DeleteFunc(s.tuples, func(t Tuple) bool {
+		return slices.Contains(deletes, t)
+	})
+	s.tuples = append(s.tuples, writes...)
+	return nil
+}
+
+// ListObjects returns the objects of the given type that the given user has
+// the given relation with.
+func (s *MemoryStore) ListObjects(ctx context.Context, user, relation, objectType string) ([]string, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	result := []string{}
+	for _, t := range s.tuples {
+		if t.Relation != relation || slices.Contains(result, t.Object) {
+			continue
+		}
+		if currentType, _ := splitObject(t.Object); currentType != objectType {
+			continue
+		}
+		if s.check(user, relation, t.Object, map[Tuple]bool{}) {
+			result = append(result, t.Object)
+		}
+	}
+	return result, nil
+}
+
+// ListUsers returns the users of the given type that have the given relation
+// with the given object.
+func (s *MemoryStore) ListUsers(ctx context.Context, object, relation, userType string) ([]string, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	wantedType, wantedRelation, _ := strings.Cut(userType, "#")
+
+	result := []string{}
+	for _, t := range s.tuples {
+		if slices.Contains(result, t.User) {
+			continue
+		}
+		if currentType, _, currentRelation := splitUser(t.User); currentType != wantedType || currentRelation != wantedRelation {
+			continue
+		}
+		if s.check(t.User, relation, object, map[Tuple]bool{}) {
+			result = append(result, t.User)
+		}
+	}
+	return result, nil
+}
+
+// check reports whether the given user has the given relation with the given
+// object, either directly or via usersets. The visited map is used to avoid
+// infinite recursion on cyclic relations.
+func (s *MemoryStore) check(user, relation, object string, visited map[Tuple]bool) bool {
+	key := Tuple{User: user, Relation: relation, Object: object}
+	if visited[key] {
+		return false
+	}
+	visited[key] = true
+
+	for _, t := range s.tuples {
+		if t.Relation != relation || t.Object != object {
+			continue
+		}
+		if t.User == user {
+			return true
+		}
+		if usersetObject, usersetRelation, ok := strings.Cut(t.User, "#"); ok {
+			if s.check(user, usersetRelation, usersetObject, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchTuple reports whether the given tuple matches the given filter.
+func matchTuple(filter, t Tuple) bool {
+	if filter.User != "" && filter.User != t.User {
+		return false
+	}
+	if filter.Relation != "" && filter.Relation != t.Relation {
+		return false
+	}
+	if filter.Object == "" {
+		return true
+	}
+	if strings.HasSuffix(filter.Object, ":") {
+		return strings.HasPrefix(t.Object, filter.Object)
+	}
+	return filter.Object == t.Object
+}
@@ -0,0 +1,155 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package openfga
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestMemoryStore_ReadTuples(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	store := NewMemoryStore(
+		Tuple{User: "user:foo", Relation: "member", Object: "group:a"},
+		Tuple{User: "user:bar", Relation: "member", Object: "group:a"},
+		Tuple{User: "user:foo", Relation: "member", Object: "group:b"},
+		Tuple{User: "user:foo", Relation: "assignee", Object: "role:x"},
+	)
+
+	tuples, next, err := store.ReadTuples(ctx, Tuple{Object: "group:a"}, 0, "")
+	c.Assert(err, qt.IsNil)
+	c.Assert(next, qt.Equals, "")
+	c.Assert(tuples, qt.DeepEquals, []Tuple{
+		{User: "user:foo", Relation: "member", Object: "group:a"},
+		{User: "user:bar", Relation: "member", Object: "group:a"},
+	})
+
+	tuples, _, err = store.ReadTuples(ctx, Tuple{User: "user:foo", Object: "group:"}, 0, "")
+	c.Assert(err, qt.IsNil)
+	c.Assert(tuples, qt.DeepEquals, []Tuple{
+		{User: "user:foo", Relation: "member", Object: "group:a"},
+		{User: "user:foo", Relation: "member", Object: "group:b"},
+	})
+
+	_, _, err = store.ReadTuples(ctx, Tuple{Object: "group:"}, 0, "")
+	c.Assert(err, qt.ErrorMatches, "user is required when reading tuples by object type")
+
+	_, _, err = store.ReadTuples(ctx, Tuple{}, 0, "invalid")
+	c.Assert(err, qt.ErrorMatches, "invalid continuation token")
+}
+
+func TestMemoryStore_ReadTuplesPagination(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	store := NewMemoryStore(
+		Tuple{User: "user:a", Relation: "member", Object: "group:g"},
+		Tuple{User: "user:b", Relation: "member", Object: "group:g"},
+		Tuple{User: "user:c", Relation: "member", Object: "group:g"},
+	)
+
+	tuples, next, err := store.ReadTuples(ctx, Tuple{}, 2, "")
+	c.Assert(err, qt.IsNil)
+	c.Assert(tuples, qt.HasLen, 2)
+	c.Assert(next, qt.Not(qt.Equals), "")
+
+	tuples, next, err = store.ReadTuples(ctx, Tuple{}, 2, next)
+	c.Assert(err, qt.IsNil)
+	c.Assert(tuples, qt.DeepEquals, []Tuple{{User: "user:c", Relation: "member", Object: "group:g"}})
+	c.Assert(next, qt.Equals, "")
+}
+
+func TestMemoryStore_WriteAndDeleteTuples(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	foo := Tuple{User: "user:foo", Relation: "member", Object: "group:a"}
+	bar := Tuple{User: "user:bar", Relation: "member", Object: "group:a"}
+
+	store := NewMemoryStore(foo)
+
+	err := store.WriteTuples(ctx, bar, foo)
+	c.Assert(err, qt.ErrorMatches, "cannot write tuple .*: tuple already exists")
+	c.Assert(err, qt.ErrorIs, ErrTupleExists)
+
+	err = store.WriteTuples(ctx, bar)
+	c.Assert(err, qt.IsNil)
+
+	tuples, _, err := store.ReadTuples(ctx, Tuple{}, 0, "")
+	c.Assert(err, qt.IsNil)
+	c.Assert(tuples, qt.DeepEquals, []Tuple{foo, bar})
+
+	err = store.DeleteTuples(ctx, foo, Tuple{User: "user:baz", Relation: "member", Object: "group:a"})
+	c.Assert(err, qt.ErrorMatches, "cannot delete tuple .*: does not exist")
+
+	err = store.DeleteTuples(ctx, foo)
+	c.Assert(err, qt.IsNil)
+
+	tuples, _, err = store.ReadTuples(ctx, Tuple{}, 0, "")
+	c.Assert(err, qt.IsNil)
+	c.Assert(tuples, qt.DeepEquals, []Tuple{bar})
+
+	// Writes and deletes are applied atomically.
+	err = store.Write(ctx, []Tuple{foo}, []Tuple{bar, bar})
+	c.Assert(err, qt.ErrorMatches, "cannot delete tuple .*: given more than once")
+
+	err = store.Write(ctx, []Tuple{foo}, []Tuple{foo})
+	c.Assert(err, qt.ErrorMatches, "cannot delete tuple .*: does not exist")
+
+	tuples, _, err = store.ReadTuples(ctx, Tuple{}, 0, "")
+	c.Assert(err, qt.IsNil)
+	c.Assert(tuples, qt.DeepEquals, []Tuple{bar})
+
+	err = store.Write(ctx, []Tuple{foo}, []Tuple{bar})
+	c.Assert(err, qt.IsNil)
+
+	tuples, _, err = store.ReadTuples(ctx, Tuple{}, 0, "")
+	c.Assert(err, qt.IsNil)
+	c.Assert(tuples, qt.DeepEquals, []Tuple{foo})
+}
+
+func TestMemoryStore_ListObjectsAndUsers(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	store := NewMemoryStore(
+		Tuple{User: "user:foo", Relation: "member", Object: "group:a"},
+		Tuple{User: "group:a#member", Relation: "member", Object: "group:b"},
+		Tuple{User: "group:b#member", Relation: "member", Object: "group:a"},
+		Tuple{User: "group:b#member", Relation: "assignee", Object: "role:x"},
+		Tuple{User: "user:bar", Relation: "assignee", Object: "role:y"},
+	)
+
+	objects, err := store.ListObjects(ctx, "user:foo", "member", "group")
+	c.Assert(err, qt.IsNil)
+	c.Assert(objects, qt.DeepEquals, []string{"group:a", "group:b"})
+
+	objects, err = store.ListObjects(ctx, "user:foo", "assignee", "role")
+	c.Assert(err, qt.IsNil)
+	c.Assert(objects, qt.DeepEquals, []string{"role:x"})
+
+	users, err := store.ListUsers(ctx, "role:x", "assignee", "user")
+	c.Assert(err, qt.IsNil)
+	c.Assert(users, qt.DeepEquals, []string{"user:foo"})
+
+	users, err = store.ListUsers(ctx, "role:x", "assignee", "group#member")
+	c.Assert(err, qt.IsNil)
+	c.Assert(users, qt.DeepEquals, []string{"group:a#member", "group:b#member"})
+}
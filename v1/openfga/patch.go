@@ -0,0 +1,106 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package openfga
+
+import (
+	"context"
+	"fmt"
+
+	v1 "github.com/canonical/rebac-admin-ui-handlers/v1"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// tuplePatch represents the addition or removal of a single tuple.
+type tuplePatch struct {
+	tuple  Tuple
+	remove bool
+}
+
+// mapPatches maps the given patch items to tuple patches, using the given
+// function which returns the corresponding tuple and the patch operation.
+func mapPatches[T any](items []T, f func(T) (Tuple, string)) []tuplePatch {
+	result := make([]tuplePatch, 0, len(items))
+	for _, item := range items {
+		t, op := f(item)
+		result = append(result, tuplePatch{tuple: t, remove: op == "remove"})
+	}
+	return result
+}
+
+// applyPatches writes/deletes the given tuples in a single write, which is
+// atomic unless the store splits it into batches (see `TupleStore.Write`). The
+// patches are applied in order, so if a tuple is patched more than once, the
+// last patch wins. Additions of existing tuples and removals of missing tuples
+// are skipped (i.e., the operation is idempotent). It returns true if anything
+// has changed.
+func (s *service) applyPatches(ctx context.Context, patches []tuplePatch) (bool, error) {
+	tuples := []Tuple{}
+	existing := map[Tuple]bool{}
+	wanted := map[Tuple]bool{}
+	for _, p := range patches {
+		if _, ok := existing[p.tuple]; !ok {
+			exists, err := s.exists(ctx, p.tuple)
+			if err != nil {
+				return false, err
+			}
+			existing[p.tuple] = exists
+			tuples = append(tuples, p.tuple)
+		}
+		wanted[p.tuple] = !p.remove
+	}
+
+	writes := []Tuple{}
+	deletes := []Tuple{}
+	for _, t := range tuples {
+		if wanted[t] && !existing[t] {
+			writes = append(writes, t)
+		} else if !wanted[t] && existing[t] {
+			deletes = append(deletes, t)
+		}
+	}
+	if len(writes) == 0 && len(deletes) == 0 {
+		return false, nil
+	}
+	if err := s.params.Store.Write(ctx, writes, deletes); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// entitlementTuple returns the tuple that represents the given entitlement
+// assigned to the given receiver.
+func entitlementTuple(receiver string, e resources.EntityEntitlement) Tuple {
+	return Tuple{
+		User:     receiver,
+		Relation: e.Entitlement,
+		Object:   fmt.Sprintf("%s:%s", e.EntityType, e.EntityId),
+	}
+}
+
+// patchEntitlements applies the given entitlement patches for the given receiver type,
+// after making sure all entitlements are known.
+func (s *service) patchEntitlements(ctx context.Context, receiverType string, patches []tuplePatch) (bool, error) {
+	for _, p := range patches {
+		entityType, entityId := splitObject(p.tuple.Object)
+		if !s.isEntitlement(receiverType, p.tuple.Relation, entityType) {
+			return false, v1.NewValidationError(fmt.Sprintf("unknown entitlement %q on %q for %s", p.tuple.Relation, entityType, receiverType))
+		}
+		if err := validateId(entityId); err != nil {
+			return false, err
+		}
+	}
+	return s.applyPatches(ctx, patches)
+}
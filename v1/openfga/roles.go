@@ -0,0 +1,120 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package openfga
+
+import (
+	"context"
+
+	v1 "github.com/canonical/rebac-admin-ui-handlers/v1"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// RolesService implements the `RolesService` interface on top of OpenFGA tuples.
+type RolesService struct {
+	service
+}
+
+// For doc/test sake, to hint that the struct needs to implement a specific interface.
+var _ interfaces.RolesService = &RolesService{}
+
+// NewRolesService returns a new RolesService instance.
+func NewRolesService(params Params) *RolesService {
+	return &RolesService{service{params: params}}
+}
+
+// newRole returns a Role object with the given ID.
+func newRole(id string) resources.Role {
+	return resources.Role{Id: &id, Name: id}
+}
+
+// ListRoles returns a page of Role objects of at least `size` elements if available.
+func (s *RolesService) ListRoles(ctx context.Context, params *resources.GetRolesParams) (*resources.PaginatedResponse[resources.Role], error) {
+	filter := Tuple{Relation: roleRegistryRelation, Object: registryObject}
+	expr, err := parseFilter(params.Filter)
+	if err != nil {
+		return nil, err
+	}
+	return listPage(ctx, &s.service, filter, expr, params.Size, params.Page, params.NextToken, params.NextPageToken, func(t Tuple) (resources.Role, bool) {
+		_, id := splitObject(t.User)
+		return newRole(id), true
+	})
+}
+
+// CreateRole creates a single Role. The role name is used as its ID.
+func (s *RolesService) CreateRole(ctx context.Context, role *resources.Role) (*resources.Role, error) {
+	if err := validateId(role.Name); err != nil {
+		return nil, err
+	}
+	if err := s.register(ctx, roleRegistryRelation, roleObject(role.Name)); err != nil {
+		return nil, err
+	}
+	result := newRole(role.Name)
+	return &result, nil
+}
+
+// GetRole returns a single Role.
+func (s *RolesService) GetRole(ctx context.Context, roleId string) (*resources.Role, error) {
+	if err := s.ensureExists(ctx, roleRegistryRelation, roleObject(roleId)); err != nil {
+		return nil, err
+	}
+	result := newRole(roleId)
+	return &result, nil
+}
+
+// UpdateRole updates a Role. Since role names are used as IDs, renaming roles
+// is not supported.
+func (s *RolesService) UpdateRole(ctx context.Context, role *resources.Role) (*resources.Role, error) {
+	if role.Id == nil {
+		return nil, v1.NewValidationError("missing role ID")
+	}
+	if err := s.ensureExists(ctx, roleRegistryRelation, roleObject(*role.Id)); err != nil {
+		return nil, err
+	}
+	if role.Name != *role.Id {
+		return nil, v1.NewInvalidRequestError("renaming roles is not supported")
+	}
+	result := newRole(*role.Id)
+	return &result, nil
+}
+
+// DeleteRole deletes a Role, along with all its relations. It returns
+// (false, nil) if the role does not exist.
+func (s *RolesService) DeleteRole(ctx context.Context, roleId string) (bool, error) {
+	filters := []Tuple{
+		{Object: roleObject(roleId)},
+	}
+	filters = append(filters, s.entitlementFilters(roleReceiverType, roleAssignees(roleId))...)
+	return s.unregister(ctx, roleRegistryRelation, roleObject(roleId), filters)
+}
+
+// GetRoleEntitlements returns a page of Entitlements for Role `roleId`.
+func (s *RolesService) GetRoleEntitlements(ctx context.Context, roleId string, params *resources.GetRolesItemEntitlementsParams) (*resources.PaginatedResponse[resources.EntityEntitlement], error) {
+	if err := s.ensureExists(ctx, roleRegistryRelation, roleObject(roleId)); err != nil {
+		return nil, err
+	}
+	return s.listEntitlements(ctx, roleReceiverType, roleAssignees(roleId), params.Size, params.Page)
+}
+
+// PatchRoleEntitlements performs addition or removal of an Entitlement to/from a Role.
+func (s *RolesService) PatchRoleEntitlements(ctx context.Context, roleId string, entitlementPatches []resources.RoleEntitlementsPatchItem) (bool, error) {
+	if err := s.ensureExists(ctx, roleRegistryRelation, roleObject(roleId)); err != nil {
+		return false, err
+	}
+	return s.patchEntitlements(ctx, roleReceiverType, mapPatches(entitlementPatches, func(p resources.RoleEntitlementsPatchItem) (Tuple, string) {
+		return entitlementTuple(roleAssignees(roleId), p.Entitlement), string(p.Op)
+	}))
+}
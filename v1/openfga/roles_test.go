@@ -0,0 +1,97 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package openfga
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+func TestRolesService_CRUD(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	params, _ := newTestParams()
+	s := NewRolesService(params)
+
+	role, err := s.CreateRole(ctx, &resources.Role{Name: "viewer"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(*role.Id, qt.Equals, "viewer")
+
+	_, err = s.CreateRole(ctx, &resources.Role{Name: "viewer"})
+	c.Assert(err, qt.ErrorMatches, "Bad Request: invalid request: role:viewer already exists")
+
+	_, err = s.GetRole(ctx, "missing")
+	c.Assert(err, qt.ErrorMatches, "Not Found: role:missing not found")
+
+	id := "viewer"
+	_, err = s.UpdateRole(ctx, &resources.Role{Id: &id, Name: "editor"})
+	c.Assert(err, qt.ErrorMatches, "Bad Request: invalid request: renaming roles is not supported")
+
+	roles, err := s.ListRoles(ctx, &resources.GetRolesParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(roles.Data, qt.DeepEquals, []resources.Role{newRole("viewer")})
+
+	deleted, err := s.DeleteRole(ctx, "viewer")
+	c.Assert(err, qt.IsNil)
+	c.Assert(deleted, qt.IsTrue)
+
+	deleted, err = s.DeleteRole(ctx, "viewer")
+	c.Assert(err, qt.IsNil)
+	c.Assert(deleted, qt.IsFalse)
+}
+
+func TestRolesService_Entitlements(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	params, store := newTestParams(
+		registryTuple(roleRegistryRelation, "role:viewer"),
+		Tuple{User: "user:joe", Relation: assigneeRelation, Object: "role:viewer"},
+	)
+	s := NewRolesService(params)
+
+	_, err := s.PatchRoleEntitlements(ctx, "viewer", []resources.RoleEntitlementsPatchItem{
+		{Entitlement: resources.EntityEntitlement{Entitlement: "can_view", EntityType: "client", EntityId: "c1"}, Op: resources.Add},
+		{Entitlement: resources.EntityEntitlement{Entitlement: "can_edit", EntityType: "client", EntityId: "c1"}, Op: resources.Add},
+	})
+	c.Assert(err, qt.IsNil)
+
+	_, err = s.PatchRoleEntitlements(ctx, "viewer", []resources.RoleEntitlementsPatchItem{
+		{Entitlement: resources.EntityEntitlement{Entitlement: "can_view", EntityType: "client", EntityId: "bad id"}, Op: resources.Add},
+	})
+	c.Assert(err, qt.ErrorMatches, "Bad Request: invalid ID .*")
+
+	size := 1
+	entitlements, err := s.GetRoleEntitlements(ctx, "viewer", &resources.GetRolesItemEntitlementsParams{Size: &size})
+	c.Assert(err, qt.IsNil)
+	c.Assert(entitlements.Data, qt.DeepEquals, []resources.EntityEntitlement{
+		{Entitlement: "can_view", EntityType: "client", EntityId: "c1"},
+	})
+	c.Assert(*entitlements.Next.Page, qt.Equals, 1)
+
+	_, err = s.GetRoleEntitlements(ctx, "missing", &resources.GetRolesItemEntitlementsParams{})
+	c.Assert(err, qt.ErrorMatches, "Not Found: role:missing not found")
+
+	// Deleting the role removes all its relations.
+	_, err = s.DeleteRole(ctx, "viewer")
+	c.Assert(err, qt.IsNil)
+	c.Assert(readAllTuples(c, store), qt.HasLen, 0)
+}
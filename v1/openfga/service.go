@@ -0,0 +1,335 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package openfga
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	v1 "github.com/canonical/rebac-admin-ui-handlers/v1"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/filter"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/sorting"
+)
+
+const (
+	identityType = "user"
+	groupType    = "group"
+	roleType     = "role"
+
+	memberRelation   = "member"
+	assigneeRelation = "assignee"
+
+	// registryObject is the object that tracks the existence of identities,
+	// groups and roles.
+	registryObject = "registry:default"
+
+	identityRegistryRelation = "identity"
+	groupRegistryRelation    = "group"
+	roleRegistryRelation     = "role"
+)
+
+// Receiver types, as used in `resources.EntitlementSchema.ReceiverType`.
+const (
+	identityReceiverType = "identity"
+	groupReceiverType    = "group"
+	roleReceiverType     = "role"
+)
+
+// Params contains the configuration shared by the OpenFGA-backed services.
+type Params struct {
+	// Store is the tuple store backend.
+	Store TupleStore
+
+	// Entitlements is the list of entitlements (i.e., relations on application
+	// types) that can be assigned to identities, groups and roles.
+	Entitlements []resources.EntitlementSchema
+
+	// AuthorizationModel is the raw authorization model, returned as is by the
+	// `EntitlementsService.RawEntitlements` method.
+	AuthorizationModel string
+}
+
+// service contains the logic shared by the OpenFGA-backed services.
+type service struct {
+	params Params
+}
+
+func identityObject(id string) string {
+	return fmt.Sprintf("%s:%s", identityType, id)
+}
+
+func groupObject(id string) string {
+	return fmt.Sprintf("%s:%s", groupType, id)
+}
+
+func roleObject(id string) string {
+	return fmt.Sprintf("%s:%s", roleType, id)
+}
+
+// groupMembers returns the userset representing the members of a group.
+func groupMembers(id string) string {
+	return fmt.Sprintf("%s#%s", groupObject(id), memberRelation)
+}
+
+// roleAssignees returns the userset representing the assignees of a role.
+func roleAssignees(id string) string {
+	return fmt.Sprintf("%s#%s", roleObject(id), assigneeRelation)
+}
+
+// validateId checks if the given ID can be safely used in a tuple.
+func validateId(id string) error {
+	if id == "" {
+		return v1.NewValidationError("empty ID")
+	}
+	if strings.ContainsAny(id, ":#* ") {
+		return v1.NewValidationError(fmt.Sprintf("invalid ID %q: should not contain any of ':', '#', '*' or spaces", id))
+	}
+	return nil
+}
+
+// registryTuple returns the tuple that tracks the existence of the given object.
+func registryTuple(registryRelation, object string) Tuple {
+	return Tuple{User: object, Relation: registryRelation, Object: registryObject}
+}
+
+// exists checks if the given tuple exists.
+func (s *service) exists(ctx context.Context, t Tuple) (bool, error) {
+	tuples, _, err := s.params.Store.ReadTuples(ctx, t, 1, "")
+	if err != nil {
+		return false, err
+	}
+	return len(tuples) > 0, nil
+}
+
+// ensureExists returns a not-found error if the given object is not registered.
+func (s *service) ensureExists(ctx context.Context, registryRelation, object string) error {
+	exists, err := s.exists(ctx, registryTuple(registryRelation, object))
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return v1.NewNotFoundError(fmt.Sprintf("%s not found", object))
+	}
+	return nil
+}
+
+// register registers the given object. If the object is already registered
+// (including by a concurrent request, after the existence check), it returns
+// an invalid request error.
+func (s *service) register(ctx context.Context, registryRelation, object string) error {
+	t := registryTuple(registryRelation, object)
+	exists, err := s.exists(ctx, t)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		err = s.params.Store.WriteTuples(ctx, t)
+		if !errors.Is(err, ErrTupleExists) {
+			// Either registered, or failed for another reason.
+			return err
+		}
+	}
+	return v1.NewInvalidRequestError(fmt.Sprintf("%s already exists", object))
+}
+
+// unregister removes the given object from the registry, along with all
+// tuples matching the given filters. It returns false if the object was not
+// registered.
+//
+// The registry tuple is deleted last; so, if the store splits the deletion
+// into batches and a batch fails, the object remains registered and the
+// deletion can be retried.
+func (s *service) unregister(ctx context.Context, registryRelation, object string, filters []Tuple) (bool, error) {
+	t := registryTuple(registryRelation, object)
+	exists, err := s.exists(ctx, t)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		return false, nil
+	}
+
+	tuples := []Tuple{}
+	for _, filter := range filters {
+		matches, err := s.readAll(ctx, filter)
+		if err != nil {
+			return false, err
+		}
+		for _, m := range matches {
+			if !slices.Contains(tuples, m) && m != t {
+				tuples = append(tuples, m)
+			}
+		}
+	}
+	if err := s.params.Store.DeleteTuples(ctx, append(tuples, t)...); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// readAll reads all tuples matching the given filter, following continuation
+// tokens.
+func (s *service) readAll(ctx context.Context, filter Tuple) ([]Tuple, error) {
+	result := []Tuple{}
+	token := ""
+	for {
+		tuples, next, err := s.params.Store.ReadTuples(ctx, filter, 0, token)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, tuples...)
+		if next == "" {
+			return result, nil
+		}
+		token = next
+	}
+}
+
+// listPage reads a page of tuples matching the given filter and maps them to
+// the desired type via the given function. Tuples for which the function
+// returns false, and entities that do not match the given expression (if
+// any), are skipped.
+//
+// Since OpenFGA only supports continuation tokens, the returned response uses
+// the next page token pagination mechanism, and requests for a page number
+// other than the first are rejected. For the same reason, entities cannot be
+// sorted across pages, so requests for a sort order (see `sorting.FromContext`)
+// are rejected as well. The tuples are read until the page is
+// full, so that skipped ones do not result in short pages. Page sizes larger
+// than `resources.DefaultMaxPageSize` are capped to it.
+func listPage[T any](ctx context.Context, s *service, filter Tuple, expr filter.Expr, size *resources.PaginationSize, page *resources.PaginationPage, nextToken *resources.PaginationNextToken, nextPageToken *resources.PaginationNextTokenHeader, f func(Tuple) (T, bool)) (*resources.PaginatedResponse[T], error) {
+	if page != nil && *page != 0 {
+		return nil, v1.NewQueryParameterValidationError("page", "not supported, use nextToken instead")
+	}
+	if order := sorting.FromContext(ctx); len(order) > 0 {
+		return nil, v1.NewQueryParameterValidationError("sort", "not supported by this backend")
+	}
+
+	pageSize := defaultPageSize
+	if size != nil && *size > 0 {
+		pageSize = min(*size, resources.DefaultMaxPageSize)
+	}
+
+	token := ""
+	if nextPageToken != nil {
+		token = *nextPageToken
+	} else if nextToken != nil {
+		token = *nextToken
+	}
+
+	data := []T{}
+	next := token
+	for {
+		// Reading at most the number of missing entities makes sure the
+		// continuation token never points past an entity that is not returned.
+		tuples, readNext, err := s.params.Store.ReadTuples(ctx, filter, pageSize-len(data), next)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tuples {
+			v, ok := f(t)
+			if !ok {
+				continue
+			}
+			if ok, err := matchFilter(expr, v); err != nil {
+				return nil, err
+			} else if !ok {
+				continue
+			}
+			data = append(data, v)
+		}
+		next = readNext
+		if next == "" || len(data) == pageSize {
+			break
+		}
+	}
+
+	result := &resources.PaginatedResponse[T]{
+		Data: data,
+		Meta: resources.ResponseMeta{
+			Size: len(data),
+		},
+	}
+	if token != "" {
+		result.Meta.PageToken = &token
+	}
+	if next != "" {
+		result.Next.PageToken = &next
+	}
+	return result, nil
+}
+
+// entityTypes returns the distinct application types that entitlements are
+// defined on, for the given receiver type.
+func (s *service) entityTypes(receiverType string) []string {
+	result := []string{}
+	for _, e := range s.params.Entitlements {
+		if e.ReceiverType == receiverType && !slices.Contains(result, e.EntityType) {
+			result = append(result, e.EntityType)
+		}
+	}
+	return result
+}
+
+// isEntitlement checks if the given relation/type pair is a known entitlement
+// for the given receiver type.
+func (s *service) isEntitlement(receiverType, entitlement, entityType string) bool {
+	return slices.ContainsFunc(s.params.Entitlements, func(e resources.EntitlementSchema) bool {
+		return e.ReceiverType == receiverType && e.Entitlement == entitlement && e.EntityType == entityType
+	})
+}
+
+// entitlementFilters returns the filters to read all entitlement tuples of the
+// given receiver.
+func (s *service) entitlementFilters(receiverType, receiver string) []Tuple {
+	result := []Tuple{}
+	for _, entityType := range s.entityTypes(receiverType) {
+		result = append(result, Tuple{User: receiver, Object: entityType + ":"})
+	}
+	return result
+}
+
+// listEntitlements returns a page of entitlements directly assigned to the given
+// receiver.
+func (s *service) listEntitlements(ctx context.Context, receiverType, receiver string, size *resources.PaginationSize, page *resources.PaginationPage) (*resources.PaginatedResponse[resources.EntityEntitlement], error) {
+	entitlements := []resources.EntityEntitlement{}
+	for _, filter := range s.entitlementFilters(receiverType, receiver) {
+		tuples, err := s.readAll(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range tuples {
+			entityType, entityId := splitObject(t.Object)
+			if !s.isEntitlement(receiverType, t.Relation, entityType) {
+				continue
+			}
+			entitlements = append(entitlements, resources.EntityEntitlement{
+				Entitlement: t.Relation,
+				EntityType:  entityType,
+				EntityId:    entityId,
+			})
+		}
+	}
+	return resources.Paginate(entitlementsPaginator, entitlements, resources.PageRequest{Size: size, Page: page})
+}
+
+// entitlementsPaginator paginates the entitlements of receivers, which are
+// read from the store as a whole.
+var entitlementsPaginator = resources.NewPaginator(resources.PaginatorParams{DefaultPageSize: defaultPageSize})
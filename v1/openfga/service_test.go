@@ -0,0 +1,108 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package openfga
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+var testEntitlements = []resources.EntitlementSchema{
+	{Entitlement: "can_view", EntityType: "client", ReceiverType: "identity"},
+	{Entitlement: "can_view", EntityType: "client", ReceiverType: "group"},
+	{Entitlement: "can_view", EntityType: "client", ReceiverType: "role"},
+	{Entitlement: "can_edit", EntityType: "client", ReceiverType: "role"},
+}
+
+// newTestParams returns service parameters backed by a memory store,
+// populated with the given tuples.
+func newTestParams(tuples ...Tuple) (Params, *MemoryStore) {
+	store := NewMemoryStore(tuples...)
+	return Params{
+		Store:              store,
+		Entitlements:       testEntitlements,
+		AuthorizationModel: "model",
+	}, store
+}
+
+// readAllTuples returns all tuples in the given store.
+func readAllTuples(c *qt.C, store *MemoryStore) []Tuple {
+	s := service{params: Params{Store: store}}
+	tuples, err := s.readAll(context.Background(), Tuple{})
+	c.Assert(err, qt.IsNil)
+	return tuples
+}
+
+func TestValidateId(t *testing.T) {
+	c := qt.New(t)
+
+	c.Assert(validateId("foo@example.com"), qt.IsNil)
+	c.Assert(validateId(""), qt.ErrorMatches, "Bad Request: empty ID")
+	for _, id := range []string{"a:b", "a#b", "*", "a b"} {
+		c.Assert(validateId(id), qt.ErrorMatches, "Bad Request: invalid ID .*", qt.Commentf("id %q", id))
+	}
+}
+
+// staleStore is a tuple store that never finds existing tuples when reading,
+// to simulate concurrent writes after existence checks.
+type staleStore struct {
+	*MemoryStore
+}
+
+func (s staleStore) ReadTuples(ctx context.Context, filter Tuple, pageSize int, continuationToken string) ([]Tuple, string, error) {
+	return []Tuple{}, "", nil
+}
+
+func TestRegisterConcurrentlyCreated(t *testing.T) {
+	c := qt.New(t)
+
+	store := staleStore{NewMemoryStore(registryTuple(groupRegistryRelation, "group:a"))}
+	s := service{params: Params{Store: store}}
+
+	err := s.register(context.Background(), groupRegistryRelation, "group:a")
+	c.Assert(err, qt.ErrorMatches, "Bad Request: invalid request: group:a already exists")
+
+	err = s.register(context.Background(), groupRegistryRelation, "group:b")
+	c.Assert(err, qt.IsNil)
+}
+
+func TestPaginate(t *testing.T) {
+	c := qt.New(t)
+
+	data := []int{1, 2, 3, 4, 5}
+	size := 2
+
+	result, err := resources.Paginate(entitlementsPaginator, data, resources.PageRequest{Size: &size})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Data, qt.DeepEquals, []int{1, 2})
+	c.Assert(*result.Next.Page, qt.Equals, 1)
+
+	page := 2
+	result, err = resources.Paginate(entitlementsPaginator, data, resources.PageRequest{Size: &size, Page: &page})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Data, qt.DeepEquals, []int{5})
+	c.Assert(result.Meta.Size, qt.Equals, 1)
+	c.Assert(result.Next.Page, qt.IsNil)
+
+	page = 10
+	result, err = resources.Paginate(entitlementsPaginator, data, resources.PageRequest{Size: &size, Page: &page})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Data, qt.HasLen, 0)
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package openfga
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// ErrTupleExists is the error (possibly wrapped) returned by TupleStore
+// implementations when writing a tuple that already exists.
+var ErrTupleExists = errors.New("tuple already exists")
+
+// Tuple represents an OpenFGA relationship tuple, in the form of
+// `(user, relation, object)`; for example, `(user:john, member, group:foo)`.
+type Tuple struct {
+	User     string `json:"user"`
+	Relation string `json:"relation"`
+	Object   string `json:"object"`
+}
+
+// TupleStore defines an abstract backend to read/write relationship tuples
+// from/to an OpenFGA store.
+type TupleStore interface {
+	// ReadTuples returns a page of tuples that match the given filter, along with
+	// a continuation token to fetch the next page. An empty continuation token
+	// means there are no more pages.
+	//
+	// As in OpenFGA, the object of the filter can either be a full object (e.g.,
+	// `group:foo`) or just a type (e.g., `group:`), in which case the user
+	// must be provided. Empty user or relation matches any value.
+	//
+	// If the given page size is zero, the store's default is used.
+	ReadTuples(ctx context.Context, filter Tuple, pageSize int, continuationToken string) ([]Tuple, string, error)
+
+	// WriteTuples writes the given tuples to the store. Writing an already
+	// existing tuple results in an `ErrTupleExists` error.
+	WriteTuples(ctx context.Context, tuples ...Tuple) error
+
+	// DeleteTuples deletes the given tuples from the store. Deleting a missing
+	// tuple results in an error.
+	DeleteTuples(ctx context.Context, tuples ...Tuple) error
+
+	// Write writes and deletes the given tuples in a single atomic operation
+	// (i.e., either all changes are applied, or none). As with WriteTuples and
+	// DeleteTuples, writing an existing tuple or deleting a missing one results
+	// in an error.
+	//
+	// Implementations may split large writes into batches, to comply with the
+	// limits of the underlying store (see `HTTPStore.Write`); in which case,
+	// the changes are only applied atomically within each batch.
+	Write(ctx context.Context, writes []Tuple, deletes []Tuple) error
+
+	// ListObjects returns the objects of the given type that the given user has
+	// the given relation with (e.g., the groups `user:john` is a member of).
+	ListObjects(ctx context.Context, user, relation, objectType string) ([]string, error)
+
+	// ListUsers returns the users of the given type that have the given relation
+	// with the given object (e.g., the users that are members of `group:foo`).
+	// The user type can also be a userset type (e.g., `group#member`).
+	ListUsers(ctx context.Context, object, relation, userType string) ([]string, error)
+}
+
+// splitObject splits an object (e.g., `group:foo`) into its type and ID.
+func splitObject(object string) (string, string) {
+	objectType, id, _ := strings.Cut(object, ":")
+	return objectType, id
+}
+
+// splitUser splits a user (e.g., `group:foo#member`) into its type, ID and
+// relation (which is empty for non-userset users).
+func splitUser(user string) (string, string, string) {
+	object, relation, _ := strings.Cut(user, "#")
+	userType, id := splitObject(object)
+	return userType, id, relation
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+// operations maps the HTTP routes, in the form of `<METHOD> <path pattern>`
+// relative to the base URL, to their OpenAPI operation IDs.
+var operations = map[string]string{
+	"GET /authentication":                         "GetIdentityProviders",
+	"POST /authentication":                        "PostIdentityProviders",
+	"GET /authentication/providers":               "GetAvailableIdentityProviders",
+	"DELETE /authentication/{id}":                 "DeleteIdentityProvidersItem",
+	"GET /authentication/{id}":                    "GetIdentityProvidersItem",
+	"PUT /authentication/{id}":                    "PutIdentityProvidersItem",
+	"POST /bulk":                                  "PostBulk",
+	"GET /capabilities":                           "GetCapabilities",
+	"POST /check":                                 "PostCheck",
+	"GET /entitlements":                           "GetEntitlements",
+	"GET /entitlements/raw":                       "GetRawEntitlements",
+	"GET /groups":                                 "GetGroups",
+	"POST /groups":                                "PostGroups",
+	"DELETE /groups/{id}":                         "DeleteGroupsItem",
+	"GET /groups/{id}":                            "GetGroupsItem",
+	"PUT /groups/{id}":                            "PutGroupsItem",
+	"GET /groups/{id}/entitlements":               "GetGroupsItemEntitlements",
+	"PATCH /groups/{id}/entitlements":             "PatchGroupsItemEntitlements",
+	"GET /groups/{id}/identities":                 "GetGroupsItemIdentities",
+	"PATCH /groups/{id}/identities":               "PatchGroupsItemIdentities",
+	"GET /groups/{id}/roles":                      "GetGroupsItemRoles",
+	"PATCH /groups/{id}/roles":                    "PatchGroupsItemRoles",
+	"GET /identities":                             "GetIdentities",
+	"POST /identities":                            "PostIdentities",
+	"DELETE /identities/{id}":                     "DeleteIdentitiesItem",
+	"GET /identities/{id}":                        "GetIdentitiesItem",
+	"PUT /identities/{id}":                        "PutIdentitiesItem",
+	"GET /identities/{id}/effective-entitlements": "GetIdentitiesItemEffectiveEntitlements",
+	"GET /identities/{id}/entitlements":           "GetIdentitiesItemEntitlements",
+	"PATCH /identities/{id}/entitlements":         "PatchIdentitiesItemEntitlements",
+	"GET /identities/{id}/groups":                 "GetIdentitiesItemGroups",
+	"PATCH /identities/{id}/groups":               "PatchIdentitiesItemGroups",
+	"GET /identities/{id}/roles":                  "GetIdentitiesItemRoles",
+	"PATCH /identities/{id}/roles":                "PatchIdentitiesItemRoles",
+	"GET /resources":                              "GetResources",
+	"GET /resources/{type}/{id}/access":           "GetResourcesItemAccess",
+	"GET /roles":                                  "GetRoles",
+	"POST /roles":                                 "PostRoles",
+	"DELETE /roles/{id}":                          "DeleteRolesItem",
+	"GET /roles/{id}":                             "GetRolesItem",
+	"PUT /roles/{id}":                             "PutRolesItem",
+	"GET /roles/{id}/entitlements":                "GetRolesItemEntitlements",
+	"PATCH /roles/{id}/entitlements":              "PatchRolesItemEntitlements",
+	"GET /swagger.json":                           "SwaggerJson",
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// writePatchResults writes the per-item outcomes of a relationship patch, as
+// reported by a service, to the HTTP response stream. Item errors are mapped
+// via the given error mapper. The status code is 200 OK if all items
+// succeeded, or 207 Multi-Status if any of them failed.
+//
+// If the number of results does not match the number of patch items (i.e.,
+// `n`), it responds with 500 Internal Server Error.
+func writePatchResults(w http.ResponseWriter, mapper ErrorResponseMapper, n int, results []resources.PatchItemResult) {
+	if len(results) != n {
+		writeErrorResponse(w, NewUnknownError(fmt.Sprintf("service returned %d patch results for %d patch items", len(results), n)))
+		return
+	}
+
+	status := http.StatusOK
+	data := make([]resources.PatchItemResponse, 0, len(results))
+	for _, result := range results {
+		if result.Outcome != resources.PatchItemOutcomeFailed && result.Error == nil {
+			data = append(data, resources.PatchItemResponse{Outcome: result.Outcome})
+			continue
+		}
+
+		err := result.Error
+		if err == nil {
+			err = NewUnknownError("patch item failed")
+		}
+		response := mapServiceErrorResponse(mapper, err)
+		logInternalError(w, response, err)
+		data = append(data, resources.PatchItemResponse{
+			Outcome: resources.PatchItemOutcomeFailed,
+			Status:  &response.Status,
+			Message: &response.Message,
+		})
+		status = http.StatusMultiStatus
+	}
+
+	writeResponse(w, status, resources.PatchResponse{
+		Data:   data,
+		Status: status,
+	})
+}
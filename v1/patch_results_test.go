@@ -0,0 +1,267 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+//go:generate mockgen -package interfaces -destination ./interfaces/mock_patch_results.go -source=./interfaces/patch_results.go
+
+func TestHandler_PatchResults(t *testing.T) {
+	c := qt.New(t)
+
+	patches := &resources.GroupIdentitiesPatchRequestBody{
+		Patches: []resources.GroupIdentitiesPatchItem{
+			{Identity: "foo", Op: resources.GroupIdentitiesPatchItemOpAdd},
+			{Identity: "bar", Op: resources.GroupIdentitiesPatchItemOpRemove},
+		},
+	}
+	status := func(code int) *int { return &code }
+
+	tests := []struct {
+		name             string
+		results          []resources.PatchItemResult
+		err              error
+		setupErrorMapper func(mapper *MockErrorResponseMapper)
+		expectedStatus   int
+		expectedBody     any
+	}{{
+		name: "success",
+		results: []resources.PatchItemResult{
+			{Outcome: resources.PatchItemOutcomeApplied},
+			{Outcome: resources.PatchItemOutcomeNotPresent},
+		},
+		expectedStatus: http.StatusOK,
+		expectedBody: resources.PatchResponse{
+			Data: []resources.PatchItemResponse{
+				{Outcome: resources.PatchItemOutcomeApplied},
+				{Outcome: resources.PatchItemOutcomeNotPresent},
+			},
+			Status: http.StatusOK,
+		},
+	}, {
+		name: "partial failure",
+		results: []resources.PatchItemResult{
+			{Outcome: resources.PatchItemOutcomeAlreadyPresent},
+			{Outcome: resources.PatchItemOutcomeFailed, Error: errors.New("test-error")},
+		},
+		setupErrorMapper: func(mapper *MockErrorResponseMapper) {
+			mapper.EXPECT().MapError(gomock.Any()).Return(&resources.Response{
+				Message: "Conflict: test-error",
+				Status:  http.StatusConflict,
+			})
+		},
+		expectedStatus: http.StatusMultiStatus,
+		expectedBody: resources.PatchResponse{
+			Data: []resources.PatchItemResponse{
+				{Outcome: resources.PatchItemOutcomeAlreadyPresent},
+				{Outcome: resources.PatchItemOutcomeFailed, Status: status(http.StatusConflict), Message: stringPtr("Conflict: test-error")},
+			},
+			Status: http.StatusMultiStatus,
+		},
+	}, {
+		name: "failure without error",
+		results: []resources.PatchItemResult{
+			{Outcome: resources.PatchItemOutcomeFailed},
+			{Outcome: resources.PatchItemOutcomeApplied},
+		},
+		expectedStatus: http.StatusMultiStatus,
+		expectedBody: resources.PatchResponse{
+			Data: []resources.PatchItemResponse{
+				{Outcome: resources.PatchItemOutcomeFailed, Status: status(http.StatusInternalServerError), Message: stringPtr("Internal Server Error: patch item failed")},
+				{Outcome: resources.PatchItemOutcomeApplied},
+			},
+			Status: http.StatusMultiStatus,
+		},
+	}, {
+		name:           "result count mismatch",
+		results:        []resources.PatchItemResult{{Outcome: resources.PatchItemOutcomeApplied}},
+		expectedStatus: http.StatusInternalServerError,
+		expectedBody: resources.Response{
+			Message: "Internal Server Error: service returned 1 patch results for 2 patch items",
+			Status:  http.StatusInternalServerError,
+		},
+	}, {
+		name:           "service error",
+		err:            NewNotFoundError(`group "some-group" not found`),
+		expectedStatus: http.StatusNotFound,
+		expectedBody: resources.Response{
+			Message: `Not Found: group "some-group" not found`,
+			Status:  http.StatusNotFound,
+		},
+	}}
+
+	for _, test := range tests {
+		tt := test
+		c.Run(tt.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+			defer ctrl.Finish()
+
+			reporter := interfaces.NewMockGroupsPatchReporter(ctrl)
+			reporter.EXPECT().
+				PatchGroupIdentitiesWithResults(gomock.Any(), "some-group", patches.Patches).
+				Return(tt.results, tt.err)
+
+			sut := handler{
+				Groups:              interfaces.NewMockGroupsService(ctrl),
+				GroupsPatchReporter: reporter,
+			}
+			if tt.setupErrorMapper != nil {
+				mapper := NewMockErrorResponseMapper(ctrl)
+				tt.setupErrorMapper(mapper)
+				sut.GroupsErrorMapper = mapper
+			}
+
+			w := httptest.NewRecorder()
+			req := newTestRequest(http.MethodPatch, "/groups/some-group/identities", patches)
+			sut.PatchGroupsItemIdentities(w, req, "some-group")
+
+			result := w.Result()
+			defer result.Body.Close()
+			c.Assert(result.StatusCode, qt.Equals, tt.expectedStatus)
+
+			body, err := io.ReadAll(result.Body)
+			c.Assert(err, qt.IsNil)
+			c.Assert(string(body), qt.JSONEquals, tt.expectedBody)
+		})
+	}
+}
+
+// groupsServiceWithPatchResults is a groups service that reports the outcome
+// of each patch item.
+type groupsServiceWithPatchResults struct {
+	*interfaces.MockGroupsService
+	*interfaces.MockGroupsPatchReporter
+}
+
+// identitiesServiceWithPatchResults is an identities service that reports the
+// outcome of each patch item.
+type identitiesServiceWithPatchResults struct {
+	*interfaces.MockIdentitiesService
+	*interfaces.MockIdentitiesPatchReporter
+}
+
+// rolesServiceWithPatchResults is a roles service that reports the outcome of
+// each patch item.
+type rolesServiceWithPatchResults struct {
+	*interfaces.MockRolesService
+	*interfaces.MockRolesPatchReporter
+}
+
+func TestPatchResultsAreWiredToTheBackend(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	applied := []resources.PatchItemResult{{Outcome: resources.PatchItemOutcomeApplied}}
+
+	groups := interfaces.NewMockGroupsPatchReporter(ctrl)
+	groups.EXPECT().PatchGroupIdentitiesWithResults(gomock.Any(), "foo", gomock.Any()).Return(applied, nil)
+	groups.EXPECT().PatchGroupRolesWithResults(gomock.Any(), "foo", gomock.Any()).Return(applied, nil)
+	groups.EXPECT().PatchGroupEntitlementsWithResults(gomock.Any(), "foo", gomock.Any()).Return(applied, nil)
+	identities := interfaces.NewMockIdentitiesPatchReporter(ctrl)
+	identities.EXPECT().PatchIdentityGroupsWithResults(gomock.Any(), "foo", gomock.Any()).Return(applied, nil)
+	identities.EXPECT().PatchIdentityRolesWithResults(gomock.Any(), "foo", gomock.Any()).Return(applied, nil)
+	identities.EXPECT().PatchIdentityEntitlementsWithResults(gomock.Any(), "foo", gomock.Any()).Return(applied, nil)
+	roles := interfaces.NewMockRolesPatchReporter(ctrl)
+	roles.EXPECT().PatchRoleEntitlementsWithResults(gomock.Any(), "foo", gomock.Any()).Return(applied, nil)
+
+	sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+		Groups: groupsServiceWithPatchResults{
+			MockGroupsService:       interfaces.NewMockGroupsService(ctrl),
+			MockGroupsPatchReporter: groups,
+		},
+		Identities: identitiesServiceWithPatchResults{
+			MockIdentitiesService:       interfaces.NewMockIdentitiesService(ctrl),
+			MockIdentitiesPatchReporter: identities,
+		},
+		Roles: rolesServiceWithPatchResults{
+			MockRolesService:       interfaces.NewMockRolesService(ctrl),
+			MockRolesPatchReporter: roles,
+		},
+	})
+
+	entitlement := `{"op":"add","entitlement":{"entitlement":"admin","entity_type":"controller","entity_id":"c"}}`
+	requests := map[string]string{
+		"/v1/groups/foo/identities":       `{"patches":[{"op":"add","identity":"bar"}]}`,
+		"/v1/groups/foo/roles":            `{"patches":[{"op":"add","role":"bar"}]}`,
+		"/v1/groups/foo/entitlements":     `{"patches":[` + entitlement + `]}`,
+		"/v1/identities/foo/groups":       `{"patches":[{"op":"add","group":"bar"}]}`,
+		"/v1/identities/foo/roles":        `{"patches":[{"op":"add","role":"bar"}]}`,
+		"/v1/identities/foo/entitlements": `{"patches":[` + entitlement + `]}`,
+		"/v1/roles/foo/entitlements":      `{"patches":[` + entitlement + `]}`,
+	}
+	for path, body := range requests {
+		req := httptest.NewRequest(http.MethodPatch, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		sut.Handler("").ServeHTTP(w, req)
+		c.Assert(w.Code, qt.Equals, http.StatusOK, qt.Commentf(path))
+		c.Assert(w.Body.String(), qt.JSONEquals, resources.PatchResponse{
+			Data:   []resources.PatchItemResponse{{Outcome: resources.PatchItemOutcomeApplied}},
+			Status: http.StatusOK,
+		}, qt.Commentf(path))
+	}
+}
+
+func TestPatchResultsAreInstrumented(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	applied := []resources.PatchItemResult{{Outcome: resources.PatchItemOutcomeApplied}}
+
+	groups := interfaces.NewMockGroupsPatchReporter(ctrl)
+	groups.EXPECT().PatchGroupIdentitiesWithResults(gomock.Any(), "foo", gomock.Any()).Return(applied, nil)
+
+	metrics := interfaces.NewMockMetricsRecorder(ctrl)
+	metrics.EXPECT().ObserveServiceCall(gomock.Any(), "GroupsService.PatchGroupIdentitiesWithResults", gomock.Any(), nil)
+	metrics.EXPECT().ObserveRequest(gomock.Any(), "PatchGroupsItemIdentities", http.StatusOK, "2xx", gomock.Any())
+
+	provider, spans := newTestTracerProvider()
+	sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+		Groups: groupsServiceWithPatchResults{
+			MockGroupsService:       interfaces.NewMockGroupsService(ctrl),
+			MockGroupsPatchReporter: groups,
+		},
+		Metrics:        metrics,
+		TracerProvider: provider,
+	})
+
+	req := httptest.NewRequest(http.MethodPatch, "/v1/groups/foo/identities", strings.NewReader(`{"patches":[{"op":"add","identity":"bar"}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	sut.Handler("").ServeHTTP(w, req)
+	c.Assert(w.Code, qt.Equals, http.StatusOK)
+
+	names := []string{}
+	for _, span := range spans.Ended() {
+		names = append(names, span.Name())
+	}
+	c.Assert(names, qt.Contains, "GroupsService.PatchGroupIdentitiesWithResults")
+}
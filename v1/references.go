@@ -0,0 +1,206 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+)
+
+// maxConcurrentReferenceLookups is the maximum number of concurrent service
+// calls made to resolve the references of a single request.
+const maxConcurrentReferenceLookups = 8
+
+// referenceResolver checks the existence of the entities referenced by
+// relationship patches (e.g., the identities added to a group), via the
+// `Get*` methods of the respective services.
+type referenceResolver struct {
+	identities            interfaces.IdentitiesService
+	identitiesErrorMapper ErrorResponseMapper
+	groups                interfaces.GroupsService
+	groupsErrorMapper     ErrorResponseMapper
+	roles                 interfaces.RolesService
+	rolesErrorMapper      ErrorResponseMapper
+}
+
+// references represents a set of references to entities of the same kind.
+type references struct {
+	// singular and plural are the names of the entity kind, used in error
+	// messages.
+	singular string
+	plural   string
+
+	ids    []string
+	mapper ErrorResponseMapper
+
+	// get returns whether the entity with the given ID exists. If nil, the
+	// references cannot be resolved (i.e., the service is not provided), and
+	// are assumed to exist.
+	get func(ctx context.Context, id string) (bool, error)
+}
+
+// identityRefs returns references to the given identities. The resolver can be
+// nil, in which case the references are not resolved (same for groupRefs and
+// roleRefs).
+func (r *referenceResolver) identityRefs(ids ...string) references {
+	refs := references{singular: "identity", plural: "identities", ids: ids}
+	if r != nil && r.identities != nil {
+		refs.mapper = r.identitiesErrorMapper
+		refs.get = func(ctx context.Context, id string) (bool, error) {
+			identity, err := r.identities.GetIdentity(ctx, id)
+			return identity != nil, err
+		}
+	}
+	return refs
+}
+
+// groupRefs returns references to the given groups.
+func (r *referenceResolver) groupRefs(ids ...string) references {
+	refs := references{singular: "group", plural: "groups", ids: ids}
+	if r != nil && r.groups != nil {
+		refs.mapper = r.groupsErrorMapper
+		refs.get = func(ctx context.Context, id string) (bool, error) {
+			group, err := r.groups.GetGroup(ctx, id)
+			return group != nil, err
+		}
+	}
+	return refs
+}
+
+// roleRefs returns references to the given roles.
+func (r *referenceResolver) roleRefs(ids ...string) references {
+	refs := references{singular: "role", plural: "roles", ids: ids}
+	if r != nil && r.roles != nil {
+		refs.mapper = r.rolesErrorMapper
+		refs.get = func(ctx context.Context, id string) (bool, error) {
+			role, err := r.roles.GetRole(ctx, id)
+			return role != nil, err
+		}
+	}
+	return refs
+}
+
+// unknown looks up the referenced entities concurrently, and returns the IDs
+// of those that do not exist, in the original order. Errors that the service
+// error mapper maps to 404 Not Found are taken as non-existence; any other
+// error is returned as is.
+func (refs references) unknown(ctx context.Context) ([]string, error) {
+	if refs.get == nil {
+		return nil, nil
+	}
+
+	ids := deduplicate(refs.ids)
+	found := make([]bool, len(ids))
+	errs := make([]error, len(ids))
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, maxConcurrentReferenceLookups)
+	for i, id := range ids {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, id string) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			found[i], errs[i] = refs.get(ctx, id)
+			if errs[i] != nil && mapServiceErrorResponse(refs.mapper, errs[i]).Status == http.StatusNotFound {
+				found[i], errs[i] = false, nil
+			}
+		}(i, id)
+	}
+	wg.Wait()
+
+	var unknown []string
+	for i, id := range ids {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		if !found[i] {
+			unknown = append(unknown, id)
+		}
+	}
+	return unknown, nil
+}
+
+// deduplicate returns the given strings without duplicates, keeping the
+// order of their first occurrences.
+func deduplicate(s []string) []string {
+	seen := make(map[string]struct{}, len(s))
+	result := make([]string, 0, len(s))
+	for _, x := range s {
+		if _, ok := seen[x]; !ok {
+			seen[x] = struct{}{}
+			result = append(result, x)
+		}
+	}
+	return result
+}
+
+// validateReferences is a helper method to avoid repetition. It checks that
+// the patched entity exists (otherwise, it responds with 404 Not Found), and
+// that the entities added to its relationship exist (otherwise, it responds
+// with 400 Bad Request, listing the unknown IDs). If it's okay, it will
+// delegate to the provided callback.
+//
+// The references are only resolved if referential integrity checks are
+// enabled, and the respective services are provided.
+func (v handlerWithValidation) validateReferences(patched references, added references, w http.ResponseWriter, r *http.Request, f func(w http.ResponseWriter, r *http.Request)) {
+	problem, mapper, err := checkReferences(r.Context(), "", patched, added)
+	if err != nil {
+		writeServiceErrorResponse(w, mapper, err)
+		return
+	}
+	if problem != nil {
+		writeErrorResponse(w, problem)
+		return
+	}
+	f(w, r)
+}
+
+// checkReferences resolves the given references, as described in
+// validateReferences. If the patched entity does not exist, it returns a not
+// found error as the problem; if any of the added entities do not exist, a
+// request body validation error. The given prefix is prepended to the message
+// of the problem. Errors returned by the services are returned as is, along
+// with the error mapper of the respective service.
+func checkReferences(ctx context.Context, prefix string, patched references, added references) (problem error, mapper ErrorResponseMapper, err error) {
+	unknown, err := patched.unknown(ctx)
+	if err != nil {
+		return nil, patched.mapper, err
+	}
+	if len(unknown) > 0 {
+		return NewNotFoundError(fmt.Sprintf("%s%s %q not found", prefix, patched.singular, unknown[0])), nil, nil
+	}
+
+	unknown, err = added.unknown(ctx)
+	if err != nil {
+		return nil, added.mapper, err
+	}
+	if len(unknown) > 0 {
+		quoted := make([]string, 0, len(unknown))
+		for _, id := range unknown {
+			quoted = append(quoted, fmt.Sprintf("%q", id))
+		}
+		return NewRequestBodyValidationError(fmt.Sprintf("%sunknown %s: %s", prefix, added.plural, strings.Join(quoted, ", "))), nil, nil
+	}
+	return nil, nil, nil
+}
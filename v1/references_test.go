@@ -0,0 +1,232 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+func TestReferencesUnknown(t *testing.T) {
+	c := qt.New(t)
+
+	var inflight, maxInflight, calls atomic.Int32
+	refs := references{
+		ids: []string{"a", "b", "a", "c", "d", "b"},
+		get: func(ctx context.Context, id string) (bool, error) {
+			calls.Add(1)
+			n := inflight.Add(1)
+			defer inflight.Add(-1)
+			for {
+				m := maxInflight.Load()
+				if n <= m || maxInflight.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			switch id {
+			case "b":
+				return false, nil
+			case "d":
+				return false, NewNotFoundError("not found")
+			}
+			return true, nil
+		},
+	}
+
+	unknown, err := refs.unknown(context.Background())
+	c.Assert(err, qt.IsNil)
+	c.Assert(unknown, qt.DeepEquals, []string{"b", "d"})
+	c.Assert(calls.Load(), qt.Equals, int32(4))
+	c.Assert(maxInflight.Load() <= maxConcurrentReferenceLookups, qt.IsTrue)
+
+	refs.get = func(ctx context.Context, id string) (bool, error) {
+		if id == "c" {
+			return false, errors.New("some-error")
+		}
+		return true, nil
+	}
+	_, err = refs.unknown(context.Background())
+	c.Assert(err, qt.ErrorMatches, "some-error")
+
+	// References without a lookup function are assumed to exist.
+	refs.get = nil
+	unknown, err = refs.unknown(context.Background())
+	c.Assert(err, qt.IsNil)
+	c.Assert(unknown, qt.HasLen, 0)
+}
+
+func TestHandlerWithValidation_ReferentialIntegrity(t *testing.T) {
+	c := qt.New(t)
+
+	existing := map[string]bool{"foo": true, "bar": true}
+
+	tests := []struct {
+		name             string
+		path             string
+		body             string
+		withoutIntegrity bool
+		withoutRoles     bool
+		lookupError      error
+		setupMocks       func(groups *interfaces.MockGroupsService, identities *interfaces.MockIdentitiesService, roles *interfaces.MockRolesService)
+		expectedStatus   int
+		expectedMessage  string
+	}{{
+		name: "PatchGroupsItemIdentities: success",
+		path: "/v1/groups/foo/identities",
+		body: `{"patches":[{"op":"add","identity":"foo"},{"op":"add","identity":"bar"},{"op":"remove","identity":"baz"}]}`,
+		setupMocks: func(groups *interfaces.MockGroupsService, _ *interfaces.MockIdentitiesService, _ *interfaces.MockRolesService) {
+			groups.EXPECT().PatchGroupIdentities(gomock.Any(), "foo", gomock.Any()).Return(true, nil)
+		},
+		expectedStatus: http.StatusOK,
+	}, {
+		name:            "PatchGroupsItemIdentities: unknown group",
+		path:            "/v1/groups/baz/identities",
+		body:            `{"patches":[{"op":"add","identity":"foo"}]}`,
+		expectedStatus:  http.StatusNotFound,
+		expectedMessage: `Not Found: group "baz" not found`,
+	}, {
+		name:            "PatchGroupsItemIdentities: unknown identities",
+		path:            "/v1/groups/foo/identities",
+		body:            `{"patches":[{"op":"add","identity":"qux"},{"op":"add","identity":"foo"},{"op":"add","identity":"baz"},{"op":"add","identity":"qux"}]}`,
+		expectedStatus:  http.StatusBadRequest,
+		expectedMessage: `Bad Request: invalid request body: unknown identities: "qux", "baz"`,
+	}, {
+		name:            "PatchGroupsItemRoles: unknown roles",
+		path:            "/v1/groups/foo/roles",
+		body:            `{"patches":[{"op":"add","role":"baz"}]}`,
+		expectedStatus:  http.StatusBadRequest,
+		expectedMessage: `Bad Request: invalid request body: unknown roles: "baz"`,
+	}, {
+		name:         "PatchGroupsItemRoles: no roles service",
+		path:         "/v1/groups/foo/roles",
+		body:         `{"patches":[{"op":"add","role":"baz"}]}`,
+		withoutRoles: true,
+		setupMocks: func(groups *interfaces.MockGroupsService, _ *interfaces.MockIdentitiesService, _ *interfaces.MockRolesService) {
+			groups.EXPECT().PatchGroupRoles(gomock.Any(), "foo", gomock.Any()).Return(true, nil)
+		},
+		expectedStatus: http.StatusOK,
+	}, {
+		name:            "PatchIdentitiesItemGroups: unknown identity",
+		path:            "/v1/identities/baz/groups",
+		body:            `{"patches":[{"op":"remove","group":"foo"}]}`,
+		expectedStatus:  http.StatusNotFound,
+		expectedMessage: `Not Found: identity "baz" not found`,
+	}, {
+		name:            "PatchIdentitiesItemGroups: unknown groups",
+		path:            "/v1/identities/foo/groups",
+		body:            `{"patches":[{"op":"add","group":"bar"},{"op":"add","group":"baz"}]}`,
+		expectedStatus:  http.StatusBadRequest,
+		expectedMessage: `Bad Request: invalid request body: unknown groups: "baz"`,
+	}, {
+		name: "PatchIdentitiesItemRoles: success",
+		path: "/v1/identities/foo/roles",
+		body: `{"patches":[{"op":"add","role":"bar"},{"op":"remove","role":"baz"}]}`,
+		setupMocks: func(_ *interfaces.MockGroupsService, identities *interfaces.MockIdentitiesService, _ *interfaces.MockRolesService) {
+			identities.EXPECT().PatchIdentityRoles(gomock.Any(), "foo", gomock.Any()).Return(true, nil)
+		},
+		expectedStatus: http.StatusOK,
+	}, {
+		name:            "PatchIdentitiesItemRoles: lookup error",
+		path:            "/v1/identities/foo/roles",
+		body:            `{"patches":[{"op":"add","role":"bar"}]}`,
+		lookupError:     errors.New("some-error"),
+		expectedStatus:  http.StatusInternalServerError,
+		expectedMessage: "Internal Server Error: some-error",
+	}, {
+		name:             "PatchIdentitiesItemRoles: referential integrity disabled",
+		path:             "/v1/identities/baz/roles",
+		body:             `{"patches":[{"op":"add","role":"baz"}]}`,
+		withoutIntegrity: true,
+		setupMocks: func(_ *interfaces.MockGroupsService, identities *interfaces.MockIdentitiesService, _ *interfaces.MockRolesService) {
+			identities.EXPECT().PatchIdentityRoles(gomock.Any(), "baz", gomock.Any()).Return(true, nil)
+		},
+		expectedStatus: http.StatusOK,
+	}}
+
+	for _, test := range tests {
+		tt := test
+		c.Run(tt.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+			defer ctrl.Finish()
+
+			groups := interfaces.NewMockGroupsService(ctrl)
+			groups.EXPECT().GetGroup(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, id string) (*resources.Group, error) {
+				if !existing[id] {
+					return nil, NewNotFoundError(fmt.Sprintf("group %q not found", id))
+				}
+				return &resources.Group{Id: &id, Name: id}, nil
+			}).AnyTimes()
+			identities := interfaces.NewMockIdentitiesService(ctrl)
+			identities.EXPECT().GetIdentity(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, id string) (*resources.Identity, error) {
+				if !existing[id] {
+					return nil, NewNotFoundError(fmt.Sprintf("identity %q not found", id))
+				}
+				return &resources.Identity{Id: &id, Email: id}, nil
+			}).AnyTimes()
+			roles := interfaces.NewMockRolesService(ctrl)
+			roles.EXPECT().GetRole(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, id string) (*resources.Role, error) {
+				if tt.lookupError != nil {
+					return nil, tt.lookupError
+				}
+				if !existing[id] {
+					// Some services return no error for non-existent entities.
+					return nil, nil
+				}
+				return &resources.Role{Id: &id, Name: id}, nil
+			}).AnyTimes()
+			if tt.setupMocks != nil {
+				tt.setupMocks(groups, identities, roles)
+			}
+
+			params := ReBACAdminBackendParams{
+				Groups:               groups,
+				Identities:           identities,
+				Roles:                roles,
+				ReferentialIntegrity: !tt.withoutIntegrity,
+			}
+			if tt.withoutRoles {
+				params.Roles = nil
+			}
+			sut, _ := NewReBACAdminBackend(params)
+
+			req := httptest.NewRequest(http.MethodPatch, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			sut.Handler("").ServeHTTP(w, req)
+			c.Assert(w.Code, qt.Equals, tt.expectedStatus)
+
+			if tt.expectedMessage != "" {
+				response := resources.Response{}
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				c.Assert(err, qt.IsNil)
+				c.Assert(response.Message, qt.Equals, tt.expectedMessage)
+			}
+		})
+	}
+}
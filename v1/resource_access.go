@@ -0,0 +1,44 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"net/http"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// GetResourcesItemAccess returns the list of entitlements held on a resource,
+// by identities, groups and roles.
+// (GET /resources/{type}/{id}/access)
+func (h handler) GetResourcesItemAccess(w http.ResponseWriter, req *http.Request, pType string, id string, params resources.GetResourcesItemAccessParams) {
+	ctx := req.Context()
+
+	access, err := h.ResourcesAccess.ListResourceAccess(ctx, pType, id, &params)
+	if err != nil {
+		writeServiceErrorResponse(w, h.ResourcesErrorMapper, err)
+		return
+	}
+
+	response := resources.GetResourceAccessResponse{
+		Links:  resources.NewResponseLinks[resources.ResourceAccess](req.URL, access),
+		Meta:   access.Meta,
+		Data:   access.Data,
+		Status: http.StatusOK,
+	}
+
+	writeResponse(w, http.StatusOK, response)
+}
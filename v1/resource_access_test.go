@@ -0,0 +1,146 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+//go:generate mockgen -package interfaces -destination ./interfaces/mock_resource_access.go -source=./interfaces/resource_access.go
+
+func TestHandler_ResourcesItemAccess(t *testing.T) {
+	c := qt.New(t)
+
+	entries := []resources.ResourceAccess{{
+		ReceiverType: resources.ResourceAccessReceiverTypeIdentity,
+		ReceiverId:   "some-identity",
+		Entitlement:  "can_view",
+		Via:          []resources.AccessPathStep{{Type: resources.AccessPathStepTypeGroup, Id: "some-group"}},
+	}}
+	nextToken := "next"
+
+	tests := []struct {
+		name             string
+		setupServiceMock func(mockService *interfaces.MockResourceAccessLister)
+		expectedStatus   int
+		expectedBody     any
+	}{{
+		name: "success",
+		setupServiceMock: func(mockService *interfaces.MockResourceAccessLister) {
+			mockService.EXPECT().
+				ListResourceAccess(gomock.Any(), "model", "foo", &resources.GetResourcesItemAccessParams{}).
+				Return(&resources.PaginatedResponse[resources.ResourceAccess]{
+					Meta: resources.ResponseMeta{Size: 1},
+					Next: resources.Next{PageToken: &nextToken},
+					Data: entries,
+				}, nil)
+		},
+		expectedStatus: http.StatusOK,
+		expectedBody: resources.GetResourceAccessResponse{
+			Links: resources.ResponseLinks{
+				Next: resources.ResponseLinksNext{Href: "/resources/model/foo/access?nextToken=next"},
+			},
+			Meta:   resources.ResponseMeta{Size: 1},
+			Data:   entries,
+			Status: http.StatusOK,
+		},
+	}, {
+		name: "service error",
+		setupServiceMock: func(mockService *interfaces.MockResourceAccessLister) {
+			mockService.EXPECT().
+				ListResourceAccess(gomock.Any(), "model", "foo", &resources.GetResourcesItemAccessParams{}).
+				Return(nil, errors.New("test-error"))
+		},
+		expectedStatus: http.StatusInternalServerError,
+		expectedBody: resources.Response{
+			Message: "Internal Server Error: test-error",
+			Status:  http.StatusInternalServerError,
+		},
+	}}
+
+	for _, test := range tests {
+		tt := test
+		c.Run(tt.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+			defer ctrl.Finish()
+
+			mockService := interfaces.NewMockResourceAccessLister(ctrl)
+			tt.setupServiceMock(mockService)
+
+			sut := handler{ResourcesAccess: mockService}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/resources/model/foo/access", nil)
+			sut.GetResourcesItemAccess(w, req, "model", "foo", resources.GetResourcesItemAccessParams{})
+
+			result := w.Result()
+			defer result.Body.Close()
+			c.Assert(result.StatusCode, qt.Equals, tt.expectedStatus)
+
+			body, err := io.ReadAll(result.Body)
+			c.Assert(err, qt.IsNil)
+			c.Assert(string(body), qt.JSONEquals, tt.expectedBody)
+		})
+	}
+}
+
+// resourcesServiceWithAccess is a resources service that lists who has access
+// to resources.
+type resourcesServiceWithAccess struct {
+	*interfaces.MockResourcesService
+	*interfaces.MockResourceAccessLister
+}
+
+func TestResourcesItemAccessIsWiredToTheBackend(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	mockService := interfaces.NewMockResourceAccessLister(ctrl)
+	mockService.EXPECT().
+		ListResourceAccess(gomock.Any(), "model", "foo", gomock.Any()).
+		Return(&resources.PaginatedResponse[resources.ResourceAccess]{}, nil)
+
+	sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+		Resources: resourcesServiceWithAccess{
+			MockResourcesService:     interfaces.NewMockResourcesService(ctrl),
+			MockResourceAccessLister: mockService,
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/resources/model/foo/access", nil)
+	w := httptest.NewRecorder()
+	sut.Handler("").ServeHTTP(w, req)
+	c.Assert(w.Code, qt.Equals, http.StatusOK)
+
+	// Without the optional interface, the endpoint is not implemented.
+	sut, _ = NewReBACAdminBackend(ReBACAdminBackendParams{
+		Resources: interfaces.NewMockResourcesService(ctrl),
+	})
+	w = httptest.NewRecorder()
+	sut.Handler("").ServeHTTP(w, req)
+	c.Assert(w.Code, qt.Equals, http.StatusNotImplemented)
+}
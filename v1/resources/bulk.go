@@ -0,0 +1,96 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package resources
+
+// BulkOperationOp defines the type of a bulk operation.
+type BulkOperationOp string
+
+// Defines values for BulkOperationOp.
+const (
+	BulkOperationOpCreateGroup               BulkOperationOp = "createGroup"
+	BulkOperationOpDeleteGroup               BulkOperationOp = "deleteGroup"
+	BulkOperationOpPatchGroupIdentities      BulkOperationOp = "patchGroupIdentities"
+	BulkOperationOpPatchGroupRoles           BulkOperationOp = "patchGroupRoles"
+	BulkOperationOpPatchGroupEntitlements    BulkOperationOp = "patchGroupEntitlements"
+	BulkOperationOpCreateRole                BulkOperationOp = "createRole"
+	BulkOperationOpDeleteRole                BulkOperationOp = "deleteRole"
+	BulkOperationOpPatchRoleEntitlements     BulkOperationOp = "patchRoleEntitlements"
+	BulkOperationOpCreateIdentity            BulkOperationOp = "createIdentity"
+	BulkOperationOpDeleteIdentity            BulkOperationOp = "deleteIdentity"
+	BulkOperationOpPatchIdentityGroups       BulkOperationOp = "patchIdentityGroups"
+	BulkOperationOpPatchIdentityRoles        BulkOperationOp = "patchIdentityRoles"
+	BulkOperationOpPatchIdentityEntitlements BulkOperationOp = "patchIdentityEntitlements"
+)
+
+// BulkOperation represents a single operation of a bulk request. Depending on
+// the operation type (`Op`), the following fields should be set:
+//   - `create*`: the entity to create (i.e., `Group`, `Role` or `Identity`).
+//   - `delete*`: the ID of the entity to delete (i.e., `Id`).
+//   - `patch*`: the ID of the entity to patch (i.e., `Id`), and the patches of
+//     the same name (e.g., `GroupIdentities` for `patchGroupIdentities`).
+type BulkOperation struct {
+	Op BulkOperationOp `json:"op" validate:"required,oneof=createGroup deleteGroup patchGroupIdentities patchGroupRoles patchGroupEntitlements createRole deleteRole patchRoleEntitlements createIdentity deleteIdentity patchIdentityGroups patchIdentityRoles patchIdentityEntitlements"`
+	Id *string         `json:"id,omitempty"`
+
+	// IfMatch is the `If-Match` precondition of the operation, as it would be
+	// sent to its equivalent endpoint (e.g., the `ETag` of the entity).
+	IfMatch *string `json:"ifMatch,omitempty"`
+
+	Group    *Group    `json:"group,omitempty"`
+	Role     *Role     `json:"role,omitempty"`
+	Identity *Identity `json:"identity,omitempty"`
+
+	GroupIdentities      []GroupIdentitiesPatchItem      `json:"groupIdentities,omitempty" validate:"omitempty,dive"`
+	GroupRoles           []GroupRolesPatchItem           `json:"groupRoles,omitempty" validate:"omitempty,dive"`
+	GroupEntitlements    []GroupEntitlementsPatchItem    `json:"groupEntitlements,omitempty" validate:"omitempty,dive"`
+	RoleEntitlements     []RoleEntitlementsPatchItem     `json:"roleEntitlements,omitempty" validate:"omitempty,dive"`
+	IdentityGroups       []IdentityGroupsPatchItem       `json:"identityGroups,omitempty" validate:"omitempty,dive"`
+	IdentityRoles        []IdentityRolesPatchItem        `json:"identityRoles,omitempty" validate:"omitempty,dive"`
+	IdentityEntitlements []IdentityEntitlementsPatchItem `json:"identityEntitlements,omitempty" validate:"omitempty,dive"`
+}
+
+// BulkRequestBody defines the request body of the `POST /bulk` endpoint.
+type BulkRequestBody struct {
+	Operations []BulkOperation `json:"operations" validate:"required,gt=0,dive"`
+}
+
+// BulkOperationResult represents the outcome of a single bulk operation.
+type BulkOperationResult struct {
+	// Status is the HTTP status code the operation would have, if it was
+	// requested via its own endpoint.
+	Status int `json:"status"`
+
+	// Message describes the failure, if any.
+	Message *string `json:"message,omitempty"`
+
+	// Group, Role and Identity hold the created entity of `create*`
+	// operations.
+	Group    *Group    `json:"group,omitempty"`
+	Role     *Role     `json:"role,omitempty"`
+	Identity *Identity `json:"identity,omitempty"`
+}
+
+// BulkResponse defines the response body of the `POST /bulk` endpoint.
+type BulkResponse struct {
+	// Atomic reports whether the operations were executed atomically (i.e.,
+	// either all or none of them have taken effect).
+	Atomic bool `json:"atomic"`
+
+	// Results holds the outcome of the operations, in the same order.
+	Results []BulkOperationResult `json:"results"`
+
+	Status int `json:"status"`
+}
@@ -0,0 +1,67 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package resources
+
+// CheckRequestBody defines the request body of the `POST /check` endpoint.
+type CheckRequestBody struct {
+	// IdentityId is the ID of the identity to check the access of.
+	IdentityId string `json:"identityId" validate:"required"`
+
+	// Entitlement is the entitlement to check (e.g., `can_view` on
+	// `controller:foo`).
+	Entitlement EntityEntitlement `json:"entitlement"`
+
+	// Explain determines whether the paths that grant the entitlement should
+	// be returned.
+	Explain bool `json:"explain,omitempty"`
+}
+
+// AccessPathStepType defines the type of an AccessPathStep.
+type AccessPathStepType string
+
+// Defines values for AccessPathStepType.
+const (
+	AccessPathStepTypeGroup AccessPathStepType = "group"
+	AccessPathStepTypeRole  AccessPathStepType = "role"
+)
+
+// AccessPathStep represents an intermediate entity of an AccessPath.
+type AccessPathStep struct {
+	Type AccessPathStepType `json:"type"`
+	Id   string             `json:"id"`
+}
+
+// AccessPath represents a chain of relations that grants an entitlement to an
+// identity, starting from the identity (e.g., via group G, then via role R).
+// An empty `Via` means the entitlement is granted directly.
+type AccessPath struct {
+	Via []AccessPathStep `json:"via"`
+}
+
+// AccessCheck represents the outcome of an access check.
+type AccessCheck struct {
+	Allowed bool `json:"allowed"`
+
+	// Paths holds the paths that grant the entitlement, if requested.
+	Paths []AccessPath `json:"paths,omitempty"`
+}
+
+// CheckResponse defines the response body of the `POST /check` endpoint.
+type CheckResponse struct {
+	AccessCheck
+
+	Status int `json:"status"`
+}
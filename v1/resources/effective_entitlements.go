@@ -0,0 +1,33 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package resources
+
+// EffectiveEntitlement represents an entitlement of an identity, whether it's
+// assigned directly or inherited via groups and roles.
+type EffectiveEntitlement struct {
+	Entitlement EntityEntitlement `json:"entitlement"`
+
+	// Sources holds the paths that grant the entitlement (e.g., directly, or
+	// via group G and then role R).
+	Sources []AccessPath `json:"sources"`
+}
+
+// GetIdentityEffectiveEntitlementsResponse defines the response body of the
+// `GET /identities/{id}/effective-entitlements` endpoint.
+type GetIdentityEffectiveEntitlementsResponse struct {
+	Data   []EffectiveEntitlement `json:"data"`
+	Status int                    `json:"status"`
+}
@@ -0,0 +1,35 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package resources
+
+import "net/http"
+
+// IdempotencyRecord represents a request made with an `Idempotency-Key` header,
+// along with its response once it's handled.
+type IdempotencyRecord struct {
+	// RequestHash is the hash of the request body, used to tell whether a
+	// reused key belongs to a retry of the same request.
+	RequestHash string
+
+	// Completed reports whether the request has been handled. Until then, the
+	// record only reserves the key, and the response fields are empty.
+	Completed bool
+
+	// Status, Header and Body hold the recorded response.
+	Status int
+	Header http.Header
+	Body   []byte
+}
@@ -0,0 +1,250 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package resources
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultPageTokenTTL is the lifetime of page tokens issued by a
+// PageTokenCodec, if not configured otherwise.
+const DefaultPageTokenTTL = 24 * time.Hour
+
+// minPageTokenKeyLength is the minimum length of page token keys, in bytes.
+const minPageTokenKeyLength = 16
+
+const (
+	// pageTokenVersion is the version of the page token format, stored in the
+	// first byte of tokens.
+	pageTokenVersion byte = 1
+	// pageTokenFlagEncrypted is set in the second byte of tokens whose payload
+	// is encrypted.
+	pageTokenFlagEncrypted byte = 1
+)
+
+// InvalidPageTokenError is returned when decoding a page token that is
+// malformed, forged, expired, or was issued for a request with different query
+// parameters. The library maps it to a 400 Bad Request response.
+type InvalidPageTokenError struct {
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *InvalidPageTokenError) Error() string {
+	return fmt.Sprintf("invalid page token: %s", e.Reason)
+}
+
+// PageTokenCodecParams contains the configuration of a PageTokenCodec.
+type PageTokenCodecParams struct {
+	// Keys are the secret keys used to sign (and, if enabled, encrypt) page
+	// tokens. New tokens are always issued with the first key, while tokens
+	// issued with any of the keys are accepted. To rotate keys, prepend the new
+	// key and keep the old one(s) until the tokens they issued have expired.
+	// At least one key of 16 bytes or more is required.
+	Keys [][]byte
+
+	// Encrypt determines whether the cursor state is encrypted, so that it's
+	// opaque to clients. Tokens are signed either way.
+	Encrypt bool
+
+	// TTL is the lifetime of issued tokens. If zero, `DefaultPageTokenTTL` is
+	// used.
+	TTL time.Duration
+}
+
+// PageTokenCodec encodes the cursor state of paginated list operations as
+// tamper-proof `NextToken`/`Next-Page-Token` values, and decodes them back.
+//
+// Each token is bound to the query parameters of the request it's issued for
+// (e.g., filter and sort order), which are passed as the `scope` arguments of
+// `Encode` and `Decode`. A token presented along with different parameters is
+// rejected.
+type PageTokenCodec struct {
+	keys    []pageTokenKey
+	encrypt bool
+	ttl     time.Duration
+
+	// now returns the current time; overridden in tests.
+	now func() time.Time
+}
+
+// pageTokenKey holds the keys derived from a configured secret key.
+type pageTokenKey struct {
+	signing []byte
+	aead    cipher.AEAD
+}
+
+// pageTokenPayload is the (JSON-serialized) content of a page token.
+type pageTokenPayload struct {
+	Cursor    json.RawMessage `json:"c"`
+	ExpiresAt int64           `json:"e"`
+	Scope     []byte          `json:"s"`
+}
+
+// NewPageTokenCodec returns a new PageTokenCodec instance.
+func NewPageTokenCodec(params PageTokenCodecParams) (*PageTokenCodec, error) {
+	if len(params.Keys) == 0 {
+		return nil, errors.New("at least one page token key is required")
+	}
+	if params.TTL < 0 {
+		return nil, errors.New("page token TTL cannot be negative")
+	}
+
+	codec := &PageTokenCodec{
+		encrypt: params.Encrypt,
+		ttl:     params.TTL,
+		now:     time.Now,
+	}
+	if codec.ttl == 0 {
+		codec.ttl = DefaultPageTokenTTL
+	}
+	for i, key := range params.Keys {
+		if len(key) < minPageTokenKeyLength {
+			return nil, fmt.Errorf("page token key #%d is shorter than %d bytes", i, minPageTokenKeyLength)
+		}
+		block, err := aes.NewCipher(deriveKey(key, "encryption"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize page token cipher: %w", err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize page token cipher: %w", err)
+		}
+		codec.keys = append(codec.keys, pageTokenKey{
+			signing: deriveKey(key, "signing"),
+			aead:    aead,
+		})
+	}
+	return codec, nil
+}
+
+// Encode returns a page token that carries the given (JSON-serializable)
+// cursor state, and is bound to the given scope.
+func (c *PageTokenCodec) Encode(cursor any, scope ...string) (string, error) {
+	rawCursor, err := json.Marshal(cursor)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal page token cursor: %w", err)
+	}
+	payload, err := json.Marshal(pageTokenPayload{
+		Cursor:    rawCursor,
+		ExpiresAt: c.now().Add(c.ttl).Unix(),
+		Scope:     hashPageTokenScope(scope),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal page token: %w", err)
+	}
+
+	key := c.keys[0]
+	token := []byte{pageTokenVersion, 0}
+	if c.encrypt {
+		token[1] |= pageTokenFlagEncrypted
+		nonce := make([]byte, key.aead.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return "", fmt.Errorf("failed to generate page token nonce: %w", err)
+		}
+		token = append(token, nonce...)
+		token = key.aead.Seal(token, nonce, payload, token[:2])
+	} else {
+		token = append(token, payload...)
+	}
+	token = append(token, sign(key.signing, token)...)
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+// Decode verifies the given page token, and unmarshals its cursor state into
+// the value pointed to by `cursor`. The scope must be the same as the one the
+// token was issued for. If the token is not valid, an *InvalidPageTokenError
+// is returned.
+func (c *PageTokenCodec) Decode(token string, cursor any, scope ...string) error {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(raw) < 2+sha256.Size || raw[0] != pageTokenVersion {
+		return &InvalidPageTokenError{Reason: "malformed token"}
+	}
+	content, signature := raw[:len(raw)-sha256.Size], raw[len(raw)-sha256.Size:]
+
+	var key *pageTokenKey
+	for i := range c.keys {
+		if hmac.Equal(signature, sign(c.keys[i].signing, content)) {
+			key = &c.keys[i]
+			break
+		}
+	}
+	if key == nil {
+		return &InvalidPageTokenError{Reason: "signature mismatch"}
+	}
+
+	payload := content[2:]
+	if content[1]&pageTokenFlagEncrypted != 0 {
+		nonceSize := key.aead.NonceSize()
+		if len(payload) < nonceSize {
+			return &InvalidPageTokenError{Reason: "malformed token"}
+		}
+		if payload, err = key.aead.Open(nil, payload[:nonceSize], payload[nonceSize:], content[:2]); err != nil {
+			return &InvalidPageTokenError{Reason: "malformed token"}
+		}
+	}
+
+	p := pageTokenPayload{}
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return &InvalidPageTokenError{Reason: "malformed token"}
+	}
+	if c.now().Unix() >= p.ExpiresAt {
+		return &InvalidPageTokenError{Reason: "token has expired"}
+	}
+	if !bytes.Equal(p.Scope, hashPageTokenScope(scope)) {
+		return &InvalidPageTokenError{Reason: "token was issued for different query parameters"}
+	}
+	if err := json.Unmarshal(p.Cursor, cursor); err != nil {
+		return &InvalidPageTokenError{Reason: "malformed cursor"}
+	}
+	return nil
+}
+
+// deriveKey derives a key for the given purpose from the given secret key, so
+// that the same secret is never used for different algorithms.
+func deriveKey(key []byte, purpose string) []byte {
+	return sign(key, []byte("rebac-admin page token "+purpose))
+}
+
+// sign returns the HMAC-SHA256 of the given data with the given key.
+func sign(key, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// hashPageTokenScope returns a (truncated) hash of the given scope values. The
+// values are length-prefixed so that, e.g., ("ab", "c") and ("a", "bc") result
+// in different hashes.
+func hashPageTokenScope(scope []string) []byte {
+	h := sha256.New()
+	for _, s := range scope {
+		_ = binary.Write(h, binary.BigEndian, uint64(len(s)))
+		h.Write([]byte(s))
+	}
+	return h.Sum(nil)[:16]
+}
@@ -0,0 +1,178 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package resources
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type testCursor struct {
+	Offset int    `json:"offset"`
+	After  string `json:"after"`
+}
+
+var (
+	testKey1 = []byte("0123456789abcdef0123456789abcdef")
+	testKey2 = []byte("fedcba9876543210fedcba9876543210")
+)
+
+func TestNewPageTokenCodec(t *testing.T) {
+	c := qt.New(t)
+
+	_, err := NewPageTokenCodec(PageTokenCodecParams{})
+	c.Assert(err, qt.ErrorMatches, "at least one page token key is required")
+
+	_, err = NewPageTokenCodec(PageTokenCodecParams{Keys: [][]byte{testKey1, []byte("short")}})
+	c.Assert(err, qt.ErrorMatches, "page token key #1 is shorter than 16 bytes")
+
+	_, err = NewPageTokenCodec(PageTokenCodecParams{Keys: [][]byte{testKey1}, TTL: -time.Second})
+	c.Assert(err, qt.ErrorMatches, "page token TTL cannot be negative")
+
+	codec, err := NewPageTokenCodec(PageTokenCodecParams{Keys: [][]byte{testKey1}})
+	c.Assert(err, qt.IsNil)
+	c.Assert(codec.ttl, qt.Equals, DefaultPageTokenTTL)
+}
+
+func TestPageTokenCodec_RoundTrip(t *testing.T) {
+	c := qt.New(t)
+
+	for _, encrypt := range []bool{false, true} {
+		codec, err := NewPageTokenCodec(PageTokenCodecParams{Keys: [][]byte{testKey1}, Encrypt: encrypt})
+		c.Assert(err, qt.IsNil)
+
+		token, err := codec.Encode(testCursor{Offset: 42, After: "secret-cursor"}, "name eq foo", "-name")
+		c.Assert(err, qt.IsNil)
+
+		raw, err := base64.RawURLEncoding.DecodeString(token)
+		c.Assert(err, qt.IsNil)
+		c.Assert(strings.Contains(string(raw), "secret-cursor"), qt.Equals, !encrypt)
+
+		cursor := testCursor{}
+		err = codec.Decode(token, &cursor, "name eq foo", "-name")
+		c.Assert(err, qt.IsNil)
+		c.Assert(cursor, qt.Equals, testCursor{Offset: 42, After: "secret-cursor"})
+	}
+}
+
+func TestPageTokenCodec_KeyRotation(t *testing.T) {
+	c := qt.New(t)
+
+	oldCodec, err := NewPageTokenCodec(PageTokenCodecParams{Keys: [][]byte{testKey1}, Encrypt: true})
+	c.Assert(err, qt.IsNil)
+	oldToken, err := oldCodec.Encode(testCursor{Offset: 1})
+	c.Assert(err, qt.IsNil)
+
+	codec, err := NewPageTokenCodec(PageTokenCodecParams{Keys: [][]byte{testKey2, testKey1}, Encrypt: true})
+	c.Assert(err, qt.IsNil)
+
+	// Tokens issued with the old key are still accepted.
+	cursor := testCursor{}
+	err = codec.Decode(oldToken, &cursor)
+	c.Assert(err, qt.IsNil)
+	c.Assert(cursor.Offset, qt.Equals, 1)
+
+	// New tokens are issued with the new key, which the old codec does not know.
+	token, err := codec.Encode(testCursor{Offset: 2})
+	c.Assert(err, qt.IsNil)
+	err = oldCodec.Decode(token, &cursor)
+	c.Assert(err, qt.ErrorMatches, "invalid page token: signature mismatch")
+}
+
+func TestPageTokenCodec_Expiry(t *testing.T) {
+	c := qt.New(t)
+
+	codec, err := NewPageTokenCodec(PageTokenCodecParams{Keys: [][]byte{testKey1}, TTL: time.Minute})
+	c.Assert(err, qt.IsNil)
+	now := time.Now()
+	codec.now = func() time.Time { return now }
+
+	token, err := codec.Encode(testCursor{})
+	c.Assert(err, qt.IsNil)
+
+	now = now.Add(59 * time.Second)
+	c.Assert(codec.Decode(token, &testCursor{}), qt.IsNil)
+
+	now = now.Add(time.Second)
+	err = codec.Decode(token, &testCursor{})
+	c.Assert(err, qt.ErrorMatches, "invalid page token: token has expired")
+}
+
+func TestPageTokenCodec_InvalidTokens(t *testing.T) {
+	c := qt.New(t)
+
+	codec, err := NewPageTokenCodec(PageTokenCodecParams{Keys: [][]byte{testKey1}})
+	c.Assert(err, qt.IsNil)
+	token, err := codec.Encode(testCursor{Offset: 1}, "filter")
+	c.Assert(err, qt.IsNil)
+
+	// Tamper with the payload, keeping the signature.
+	raw, _ := base64.RawURLEncoding.DecodeString(token)
+	forged := strings.Replace(string(raw), `"offset":1`, `"offset":9`, 1)
+	c.Assert(forged, qt.Not(qt.Equals), string(raw))
+
+	// Legacy, unsigned tokens.
+	legacy := base64.RawURLEncoding.EncodeToString([]byte(`{"page":1,"size":10}`))
+
+	tests := []struct {
+		name          string
+		token         string
+		scope         []string
+		expectedError string
+	}{{
+		name:          "not base64",
+		token:         "not-base64!",
+		scope:         []string{"filter"},
+		expectedError: "invalid page token: malformed token",
+	}, {
+		name:          "legacy token",
+		token:         legacy,
+		scope:         []string{"filter"},
+		expectedError: "invalid page token: malformed token",
+	}, {
+		name:          "forged payload",
+		token:         base64.RawURLEncoding.EncodeToString([]byte(forged)),
+		scope:         []string{"filter"},
+		expectedError: "invalid page token: signature mismatch",
+	}, {
+		name:          "different scope",
+		token:         token,
+		scope:         []string{"other filter"},
+		expectedError: "invalid page token: token was issued for different query parameters",
+	}, {
+		name:          "missing scope",
+		token:         token,
+		expectedError: "invalid page token: token was issued for different query parameters",
+	}, {
+		name:          "shifted scope",
+		token:         token,
+		scope:         []string{"fil", "ter"},
+		expectedError: "invalid page token: token was issued for different query parameters",
+	}}
+
+	for _, test := range tests {
+		tt := test
+		c.Run(tt.name, func(c *qt.C) {
+			err := codec.Decode(tt.token, &testCursor{}, tt.scope...)
+			c.Assert(err, qt.ErrorMatches, tt.expectedError)
+			c.Assert(err, qt.ErrorAs, new(*InvalidPageTokenError))
+		})
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package resources
+
+import (
+	"errors"
+	"math"
+)
+
+// DefaultPageSize is the page size used by a Paginator when the request does
+// not specify one, if not configured otherwise.
+const DefaultPageSize = 10
+
+// DefaultMaxPageSize is the maximum page size of a Paginator, if not
+// configured otherwise.
+const DefaultMaxPageSize = 1000
+
+// PaginatorParams contains the configuration of a Paginator.
+type PaginatorParams struct {
+	// DefaultPageSize is the page size used when the request does not specify
+	// one. If zero, `DefaultPageSize` is used.
+	DefaultPageSize int
+
+	// MaxPageSize is the maximum page size. Larger requested sizes are capped
+	// to it. If zero, `DefaultMaxPageSize` is used. A negative value disables
+	// the limit.
+	//
+	// Note that requests with out-of-range sizes are already rejected by the
+	// library, according to the `PaginationPolicy` of the backend.
+	MaxPageSize int
+
+	// Tokens is the codec used to issue and verify next page tokens. It's
+	// required for the next page token pagination mechanism.
+	Tokens *PageTokenCodec
+}
+
+// Paginator returns pages of in-memory data, for backends that paginate
+// locally. See `Paginate`.
+type Paginator struct {
+	params PaginatorParams
+}
+
+// NewPaginator returns a new Paginator instance.
+func NewPaginator(params PaginatorParams) *Paginator {
+	if params.DefaultPageSize <= 0 {
+		params.DefaultPageSize = DefaultPageSize
+	}
+	if params.MaxPageSize == 0 {
+		params.MaxPageSize = DefaultMaxPageSize
+	}
+	return &Paginator{params: params}
+}
+
+// PageRequest contains the pagination parameters of a list request.
+type PageRequest struct {
+	Size          *PaginationSize
+	Page          *PaginationPage
+	NextToken     *PaginationNextToken
+	NextPageToken *PaginationNextTokenHeader
+
+	// PreferNextPageToken determines whether the next page token mechanism
+	// should be used even if the request does not contain a token (i.e., for
+	// the first page).
+	PreferNextPageToken bool
+
+	// Scope is the list of query parameters that affect the data (e.g., the
+	// filter and sort order), which the next page tokens are bound to.
+	Scope []string
+}
+
+// pageCursor is the cursor state encoded in next page tokens.
+type pageCursor struct {
+	Offset int `json:"offset"`
+	Size   int `json:"size"`
+}
+
+// Paginate returns a page of the given data.
+//
+// If the request contains a next page token (either via the query parameter or
+// the header), or `PreferNextPageToken` is set, the next page token mechanism
+// is used; otherwise, the page/size mechanism is used. In the former case, the
+// page size is the one requested along with the first page.
+//
+// The total number of entries is reported in the `Meta.Total` field of the
+// response.
+func Paginate[T any](p *Paginator, data []T, req PageRequest) (*PaginatedResponse[T], error) {
+	token := ""
+	if req.NextPageToken != nil {
+		token = *req.NextPageToken
+	} else if req.NextToken != nil {
+		token = *req.NextToken
+	}
+
+	cursor := pageCursor{Size: p.pageSize(req.Size)}
+	page := 0
+	tokenMode := req.PreferNextPageToken || token != ""
+	if tokenMode {
+		if p.params.Tokens == nil {
+			return nil, errors.New("paginator has no page token codec")
+		}
+		if token != "" {
+			if err := p.params.Tokens.Decode(token, &cursor, req.Scope...); err != nil {
+				return nil, err
+			}
+			cursor.Size = p.pageSize(&cursor.Size)
+		}
+	} else if req.Page != nil && *req.Page > 0 {
+		page = *req.Page
+		if page > math.MaxInt/cursor.Size {
+			// The offset is beyond any data.
+			cursor.Offset = math.MaxInt
+		} else {
+			cursor.Offset = page * cursor.Size
+		}
+	}
+
+	if data == nil {
+		data = []T{}
+	}
+	start := min(max(cursor.Offset, 0), len(data))
+	end := start + min(cursor.Size, len(data)-start)
+	total := len(data)
+
+	result := &PaginatedResponse[T]{
+		Data: data[start:end],
+		Meta: ResponseMeta{
+			Size:  end - start,
+			Total: &total,
+		},
+	}
+
+	if tokenMode {
+		if token != "" {
+			result.Meta.PageToken = &token
+		}
+		if end < len(data) {
+			next, err := p.params.Tokens.Encode(pageCursor{Offset: end, Size: cursor.Size}, req.Scope...)
+			if err != nil {
+				return nil, err
+			}
+			result.Next.PageToken = &next
+		}
+		return result, nil
+	}
+
+	result.Meta.Page = &page
+	if end < len(data) {
+		next := page + 1
+		result.Next.Page = &next
+	}
+	return result, nil
+}
+
+// pageSize returns the effective page size for the given requested size.
+func (p *Paginator) pageSize(size *PaginationSize) int {
+	result := p.params.DefaultPageSize
+	if size != nil && *size > 0 {
+		result = *size
+	}
+	if p.params.MaxPageSize > 0 {
+		result = min(result, p.params.MaxPageSize)
+	}
+	return result
+}
@@ -0,0 +1,218 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package resources
+
+import (
+	"math"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestPaginate_PageAndSize(t *testing.T) {
+	c := qt.New(t)
+
+	p := NewPaginator(PaginatorParams{})
+	data := []int{0, 1, 2, 3, 4}
+
+	size := 2
+	page := 1
+	result, err := Paginate(p, data, PageRequest{Size: &size, Page: &page})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Data, qt.DeepEquals, []int{2, 3})
+	c.Assert(*result.Meta.Page, qt.Equals, 1)
+	c.Assert(result.Meta.Size, qt.Equals, 2)
+	c.Assert(*result.Next.Page, qt.Equals, 2)
+
+	page = 2
+	result, err = Paginate(p, data, PageRequest{Size: &size, Page: &page})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Data, qt.DeepEquals, []int{4})
+	c.Assert(result.Next.Page, qt.IsNil)
+
+	page = 10
+	result, err = Paginate(p, data, PageRequest{Size: &size, Page: &page})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Data, qt.DeepEquals, []int{})
+	c.Assert(result.Next.Page, qt.IsNil)
+
+	// Default page size.
+	result, err = Paginate(p, data, PageRequest{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Data, qt.DeepEquals, data)
+	c.Assert(*result.Meta.Page, qt.Equals, 0)
+
+	// No data.
+	result, err = Paginate[int](p, nil, PageRequest{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Data, qt.DeepEquals, []int{})
+}
+
+func TestPaginate_NextPageToken(t *testing.T) {
+	c := qt.New(t)
+
+	codec, err := NewPageTokenCodec(PageTokenCodecParams{Keys: [][]byte{testKey1}})
+	c.Assert(err, qt.IsNil)
+	p := NewPaginator(PaginatorParams{Tokens: codec})
+
+	data := []int{0, 1, 2, 3, 4}
+
+	size := 2
+	result, err := Paginate(p, data, PageRequest{Size: &size, PreferNextPageToken: true})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Data, qt.DeepEquals, []int{0, 1})
+	c.Assert(result.Meta.PageToken, qt.IsNil)
+	c.Assert(result.Next.PageToken, qt.IsNotNil)
+
+	// The page size is carried by the token, via either the header or the
+	// query parameter.
+	result, err = Paginate(p, data, PageRequest{NextPageToken: result.Next.PageToken})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Data, qt.DeepEquals, []int{2, 3})
+	c.Assert(result.Meta.PageToken, qt.IsNotNil)
+
+	result, err = Paginate(p, data, PageRequest{NextToken: result.Next.PageToken})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Data, qt.DeepEquals, []int{4})
+	c.Assert(result.Next.PageToken, qt.IsNil)
+
+	for _, token := range []string{"not-base64!", "bm90LWpzb24", "eyJwYWdlIjoxLCJzaXplIjoxMH0"} {
+		_, err = Paginate(p, data, PageRequest{NextToken: &token})
+		c.Assert(err, qt.ErrorAs, new(*InvalidPageTokenError))
+	}
+}
+
+func TestPaginate_NextPageTokenScope(t *testing.T) {
+	c := qt.New(t)
+
+	codec, err := NewPageTokenCodec(PageTokenCodecParams{Keys: [][]byte{testKey1}})
+	c.Assert(err, qt.IsNil)
+	p := NewPaginator(PaginatorParams{Tokens: codec})
+
+	data := []int{0, 1, 2, 3, 4}
+
+	size := 2
+	result, err := Paginate(p, data, PageRequest{Size: &size, PreferNextPageToken: true, Scope: []string{"name eq foo"}})
+	c.Assert(err, qt.IsNil)
+
+	_, err = Paginate(p, data, PageRequest{NextToken: result.Next.PageToken, Scope: []string{"name eq foo"}})
+	c.Assert(err, qt.IsNil)
+
+	_, err = Paginate(p, data, PageRequest{NextToken: result.Next.PageToken, Scope: []string{"name eq bar"}})
+	c.Assert(err, qt.ErrorMatches, "invalid page token: token was issued for different query parameters")
+}
+
+func TestPaginate_Total(t *testing.T) {
+	c := qt.New(t)
+
+	codec, err := NewPageTokenCodec(PageTokenCodecParams{Keys: [][]byte{testKey1}})
+	c.Assert(err, qt.IsNil)
+	p := NewPaginator(PaginatorParams{Tokens: codec})
+
+	data := []int{0, 1, 2, 3, 4}
+	size := 2
+
+	result, err := Paginate(p, data, PageRequest{Size: &size})
+	c.Assert(err, qt.IsNil)
+	c.Assert(*result.Meta.Total, qt.Equals, 5)
+
+	result, err = Paginate(p, data, PageRequest{Size: &size, PreferNextPageToken: true})
+	c.Assert(err, qt.IsNil)
+	c.Assert(*result.Meta.Total, qt.Equals, 5)
+}
+
+func TestPaginate_PageSizeBounds(t *testing.T) {
+	c := qt.New(t)
+
+	p := NewPaginator(PaginatorParams{DefaultPageSize: 2, MaxPageSize: 3})
+	data := []int{0, 1, 2, 3, 4}
+
+	result, err := Paginate(p, data, PageRequest{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Data, qt.DeepEquals, []int{0, 1})
+
+	size := 100
+	page := 1
+	result, err = Paginate(p, data, PageRequest{Size: &size, Page: &page})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Data, qt.DeepEquals, []int{3, 4})
+	c.Assert(result.Next.Page, qt.IsNil)
+}
+
+func TestPaginate_NoPageTokenCodec(t *testing.T) {
+	c := qt.New(t)
+
+	p := NewPaginator(PaginatorParams{})
+	_, err := Paginate(p, []int{0}, PageRequest{PreferNextPageToken: true})
+	c.Assert(err, qt.ErrorMatches, "paginator has no page token codec")
+}
+
+func TestPaginate_HugeSizeAndPage(t *testing.T) {
+	c := qt.New(t)
+
+	codec, err := NewPageTokenCodec(PageTokenCodecParams{Keys: [][]byte{testKey1}})
+	c.Assert(err, qt.IsNil)
+	p := NewPaginator(PaginatorParams{MaxPageSize: -1, Tokens: codec})
+	data := []int{0, 1, 2}
+
+	size := math.MaxInt
+	page := 1
+	result, err := Paginate(p, data, PageRequest{Size: &size, Page: &page})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Data, qt.DeepEquals, []int{})
+	c.Assert(*result.Meta.Page, qt.Equals, 1)
+	c.Assert(result.Next.Page, qt.IsNil)
+
+	size = 2
+	page = math.MaxInt
+	result, err = Paginate(p, data, PageRequest{Size: &size, Page: &page})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Data, qt.DeepEquals, []int{})
+	c.Assert(*result.Meta.Page, qt.Equals, math.MaxInt)
+
+	size = math.MaxInt
+	page = 0
+	result, err = Paginate(p, data, PageRequest{Size: &size, Page: &page})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Data, qt.DeepEquals, data)
+
+	// Tokens carrying huge offsets and sizes.
+	token, err := codec.Encode(pageCursor{Offset: math.MaxInt - 1, Size: math.MaxInt})
+	c.Assert(err, qt.IsNil)
+	result, err = Paginate(p, data, PageRequest{NextToken: &token})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Data, qt.DeepEquals, []int{})
+	c.Assert(result.Next.PageToken, qt.IsNil)
+
+	token, err = codec.Encode(pageCursor{Offset: 1, Size: math.MaxInt})
+	c.Assert(err, qt.IsNil)
+	result, err = Paginate(p, data, PageRequest{NextToken: &token})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Data, qt.DeepEquals, []int{1, 2})
+}
+
+func TestPaginate_DefaultMaxPageSize(t *testing.T) {
+	c := qt.New(t)
+
+	p := NewPaginator(PaginatorParams{})
+	data := make([]int, DefaultMaxPageSize+1)
+
+	size := math.MaxInt
+	result, err := Paginate(p, data, PageRequest{Size: &size})
+	c.Assert(err, qt.IsNil)
+	c.Assert(result.Data, qt.HasLen, DefaultMaxPageSize)
+	c.Assert(*result.Next.Page, qt.Equals, 1)
+}
@@ -0,0 +1,67 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package resources
+
+// PatchItemOutcome defines the outcome of a single relationship patch item.
+type PatchItemOutcome string
+
+// Defines values for PatchItemOutcome.
+const (
+	// PatchItemOutcomeApplied means the relationship was added/removed.
+	PatchItemOutcomeApplied PatchItemOutcome = "applied"
+
+	// PatchItemOutcomeAlreadyPresent means the relationship to add already
+	// existed, so nothing changed.
+	PatchItemOutcomeAlreadyPresent PatchItemOutcome = "alreadyPresent"
+
+	// PatchItemOutcomeNotPresent means the relationship to remove did not
+	// exist, so nothing changed.
+	PatchItemOutcomeNotPresent PatchItemOutcome = "notPresent"
+
+	// PatchItemOutcomeFailed means the patch item could not be applied.
+	PatchItemOutcomeFailed PatchItemOutcome = "failed"
+)
+
+// PatchItemResult represents the outcome of a single relationship patch item,
+// as reported by a service.
+type PatchItemResult struct {
+	Outcome PatchItemOutcome
+
+	// Error describes the failure, if the outcome is `PatchItemOutcomeFailed`.
+	Error error
+}
+
+// PatchItemResponse represents the outcome of a single relationship patch
+// item, as returned to the client.
+type PatchItemResponse struct {
+	Outcome PatchItemOutcome `json:"outcome"`
+
+	// Status is the HTTP status code of the failure, if any.
+	Status *int `json:"status,omitempty"`
+
+	// Message describes the failure, if any.
+	Message *string `json:"message,omitempty"`
+}
+
+// PatchResponse defines the response body of the relationship PATCH endpoints
+// (e.g., `PATCH /groups/{id}/identities`), if the service reports per-item
+// results.
+type PatchResponse struct {
+	// Data holds the outcome of the patch items, in the same order.
+	Data []PatchItemResponse `json:"data"`
+
+	Status int `json:"status"`
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package resources
+
+// ResourceAccessReceiverType defines the type of the receiver of a
+// ResourceAccess entry.
+type ResourceAccessReceiverType string
+
+// Defines values for ResourceAccessReceiverType.
+const (
+	ResourceAccessReceiverTypeIdentity ResourceAccessReceiverType = "identity"
+	ResourceAccessReceiverTypeGroup    ResourceAccessReceiverType = "group"
+	ResourceAccessReceiverTypeRole     ResourceAccessReceiverType = "role"
+)
+
+// ResourceAccess represents an entitlement that a receiver (i.e., an identity,
+// a group, or a role) holds on a resource.
+type ResourceAccess struct {
+	ReceiverType ResourceAccessReceiverType `json:"receiverType"`
+	ReceiverId   string                     `json:"receiverId"`
+	Entitlement  string                     `json:"entitlement"`
+
+	// Via holds the intermediate entities that grant the entitlement to the
+	// receiver (e.g., group G, and then role R). An empty `Via` means the
+	// entitlement is granted directly.
+	Via []AccessPathStep `json:"via"`
+}
+
+// GetResourcesItemAccessParams defines parameters for GetResourcesItemAccess.
+type GetResourcesItemAccessParams struct {
+	// Size The number of records to return per response
+	Size *PaginationSize `form:"size,omitempty" json:"size,omitempty"`
+
+	// Page The record offset to return results from
+	Page *PaginationPage `form:"page,omitempty" json:"page,omitempty"`
+
+	// NextToken The continuation token to retrieve the next set of results
+	NextToken *PaginationNextToken `form:"nextToken,omitempty" json:"nextToken,omitempty"`
+
+	// NextPageToken The continuation token to retrieve the next set of results
+	NextPageToken *PaginationNextTokenHeader `json:"Next-Page-Token,omitempty"`
+}
+
+// GetResourceAccessResponse defines the response body of the
+// `GET /resources/{type}/{id}/access` endpoint.
+type GetResourceAccessResponse struct {
+	Links   ResponseLinks    `json:"_links"`
+	Meta    ResponseMeta     `json:"_meta"`
+	Data    []ResourceAccess `json:"data"`
+	Message string           `json:"message"`
+	Status  int              `json:"status"`
+}
@@ -0,0 +1,46 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"net/http"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// GetResources validates the query parameters of the GetResources method and delegates to the underlying handler.
+func (v handlerWithValidation) GetResources(w http.ResponseWriter, r *http.Request, params resources.GetResourcesParams) {
+	if err := checkNotEmpty("entityType", params.EntityType); err != nil {
+		writeErrorResponse(w, err)
+		return
+	}
+	if err := checkNotEmpty("entityName", params.EntityName); err != nil {
+		writeErrorResponse(w, err)
+		return
+	}
+	v.validatePagination(&params.Size, params.Page, params.NextToken, params.NextPageToken, w, r, func(w http.ResponseWriter, r *http.Request) {
+		v.validateSort(resourcesSortableFields, w, r, func(w http.ResponseWriter, r *http.Request) {
+			v.ServerInterface.GetResources(w, r, params)
+		})
+	})
+}
+
+// GetResourcesItemAccess validates the pagination parameters of the GetResourcesItemAccess method and delegates to the underlying handler.
+func (v handlerWithValidation) GetResourcesItemAccess(w http.ResponseWriter, r *http.Request, pType string, id string, params resources.GetResourcesItemAccessParams) {
+	v.validatePagination(&params.Size, params.Page, params.NextToken, params.NextPageToken, w, r, func(w http.ResponseWriter, r *http.Request) {
+		extensionsOf(v.ServerInterface).GetResourcesItemAccess(w, r, pType, id, params)
+	})
+}
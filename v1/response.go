@@ -17,6 +17,7 @@ package v1
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
@@ -26,13 +27,14 @@ import (
 // by the OpenAPI spec.
 func writeErrorResponse(w http.ResponseWriter, err error) {
 	resp := mapErrorResponse(err)
+	logInternalError(w, resp, err)
 
 	body, err := json.Marshal(resp)
 	if err != nil {
+		getLogger(w).Error("failed to marshal error response", "error", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		if _, err := w.Write([]byte("unexpected marshalling error")); err != nil {
-			// TODO(CSS-7642): we should log the error.
-			return
+			getLogger(w).Error("failed to write response body", "error", err)
 		}
 		return
 	}
@@ -40,9 +42,17 @@ func writeErrorResponse(w http.ResponseWriter, err error) {
 	setJSONContentTypeHeader(w)
 	w.WriteHeader(int(resp.Status))
 	if _, err := w.Write(body); err != nil {
-		// TODO(CSS-7642): we should log the error.
+		getLogger(w).Error("failed to write response body", "error", err)
+	}
+}
+
+// logInternalError logs the given error if it's mapped to a server-side error
+// response (i.e., 5xx status codes), which otherwise would go unnoticed.
+func logInternalError(w http.ResponseWriter, resp *resources.Response, err error) {
+	if resp.Status < http.StatusInternalServerError || err == nil {
 		return
 	}
+	getLogger(w).Error("request failed with server error", "status", resp.Status, "error", err)
 }
 
 // mapErrorResponse returns a Response instance filled with the given error.
@@ -56,6 +66,11 @@ func mapErrorResponse(err error) *resources.Response {
 		asErrorWithStatus = e
 	} else if e := mapHandlerBadRequestError(err); e != nil {
 		asErrorWithStatus = e
+	} else if e := (*resources.InvalidPageTokenError)(nil); errors.As(err, &e) {
+		asErrorWithStatus = &errorWithStatus{
+			status:  http.StatusBadRequest,
+			message: e.Error(),
+		}
 	} else {
 		asErrorWithStatus = &errorWithStatus{
 			status:  http.StatusInternalServerError,
@@ -73,6 +88,7 @@ func mapErrorResponse(err error) *resources.Response {
 func writeResponse(w http.ResponseWriter, status int, responseObject interface{}) {
 	data, err := json.Marshal(responseObject)
 	if err != nil {
+		// The error is logged by writeErrorResponse, as it's mapped to a 500.
 		writeErrorResponse(w, err)
 		return
 	}
@@ -80,8 +96,7 @@ func writeResponse(w http.ResponseWriter, status int, responseObject interface{}
 	setJSONContentTypeHeader(w)
 	w.WriteHeader(status)
 	if _, err := w.Write(data); err != nil {
-		// TODO(CSS-7642): we should log the error.
-		return
+		getLogger(w).Error("failed to write response body", "error", err)
 	}
 }
 
@@ -91,21 +106,28 @@ func writeResponse(w http.ResponseWriter, status int, responseObject interface{}
 //
 // This method should never return nil response.
 func mapServiceErrorResponse(mapper ErrorResponseMapper, err error) *resources.Response {
-	var response *resources.Response
-	if mapper != nil {
-		response = mapper.MapError(err)
-	}
+	response, _ := mapServiceError(mapper, err)
+	return response
+}
 
-	if response == nil {
-		response = mapErrorResponse(err)
+// mapServiceError works the same as mapServiceErrorResponse, but it also
+// reports whether the error is mapped by the given mapper (true), or by the
+// default mapping strategy (false).
+func mapServiceError(mapper ErrorResponseMapper, err error) (*resources.Response, bool) {
+	if mapper != nil {
+		if response := mapper.MapError(err); response != nil {
+			return response, true
+		}
 	}
-	return response
+	return mapErrorResponse(err), false
 }
 
 // writeServiceErrorResponse is a helper method that maps errors thrown by
 // services and writes them to the HTTP response stream.
 func writeServiceErrorResponse(w http.ResponseWriter, mapper ErrorResponseMapper, err error) {
-	response := mapServiceErrorResponse(mapper, err)
+	response, mapped := mapServiceError(mapper, err)
+	logInternalError(w, response, err)
+	traceServiceError(w, response, mapped, err)
 	writeResponse(w, response.Status, response)
 }
 
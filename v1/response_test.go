@@ -17,6 +17,7 @@ package v1
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"testing"
 
@@ -92,6 +93,13 @@ func TestMapErrorResponse(t *testing.T) {
 			Status:  http.StatusUnauthorized,
 			Message: "Unauthorized: authorization failed: forbidden",
 		},
+	}, {
+		name: "service error: ForbiddenError",
+		arg:  NewForbiddenError("GetGroups"),
+		expected: &resources.Response{
+			Status:  http.StatusForbidden,
+			Message: "Forbidden: access denied: GetGroups",
+		},
 	}, {
 		name: "service error: NotFoundError",
 		arg:  NewNotFoundError("something not found"),
@@ -106,6 +114,41 @@ func TestMapErrorResponse(t *testing.T) {
 			Status:  http.StatusBadRequest,
 			Message: "Bad Request: request is not valid",
 		},
+	}, {
+		name: "query parameter validation error",
+		arg:  NewQueryParameterValidationError("page", "must not be negative"),
+		expected: &resources.Response{
+			Status:  http.StatusBadRequest,
+			Message: `Bad Request: invalid query parameter "page": must not be negative`,
+		},
+	}, {
+		name: "header validation error",
+		arg:  NewHeaderValidationError("Next-Page-Token", "does not match"),
+		expected: &resources.Response{
+			Status:  http.StatusBadRequest,
+			Message: `Bad Request: invalid header "Next-Page-Token": does not match`,
+		},
+	}, {
+		name: "conflict error",
+		arg:  NewConflictError("request in progress"),
+		expected: &resources.Response{
+			Status:  http.StatusConflict,
+			Message: "Conflict: request in progress",
+		},
+	}, {
+		name: "unprocessable entity error",
+		arg:  NewUnprocessableEntityError("key reused"),
+		expected: &resources.Response{
+			Status:  http.StatusUnprocessableEntity,
+			Message: "Unprocessable Entity: key reused",
+		},
+	}, {
+		name: "invalid page token error",
+		arg:  fmt.Errorf("cannot list groups: %w", &resources.InvalidPageTokenError{Reason: "token has expired"}),
+		expected: &resources.Response{
+			Status:  http.StatusBadRequest,
+			Message: "Bad Request: invalid page token: token has expired",
+		},
 	}, {
 		name: "unknown error",
 		arg:  errors.New("unexpected error"),
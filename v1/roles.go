@@ -73,6 +73,10 @@ func (h handler) PostRoles(w http.ResponseWriter, req *http.Request) {
 func (h handler) DeleteRolesItem(w http.ResponseWriter, req *http.Request, id string) {
 	ctx := req.Context()
 
+	if !h.rolesTagger().checkPreconditions(w, req, id) {
+		return
+	}
+
 	_, err := h.Roles.DeleteRole(ctx, id)
 	if err != nil {
 		writeServiceErrorResponse(w, h.RolesErrorMapper, err)
@@ -93,6 +97,7 @@ func (h handler) GetRolesItem(w http.ResponseWriter, req *http.Request, id strin
 		return
 	}
 
+	h.rolesTagger().setTag(w, req, id, role)
 	writeResponse(w, http.StatusOK, role)
 }
 
@@ -113,12 +118,17 @@ func (h handler) PutRolesItem(w http.ResponseWriter, req *http.Request, id strin
 		return
 	}
 
+	if !h.rolesTagger().checkPreconditions(w, req, id) {
+		return
+	}
+
 	result, err := h.Roles.UpdateRole(ctx, role)
 	if err != nil {
 		writeServiceErrorResponse(w, h.RolesErrorMapper, err)
 		return
 	}
 
+	h.rolesTagger().setTag(w, req, id, result)
 	writeResponse(w, http.StatusOK, result)
 }
 
@@ -160,6 +170,16 @@ func (h handler) PatchRolesItemEntitlements(w http.ResponseWriter, req *http.Req
 		return
 	}
 
+	if h.RolesPatchReporter != nil {
+		results, err := h.RolesPatchReporter.PatchRoleEntitlementsWithResults(ctx, id, roleEntitlements.Patches)
+		if err != nil {
+			writeServiceErrorResponse(w, h.RolesErrorMapper, err)
+			return
+		}
+		writePatchResults(w, h.RolesErrorMapper, len(roleEntitlements.Patches), results)
+		return
+	}
+
 	_, err = h.Roles.PatchRoleEntitlements(ctx, id, roleEntitlements.Patches)
 	if err != nil {
 		writeServiceErrorResponse(w, h.RolesErrorMapper, err)
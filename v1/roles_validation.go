@@ -21,6 +21,17 @@ import (
 	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
 )
 
+// GetRoles validates the pagination parameters, filter and sort order of the GetRoles method and delegates to the underlying handler.
+func (v handlerWithValidation) GetRoles(w http.ResponseWriter, r *http.Request, params resources.GetRolesParams) {
+	v.validatePagination(&params.Size, params.Page, params.NextToken, params.NextPageToken, w, r, func(w http.ResponseWriter, r *http.Request) {
+		v.validateFilter(params.Filter, resources.Role{}, w, r, func(w http.ResponseWriter, r *http.Request) {
+			v.validateSort(rolesSortableFields, w, r, func(w http.ResponseWriter, r *http.Request) {
+				v.ServerInterface.GetRoles(w, r, params)
+			})
+		})
+	})
+}
+
 // PostRoles validates request body for the PostRoles method and delegates to the underlying handler.
 func (v handlerWithValidation) PostRoles(w http.ResponseWriter, r *http.Request) {
 	body := &resources.Role{}
@@ -41,10 +52,25 @@ func (v handlerWithValidation) PutRolesItem(w http.ResponseWriter, r *http.Reque
 	})
 }
 
+// GetRolesItemEntitlements validates the pagination parameters of the GetRolesItemEntitlements method and delegates to the underlying handler.
+func (v handlerWithValidation) GetRolesItemEntitlements(w http.ResponseWriter, r *http.Request, id string, params resources.GetRolesItemEntitlementsParams) {
+	v.validatePagination(&params.Size, params.Page, params.NextToken, params.NextPageToken, w, r, func(w http.ResponseWriter, r *http.Request) {
+		v.ServerInterface.GetRolesItemEntitlements(w, r, id, params)
+	})
+}
+
 // PatchRolesItemEntitlements validates request body for the PatchRolesItemEntitlements method and delegates to the underlying handler.
 func (v handlerWithValidation) PatchRolesItemEntitlements(w http.ResponseWriter, r *http.Request, id string) {
 	body := &resources.RoleEntitlementsPatchRequestBody{}
 	v.validateRequestBody(body, w, r, func(w http.ResponseWriter, r *http.Request) {
-		v.ServerInterface.PatchRolesItemEntitlements(w, r, id)
+		entitlements := make([]addedEntitlement, 0, len(body.Patches))
+		for i, p := range body.Patches {
+			if p.Op == resources.Add {
+				entitlements = append(entitlements, addedEntitlement{index: i, entitlement: p.Entitlement})
+			}
+		}
+		v.validateEntitlementPatches(entitlementReceiverTypeRole, entitlements, w, r, func(w http.ResponseWriter, r *http.Request) {
+			v.ServerInterface.PatchRolesItemEntitlements(w, r, id)
+		})
 	})
 }
@@ -219,7 +219,7 @@ func TestHandlerWithValidation_Roles(t *testing.T) {
 				tt.setupHandlerMock(mockHandler)
 			}
 
-			sut := newHandlerWithValidation(mockHandler)
+			sut := newHandlerWithValidation(mockHandler, RequestDecodingPolicy{}, PaginationPolicy{})
 
 			var req *http.Request
 			if tt.requestBody != nil {
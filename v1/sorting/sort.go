@@ -0,0 +1,149 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package sorting
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Sort sorts the given entities in place, according to the given order.
+// The sort is stable, so entities with equal values of all the fields keep
+// their original order.
+//
+// Only string, integer and boolean fields (or pointers to them) are
+// supported; nil pointers sort before any other value. An error is returned
+// if the order refers to a field that does not exist or is not supported.
+func Sort[T any](entities []T, order Order) error {
+	if len(order) == 0 {
+		return nil
+	}
+
+	var zero T
+	t := reflect.TypeOf(zero)
+	paths := make([][]int, 0, len(order))
+	for _, f := range order {
+		path, err := fieldPath(t, f.Name)
+		if err != nil {
+			return err
+		}
+		paths = append(paths, path)
+	}
+
+	slices.SortStableFunc(entities, func(a, b T) int {
+		va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+		for i, f := range order {
+			c := compare(fieldValue(va, paths[i]), fieldValue(vb, paths[i]))
+			if f.Descending {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+	return nil
+}
+
+// fieldPath returns the indices of the struct fields along the given
+// dot-separated path of JSON names.
+func fieldPath(t reflect.Type, name string) ([]int, error) {
+	path := []int{}
+	for _, part := range strings.Split(name, ".") {
+		t = indirect(t)
+		if t.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("unknown sort field %q", name)
+		}
+		index := -1
+		for i := 0; i < t.NumField(); i++ {
+			if tag, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ","); tag == part {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return nil, fmt.Errorf("unknown sort field %q", name)
+		}
+		path = append(path, index)
+		t = t.Field(index).Type
+	}
+
+	switch indirect(t).Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return path, nil
+	}
+	return nil, fmt.Errorf("unsupported sort field %q", name)
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+	return t
+}
+
+// fieldValue returns the value of the field at the given path, or an invalid
+// value if any pointer along the path is nil.
+func fieldValue(v reflect.Value, path []int) reflect.Value {
+	for _, i := range path {
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// compare compares two values of the same kind. Invalid values (i.e., nil
+// pointers) are less than any other value.
+func compare(a, b reflect.Value) int {
+	if !a.IsValid() || !b.IsValid() {
+		return cmp.Compare(boolToInt(a.IsValid()), boolToInt(b.IsValid()))
+	}
+	switch a.Kind() {
+	case reflect.String:
+		return cmp.Compare(a.String(), b.String())
+	case reflect.Bool:
+		return cmp.Compare(boolToInt(a.Bool()), boolToInt(b.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cmp.Compare(a.Uint(), b.Uint())
+	}
+	return 0
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,141 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package sorting
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+func TestSort(t *testing.T) {
+	c := qt.New(t)
+
+	jane, john, doe, smith := "Jane", "John", "Doe", "Smith"
+	identities := []resources.Identity{
+		{Email: "c@example.com", FirstName: &john, LastName: &doe},
+		{Email: "a@example.com", FirstName: &jane, LastName: &smith},
+		{Email: "b@example.com", FirstName: &jane},
+		{Email: "d@example.com", FirstName: &jane, LastName: &doe},
+	}
+
+	emails := func(identities []resources.Identity) []string {
+		result := []string{}
+		for _, i := range identities {
+			result = append(result, i.Email)
+		}
+		return result
+	}
+
+	tests := []struct {
+		sort     string
+		expected []string
+	}{
+		{sort: "", expected: []string{"c@example.com", "a@example.com", "b@example.com", "d@example.com"}},
+		{sort: "email", expected: []string{"a@example.com", "b@example.com", "c@example.com", "d@example.com"}},
+		{sort: "-email", expected: []string{"d@example.com", "c@example.com", "b@example.com", "a@example.com"}},
+		{sort: "firstName", expected: []string{"a@example.com", "b@example.com", "d@example.com", "c@example.com"}},
+		{sort: "lastName", expected: []string{"b@example.com", "c@example.com", "d@example.com", "a@example.com"}},
+		{sort: "firstName,-lastName", expected: []string{"a@example.com", "d@example.com", "b@example.com", "c@example.com"}},
+	}
+
+	for _, test := range tests {
+		tt := test
+		c.Run(tt.sort, func(c *qt.C) {
+			order, err := Parse(tt.sort)
+			c.Assert(err, qt.IsNil)
+
+			sorted := append([]resources.Identity{}, identities...)
+			err = Sort(sorted, order)
+			c.Assert(err, qt.IsNil)
+			c.Assert(emails(sorted), qt.DeepEquals, tt.expected)
+		})
+	}
+}
+
+func TestSortNestedFields(t *testing.T) {
+	c := qt.New(t)
+
+	data := []resources.Resource{
+		{Entity: resources.Entity{Id: "1", Name: "foo", Type: "model"}, Parent: &resources.Entity{Name: "b"}},
+		{Entity: resources.Entity{Id: "2", Name: "bar", Type: "model"}},
+		{Entity: resources.Entity{Id: "3", Name: "baz", Type: "controller"}, Parent: &resources.Entity{Name: "a"}},
+	}
+
+	ids := func(data []resources.Resource) []string {
+		result := []string{}
+		for _, r := range data {
+			result = append(result, r.Entity.Id)
+		}
+		return result
+	}
+
+	err := Sort(data, Order{{Name: "entity.type", Descending: true}, {Name: "entity.name"}})
+	c.Assert(err, qt.IsNil)
+	c.Assert(ids(data), qt.DeepEquals, []string{"2", "1", "3"})
+
+	err = Sort(data, Order{{Name: "parent.name"}})
+	c.Assert(err, qt.IsNil)
+	c.Assert(ids(data), qt.DeepEquals, []string{"2", "3", "1"})
+}
+
+func TestSortPointerFields(t *testing.T) {
+	c := qt.New(t)
+
+	name := func(s string) *string { return &s }
+	count := func(n int) *int { return &n }
+	enabled := func(b bool) *bool { return &b }
+	idps := []resources.IdentityProvider{
+		{Name: name("a"), IdentityCount: count(3), Enabled: enabled(true)},
+		{Name: name("b"), IdentityCount: count(10)},
+		{Name: name("c"), IdentityCount: count(1), Enabled: enabled(false)},
+	}
+
+	names := func(idps []resources.IdentityProvider) []string {
+		result := []string{}
+		for _, idp := range idps {
+			result = append(result, *idp.Name)
+		}
+		return result
+	}
+
+	err := Sort(idps, Order{{Name: "identityCount", Descending: true}})
+	c.Assert(err, qt.IsNil)
+	c.Assert(names(idps), qt.DeepEquals, []string{"b", "a", "c"})
+
+	err = Sort(idps, Order{{Name: "enabled"}})
+	c.Assert(err, qt.IsNil)
+	c.Assert(names(idps), qt.DeepEquals, []string{"b", "c", "a"})
+}
+
+func TestSortErrors(t *testing.T) {
+	c := qt.New(t)
+
+	groups := []resources.Group{{Name: "b"}, {Name: "a"}}
+
+	err := Sort(groups, Order{{Name: "title"}})
+	c.Assert(err, qt.ErrorMatches, `unknown sort field "title"`)
+
+	err = Sort(groups, Order{{Name: "name.first"}})
+	c.Assert(err, qt.ErrorMatches, `unknown sort field "name.first"`)
+
+	err = Sort([]resources.Resource{}, Order{{Name: "entity"}})
+	c.Assert(err, qt.ErrorMatches, `unsupported sort field "entity"`)
+
+	c.Assert(groups, qt.DeepEquals, []resources.Group{{Name: "b"}, {Name: "a"}})
+}
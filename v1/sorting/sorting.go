@@ -0,0 +1,124 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package sorting implements the `sort` query parameter of list endpoints
+// (e.g., `GET /identities?sort=lastName,-joined`), which is a comma-separated
+// list of field names, each optionally prefixed with `-` for descending (or
+// `+` for ascending) order. Fields are referred to by their JSON names, and
+// fields of nested objects by dot-separated paths (e.g., `entity.name`).
+//
+// The library parses and validates the parameter, and passes it to the
+// services via the request context (see `FromContext`), since the `params`
+// arguments of the service methods are generated from the OpenAPI spec, which
+// does not define the parameter. Hence, code that calls the services directly
+// (i.e., not via the HTTP handlers) should use `NewContext` to request a sort
+// order. Backends that paginate locally can use `Sort` to sort in-memory
+// slices accordingly.
+//
+// Services implementing the `List*` methods (e.g., `GroupsService.ListGroups`)
+// are expected to return pages sorted by the order returned by `FromContext`,
+// where a nil order means the service's default order. The order only refers
+// to the sortable fields documented on each method, as enforced by the
+// handlers via `Order.Validate`. Since the order is not part of the method
+// signatures, the compiler cannot catch a service that ignores it; backends
+// that cannot sort should reject a non-nil order with a validation error
+// instead.
+package sorting
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Field represents a field to sort by.
+type Field struct {
+	// Name is the JSON name (or dot-separated path) of the field.
+	Name string
+	// Descending determines whether the entities should be sorted in
+	// descending order of the field.
+	Descending bool
+}
+
+// Order represents a sort order, as a list of fields by priority (i.e., the
+// second field is used to sort entities with equal values of the first field,
+// and so on).
+type Order []Field
+
+// String returns the order in the `sort` query parameter format.
+func (o Order) String() string {
+	parts := make([]string, 0, len(o))
+	for _, f := range o {
+		if f.Descending {
+			parts = append(parts, "-"+f.Name)
+		} else {
+			parts = append(parts, f.Name)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// Parse parses the given `sort` query parameter. It returns a nil order if the
+// parameter is empty.
+func Parse(s string) (Order, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	var order Order
+	for _, part := range strings.Split(s, ",") {
+		f := Field{Name: strings.TrimSpace(part)}
+		if name, ok := strings.CutPrefix(f.Name, "-"); ok {
+			f.Name, f.Descending = name, true
+		} else if name, ok := strings.CutPrefix(f.Name, "+"); ok {
+			f.Name = name
+		}
+		if f.Name == "" || strings.ContainsAny(f.Name, " \t+-") {
+			return nil, fmt.Errorf("invalid sort field %q", strings.TrimSpace(part))
+		}
+		if slices.ContainsFunc(order, func(x Field) bool { return x.Name == f.Name }) {
+			return nil, fmt.Errorf("duplicate sort field %q", f.Name)
+		}
+		order = append(order, f)
+	}
+	return order, nil
+}
+
+// Validate checks that the order only refers to the given fields.
+func (o Order) Validate(allowed ...string) error {
+	for _, f := range o {
+		if !slices.Contains(allowed, f.Name) {
+			return fmt.Errorf("cannot sort by %q (sortable fields: %s)", f.Name, strings.Join(allowed, ", "))
+		}
+	}
+	return nil
+}
+
+// contextKey is the key of the sort order in the request context.
+type contextKey struct{}
+
+// NewContext returns a new context that carries the given sort order.
+func NewContext(ctx context.Context, order Order) context.Context {
+	return context.WithValue(ctx, contextKey{}, order)
+}
+
+// FromContext returns the sort order of the request, if any. A nil order
+// means the request did not specify one, in which case the services should
+// use their default order.
+func FromContext(ctx context.Context) Order {
+	order, _ := ctx.Value(contextKey{}).(Order)
+	return order
+}
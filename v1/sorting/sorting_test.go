@@ -0,0 +1,85 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package sorting
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParse(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		sort          string
+		expected      Order
+		expectedError string
+	}{
+		{sort: "", expected: nil},
+		{sort: "  ", expected: nil},
+		{sort: "name", expected: Order{{Name: "name"}}},
+		{sort: "name,-email", expected: Order{{Name: "name"}, {Name: "email", Descending: true}}},
+		{sort: " +name , -entity.type ", expected: Order{{Name: "name"}, {Name: "entity.type", Descending: true}}},
+		{sort: "name,", expectedError: `invalid sort field ""`},
+		{sort: "-", expectedError: `invalid sort field "-"`},
+		{sort: "--name", expectedError: `invalid sort field "--name"`},
+		{sort: "first name", expectedError: `invalid sort field "first name"`},
+		{sort: "name,-name", expectedError: `duplicate sort field "name"`},
+	}
+
+	for _, test := range tests {
+		tt := test
+		c.Run(tt.sort, func(c *qt.C) {
+			order, err := Parse(tt.sort)
+			if tt.expectedError != "" {
+				c.Assert(err, qt.ErrorMatches, tt.expectedError)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+			c.Assert(order, qt.DeepEquals, tt.expected)
+		})
+	}
+}
+
+func TestOrderString(t *testing.T) {
+	c := qt.New(t)
+
+	order, err := Parse("+name, -email")
+	c.Assert(err, qt.IsNil)
+	c.Assert(order.String(), qt.Equals, "name,-email")
+	c.Assert(Order(nil).String(), qt.Equals, "")
+}
+
+func TestOrderValidate(t *testing.T) {
+	c := qt.New(t)
+
+	order := Order{{Name: "name"}, {Name: "email", Descending: true}}
+	c.Assert(order.Validate("email", "name"), qt.IsNil)
+	c.Assert(order.Validate("name"), qt.ErrorMatches, `cannot sort by "email" \(sortable fields: name\)`)
+	c.Assert(Order(nil).Validate(), qt.IsNil)
+}
+
+func TestContext(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	c.Assert(FromContext(ctx), qt.IsNil)
+
+	order := Order{{Name: "name"}}
+	c.Assert(FromContext(NewContext(ctx, order)), qt.DeepEquals, order)
+}
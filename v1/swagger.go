@@ -16,28 +16,54 @@
 package v1
 
 import (
-	"log"
+	"bytes"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
 )
 
-// SwaggerJson Returns the OpenAPI spec as a JSON file.
-// (GET /swagger.json)
-func (h handler) SwaggerJson(w http.ResponseWriter, req *http.Request) {
+// swaggerJSON returns the OpenAPI spec marshalled as JSON, along with its
+// `ETag`. Since the spec is embedded, it's only computed once.
+var swaggerJSON = sync.OnceValues(func() (swaggerContent, error) {
 	swagger, err := resources.GetSwagger()
 	if err != nil {
-		writeErrorResponse(w, NewUnknownError("cannot retrieve swagger data"))
-		return
+		return swaggerContent{}, NewUnknownError("cannot retrieve swagger data")
 	}
 
 	body, err := swagger.MarshalJSON()
 	if err != nil {
-		writeErrorResponse(w, NewUnknownError("cannot marshal spec as JSON"))
+		return swaggerContent{}, NewUnknownError("cannot marshal spec as JSON")
+	}
+	return swaggerContent{body: body, tag: contentEntityTag(body)}, nil
+})
+
+// swaggerModTime is used as the `Last-Modified` time of the OpenAPI spec. The
+// spec is embedded in the binary, so it can only change across restarts.
+var swaggerModTime = time.Now()
+
+// swaggerContent represents the OpenAPI spec, marshalled as JSON.
+type swaggerContent struct {
+	body []byte
+	tag  string
+}
+
+// SwaggerJson Returns the OpenAPI spec as a JSON file.
+// (GET /swagger.json)
+func (h handler) SwaggerJson(w http.ResponseWriter, req *http.Request) {
+	content, err := swaggerJSON()
+	if err != nil {
+		writeErrorResponse(w, err)
 		return
 	}
 
-	if _, err := w.Write(body); err != nil {
-		log.Printf("failed to write response body: %v", err)
+	w.Header().Set("ETag", content.tag)
+	if h.CacheControl.SwaggerJson != "" {
+		w.Header().Set("Cache-Control", h.CacheControl.SwaggerJson)
 	}
+
+	// This takes care of the conditional request headers (i.e., `If-None-Match`
+	// and `If-Modified-Since`), and the `Content-Type` header.
+	http.ServeContent(w, req, "swagger.json", swaggerModTime, bytes.NewReader(content.body))
 }
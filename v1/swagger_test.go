@@ -45,3 +45,28 @@ func TestHandler_SwaggerJson(t *testing.T) {
 	c.Assert(err, qt.IsNil)
 	c.Assert(len(parsedSpec) > 0, qt.IsTrue)
 }
+
+func TestHandler_SwaggerJson_IfModifiedSince(t *testing.T) {
+	c := qt.New(t)
+
+	sut := handler{}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/swagger.json", nil)
+	sut.SwaggerJson(w, req)
+
+	result := w.Result()
+	defer result.Body.Close()
+	c.Assert(result.StatusCode, qt.Equals, http.StatusOK)
+	lastModified := result.Header.Get("Last-Modified")
+	c.Assert(lastModified, qt.Not(qt.Equals), "")
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/swagger.json", nil)
+	req.Header.Set("If-Modified-Since", lastModified)
+	sut.SwaggerJson(w, req)
+
+	result = w.Result()
+	defer result.Body.Close()
+	c.Assert(result.StatusCode, qt.Equals, http.StatusNotModified)
+}
@@ -0,0 +1,145 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// tracerName is the name of the tracer (i.e., the instrumentation scope) used
+// by this library.
+const tracerName = "github.com/canonical/rebac-admin-ui-handlers/v1"
+
+// Span attribute keys.
+const (
+	// operationIdAttribute is the OpenAPI operation ID (e.g., `GetGroups`).
+	operationIdAttribute = attribute.Key("rebac.operation_id")
+
+	// entityIdAttribute is the ID of the target entity (e.g., the `id` path
+	// parameter).
+	entityIdAttribute = attribute.Key("rebac.entity_id")
+
+	// pageSizeAttribute is the requested page size.
+	pageSizeAttribute = attribute.Key("rebac.page_size")
+
+	// errorStatusAttribute is the HTTP status code that a service error is
+	// mapped to.
+	errorStatusAttribute = attribute.Key("rebac.error.status")
+
+	// errorMappedAttribute indicates whether a service error is mapped by the
+	// user-provided error mapper (true), or by the default mapping (false).
+	errorMappedAttribute = attribute.Key("rebac.error.mapped")
+)
+
+// tracingResponseWriter is a wrapper around http.ResponseWriter that carries
+// the request span and records the written status code.
+type tracingResponseWriter struct {
+	statusResponseWriter
+	span trace.Span
+}
+
+// getSpan returns the request span associated with the given response writer,
+// or nil if there's none.
+func getSpan(w http.ResponseWriter) trace.Span {
+	for {
+		switch v := w.(type) {
+		case *tracingResponseWriter:
+			return v.span
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = v.Unwrap()
+		default:
+			return nil
+		}
+	}
+}
+
+// tracingHandler wraps the given handler to start a span for each request. The
+// span is named after the OpenAPI operation ID of the matched route.
+func (b *ReBACAdminBackend) tracingHandler(baseURL string, next http.Handler) http.Handler {
+	tracer := b.params.TracerProvider.Tracer(tracerName)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r, rctx := withRouteContext(r)
+		ctx, span := tracer.Start(r.Context(), "HTTP "+r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.HTTPRequestMethodKey.String(r.Method)),
+		)
+		defer span.End()
+
+		tw := &tracingResponseWriter{
+			statusResponseWriter: statusResponseWriter{ResponseWriter: w},
+			span:                 span,
+		}
+		next.ServeHTTP(tw, r.WithContext(ctx))
+
+		status := tw.responseStatus()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+		if pattern := rctx.RoutePattern(); pattern != "" {
+			span.SetAttributes(semconv.HTTPRoute(pattern))
+		}
+		if operationId := getOperationId(rctx, r.Method, baseURL); operationId != "" {
+			span.SetName(operationId)
+			span.SetAttributes(operationIdAttribute.String(operationId))
+		}
+	})
+}
+
+// traceServiceError records the given service error, and the outcome of its
+// mapping to an HTTP response, on the request span (if any).
+func traceServiceError(w http.ResponseWriter, response *resources.Response, mapped bool, err error) {
+	span := getSpan(w)
+	if span == nil || err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetAttributes(
+		errorStatusAttribute.Int(response.Status),
+		errorMappedAttribute.Bool(mapped),
+	)
+}
+
+// pageSizeAttributes returns the span attributes for the given page size, if
+// provided.
+func pageSizeAttributes(size *resources.PaginationSize) []attribute.KeyValue {
+	if size == nil {
+		return nil
+	}
+	return []attribute.KeyValue{pageSizeAttribute.Int(*size)}
+}
+
+// startServiceSpan starts a new span for a call to a backend method.
+func startServiceSpan(ctx context.Context, tracer trace.Tracer, method string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, method, trace.WithAttributes(attributes...))
+}
+
+// endServiceSpan ends the given span, and records the given error (if any).
+func endServiceSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
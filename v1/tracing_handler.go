@@ -0,0 +1,362 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// handlerWithTracing decorates a given handler with tracing logic. Each call is
+// wrapped in a span, named after the handler stage (e.g., \`validator\`), so that
+// the time spent in each stage of the handler chain can be observed.
+type handlerWithTracing struct {
+	// Wrapped/decorated handler
+	resources.ServerInterface
+
+	tracer trace.Tracer
+	stage  string
+}
+
+// newHandlerWithTracing returns a new instance of the handlerWithTracing struct.
+func newHandlerWithTracing(handler resources.ServerInterface, tracer trace.Tracer, stage string) *handlerWithTracing {
+	return &handlerWithTracing{
+		ServerInterface: handler,
+		tracer:          tracer,
+		stage:           stage,
+	}
+}
+
+// trace is a helper method to avoid repetition. It starts a new span for the
+// handler stage and delegates to the provided callback, with the span attached
+// to the request context.
+func (h handlerWithTracing) trace(w http.ResponseWriter, r *http.Request, operationId string, attributes []attribute.KeyValue, f func(w http.ResponseWriter, r *http.Request)) {
+	ctx, span := h.tracer.Start(r.Context(), h.stage, trace.WithAttributes(operationIdAttribute.String(operationId)), trace.WithAttributes(attributes...))
+	defer span.End()
+	f(w, r.WithContext(ctx))
+}
+
+// GetIdentityProviders traces the request and delegates the call to the wrapped handler's `GetIdentityProviders` method.
+func (h handlerWithTracing) GetIdentityProviders(w http.ResponseWriter, r *http.Request, params resources.GetIdentityProvidersParams) {
+	h.trace(w, r, "GetIdentityProviders", pageSizeAttributes(params.Size), func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetIdentityProviders(w, r, params)
+	})
+}
+
+// PostIdentityProviders traces the request and delegates the call to the wrapped handler's `PostIdentityProviders` method.
+func (h handlerWithTracing) PostIdentityProviders(w http.ResponseWriter, r *http.Request) {
+	h.trace(w, r, "PostIdentityProviders", nil, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PostIdentityProviders(w, r)
+	})
+}
+
+// GetAvailableIdentityProviders traces the request and delegates the call to the wrapped handler's `GetAvailableIdentityProviders` method.
+func (h handlerWithTracing) GetAvailableIdentityProviders(w http.ResponseWriter, r *http.Request, params resources.GetAvailableIdentityProvidersParams) {
+	h.trace(w, r, "GetAvailableIdentityProviders", pageSizeAttributes(params.Size), func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetAvailableIdentityProviders(w, r, params)
+	})
+}
+
+// DeleteIdentityProvidersItem traces the request and delegates the call to the wrapped handler's `DeleteIdentityProvidersItem` method.
+func (h handlerWithTracing) DeleteIdentityProvidersItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.trace(w, r, "DeleteIdentityProvidersItem", []attribute.KeyValue{entityIdAttribute.String(id)}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.DeleteIdentityProvidersItem(w, r, id)
+	})
+}
+
+// GetIdentityProvidersItem traces the request and delegates the call to the wrapped handler's `GetIdentityProvidersItem` method.
+func (h handlerWithTracing) GetIdentityProvidersItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.trace(w, r, "GetIdentityProvidersItem", []attribute.KeyValue{entityIdAttribute.String(id)}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetIdentityProvidersItem(w, r, id)
+	})
+}
+
+// PutIdentityProvidersItem traces the request and delegates the call to the wrapped handler's `PutIdentityProvidersItem` method.
+func (h handlerWithTracing) PutIdentityProvidersItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.trace(w, r, "PutIdentityProvidersItem", []attribute.KeyValue{entityIdAttribute.String(id)}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PutIdentityProvidersItem(w, r, id)
+	})
+}
+
+// GetCapabilities traces the request and delegates the call to the wrapped handler's `GetCapabilities` method.
+func (h handlerWithTracing) GetCapabilities(w http.ResponseWriter, r *http.Request) {
+	h.trace(w, r, "GetCapabilities", nil, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetCapabilities(w, r)
+	})
+}
+
+// GetEntitlements traces the request and delegates the call to the wrapped handler's `GetEntitlements` method.
+func (h handlerWithTracing) GetEntitlements(w http.ResponseWriter, r *http.Request, params resources.GetEntitlementsParams) {
+	h.trace(w, r, "GetEntitlements", nil, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetEntitlements(w, r, params)
+	})
+}
+
+// GetRawEntitlements traces the request and delegates the call to the wrapped handler's `GetRawEntitlements` method.
+func (h handlerWithTracing) GetRawEntitlements(w http.ResponseWriter, r *http.Request) {
+	h.trace(w, r, "GetRawEntitlements", nil, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetRawEntitlements(w, r)
+	})
+}
+
+// GetGroups traces the request and delegates the call to the wrapped handler's `GetGroups` method.
+func (h handlerWithTracing) GetGroups(w http.ResponseWriter, r *http.Request, params resources.GetGroupsParams) {
+	h.trace(w, r, "GetGroups", pageSizeAttributes(params.Size), func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetGroups(w, r, params)
+	})
+}
+
+// PostGroups traces the request and delegates the call to the wrapped handler's `PostGroups` method.
+func (h handlerWithTracing) PostGroups(w http.ResponseWriter, r *http.Request) {
+	h.trace(w, r, "PostGroups", nil, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PostGroups(w, r)
+	})
+}
+
+// DeleteGroupsItem traces the request and delegates the call to the wrapped handler's `DeleteGroupsItem` method.
+func (h handlerWithTracing) DeleteGroupsItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.trace(w, r, "DeleteGroupsItem", []attribute.KeyValue{entityIdAttribute.String(id)}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.DeleteGroupsItem(w, r, id)
+	})
+}
+
+// GetGroupsItem traces the request and delegates the call to the wrapped handler's `GetGroupsItem` method.
+func (h handlerWithTracing) GetGroupsItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.trace(w, r, "GetGroupsItem", []attribute.KeyValue{entityIdAttribute.String(id)}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetGroupsItem(w, r, id)
+	})
+}
+
+// PutGroupsItem traces the request and delegates the call to the wrapped handler's `PutGroupsItem` method.
+func (h handlerWithTracing) PutGroupsItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.trace(w, r, "PutGroupsItem", []attribute.KeyValue{entityIdAttribute.String(id)}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PutGroupsItem(w, r, id)
+	})
+}
+
+// GetGroupsItemEntitlements traces the request and delegates the call to the wrapped handler's `GetGroupsItemEntitlements` method.
+func (h handlerWithTracing) GetGroupsItemEntitlements(w http.ResponseWriter, r *http.Request, id string, params resources.GetGroupsItemEntitlementsParams) {
+	h.trace(w, r, "GetGroupsItemEntitlements", append([]attribute.KeyValue{entityIdAttribute.String(id)}, pageSizeAttributes(params.Size)...), func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetGroupsItemEntitlements(w, r, id, params)
+	})
+}
+
+// PatchGroupsItemEntitlements traces the request and delegates the call to the wrapped handler's `PatchGroupsItemEntitlements` method.
+func (h handlerWithTracing) PatchGroupsItemEntitlements(w http.ResponseWriter, r *http.Request, id string) {
+	h.trace(w, r, "PatchGroupsItemEntitlements", []attribute.KeyValue{entityIdAttribute.String(id)}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PatchGroupsItemEntitlements(w, r, id)
+	})
+}
+
+// GetGroupsItemIdentities traces the request and delegates the call to the wrapped handler's `GetGroupsItemIdentities` method.
+func (h handlerWithTracing) GetGroupsItemIdentities(w http.ResponseWriter, r *http.Request, id string, params resources.GetGroupsItemIdentitiesParams) {
+	h.trace(w, r, "GetGroupsItemIdentities", append([]attribute.KeyValue{entityIdAttribute.String(id)}, pageSizeAttributes(params.Size)...), func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetGroupsItemIdentities(w, r, id, params)
+	})
+}
+
+// PatchGroupsItemIdentities traces the request and delegates the call to the wrapped handler's `PatchGroupsItemIdentities` method.
+func (h handlerWithTracing) PatchGroupsItemIdentities(w http.ResponseWriter, r *http.Request, id string) {
+	h.trace(w, r, "PatchGroupsItemIdentities", []attribute.KeyValue{entityIdAttribute.String(id)}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PatchGroupsItemIdentities(w, r, id)
+	})
+}
+
+// GetGroupsItemRoles traces the request and delegates the call to the wrapped handler's `GetGroupsItemRoles` method.
+func (h handlerWithTracing) GetGroupsItemRoles(w http.ResponseWriter, r *http.Request, id string, params resources.GetGroupsItemRolesParams) {
+	h.trace(w, r, "GetGroupsItemRoles", append([]attribute.KeyValue{entityIdAttribute.String(id)}, pageSizeAttributes(params.Size)...), func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetGroupsItemRoles(w, r, id, params)
+	})
+}
+
+// PatchGroupsItemRoles traces the request and delegates the call to the wrapped handler's `PatchGroupsItemRoles` method.
+func (h handlerWithTracing) PatchGroupsItemRoles(w http.ResponseWriter, r *http.Request, id string) {
+	h.trace(w, r, "PatchGroupsItemRoles", []attribute.KeyValue{entityIdAttribute.String(id)}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PatchGroupsItemRoles(w, r, id)
+	})
+}
+
+// GetIdentities traces the request and delegates the call to the wrapped handler's `GetIdentities` method.
+func (h handlerWithTracing) GetIdentities(w http.ResponseWriter, r *http.Request, params resources.GetIdentitiesParams) {
+	h.trace(w, r, "GetIdentities", pageSizeAttributes(params.Size), func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetIdentities(w, r, params)
+	})
+}
+
+// PostIdentities traces the request and delegates the call to the wrapped handler's `PostIdentities` method.
+func (h handlerWithTracing) PostIdentities(w http.ResponseWriter, r *http.Request) {
+	h.trace(w, r, "PostIdentities", nil, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PostIdentities(w, r)
+	})
+}
+
+// DeleteIdentitiesItem traces the request and delegates the call to the wrapped handler's `DeleteIdentitiesItem` method.
+func (h handlerWithTracing) DeleteIdentitiesItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.trace(w, r, "DeleteIdentitiesItem", []attribute.KeyValue{entityIdAttribute.String(id)}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.DeleteIdentitiesItem(w, r, id)
+	})
+}
+
+// GetIdentitiesItem traces the request and delegates the call to the wrapped handler's `GetIdentitiesItem` method.
+func (h handlerWithTracing) GetIdentitiesItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.trace(w, r, "GetIdentitiesItem", []attribute.KeyValue{entityIdAttribute.String(id)}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetIdentitiesItem(w, r, id)
+	})
+}
+
+// PutIdentitiesItem traces the request and delegates the call to the wrapped handler's `PutIdentitiesItem` method.
+func (h handlerWithTracing) PutIdentitiesItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.trace(w, r, "PutIdentitiesItem", []attribute.KeyValue{entityIdAttribute.String(id)}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PutIdentitiesItem(w, r, id)
+	})
+}
+
+// GetIdentitiesItemEntitlements traces the request and delegates the call to the wrapped handler's `GetIdentitiesItemEntitlements` method.
+func (h handlerWithTracing) GetIdentitiesItemEntitlements(w http.ResponseWriter, r *http.Request, id string, params resources.GetIdentitiesItemEntitlementsParams) {
+	h.trace(w, r, "GetIdentitiesItemEntitlements", append([]attribute.KeyValue{entityIdAttribute.String(id)}, pageSizeAttributes(params.Size)...), func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetIdentitiesItemEntitlements(w, r, id, params)
+	})
+}
+
+// PatchIdentitiesItemEntitlements traces the request and delegates the call to the wrapped handler's `PatchIdentitiesItemEntitlements` method.
+func (h handlerWithTracing) PatchIdentitiesItemEntitlements(w http.ResponseWriter, r *http.Request, id string) {
+	h.trace(w, r, "PatchIdentitiesItemEntitlements", []attribute.KeyValue{entityIdAttribute.String(id)}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PatchIdentitiesItemEntitlements(w, r, id)
+	})
+}
+
+// GetIdentitiesItemGroups traces the request and delegates the call to the wrapped handler's `GetIdentitiesItemGroups` method.
+func (h handlerWithTracing) GetIdentitiesItemGroups(w http.ResponseWriter, r *http.Request, id string, params resources.GetIdentitiesItemGroupsParams) {
+	h.trace(w, r, "GetIdentitiesItemGroups", append([]attribute.KeyValue{entityIdAttribute.String(id)}, pageSizeAttributes(params.Size)...), func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetIdentitiesItemGroups(w, r, id, params)
+	})
+}
+
+// PatchIdentitiesItemGroups traces the request and delegates the call to the wrapped handler's `PatchIdentitiesItemGroups` method.
+func (h handlerWithTracing) PatchIdentitiesItemGroups(w http.ResponseWriter, r *http.Request, id string) {
+	h.trace(w, r, "PatchIdentitiesItemGroups", []attribute.KeyValue{entityIdAttribute.String(id)}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PatchIdentitiesItemGroups(w, r, id)
+	})
+}
+
+// GetIdentitiesItemRoles traces the request and delegates the call to the wrapped handler's `GetIdentitiesItemRoles` method.
+func (h handlerWithTracing) GetIdentitiesItemRoles(w http.ResponseWriter, r *http.Request, id string, params resources.GetIdentitiesItemRolesParams) {
+	h.trace(w, r, "GetIdentitiesItemRoles", append([]attribute.KeyValue{entityIdAttribute.String(id)}, pageSizeAttributes(params.Size)...), func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetIdentitiesItemRoles(w, r, id, params)
+	})
+}
+
+// PatchIdentitiesItemRoles traces the request and delegates the call to the wrapped handler's `PatchIdentitiesItemRoles` method.
+func (h handlerWithTracing) PatchIdentitiesItemRoles(w http.ResponseWriter, r *http.Request, id string) {
+	h.trace(w, r, "PatchIdentitiesItemRoles", []attribute.KeyValue{entityIdAttribute.String(id)}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PatchIdentitiesItemRoles(w, r, id)
+	})
+}
+
+// GetResources traces the request and delegates the call to the wrapped handler's `GetResources` method.
+func (h handlerWithTracing) GetResources(w http.ResponseWriter, r *http.Request, params resources.GetResourcesParams) {
+	h.trace(w, r, "GetResources", pageSizeAttributes(params.Size), func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetResources(w, r, params)
+	})
+}
+
+// GetRoles traces the request and delegates the call to the wrapped handler's `GetRoles` method.
+func (h handlerWithTracing) GetRoles(w http.ResponseWriter, r *http.Request, params resources.GetRolesParams) {
+	h.trace(w, r, "GetRoles", pageSizeAttributes(params.Size), func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetRoles(w, r, params)
+	})
+}
+
+// PostRoles traces the request and delegates the call to the wrapped handler's `PostRoles` method.
+func (h handlerWithTracing) PostRoles(w http.ResponseWriter, r *http.Request) {
+	h.trace(w, r, "PostRoles", nil, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PostRoles(w, r)
+	})
+}
+
+// DeleteRolesItem traces the request and delegates the call to the wrapped handler's `DeleteRolesItem` method.
+func (h handlerWithTracing) DeleteRolesItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.trace(w, r, "DeleteRolesItem", []attribute.KeyValue{entityIdAttribute.String(id)}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.DeleteRolesItem(w, r, id)
+	})
+}
+
+// GetRolesItem traces the request and delegates the call to the wrapped handler's `GetRolesItem` method.
+func (h handlerWithTracing) GetRolesItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.trace(w, r, "GetRolesItem", []attribute.KeyValue{entityIdAttribute.String(id)}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetRolesItem(w, r, id)
+	})
+}
+
+// PutRolesItem traces the request and delegates the call to the wrapped handler's `PutRolesItem` method.
+func (h handlerWithTracing) PutRolesItem(w http.ResponseWriter, r *http.Request, id string) {
+	h.trace(w, r, "PutRolesItem", []attribute.KeyValue{entityIdAttribute.String(id)}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PutRolesItem(w, r, id)
+	})
+}
+
+// GetRolesItemEntitlements traces the request and delegates the call to the wrapped handler's `GetRolesItemEntitlements` method.
+func (h handlerWithTracing) GetRolesItemEntitlements(w http.ResponseWriter, r *http.Request, id string, params resources.GetRolesItemEntitlementsParams) {
+	h.trace(w, r, "GetRolesItemEntitlements", append([]attribute.KeyValue{entityIdAttribute.String(id)}, pageSizeAttributes(params.Size)...), func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.GetRolesItemEntitlements(w, r, id, params)
+	})
+}
+
+// PatchRolesItemEntitlements traces the request and delegates the call to the wrapped handler's `PatchRolesItemEntitlements` method.
+func (h handlerWithTracing) PatchRolesItemEntitlements(w http.ResponseWriter, r *http.Request, id string) {
+	h.trace(w, r, "PatchRolesItemEntitlements", []attribute.KeyValue{entityIdAttribute.String(id)}, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.PatchRolesItemEntitlements(w, r, id)
+	})
+}
+
+// SwaggerJson traces the request and delegates the call to the wrapped handler's `SwaggerJson` method.
+func (h handlerWithTracing) SwaggerJson(w http.ResponseWriter, r *http.Request) {
+	h.trace(w, r, "SwaggerJson", nil, func(w http.ResponseWriter, r *http.Request) {
+		h.ServerInterface.SwaggerJson(w, r)
+	})
+}
+
+// PostBulk traces the request and delegates the call to the wrapped handler's `PostBulk` method.
+func (h handlerWithTracing) PostBulk(w http.ResponseWriter, r *http.Request) {
+	h.trace(w, r, "PostBulk", nil, func(w http.ResponseWriter, r *http.Request) {
+		extensionsOf(h.ServerInterface).PostBulk(w, r)
+	})
+}
+
+// PostCheck traces the request and delegates the call to the wrapped handler's `PostCheck` method.
+func (h handlerWithTracing) PostCheck(w http.ResponseWriter, r *http.Request) {
+	h.trace(w, r, "PostCheck", nil, func(w http.ResponseWriter, r *http.Request) {
+		extensionsOf(h.ServerInterface).PostCheck(w, r)
+	})
+}
+
+// GetIdentitiesItemEffectiveEntitlements traces the request and delegates the call to the wrapped handler's `GetIdentitiesItemEffectiveEntitlements` method.
+func (h handlerWithTracing) GetIdentitiesItemEffectiveEntitlements(w http.ResponseWriter, r *http.Request, id string) {
+	h.trace(w, r, "GetIdentitiesItemEffectiveEntitlements", []attribute.KeyValue{entityIdAttribute.String(id)}, func(w http.ResponseWriter, r *http.Request) {
+		extensionsOf(h.ServerInterface).GetIdentitiesItemEffectiveEntitlements(w, r, id)
+	})
+}
+
+// GetResourcesItemAccess traces the request and delegates the call to the wrapped handler's `GetResourcesItemAccess` method.
+func (h handlerWithTracing) GetResourcesItemAccess(w http.ResponseWriter, r *http.Request, pType string, id string, params resources.GetResourcesItemAccessParams) {
+	h.trace(w, r, "GetResourcesItemAccess", append([]attribute.KeyValue{entityIdAttribute.String(id)}, pageSizeAttributes(params.Size)...), func(w http.ResponseWriter, r *http.Request) {
+		extensionsOf(h.ServerInterface).GetResourcesItemAccess(w, r, pType, id, params)
+	})
+}
@@ -0,0 +1,649 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// withServiceTracing returns a copy of the given parameters, where all provided
+// backends (i.e., non-nil ones) are wrapped to trace their method calls.
+func withServiceTracing(params ReBACAdminBackendParams, tracer trace.Tracer) ReBACAdminBackendParams {
+	if params.AccessCheck != nil {
+		params.AccessCheck = &accessCheckServiceWithTracing{params.AccessCheck, tracer}
+	}
+	if params.AuditSink != nil {
+		params.AuditSink = &auditSinkWithTracing{params.AuditSink, tracer}
+	}
+	if params.Authenticator != nil {
+		params.Authenticator = &authenticatorWithTracing{params.Authenticator, tracer}
+	}
+	if params.Authorizer != nil {
+		params.Authorizer = &authorizerWithTracing{params.Authorizer, tracer}
+	}
+	if params.Bulk != nil {
+		params.Bulk = &bulkServiceWithTracing{params.Bulk, tracer}
+	}
+	if params.Capabilities != nil {
+		params.Capabilities = &capabilitiesServiceWithTracing{params.Capabilities, tracer}
+	}
+	if params.Entitlements != nil {
+		params.Entitlements = &entitlementsServiceWithTracing{params.Entitlements, tracer}
+	}
+	if params.Groups != nil {
+		params.Groups = &groupsServiceWithTracing{params.Groups, tracer}
+	}
+	if params.Identities != nil {
+		params.Identities = &identitiesServiceWithTracing{params.Identities, tracer}
+	}
+	if params.IdentityProviders != nil {
+		params.IdentityProviders = &identityProvidersServiceWithTracing{params.IdentityProviders, tracer}
+	}
+	if params.Resources != nil {
+		params.Resources = &resourcesServiceWithTracing{params.Resources, tracer}
+	}
+	if params.Roles != nil {
+		params.Roles = &rolesServiceWithTracing{params.Roles, tracer}
+	}
+	return params
+}
+
+// accessCheckServiceWithTracing wraps an AccessCheckService to trace its method calls.
+type accessCheckServiceWithTracing struct {
+	interfaces.AccessCheckService
+	tracer trace.Tracer
+}
+
+// CheckAccess implements the AccessCheckService interface.
+func (s *accessCheckServiceWithTracing) CheckAccess(ctx context.Context, identityId string, entitlement resources.EntityEntitlement, explain bool) (*resources.AccessCheck, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "AccessCheckService.CheckAccess")
+	result, err := s.AccessCheckService.CheckAccess(ctx, identityId, entitlement, explain)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// auditSinkWithTracing wraps an AuditSink to trace its method calls.
+type auditSinkWithTracing struct {
+	interfaces.AuditSink
+	tracer trace.Tracer
+}
+
+// Record implements the AuditSink interface.
+func (s *auditSinkWithTracing) Record(ctx context.Context, event interfaces.AuditEvent) error {
+	ctx, span := startServiceSpan(ctx, s.tracer, "AuditSink.Record")
+	err := s.AuditSink.Record(ctx, event)
+	endServiceSpan(span, err)
+	return err
+}
+
+// authenticatorWithTracing wraps an Authenticator to trace its method calls.
+type authenticatorWithTracing struct {
+	interfaces.Authenticator
+	tracer trace.Tracer
+}
+
+// Authenticate implements the Authenticator interface.
+func (s *authenticatorWithTracing) Authenticate(r *http.Request) (any, error) {
+	ctx, span := startServiceSpan(r.Context(), s.tracer, "Authenticator.Authenticate")
+	r = r.WithContext(ctx)
+	result, err := s.Authenticator.Authenticate(r)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// authorizerWithTracing wraps an Authorizer to trace its method calls.
+type authorizerWithTracing struct {
+	interfaces.Authorizer
+	tracer trace.Tracer
+}
+
+// Authorize implements the Authorizer interface.
+func (s *authorizerWithTracing) Authorize(ctx context.Context, identity any, operationId string, targetIds []string) (bool, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "Authorizer.Authorize")
+	result, err := s.Authorizer.Authorize(ctx, identity, operationId, targetIds)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// bulkServiceWithTracing wraps a BulkService to trace its method calls.
+type bulkServiceWithTracing struct {
+	interfaces.BulkService
+	tracer trace.Tracer
+}
+
+// ExecuteBulk implements the BulkService interface.
+func (s *bulkServiceWithTracing) ExecuteBulk(ctx context.Context, operations []resources.BulkOperation) ([]resources.BulkOperationResult, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "BulkService.ExecuteBulk")
+	result, err := s.BulkService.ExecuteBulk(ctx, operations)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// capabilitiesServiceWithTracing wraps a CapabilitiesService to trace its method calls.
+type capabilitiesServiceWithTracing struct {
+	interfaces.CapabilitiesService
+	tracer trace.Tracer
+}
+
+// ListCapabilities implements the CapabilitiesService interface.
+func (s *capabilitiesServiceWithTracing) ListCapabilities(ctx context.Context) ([]resources.Capability, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "CapabilitiesService.ListCapabilities")
+	result, err := s.CapabilitiesService.ListCapabilities(ctx)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// entitlementsServiceWithTracing wraps an EntitlementsService to trace its method calls.
+type entitlementsServiceWithTracing struct {
+	interfaces.EntitlementsService
+	tracer trace.Tracer
+}
+
+// ListEntitlements implements the EntitlementsService interface.
+func (s *entitlementsServiceWithTracing) ListEntitlements(ctx context.Context, params *resources.GetEntitlementsParams) ([]resources.EntitlementSchema, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "EntitlementsService.ListEntitlements")
+	result, err := s.EntitlementsService.ListEntitlements(ctx, params)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// RawEntitlements implements the EntitlementsService interface.
+func (s *entitlementsServiceWithTracing) RawEntitlements(ctx context.Context) (string, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "EntitlementsService.RawEntitlements")
+	result, err := s.EntitlementsService.RawEntitlements(ctx)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// groupsServiceWithTracing wraps a GroupsService to trace its method calls.
+type groupsServiceWithTracing struct {
+	interfaces.GroupsService
+	tracer trace.Tracer
+}
+
+// ListGroups implements the GroupsService interface.
+func (s *groupsServiceWithTracing) ListGroups(ctx context.Context, params *resources.GetGroupsParams) (*resources.PaginatedResponse[resources.Group], error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "GroupsService.ListGroups")
+	result, err := s.GroupsService.ListGroups(ctx, params)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// CreateGroup implements the GroupsService interface.
+func (s *groupsServiceWithTracing) CreateGroup(ctx context.Context, group *resources.Group) (*resources.Group, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "GroupsService.CreateGroup")
+	result, err := s.GroupsService.CreateGroup(ctx, group)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// GetGroup implements the GroupsService interface.
+func (s *groupsServiceWithTracing) GetGroup(ctx context.Context, groupId string) (*resources.Group, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "GroupsService.GetGroup", entityIdAttribute.String(groupId))
+	result, err := s.GroupsService.GetGroup(ctx, groupId)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// UpdateGroup implements the GroupsService interface.
+func (s *groupsServiceWithTracing) UpdateGroup(ctx context.Context, group *resources.Group) (*resources.Group, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "GroupsService.UpdateGroup")
+	result, err := s.GroupsService.UpdateGroup(ctx, group)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// DeleteGroup implements the GroupsService interface.
+func (s *groupsServiceWithTracing) DeleteGroup(ctx context.Context, groupId string) (bool, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "GroupsService.DeleteGroup", entityIdAttribute.String(groupId))
+	result, err := s.GroupsService.DeleteGroup(ctx, groupId)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// GetGroupIdentities implements the GroupsService interface.
+func (s *groupsServiceWithTracing) GetGroupIdentities(ctx context.Context, groupId string, params *resources.GetGroupsItemIdentitiesParams) (*resources.PaginatedResponse[resources.Identity], error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "GroupsService.GetGroupIdentities", entityIdAttribute.String(groupId))
+	result, err := s.GroupsService.GetGroupIdentities(ctx, groupId, params)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// PatchGroupIdentities implements the GroupsService interface.
+func (s *groupsServiceWithTracing) PatchGroupIdentities(ctx context.Context, groupId string, identityPatches []resources.GroupIdentitiesPatchItem) (bool, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "GroupsService.PatchGroupIdentities", entityIdAttribute.String(groupId))
+	result, err := s.GroupsService.PatchGroupIdentities(ctx, groupId, identityPatches)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// GetGroupRoles implements the GroupsService interface.
+func (s *groupsServiceWithTracing) GetGroupRoles(ctx context.Context, groupId string, params *resources.GetGroupsItemRolesParams) (*resources.PaginatedResponse[resources.Role], error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "GroupsService.GetGroupRoles", entityIdAttribute.String(groupId))
+	result, err := s.GroupsService.GetGroupRoles(ctx, groupId, params)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// PatchGroupRoles implements the GroupsService interface.
+func (s *groupsServiceWithTracing) PatchGroupRoles(ctx context.Context, groupId string, rolePatches []resources.GroupRolesPatchItem) (bool, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "GroupsService.PatchGroupRoles", entityIdAttribute.String(groupId))
+	result, err := s.GroupsService.PatchGroupRoles(ctx, groupId, rolePatches)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// GetGroupEntitlements implements the GroupsService interface.
+func (s *groupsServiceWithTracing) GetGroupEntitlements(ctx context.Context, groupId string, params *resources.GetGroupsItemEntitlementsParams) (*resources.PaginatedResponse[resources.EntityEntitlement], error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "GroupsService.GetGroupEntitlements", entityIdAttribute.String(groupId))
+	result, err := s.GroupsService.GetGroupEntitlements(ctx, groupId, params)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// PatchGroupEntitlements implements the GroupsService interface.
+func (s *groupsServiceWithTracing) PatchGroupEntitlements(ctx context.Context, groupId string, entitlementPatches []resources.GroupEntitlementsPatchItem) (bool, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "GroupsService.PatchGroupEntitlements", entityIdAttribute.String(groupId))
+	result, err := s.GroupsService.PatchGroupEntitlements(ctx, groupId, entitlementPatches)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// identitiesServiceWithTracing wraps an IdentitiesService to trace its method calls.
+type identitiesServiceWithTracing struct {
+	interfaces.IdentitiesService
+	tracer trace.Tracer
+}
+
+// ListIdentities implements the IdentitiesService interface.
+func (s *identitiesServiceWithTracing) ListIdentities(ctx context.Context, params *resources.GetIdentitiesParams) (*resources.PaginatedResponse[resources.Identity], error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "IdentitiesService.ListIdentities")
+	result, err := s.IdentitiesService.ListIdentities(ctx, params)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// CreateIdentity implements the IdentitiesService interface.
+func (s *identitiesServiceWithTracing) CreateIdentity(ctx context.Context, identity *resources.Identity) (*resources.Identity, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "IdentitiesService.CreateIdentity")
+	result, err := s.IdentitiesService.CreateIdentity(ctx, identity)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// GetIdentity implements the IdentitiesService interface.
+func (s *identitiesServiceWithTracing) GetIdentity(ctx context.Context, identityId string) (*resources.Identity, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "IdentitiesService.GetIdentity", entityIdAttribute.String(identityId))
+	result, err := s.IdentitiesService.GetIdentity(ctx, identityId)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// UpdateIdentity implements the IdentitiesService interface.
+func (s *identitiesServiceWithTracing) UpdateIdentity(ctx context.Context, identity *resources.Identity) (*resources.Identity, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "IdentitiesService.UpdateIdentity")
+	result, err := s.IdentitiesService.UpdateIdentity(ctx, identity)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// DeleteIdentity implements the IdentitiesService interface.
+func (s *identitiesServiceWithTracing) DeleteIdentity(ctx context.Context, identityId string) (bool, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "IdentitiesService.DeleteIdentity", entityIdAttribute.String(identityId))
+	result, err := s.IdentitiesService.DeleteIdentity(ctx, identityId)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// GetIdentityGroups implements the IdentitiesService interface.
+func (s *identitiesServiceWithTracing) GetIdentityGroups(ctx context.Context, identityId string, params *resources.GetIdentitiesItemGroupsParams) (*resources.PaginatedResponse[resources.Group], error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "IdentitiesService.GetIdentityGroups", entityIdAttribute.String(identityId))
+	result, err := s.IdentitiesService.GetIdentityGroups(ctx, identityId, params)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// PatchIdentityGroups implements the IdentitiesService interface.
+func (s *identitiesServiceWithTracing) PatchIdentityGroups(ctx context.Context, identityId string, groupPatches []resources.IdentityGroupsPatchItem) (bool, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "IdentitiesService.PatchIdentityGroups", entityIdAttribute.String(identityId))
+	result, err := s.IdentitiesService.PatchIdentityGroups(ctx, identityId, groupPatches)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// GetIdentityRoles implements the IdentitiesService interface.
+func (s *identitiesServiceWithTracing) GetIdentityRoles(ctx context.Context, identityId string, params *resources.GetIdentitiesItemRolesParams) (*resources.PaginatedResponse[resources.Role], error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "IdentitiesService.GetIdentityRoles", entityIdAttribute.String(identityId))
+	result, err := s.IdentitiesService.GetIdentityRoles(ctx, identityId, params)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// PatchIdentityRoles implements the IdentitiesService interface.
+func (s *identitiesServiceWithTracing) PatchIdentityRoles(ctx context.Context, identityId string, rolePatches []resources.IdentityRolesPatchItem) (bool, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "IdentitiesService.PatchIdentityRoles", entityIdAttribute.String(identityId))
+	result, err := s.IdentitiesService.PatchIdentityRoles(ctx, identityId, rolePatches)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// GetIdentityEntitlements implements the IdentitiesService interface.
+func (s *identitiesServiceWithTracing) GetIdentityEntitlements(ctx context.Context, identityId string, params *resources.GetIdentitiesItemEntitlementsParams) (*resources.PaginatedResponse[resources.EntityEntitlement], error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "IdentitiesService.GetIdentityEntitlements", entityIdAttribute.String(identityId))
+	result, err := s.IdentitiesService.GetIdentityEntitlements(ctx, identityId, params)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// PatchIdentityEntitlements implements the IdentitiesService interface.
+func (s *identitiesServiceWithTracing) PatchIdentityEntitlements(ctx context.Context, identityId string, entitlementPatches []resources.IdentityEntitlementsPatchItem) (bool, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "IdentitiesService.PatchIdentityEntitlements", entityIdAttribute.String(identityId))
+	result, err := s.IdentitiesService.PatchIdentityEntitlements(ctx, identityId, entitlementPatches)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// identityProvidersServiceWithTracing wraps an IdentityProvidersService to trace its method calls.
+type identityProvidersServiceWithTracing struct {
+	interfaces.IdentityProvidersService
+	tracer trace.Tracer
+}
+
+// ListAvailableIdentityProviders implements the IdentityProvidersService interface.
+func (s *identityProvidersServiceWithTracing) ListAvailableIdentityProviders(ctx context.Context, params *resources.GetAvailableIdentityProvidersParams) (*resources.PaginatedResponse[resources.AvailableIdentityProvider], error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "IdentityProvidersService.ListAvailableIdentityProviders")
+	result, err := s.IdentityProvidersService.ListAvailableIdentityProviders(ctx, params)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// ListIdentityProviders implements the IdentityProvidersService interface.
+func (s *identityProvidersServiceWithTracing) ListIdentityProviders(ctx context.Context, params *resources.GetIdentityProvidersParams) (*resources.PaginatedResponse[resources.IdentityProvider], error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "IdentityProvidersService.ListIdentityProviders")
+	result, err := s.IdentityProvidersService.ListIdentityProviders(ctx, params)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// RegisterConfiguration implements the IdentityProvidersService interface.
+func (s *identityProvidersServiceWithTracing) RegisterConfiguration(ctx context.Context, provider *resources.IdentityProvider) (*resources.IdentityProvider, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "IdentityProvidersService.RegisterConfiguration")
+	result, err := s.IdentityProvidersService.RegisterConfiguration(ctx, provider)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// DeleteConfiguration implements the IdentityProvidersService interface.
+func (s *identityProvidersServiceWithTracing) DeleteConfiguration(ctx context.Context, id string) (bool, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "IdentityProvidersService.DeleteConfiguration", entityIdAttribute.String(id))
+	result, err := s.IdentityProvidersService.DeleteConfiguration(ctx, id)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// GetConfiguration implements the IdentityProvidersService interface.
+func (s *identityProvidersServiceWithTracing) GetConfiguration(ctx context.Context, id string) (*resources.IdentityProvider, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "IdentityProvidersService.GetConfiguration", entityIdAttribute.String(id))
+	result, err := s.IdentityProvidersService.GetConfiguration(ctx, id)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// UpdateConfiguration implements the IdentityProvidersService interface.
+func (s *identityProvidersServiceWithTracing) UpdateConfiguration(ctx context.Context, provider *resources.IdentityProvider) (*resources.IdentityProvider, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "IdentityProvidersService.UpdateConfiguration")
+	result, err := s.IdentityProvidersService.UpdateConfiguration(ctx, provider)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// resourcesServiceWithTracing wraps a ResourcesService to trace its method calls.
+type resourcesServiceWithTracing struct {
+	interfaces.ResourcesService
+	tracer trace.Tracer
+}
+
+// ListResources implements the ResourcesService interface.
+func (s *resourcesServiceWithTracing) ListResources(ctx context.Context, params *resources.GetResourcesParams) (*resources.PaginatedResponse[resources.Resource], error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "ResourcesService.ListResources")
+	result, err := s.ResourcesService.ListResources(ctx, params)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// rolesServiceWithTracing wraps a RolesService to trace its method calls.
+type rolesServiceWithTracing struct {
+	interfaces.RolesService
+	tracer trace.Tracer
+}
+
+// ListRoles implements the RolesService interface.
+func (s *rolesServiceWithTracing) ListRoles(ctx context.Context, params *resources.GetRolesParams) (*resources.PaginatedResponse[resources.Role], error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "RolesService.ListRoles")
+	result, err := s.RolesService.ListRoles(ctx, params)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// CreateRole implements the RolesService interface.
+func (s *rolesServiceWithTracing) CreateRole(ctx context.Context, role *resources.Role) (*resources.Role, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "RolesService.CreateRole")
+	result, err := s.RolesService.CreateRole(ctx, role)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// GetRole implements the RolesService interface.
+func (s *rolesServiceWithTracing) GetRole(ctx context.Context, roleId string) (*resources.Role, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "RolesService.GetRole", entityIdAttribute.String(roleId))
+	result, err := s.RolesService.GetRole(ctx, roleId)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// UpdateRole implements the RolesService interface.
+func (s *rolesServiceWithTracing) UpdateRole(ctx context.Context, role *resources.Role) (*resources.Role, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "RolesService.UpdateRole")
+	result, err := s.RolesService.UpdateRole(ctx, role)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// DeleteRole implements the RolesService interface.
+func (s *rolesServiceWithTracing) DeleteRole(ctx context.Context, roleId string) (bool, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "RolesService.DeleteRole", entityIdAttribute.String(roleId))
+	result, err := s.RolesService.DeleteRole(ctx, roleId)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// GetRoleEntitlements implements the RolesService interface.
+func (s *rolesServiceWithTracing) GetRoleEntitlements(ctx context.Context, roleId string, params *resources.GetRolesItemEntitlementsParams) (*resources.PaginatedResponse[resources.EntityEntitlement], error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "RolesService.GetRoleEntitlements", entityIdAttribute.String(roleId))
+	result, err := s.RolesService.GetRoleEntitlements(ctx, roleId, params)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// PatchRoleEntitlements implements the RolesService interface.
+func (s *rolesServiceWithTracing) PatchRoleEntitlements(ctx context.Context, roleId string, entitlementPatches []resources.RoleEntitlementsPatchItem) (bool, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "RolesService.PatchRoleEntitlements", entityIdAttribute.String(roleId))
+	result, err := s.RolesService.PatchRoleEntitlements(ctx, roleId, entitlementPatches)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// withServiceHooksTracing returns a copy of the given hooks, where all provided
+// ones (i.e., non-nil ones) are wrapped to trace their method calls.
+func withServiceHooksTracing(hooks serviceHooks, tracer trace.Tracer) serviceHooks {
+	if hooks.groupsVersioner != nil {
+		hooks.groupsVersioner = &entityVersionerWithTracing{hooks.groupsVersioner, tracer, "GroupsService"}
+	}
+	if hooks.rolesVersioner != nil {
+		hooks.rolesVersioner = &entityVersionerWithTracing{hooks.rolesVersioner, tracer, "RolesService"}
+	}
+	if hooks.identitiesVersioner != nil {
+		hooks.identitiesVersioner = &entityVersionerWithTracing{hooks.identitiesVersioner, tracer, "IdentitiesService"}
+	}
+	if hooks.identityProvidersVersioner != nil {
+		hooks.identityProvidersVersioner = &entityVersionerWithTracing{hooks.identityProvidersVersioner, tracer, "IdentityProvidersService"}
+	}
+	if hooks.identitiesEffectiveEntitlements != nil {
+		hooks.identitiesEffectiveEntitlements = &effectiveEntitlementsResolverWithTracing{hooks.identitiesEffectiveEntitlements, tracer}
+	}
+	if hooks.resourcesAccess != nil {
+		hooks.resourcesAccess = &resourceAccessListerWithTracing{hooks.resourcesAccess, tracer}
+	}
+	if hooks.groupsPatchReporter != nil {
+		hooks.groupsPatchReporter = &groupsPatchReporterWithTracing{hooks.groupsPatchReporter, tracer}
+	}
+	if hooks.identitiesPatchReporter != nil {
+		hooks.identitiesPatchReporter = &identitiesPatchReporterWithTracing{hooks.identitiesPatchReporter, tracer}
+	}
+	if hooks.rolesPatchReporter != nil {
+		hooks.rolesPatchReporter = &rolesPatchReporterWithTracing{hooks.rolesPatchReporter, tracer}
+	}
+	return hooks
+}
+
+// entityVersionerWithTracing wraps an EntityVersioner to trace its method
+// calls. The spans are named after the service that implements the hook.
+type entityVersionerWithTracing struct {
+	interfaces.EntityVersioner
+	tracer  trace.Tracer
+	service string
+}
+
+// EntityVersion implements the EntityVersioner interface.
+func (s *entityVersionerWithTracing) EntityVersion(ctx context.Context, id string) (string, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, s.service+".EntityVersion", entityIdAttribute.String(id))
+	result, err := s.EntityVersioner.EntityVersion(ctx, id)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// effectiveEntitlementsResolverWithTracing wraps an
+// EffectiveEntitlementsResolver to trace its method calls.
+type effectiveEntitlementsResolverWithTracing struct {
+	interfaces.EffectiveEntitlementsResolver
+	tracer trace.Tracer
+}
+
+// GetIdentityEffectiveEntitlements implements the EffectiveEntitlementsResolver interface.
+func (s *effectiveEntitlementsResolverWithTracing) GetIdentityEffectiveEntitlements(ctx context.Context, identityId string) ([]resources.EffectiveEntitlement, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "IdentitiesService.GetIdentityEffectiveEntitlements", entityIdAttribute.String(identityId))
+	result, err := s.EffectiveEntitlementsResolver.GetIdentityEffectiveEntitlements(ctx, identityId)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// resourceAccessListerWithTracing wraps a ResourceAccessLister to trace its
+// method calls.
+type resourceAccessListerWithTracing struct {
+	interfaces.ResourceAccessLister
+	tracer trace.Tracer
+}
+
+// ListResourceAccess implements the ResourceAccessLister interface.
+func (s *resourceAccessListerWithTracing) ListResourceAccess(ctx context.Context, entityType string, entityId string, params *resources.GetResourcesItemAccessParams) (*resources.PaginatedResponse[resources.ResourceAccess], error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "ResourcesService.ListResourceAccess", entityIdAttribute.String(entityId))
+	result, err := s.ResourceAccessLister.ListResourceAccess(ctx, entityType, entityId, params)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// groupsPatchReporterWithTracing wraps a GroupsPatchReporter to trace its
+// method calls.
+type groupsPatchReporterWithTracing struct {
+	interfaces.GroupsPatchReporter
+	tracer trace.Tracer
+}
+
+// PatchGroupIdentitiesWithResults implements the GroupsPatchReporter interface.
+func (s *groupsPatchReporterWithTracing) PatchGroupIdentitiesWithResults(ctx context.Context, groupId string, identityPatches []resources.GroupIdentitiesPatchItem) ([]resources.PatchItemResult, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "GroupsService.PatchGroupIdentitiesWithResults", entityIdAttribute.String(groupId))
+	result, err := s.GroupsPatchReporter.PatchGroupIdentitiesWithResults(ctx, groupId, identityPatches)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// PatchGroupRolesWithResults implements the GroupsPatchReporter interface.
+func (s *groupsPatchReporterWithTracing) PatchGroupRolesWithResults(ctx context.Context, groupId string, rolePatches []resources.GroupRolesPatchItem) ([]resources.PatchItemResult, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "GroupsService.PatchGroupRolesWithResults", entityIdAttribute.String(groupId))
+	result, err := s.GroupsPatchReporter.PatchGroupRolesWithResults(ctx, groupId, rolePatches)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// PatchGroupEntitlementsWithResults implements the GroupsPatchReporter interface.
+func (s *groupsPatchReporterWithTracing) PatchGroupEntitlementsWithResults(ctx context.Context, groupId string, entitlementPatches []resources.GroupEntitlementsPatchItem) ([]resources.PatchItemResult, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "GroupsService.PatchGroupEntitlementsWithResults", entityIdAttribute.String(groupId))
+	result, err := s.GroupsPatchReporter.PatchGroupEntitlementsWithResults(ctx, groupId, entitlementPatches)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// identitiesPatchReporterWithTracing wraps an IdentitiesPatchReporter to trace
+// its method calls.
+type identitiesPatchReporterWithTracing struct {
+	interfaces.IdentitiesPatchReporter
+	tracer trace.Tracer
+}
+
+// PatchIdentityGroupsWithResults implements the IdentitiesPatchReporter interface.
+func (s *identitiesPatchReporterWithTracing) PatchIdentityGroupsWithResults(ctx context.Context, identityId string, groupPatches []resources.IdentityGroupsPatchItem) ([]resources.PatchItemResult, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "IdentitiesService.PatchIdentityGroupsWithResults", entityIdAttribute.String(identityId))
+	result, err := s.IdentitiesPatchReporter.PatchIdentityGroupsWithResults(ctx, identityId, groupPatches)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// PatchIdentityRolesWithResults implements the IdentitiesPatchReporter interface.
+func (s *identitiesPatchReporterWithTracing) PatchIdentityRolesWithResults(ctx context.Context, identityId string, rolePatches []resources.IdentityRolesPatchItem) ([]resources.PatchItemResult, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "IdentitiesService.PatchIdentityRolesWithResults", entityIdAttribute.String(identityId))
+	result, err := s.IdentitiesPatchReporter.PatchIdentityRolesWithResults(ctx, identityId, rolePatches)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// PatchIdentityEntitlementsWithResults implements the IdentitiesPatchReporter interface.
+func (s *identitiesPatchReporterWithTracing) PatchIdentityEntitlementsWithResults(ctx context.Context, identityId string, entitlementPatches []resources.IdentityEntitlementsPatchItem) ([]resources.PatchItemResult, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "IdentitiesService.PatchIdentityEntitlementsWithResults", entityIdAttribute.String(identityId))
+	result, err := s.IdentitiesPatchReporter.PatchIdentityEntitlementsWithResults(ctx, identityId, entitlementPatches)
+	endServiceSpan(span, err)
+	return result, err
+}
+
+// rolesPatchReporterWithTracing wraps a RolesPatchReporter to trace its
+// method calls.
+type rolesPatchReporterWithTracing struct {
+	interfaces.RolesPatchReporter
+	tracer trace.Tracer
+}
+
+// PatchRoleEntitlementsWithResults implements the RolesPatchReporter interface.
+func (s *rolesPatchReporterWithTracing) PatchRoleEntitlementsWithResults(ctx context.Context, roleId string, entitlementPatches []resources.RoleEntitlementsPatchItem) ([]resources.PatchItemResult, error) {
+	ctx, span := startServiceSpan(ctx, s.tracer, "RolesService.PatchRoleEntitlementsWithResults", entityIdAttribute.String(roleId))
+	result, err := s.RolesPatchReporter.PatchRoleEntitlementsWithResults(ctx, roleId, entitlementPatches)
+	endServiceSpan(span, err)
+	return result, err
+}
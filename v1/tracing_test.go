@@ -0,0 +1,189 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+)
+
+// newTestTracerProvider returns a tracer provider that records the ended spans
+// in memory.
+func newTestTracerProvider() (*sdktrace.TracerProvider, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	return sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)), recorder
+}
+
+// spanAttributes returns the attributes of the given span as a map.
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	result := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		result[kv.Key] = kv.Value
+	}
+	return result
+}
+
+func TestTracing_SpansAreNested(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	groups := interfaces.NewMockGroupsService(ctrl)
+	groups.EXPECT().GetGroupIdentities(gomock.Any(), "some-group", gomock.Any()).
+		Return(&resources.PaginatedResponse[resources.Identity]{}, nil)
+
+	authorizer := interfaces.NewMockAuthorizer(ctrl)
+	authorizer.EXPECT().Authorize(gomock.Any(), gomock.Any(), "GetGroupsItemIdentities", []string{"some-group"}).Return(true, nil)
+
+	provider, recorder := newTestTracerProvider()
+	sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+		Groups:         groups,
+		Authorizer:     authorizer,
+		TracerProvider: provider,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/groups/some-group/identities?size=10", nil)
+	w := httptest.NewRecorder()
+	sut.Handler("").ServeHTTP(w, req)
+	c.Assert(w.Code, qt.Equals, http.StatusOK)
+
+	spans := recorder.Ended()
+	names := []string{}
+	for _, span := range spans {
+		names = append(names, span.Name())
+	}
+	c.Assert(names, qt.DeepEquals, []string{
+		"Authorizer.Authorize",
+		"GroupsService.GetGroupIdentities",
+		"core",
+		"validator",
+		"authorizer",
+		"dispatcher",
+		"GetGroupsItemIdentities",
+	})
+
+	// Each span should be the child of the next one, except for the authorizer
+	// backend call, which is a child of the authorizer stage.
+	parents := map[string]string{}
+	for _, span := range spans {
+		for _, parent := range spans {
+			if span.Parent().SpanID() == parent.SpanContext().SpanID() {
+				parents[span.Name()] = parent.Name()
+			}
+		}
+	}
+	c.Assert(parents, qt.DeepEquals, map[string]string{
+		"GroupsService.GetGroupIdentities": "core",
+		"core":                             "validator",
+		"validator":                        "authorizer",
+		"Authorizer.Authorize":             "authorizer",
+		"authorizer":                       "dispatcher",
+		"dispatcher":                       "GetGroupsItemIdentities",
+	})
+
+	request := spanAttributes(spans[6])
+	c.Assert(request["rebac.operation_id"].AsString(), qt.Equals, "GetGroupsItemIdentities")
+	c.Assert(request["http.route"].AsString(), qt.Equals, "/v1/groups/{id}/identities")
+	c.Assert(request["http.request.method"].AsString(), qt.Equals, http.MethodGet)
+	c.Assert(request["http.response.status_code"].AsInt64(), qt.Equals, int64(http.StatusOK))
+
+	core := spanAttributes(spans[2])
+	c.Assert(core["rebac.operation_id"].AsString(), qt.Equals, "GetGroupsItemIdentities")
+	c.Assert(core["rebac.entity_id"].AsString(), qt.Equals, "some-group")
+	c.Assert(core["rebac.page_size"].AsInt64(), qt.Equals, int64(10))
+
+	service := spanAttributes(spans[1])
+	c.Assert(service["rebac.entity_id"].AsString(), qt.Equals, "some-group")
+}
+
+func TestTracing_ServiceErrors(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	serviceError := errors.New("group not found")
+
+	groups := interfaces.NewMockGroupsService(ctrl)
+	groups.EXPECT().GetGroup(gomock.Any(), "some-group").Return(nil, serviceError)
+
+	mapper := NewMockErrorResponseMapper(ctrl)
+	mapper.EXPECT().MapError(serviceError).Return(&resources.Response{Status: http.StatusNotFound, Message: "not found"})
+
+	provider, recorder := newTestTracerProvider()
+	sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+		Groups:            groups,
+		GroupsErrorMapper: mapper,
+		TracerProvider:    provider,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/groups/some-group", nil)
+	w := httptest.NewRecorder()
+	sut.Handler("").ServeHTTP(w, req)
+	c.Assert(w.Code, qt.Equals, http.StatusNotFound)
+
+	spans := recorder.Ended()
+	c.Assert(spans, qt.HasLen, 5)
+
+	service := spans[0]
+	c.Assert(service.Name(), qt.Equals, "GroupsService.GetGroup")
+	c.Assert(service.Status().Code, qt.Equals, codes.Error)
+	c.Assert(service.Status().Description, qt.Equals, "group not found")
+
+	request := spans[4]
+	c.Assert(request.Name(), qt.Equals, "GetGroupsItem")
+	attributes := spanAttributes(request)
+	c.Assert(attributes["rebac.error.status"].AsInt64(), qt.Equals, int64(http.StatusNotFound))
+	c.Assert(attributes["rebac.error.mapped"].AsBool(), qt.IsTrue)
+	c.Assert(attributes["http.response.status_code"].AsInt64(), qt.Equals, int64(http.StatusNotFound))
+	c.Assert(request.Events(), qt.HasLen, 1)
+	c.Assert(request.Events()[0].Name, qt.Equals, "exception")
+	// Client errors should not mark the request span as failed.
+	c.Assert(request.Status().Code, qt.Equals, codes.Unset)
+}
+
+func TestTracing_Disabled(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	groups := interfaces.NewMockGroupsService(ctrl)
+	groups.EXPECT().DeleteGroup(gomock.Any(), "some-group").Return(true, nil)
+
+	sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+		Groups: groups,
+	})
+
+	// No tracing decorators are expected when no tracer provider is given.
+	_, ok := sut.handler.(*handlerWithTracing)
+	c.Assert(ok, qt.IsFalse)
+
+	req := httptest.NewRequest(http.MethodDelete, "/v1/groups/some-group", nil)
+	w := httptest.NewRecorder()
+	sut.Handler("").ServeHTTP(w, req)
+	c.Assert(w.Code, qt.Equals, http.StatusOK)
+}
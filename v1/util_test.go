@@ -31,3 +31,8 @@ func newTestRequest[T any](method, path string, body *T) *http.Request {
 func stringPtr(s string) *string {
 	return &s
 }
+
+// intPtr is a helper function that returns a pointer to the given int literal.
+func intPtr(i int) *int {
+	return &i
+}
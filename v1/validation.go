@@ -18,11 +18,19 @@ package v1
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"reflect"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
 
+	"github.com/canonical/rebac-admin-ui-handlers/v1/filter"
 	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/sorting"
 )
 
 // handlerWithValidation decorates a given handler with validation logic. The
@@ -32,17 +40,70 @@ type handlerWithValidation struct {
 	// Wrapped/decorated handler
 	resources.ServerInterface
 
-	validate *validator.Validate
+	validate   *validator.Validate
+	decoding   RequestDecodingPolicy
+	pagination PaginationPolicy
+
+	// entitlementSchema is used to validate entitlement patches, if an
+	// entitlements service is configured.
+	entitlementSchema *entitlementSchemaCache
+
+	// references is used to resolve the entities referenced by relationship
+	// patches, if referential integrity checks are enabled.
+	references *referenceResolver
 }
 
 // newHandlerWithValidation returns a new instance of the validationHandlerDecorator struct.
-func newHandlerWithValidation(handler resources.ServerInterface) *handlerWithValidation {
+func newHandlerWithValidation(handler resources.ServerInterface, decoding RequestDecodingPolicy, pagination PaginationPolicy) *handlerWithValidation {
 	return &handlerWithValidation{
 		ServerInterface: handler,
 		validate:        validator.New(),
+		decoding:        decoding,
+		pagination:      pagination,
 	}
 }
 
+// DefaultMaxRequestBodySize is the maximum size of request bodies (in bytes),
+// used when `RequestDecodingPolicy.MaxBodySize` is not set.
+const DefaultMaxRequestBodySize = 1 << 20
+
+// RequestDecodingPolicy determines how request bodies are decoded.
+//
+// Regardless of the policy, requests with a `Content-Type` header other than
+// JSON (i.e., `application/json` or `application/*+json`) are rejected with
+// a 415 status code, and so are bodies with trailing data after the JSON value.
+type RequestDecodingPolicy struct {
+	// MaxBodySize is the maximum size of request bodies, in bytes. Larger
+	// bodies are rejected with a 413 status code. If zero, the
+	// `DefaultMaxRequestBodySize` is used. A negative value disables the limit.
+	MaxBodySize int64
+
+	// DisallowUnknownFields determines whether request bodies with fields that
+	// are not defined in the spec should be rejected. By default, such fields
+	// are ignored.
+	DisallowUnknownFields bool
+}
+
+// PaginationPolicy determines the accepted pagination parameters of list
+// operations.
+//
+// Regardless of the policy, requests with a page size less than 1, a negative
+// page number, both a page number and a next page token (either via the
+// `nextToken` query parameter or the `Next-Page-Token` header), or different
+// tokens via the query parameter and the header, are rejected with a 400
+// status code.
+type PaginationPolicy struct {
+	// DefaultPageSize is the page size passed to the services when the request
+	// does not specify one. If zero, the size is left unset, and the services
+	// apply their own default.
+	DefaultPageSize int
+
+	// MaxPageSize is the maximum page size. Requests with larger sizes are
+	// rejected with a 400 status code. If zero (or negative), page sizes are
+	// not limited.
+	MaxPageSize int
+}
+
 // requestBodyContextKey is the context key to retrieve the parsed request body struct instance.
 type requestBodyContextKey struct{}
 
@@ -59,24 +120,118 @@ func getRequestBodyFromContext(ctx context.Context) (any, error) {
 // newRequestWithBodyInContext sets the given body in a new request instance context
 // and returns the new request.
 func newRequestWithBodyInContext(r *http.Request, body any) *http.Request {
+	// Making the parsed body available to the audit decorator, if any.
+	setAuditRequestBody(r.Context(), body)
 	return r.WithContext(context.WithValue(r.Context(), requestBodyContextKey{}, body))
 }
 
-// parseRequestBody parses request body as JSON and populates the given body instance.
-func parseRequestBody(body any, r *http.Request) error {
+// maxBodySize returns the effective maximum size of request bodies, in bytes.
+// A non-positive value means there's no limit.
+func (policy RequestDecodingPolicy) maxBodySize() int64 {
+	if policy.MaxBodySize == 0 {
+		return DefaultMaxRequestBodySize
+	}
+	return policy.MaxBodySize
+}
+
+// parseRequestBody parses request body as JSON, as per the given policy, and
+// populates the given body instance.
+func parseRequestBody(body any, r *http.Request, policy RequestDecodingPolicy) error {
 	defer r.Body.Close()
-	if err := json.NewDecoder(r.Body).Decode(body); err != nil {
-		return NewMissingRequestBodyError("request body is not a valid JSON")
+
+	if contentType := r.Header.Get("Content-Type"); contentType != "" && !isJSONContentType(contentType) {
+		return NewUnsupportedMediaTypeError(fmt.Sprintf("expected JSON request body, got %q", contentType))
+	}
+
+	reader := r.Body
+	if maxBodySize := policy.maxBodySize(); maxBodySize > 0 {
+		reader = http.MaxBytesReader(nil, r.Body, maxBodySize)
+	}
+
+	decoder := json.NewDecoder(reader)
+	if policy.DisallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+	if err := decoder.Decode(body); err != nil {
+		return mapDecodingError(err)
+	}
+
+	// The body should contain a single JSON value.
+	if err := decoder.Decode(&json.RawMessage{}); err != io.EOF {
+		if err == nil {
+			return NewRequestBodyValidationError("unexpected data after the JSON value")
+		}
+		return mapDecodingError(err)
 	}
 	return nil
 }
 
+// isJSONContentType checks if the given `Content-Type` header value represents
+// a JSON media type.
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || (strings.HasPrefix(mediaType, "application/") && strings.HasSuffix(mediaType, "+json"))
+}
+
+// mapDecodingError maps the given JSON decoding error to the equivalent
+// errorWithStatus instance.
+func mapDecodingError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+
+	switch {
+	case errors.Is(err, io.EOF):
+		return NewMissingRequestBodyError("request body is not a valid JSON")
+	case errors.As(err, &maxBytesErr):
+		return NewRequestBodyTooLargeError(fmt.Sprintf("request body exceeds %d bytes", maxBytesErr.Limit))
+	case errors.As(err, &syntaxErr):
+		return NewRequestBodyValidationError(fmt.Sprintf("malformed JSON at offset %d: %s", syntaxErr.Offset, syntaxErr.Error()))
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return NewRequestBodyValidationError("malformed JSON: unexpected end of input")
+	case errors.As(err, &typeErr):
+		if typeErr.Field == "" {
+			return NewRequestBodyValidationError(fmt.Sprintf("expected JSON %s, got %s", jsonTypeName(typeErr.Type), typeErr.Value))
+		}
+		return NewRequestBodyValidationError(fmt.Sprintf("field %q should be JSON %s, got %s", typeErr.Field, jsonTypeName(typeErr.Type), typeErr.Value))
+	}
+
+	// Unknown fields are reported by the decoder as plain errors, like
+	// `json: unknown field "foo"`.
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		return NewRequestBodyValidationError(fmt.Sprintf("unknown field %s", field))
+	}
+	return NewRequestBodyValidationError(err.Error())
+}
+
+// jsonTypeName returns the JSON type name equivalent to the given Go type.
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Pointer:
+		return jsonTypeName(t.Elem())
+	}
+	return "object"
+}
+
 // validateRequestBody is a helper method to avoid repetition. It parses
 // request body, validates it against the given validator instance and if it's
 // okay, will delegate to the provided callback with a new HTTP request instance
 // with the parse body in the context.
 func (v handlerWithValidation) validateRequestBody(body any, w http.ResponseWriter, r *http.Request, f func(w http.ResponseWriter, r *http.Request)) {
-	err := parseRequestBody(body, r)
+	err := parseRequestBody(body, r, v.decoding)
 	if err != nil {
 		writeErrorResponse(w, err)
 		return
@@ -87,3 +242,105 @@ func (v handlerWithValidation) validateRequestBody(body any, w http.ResponseWrit
 	}
 	f(w, newRequestWithBodyInContext(r, body))
 }
+
+// validateFilter is a helper method to avoid repetition. It checks that the
+// given `filter` query parameter, if any, is well-formed and only refers to
+// fields of the given entity type, and if it's okay, will delegate to the
+// provided callback.
+func (v handlerWithValidation) validateFilter(param *resources.FilterParam, entity any, w http.ResponseWriter, r *http.Request, f func(w http.ResponseWriter, r *http.Request)) {
+	if param != nil {
+		expr, err := filter.Parse(*param)
+		if err == nil {
+			err = filter.Validate(expr, entity)
+		}
+		if err != nil {
+			writeErrorResponse(w, NewValidationError(fmt.Sprintf("invalid filter: %s", err)))
+			return
+		}
+	}
+	f(w, r)
+}
+
+// validatePagination is a helper method to avoid repetition. It checks that
+// the given pagination parameters are consistent and accepted by the
+// pagination policy, and if it's okay, will delegate to the provided callback.
+// If the page size is not specified, it's set to the default page size of the
+// policy, if any.
+func (v handlerWithValidation) validatePagination(size **resources.PaginationSize, page *resources.PaginationPage, nextToken *resources.PaginationNextToken, nextPageToken *resources.PaginationNextTokenHeader, w http.ResponseWriter, r *http.Request, f func(w http.ResponseWriter, r *http.Request)) {
+	if err := v.checkPagination(*size, page, nextToken, nextPageToken); err != nil {
+		writeErrorResponse(w, err)
+		return
+	}
+	if *size == nil && v.pagination.DefaultPageSize > 0 {
+		defaultSize := v.pagination.DefaultPageSize
+		*size = &defaultSize
+	}
+	f(w, r)
+}
+
+// checkPagination returns an error if the given pagination parameters are
+// inconsistent or not accepted by the pagination policy.
+func (v handlerWithValidation) checkPagination(size *resources.PaginationSize, page *resources.PaginationPage, nextToken *resources.PaginationNextToken, nextPageToken *resources.PaginationNextTokenHeader) error {
+	if size != nil {
+		if *size < 1 {
+			return NewQueryParameterValidationError("size", "must be positive")
+		}
+		if maxSize := v.pagination.MaxPageSize; maxSize > 0 && *size > maxSize {
+			return NewQueryParameterValidationError("size", fmt.Sprintf("must not exceed %d", maxSize))
+		}
+	}
+	if page != nil {
+		if *page < 0 {
+			return NewQueryParameterValidationError("page", "must not be negative")
+		}
+		if nextPageToken != nil {
+			return NewHeaderValidationError("Next-Page-Token", "cannot be used along with the page query parameter")
+		}
+		if nextToken != nil {
+			return NewQueryParameterValidationError("nextToken", "cannot be used along with the page query parameter")
+		}
+	}
+	if nextToken != nil && nextPageToken != nil && *nextToken != *nextPageToken {
+		return NewHeaderValidationError("Next-Page-Token", "does not match the nextToken query parameter")
+	}
+	return nil
+}
+
+// checkNotEmpty returns an error if the given query parameter is specified,
+// but empty.
+func checkNotEmpty(name string, value *string) error {
+	if value != nil && *value == "" {
+		return NewQueryParameterValidationError(name, "must not be empty")
+	}
+	return nil
+}
+
+// Sortable fields of the entities returned by list endpoints, referred to by
+// their JSON names.
+var (
+	groupsSortableFields            = []string{"id", "name"}
+	identitiesSortableFields        = []string{"id", "email", "firstName", "lastName", "addedBy", "source", "joined", "lastLogin"}
+	rolesSortableFields             = []string{"id", "name"}
+	resourcesSortableFields         = []string{"entity.id", "entity.name", "entity.type", "parent.name", "parent.type"}
+	identityProvidersSortableFields = []string{"id", "name", "enabled", "identityCount"}
+)
+
+// validateSort is a helper method to avoid repetition. It checks that the
+// `sort` query parameter, if any, is well-formed and only refers to the given
+// sortable fields, and if it's okay, will delegate to the provided callback
+// with the parsed sort order stored in the request context.
+func (v handlerWithValidation) validateSort(allowed []string, w http.ResponseWriter, r *http.Request, f func(w http.ResponseWriter, r *http.Request)) {
+	if !r.URL.Query().Has("sort") {
+		f(w, r)
+		return
+	}
+	order, err := sorting.Parse(r.URL.Query().Get("sort"))
+	if err == nil {
+		err = order.Validate(allowed...)
+	}
+	if err != nil {
+		writeErrorResponse(w, NewValidationError(fmt.Sprintf("invalid sort: %s", err)))
+		return
+	}
+	f(w, r.WithContext(sorting.NewContext(r.Context(), order)))
+}
@@ -0,0 +1,597 @@
+// Copyright (C) 2024 Canonical Ltd.
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"go.uber.org/mock/gomock"
+
+	"github.com/canonical/rebac-admin-ui-handlers/v1/interfaces"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/sorting"
+)
+
+func TestParseRequestBody(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		name           string
+		body           string
+		contentType    string
+		policy         RequestDecodingPolicy
+		expectedStatus int
+		expectedError  string
+	}{{
+		name: "success",
+		body: `{"patches":[{"op":"add","identity":"foo"}]}`,
+	}, {
+		name:        "success; JSON content type with parameters",
+		body:        `{"patches":[]}`,
+		contentType: "application/json; charset=utf-8",
+	}, {
+		name:        "success; structured JSON content type",
+		body:        `{"patches":[]}`,
+		contentType: "application/merge-patch+json",
+	}, {
+		name: "success; unknown fields are ignored by default",
+		body: `{"patches":[],"foo":"bar"}`,
+	}, {
+		name: "success; trailing whitespace",
+		body: "{\"patches\":[]}\n\n",
+	}, {
+		name:           "empty body",
+		expectedStatus: http.StatusBadRequest,
+		expectedError:  "Bad Request: missing request body: request body is not a valid JSON",
+	}, {
+		name:           "non-JSON content type",
+		body:           `{"patches":[]}`,
+		contentType:    "text/plain",
+		expectedStatus: http.StatusUnsupportedMediaType,
+		expectedError:  `Unsupported Media Type: unsupported media type: expected JSON request body, got "text/plain"`,
+	}, {
+		name:           "invalid content type",
+		body:           `{"patches":[]}`,
+		contentType:    "application/json; =",
+		expectedStatus: http.StatusUnsupportedMediaType,
+	}, {
+		name:           "syntax error",
+		body:           `{"patches":[}`,
+		expectedStatus: http.StatusBadRequest,
+		expectedError:  "Bad Request: invalid request body: malformed JSON at offset 13: invalid character '}' looking for beginning of value",
+	}, {
+		name:           "truncated body",
+		body:           `{"patches":[`,
+		expectedStatus: http.StatusBadRequest,
+		expectedError:  "Bad Request: invalid request body: malformed JSON: unexpected end of input",
+	}, {
+		name:           "type mismatch",
+		body:           `{"patches":{}}`,
+		expectedStatus: http.StatusBadRequest,
+		expectedError:  `Bad Request: invalid request body: field "patches" should be JSON array, got object`,
+	}, {
+		name:           "type mismatch; top level",
+		body:           `[]`,
+		expectedStatus: http.StatusBadRequest,
+		expectedError:  "Bad Request: invalid request body: expected JSON object, got array",
+	}, {
+		name:           "trailing data",
+		body:           `{"patches":[]}{"patches":[]}`,
+		expectedStatus: http.StatusBadRequest,
+		expectedError:  "Bad Request: invalid request body: unexpected data after the JSON value",
+	}, {
+		name:           "trailing garbage",
+		body:           `{"patches":[]}}`,
+		expectedStatus: http.StatusBadRequest,
+		expectedError:  "Bad Request: invalid request body: malformed JSON at offset 15: invalid character '}' looking for beginning of value",
+	}, {
+		name:           "unknown fields are rejected in strict mode",
+		body:           `{"patches":[],"foo":"bar"}`,
+		policy:         RequestDecodingPolicy{DisallowUnknownFields: true},
+		expectedStatus: http.StatusBadRequest,
+		expectedError:  `Bad Request: invalid request body: unknown field "foo"`,
+	}, {
+		name:           "body too large",
+		body:           `{"patches":[{"op":"add","identity":"foo"}]}`,
+		policy:         RequestDecodingPolicy{MaxBodySize: 16},
+		expectedStatus: http.StatusRequestEntityTooLarge,
+		expectedError:  "Request Entity Too Large: request body too large: request body exceeds 16 bytes",
+	}, {
+		name:           "body too large; default limit",
+		body:           `{"patches":[],"foo":"` + strings.Repeat("x", DefaultMaxRequestBodySize) + `"}`,
+		expectedStatus: http.StatusRequestEntityTooLarge,
+	}, {
+		name:   "body size limit disabled",
+		body:   `{"patches":[],"foo":"` + strings.Repeat("x", DefaultMaxRequestBodySize) + `"}`,
+		policy: RequestDecodingPolicy{MaxBodySize: -1},
+	}}
+
+	for _, t := range tests {
+		tt := t
+		c.Run(tt.name, func(c *qt.C) {
+			req := httptest.NewRequest(http.MethodPatch, "/blah", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+
+			body := &resources.GroupIdentitiesPatchRequestBody{}
+			err := parseRequestBody(body, req, tt.policy)
+			if tt.expectedStatus == 0 {
+				c.Assert(err, qt.IsNil)
+				return
+			}
+
+			c.Assert(err, qt.Not(qt.IsNil))
+			e, ok := err.(*errorWithStatus)
+			c.Assert(ok, qt.IsTrue)
+			c.Assert(e.status, qt.Equals, tt.expectedStatus)
+			if tt.expectedError != "" {
+				c.Assert(err.Error(), qt.Equals, tt.expectedError)
+			}
+		})
+	}
+}
+
+// TestRequestDecodingIsWiredToTheBackend asserts that the request decoding
+// policy is applied to the requests served by the backend.
+func TestRequestDecodingIsWiredToTheBackend(t *testing.T) {
+	c := qt.New(t)
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	// No calls are expected on the service backend.
+	groups := interfaces.NewMockGroupsService(ctrl)
+
+	sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+		Groups: groups,
+		RequestDecoding: RequestDecodingPolicy{
+			DisallowUnknownFields: true,
+		},
+	})
+
+	server := httptest.NewServer(sut.Handler(""))
+	defer server.Close()
+
+	raw, _ := json.Marshal(map[string]any{"name": "foo", "foo": "bar"})
+	res, err := http.Post(server.URL+"/v1/groups", "application/json", bytes.NewReader(raw))
+	c.Assert(err, qt.IsNil)
+	defer res.Body.Close()
+	c.Assert(res.StatusCode, qt.Equals, http.StatusBadRequest)
+
+	body, err := io.ReadAll(res.Body)
+	c.Assert(err, qt.IsNil)
+	parsed := &resources.Response{}
+	err = json.Unmarshal(body, parsed)
+	c.Assert(err, qt.IsNil)
+	c.Assert(parsed.Message, qt.Equals, `Bad Request: invalid request body: unknown field "foo"`)
+}
+
+func TestHandlerWithValidation_Filter(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		name            string
+		path            string
+		filter          string
+		setupMocks      func(groups *interfaces.MockGroupsService, identities *interfaces.MockIdentitiesService, roles *interfaces.MockRolesService, entitlements *interfaces.MockEntitlementsService)
+		expectedStatus  int
+		expectedMessage string
+	}{{
+		name:   "GetGroups: success",
+		path:   "/v1/groups",
+		filter: `name startsWith "adm" or viewers`,
+		setupMocks: func(groups *interfaces.MockGroupsService, _ *interfaces.MockIdentitiesService, _ *interfaces.MockRolesService, _ *interfaces.MockEntitlementsService) {
+			groups.EXPECT().ListGroups(gomock.Any(), gomock.Any()).Return(&resources.PaginatedResponse[resources.Group]{}, nil)
+		},
+		expectedStatus: http.StatusOK,
+	}, {
+		name:            "GetGroups: malformed filter",
+		path:            "/v1/groups",
+		filter:          "name eq",
+		expectedStatus:  http.StatusBadRequest,
+		expectedMessage: "Bad Request: invalid filter: unexpected end of filter at offset 7",
+	}, {
+		name:            "GetIdentities: unknown field",
+		path:            "/v1/identities",
+		filter:          "name eq foo",
+		expectedStatus:  http.StatusBadRequest,
+		expectedMessage: `Bad Request: invalid filter: unknown field "name"`,
+	}, {
+		name:   "GetIdentities: success",
+		path:   "/v1/identities",
+		filter: "email contains example.com",
+		setupMocks: func(_ *interfaces.MockGroupsService, identities *interfaces.MockIdentitiesService, _ *interfaces.MockRolesService, _ *interfaces.MockEntitlementsService) {
+			identities.EXPECT().ListIdentities(gomock.Any(), gomock.Any()).Return(&resources.PaginatedResponse[resources.Identity]{}, nil)
+		},
+		expectedStatus: http.StatusOK,
+	}, {
+		name:            "GetRoles: malformed filter",
+		path:            "/v1/roles",
+		filter:          "(viewer",
+		expectedStatus:  http.StatusBadRequest,
+		expectedMessage: "Bad Request: invalid filter: missing closing parenthesis at offset 7",
+	}, {
+		name:            "GetEntitlements: unknown field",
+		path:            "/v1/entitlements",
+		filter:          "entityType eq model",
+		expectedStatus:  http.StatusBadRequest,
+		expectedMessage: `Bad Request: invalid filter: unknown field "entityType"`,
+	}, {
+		name:   "GetEntitlements: success",
+		path:   "/v1/entitlements",
+		filter: "entity_type eq model",
+		setupMocks: func(_ *interfaces.MockGroupsService, _ *interfaces.MockIdentitiesService, _ *interfaces.MockRolesService, entitlements *interfaces.MockEntitlementsService) {
+			entitlements.EXPECT().ListEntitlements(gomock.Any(), gomock.Any()).Return(nil, nil)
+		},
+		expectedStatus: http.StatusOK,
+	}}
+
+	for _, test := range tests {
+		tt := test
+		c.Run(tt.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+			defer ctrl.Finish()
+
+			groups := interfaces.NewMockGroupsService(ctrl)
+			identities := interfaces.NewMockIdentitiesService(ctrl)
+			roles := interfaces.NewMockRolesService(ctrl)
+			entitlements := interfaces.NewMockEntitlementsService(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(groups, identities, roles, entitlements)
+			}
+
+			sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+				Groups:       groups,
+				Identities:   identities,
+				Roles:        roles,
+				Entitlements: entitlements,
+			})
+
+			req := httptest.NewRequest(http.MethodGet, tt.path+"?"+url.Values{"filter": {tt.filter}}.Encode(), nil)
+			w := httptest.NewRecorder()
+			sut.Handler("").ServeHTTP(w, req)
+			c.Assert(w.Code, qt.Equals, tt.expectedStatus)
+
+			if tt.expectedMessage != "" {
+				response := resources.Response{}
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				c.Assert(err, qt.IsNil)
+				c.Assert(response.Message, qt.Equals, tt.expectedMessage)
+			}
+		})
+	}
+}
+
+func TestHandlerWithValidation_Sort(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		name            string
+		path            string
+		sort            string
+		setupMocks      func(groups *interfaces.MockGroupsService, identities *interfaces.MockIdentitiesService, resourcesService *interfaces.MockResourcesService, idps *interfaces.MockIdentityProvidersService)
+		expectedStatus  int
+		expectedMessage string
+	}{{
+		name: "GetGroups: success",
+		path: "/v1/groups",
+		sort: "-name",
+		setupMocks: func(groups *interfaces.MockGroupsService, _ *interfaces.MockIdentitiesService, _ *interfaces.MockResourcesService, _ *interfaces.MockIdentityProvidersService) {
+			groups.EXPECT().ListGroups(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, _ *resources.GetGroupsParams) (*resources.PaginatedResponse[resources.Group], error) {
+				c.Check(sorting.FromContext(ctx), qt.DeepEquals, sorting.Order{{Name: "name", Descending: true}})
+				return &resources.PaginatedResponse[resources.Group]{}, nil
+			})
+		},
+		expectedStatus: http.StatusOK,
+	}, {
+		name: "GetIdentities: success",
+		path: "/v1/identities",
+		sort: "lastName,-joined",
+		setupMocks: func(_ *interfaces.MockGroupsService, identities *interfaces.MockIdentitiesService, _ *interfaces.MockResourcesService, _ *interfaces.MockIdentityProvidersService) {
+			identities.EXPECT().ListIdentities(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, _ *resources.GetIdentitiesParams) (*resources.PaginatedResponse[resources.Identity], error) {
+				c.Check(sorting.FromContext(ctx), qt.DeepEquals, sorting.Order{{Name: "lastName"}, {Name: "joined", Descending: true}})
+				return &resources.PaginatedResponse[resources.Identity]{}, nil
+			})
+		},
+		expectedStatus: http.StatusOK,
+	}, {
+		name:            "GetIdentities: unknown field",
+		path:            "/v1/identities",
+		sort:            "name",
+		expectedStatus:  http.StatusBadRequest,
+		expectedMessage: `Bad Request: invalid sort: cannot sort by "name" (sortable fields: id, email, firstName, lastName, addedBy, source, joined, lastLogin)`,
+	}, {
+		name:            "GetGroups: malformed sort",
+		path:            "/v1/groups",
+		sort:            "name,",
+		expectedStatus:  http.StatusBadRequest,
+		expectedMessage: `Bad Request: invalid sort: invalid sort field ""`,
+	}, {
+		name: "GetResources: success",
+		path: "/v1/resources",
+		sort: "entity.type,entity.name",
+		setupMocks: func(_ *interfaces.MockGroupsService, _ *interfaces.MockIdentitiesService, resourcesService *interfaces.MockResourcesService, _ *interfaces.MockIdentityProvidersService) {
+			resourcesService.EXPECT().ListResources(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, _ *resources.GetResourcesParams) (*resources.PaginatedResponse[resources.Resource], error) {
+				c.Check(sorting.FromContext(ctx), qt.DeepEquals, sorting.Order{{Name: "entity.type"}, {Name: "entity.name"}})
+				return &resources.PaginatedResponse[resources.Resource]{}, nil
+			})
+		},
+		expectedStatus: http.StatusOK,
+	}, {
+		name:            "GetResources: duplicate field",
+		path:            "/v1/resources",
+		sort:            "entity.name,-entity.name",
+		expectedStatus:  http.StatusBadRequest,
+		expectedMessage: `Bad Request: invalid sort: duplicate sort field "entity.name"`,
+	}, {
+		name: "GetIdentityProviders: no sort",
+		path: "/v1/authentication",
+		setupMocks: func(_ *interfaces.MockGroupsService, _ *interfaces.MockIdentitiesService, _ *interfaces.MockResourcesService, idps *interfaces.MockIdentityProvidersService) {
+			idps.EXPECT().ListIdentityProviders(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, _ *resources.GetIdentityProvidersParams) (*resources.PaginatedResponse[resources.IdentityProvider], error) {
+				c.Check(sorting.FromContext(ctx), qt.IsNil)
+				return &resources.PaginatedResponse[resources.IdentityProvider]{}, nil
+			})
+		},
+		expectedStatus: http.StatusOK,
+	}, {
+		name:            "GetIdentityProviders: unknown field",
+		path:            "/v1/authentication",
+		sort:            "clientSecret",
+		expectedStatus:  http.StatusBadRequest,
+		expectedMessage: `Bad Request: invalid sort: cannot sort by "clientSecret" (sortable fields: id, name, enabled, identityCount)`,
+	}}
+
+	for _, test := range tests {
+		tt := test
+		c.Run(tt.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+			defer ctrl.Finish()
+
+			groups := interfaces.NewMockGroupsService(ctrl)
+			identities := interfaces.NewMockIdentitiesService(ctrl)
+			resourcesService := interfaces.NewMockResourcesService(ctrl)
+			idps := interfaces.NewMockIdentityProvidersService(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(groups, identities, resourcesService, idps)
+			}
+
+			sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+				Groups:            groups,
+				Identities:        identities,
+				Resources:         resourcesService,
+				IdentityProviders: idps,
+			})
+
+			target := tt.path
+			if tt.sort != "" {
+				target += "?" + url.Values{"sort": {tt.sort}}.Encode()
+			}
+			req := httptest.NewRequest(http.MethodGet, target, nil)
+			w := httptest.NewRecorder()
+			sut.Handler("").ServeHTTP(w, req)
+			c.Assert(w.Code, qt.Equals, tt.expectedStatus)
+
+			if tt.expectedMessage != "" {
+				response := resources.Response{}
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				c.Assert(err, qt.IsNil)
+				c.Assert(response.Message, qt.Equals, tt.expectedMessage)
+			}
+		})
+	}
+}
+
+func TestHandlerWithValidation_Pagination(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		name            string
+		policy          PaginationPolicy
+		path            string
+		header          http.Header
+		setupMocks      func(groups *interfaces.MockGroupsService, resourcesService *interfaces.MockResourcesService)
+		expectedStatus  int
+		expectedMessage string
+	}{{
+		name: "GetGroups: success",
+		path: "/v1/groups?size=1000&filter=adm",
+		setupMocks: func(groups *interfaces.MockGroupsService, _ *interfaces.MockResourcesService) {
+			size := 1000
+			filter := "adm"
+			groups.EXPECT().ListGroups(gomock.Any(), &resources.GetGroupsParams{Size: &size, Filter: &filter}).Return(&resources.PaginatedResponse[resources.Group]{}, nil)
+		},
+		expectedStatus: http.StatusOK,
+	}, {
+		name:            "GetGroups: zero size",
+		path:            "/v1/groups?size=0",
+		expectedStatus:  http.StatusBadRequest,
+		expectedMessage: `Bad Request: invalid query parameter "size": must be positive`,
+	}, {
+		name: "GetGroups: size is not limited by default",
+		path: "/v1/groups?size=1000000",
+		setupMocks: func(groups *interfaces.MockGroupsService, _ *interfaces.MockResourcesService) {
+			size := 1000000
+			groups.EXPECT().ListGroups(gomock.Any(), &resources.GetGroupsParams{Size: &size}).Return(&resources.PaginatedResponse[resources.Group]{}, nil)
+		},
+		expectedStatus: http.StatusOK,
+	}, {
+		name:            "GetGroupsItemRoles: size exceeds configured maximum",
+		policy:          PaginationPolicy{MaxPageSize: 50},
+		path:            "/v1/groups/foo/roles?size=51",
+		expectedStatus:  http.StatusBadRequest,
+		expectedMessage: `Bad Request: invalid query parameter "size": must not exceed 50`,
+	}, {
+		name:   "GetGroupsItemRoles: unlimited size",
+		policy: PaginationPolicy{MaxPageSize: -1},
+		path:   "/v1/groups/foo/roles?size=1000000",
+		setupMocks: func(groups *interfaces.MockGroupsService, _ *interfaces.MockResourcesService) {
+			size := 1000000
+			groups.EXPECT().GetGroupRoles(gomock.Any(), "foo", &resources.GetGroupsItemRolesParams{Size: &size}).Return(&resources.PaginatedResponse[resources.Role]{}, nil)
+		},
+		expectedStatus: http.StatusOK,
+	}, {
+		name:   "GetGroupsItemIdentities: default size",
+		policy: PaginationPolicy{DefaultPageSize: 25},
+		path:   "/v1/groups/foo/identities",
+		setupMocks: func(groups *interfaces.MockGroupsService, _ *interfaces.MockResourcesService) {
+			size := 25
+			groups.EXPECT().GetGroupIdentities(gomock.Any(), "foo", &resources.GetGroupsItemIdentitiesParams{Size: &size}).Return(&resources.PaginatedResponse[resources.Identity]{}, nil)
+		},
+		expectedStatus: http.StatusOK,
+	}, {
+		name:            "GetResources: page and next token",
+		path:            "/v1/resources?page=1&nextToken=foo",
+		expectedStatus:  http.StatusBadRequest,
+		expectedMessage: `Bad Request: invalid query parameter "nextToken": cannot be used along with the page query parameter`,
+	}, {
+		name:            "GetResources: page and next page token header",
+		path:            "/v1/resources?page=1",
+		header:          http.Header{"Next-Page-Token": {"foo"}},
+		expectedStatus:  http.StatusBadRequest,
+		expectedMessage: `Bad Request: invalid header "Next-Page-Token": cannot be used along with the page query parameter`,
+	}, {
+		name: "GetResources: next token",
+		path: "/v1/resources?nextToken=foo",
+		setupMocks: func(_ *interfaces.MockGroupsService, resourcesService *interfaces.MockResourcesService) {
+			nextToken := "foo"
+			resourcesService.EXPECT().ListResources(gomock.Any(), &resources.GetResourcesParams{NextToken: &nextToken}).Return(&resources.PaginatedResponse[resources.Resource]{}, nil)
+		},
+		expectedStatus: http.StatusOK,
+	}}
+
+	for _, test := range tests {
+		tt := test
+		c.Run(tt.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+			defer ctrl.Finish()
+
+			groups := interfaces.NewMockGroupsService(ctrl)
+			resourcesService := interfaces.NewMockResourcesService(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(groups, resourcesService)
+			}
+
+			sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+				Groups:     groups,
+				Resources:  resourcesService,
+				Pagination: tt.policy,
+			})
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			for k, v := range tt.header {
+				req.Header[k] = v
+			}
+			w := httptest.NewRecorder()
+			sut.Handler("").ServeHTTP(w, req)
+			c.Assert(w.Code, qt.Equals, tt.expectedStatus)
+
+			if tt.expectedMessage != "" {
+				response := resources.Response{}
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				c.Assert(err, qt.IsNil)
+				c.Assert(response.Message, qt.Equals, tt.expectedMessage)
+			}
+		})
+	}
+}
+
+func TestHandlerWithValidation_QueryParameters(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		name            string
+		path            string
+		header          http.Header
+		setupMocks      func(groups *interfaces.MockGroupsService, resourcesService *interfaces.MockResourcesService)
+		expectedStatus  int
+		expectedMessage string
+	}{{
+		name:            "GetIdentitiesItemRoles: negative page",
+		path:            "/v1/identities/foo/roles?page=-1",
+		expectedStatus:  http.StatusBadRequest,
+		expectedMessage: `Bad Request: invalid query parameter "page": must not be negative`,
+	}, {
+		name:            "GetRolesItemEntitlements: mismatched next page tokens",
+		path:            "/v1/roles/foo/entitlements?nextToken=foo",
+		header:          http.Header{"Next-Page-Token": {"bar"}},
+		expectedStatus:  http.StatusBadRequest,
+		expectedMessage: `Bad Request: invalid header "Next-Page-Token": does not match the nextToken query parameter`,
+	}, {
+		name:   "GetGroupsItemEntitlements: matching next page tokens",
+		path:   "/v1/groups/foo/entitlements?nextToken=foo",
+		header: http.Header{"Next-Page-Token": {"foo"}},
+		setupMocks: func(groups *interfaces.MockGroupsService, _ *interfaces.MockResourcesService) {
+			token := "foo"
+			groups.EXPECT().GetGroupEntitlements(gomock.Any(), "foo", &resources.GetGroupsItemEntitlementsParams{NextToken: &token, NextPageToken: &token}).Return(&resources.PaginatedResponse[resources.EntityEntitlement]{}, nil)
+		},
+		expectedStatus: http.StatusOK,
+	}, {
+		name:            "GetResources: empty entity type",
+		path:            "/v1/resources?entityType=",
+		expectedStatus:  http.StatusBadRequest,
+		expectedMessage: `Bad Request: invalid query parameter "entityType": must not be empty`,
+	}, {
+		name:            "GetResources: empty entity name",
+		path:            "/v1/resources?entityType=model&entityName=",
+		expectedStatus:  http.StatusBadRequest,
+		expectedMessage: `Bad Request: invalid query parameter "entityName": must not be empty`,
+	}}
+
+	for _, test := range tests {
+		tt := test
+		c.Run(tt.name, func(c *qt.C) {
+			ctrl := gomock.NewController(c)
+			defer ctrl.Finish()
+
+			groups := interfaces.NewMockGroupsService(ctrl)
+			resourcesService := interfaces.NewMockResourcesService(ctrl)
+			if tt.setupMocks != nil {
+				tt.setupMocks(groups, resourcesService)
+			}
+
+			sut, _ := NewReBACAdminBackend(ReBACAdminBackendParams{
+				Groups:     groups,
+				Identities: interfaces.NewMockIdentitiesService(ctrl),
+				Roles:      interfaces.NewMockRolesService(ctrl),
+				Resources:  resourcesService,
+			})
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			for k, v := range tt.header {
+				req.Header[k] = v
+			}
+			w := httptest.NewRecorder()
+			sut.Handler("").ServeHTTP(w, req)
+			c.Assert(w.Code, qt.Equals, tt.expectedStatus)
+
+			if tt.expectedMessage != "" {
+				response := resources.Response{}
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				c.Assert(err, qt.IsNil)
+				c.Assert(response.Message, qt.Equals, tt.expectedMessage)
+			}
+		})
+	}
+}